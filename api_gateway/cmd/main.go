@@ -11,14 +11,18 @@ import (
 
 	"api_gateway/internal/client/cart"
 	"api_gateway/internal/client/favourites"
+	"api_gateway/internal/client/notification"
 	"api_gateway/internal/client/order"
 	"api_gateway/internal/client/product"
 	"api_gateway/internal/client/sso"
 	"api_gateway/internal/config"
+	address_handler "api_gateway/internal/handler/address"
 	cart_handler "api_gateway/internal/handler/cart"
 	fav_handler "api_gateway/internal/handler/favourites"
+	notification_handler "api_gateway/internal/handler/notification"
 	order_handler "api_gateway/internal/handler/order"
 	product_handler "api_gateway/internal/handler/product"
+	promo_handler "api_gateway/internal/handler/promo"
 	auth_handler "api_gateway/internal/handler/sso"
 	"api_gateway/internal/router"
 )
@@ -81,13 +85,22 @@ func run() error {
 	}
 	defer orderClient.Close()
 
+	notificationClient, err := notification.New(ctx, log, cfg.Downstream.NotificationgRPC, dialTimeout, retries)
+	if err != nil {
+		return err
+	}
+	defer notificationClient.Close()
+
 	// Создаём хендлеры (каждый принимает интерфейс, реализуемый конкретным клиентом).
 	handlers := router.Handlers{
-		Product:    product_handler.NewHandler(productClient, log),
-		Auth:       auth_handler.NewHandler(ssoClient, log),
-		Cart:       cart_handler.NewHandler(cartClient, log),
-		Favourites: fav_handler.NewHandler(favClient, log),
-		Order:      order_handler.New(orderClient, productClient, cartClient, log),
+		Product:      product_handler.NewHandler(productClient, log),
+		Auth:         auth_handler.NewHandler(ssoClient, log),
+		Cart:         cart_handler.NewHandler(cartClient, log),
+		Favourites:   fav_handler.NewHandler(favClient, log),
+		Order:        order_handler.New(orderClient, cartClient, log),
+		Address:      address_handler.NewHandler(ssoClient, log),
+		Promo:        promo_handler.NewHandler(orderClient, log),
+		Notification: notification_handler.NewHandler(notificationClient, log),
 	}
 
 	engine := router.New(cfg.AppSecret, log, handlers, ssoClient)
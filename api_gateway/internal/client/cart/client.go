@@ -59,7 +59,7 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-func (c *Client) AddToCart(ctx context.Context, userID int64, sneakerID int64, quantity int32) error {
+func (c *Client) AddToCart(ctx context.Context, userID int64, sneakerID, variantID int64, quantity int32) (*cartv1.CartItem, error) {
 	const op = "grpc.AddToCart"
 
 	md := metadata.New(map[string]string{
@@ -67,15 +67,16 @@ func (c *Client) AddToCart(ctx context.Context, userID int64, sneakerID int64, q
 	})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	_, err := c.api.AddToCart(ctx, &cartv1.AddToCartRequest{
+	resp, err := c.api.AddToCart(ctx, &cartv1.AddToCartRequest{
 		SneakerId: sneakerID,
+		VariantId: variantID,
 		Quantity:  quantity,
 	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return resp.GetItem(), nil
 }
 
 func (c *Client) GetCart(ctx context.Context, userID int64) (*cartv1.Cart, error) {
@@ -94,7 +95,7 @@ func (c *Client) GetCart(ctx context.Context, userID int64) (*cartv1.Cart, error
 	return resp.GetCart(), nil
 }
 
-func (c *Client) UpdateCartItemQuantity(ctx context.Context, userID int64, itemID string, quantity int32) error {
+func (c *Client) UpdateCartItemQuantity(ctx context.Context, userID int64, itemID string, quantity int32) (*cartv1.CartItem, error) {
 	const op = "grpc.UpdateCartItemQuantity"
 
 	md := metadata.New(map[string]string{
@@ -102,15 +103,15 @@ func (c *Client) UpdateCartItemQuantity(ctx context.Context, userID int64, itemI
 	})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	_, err := c.api.UpdateCartItemQuantity(ctx, &cartv1.UpdateQuantityRequest{
+	resp, err := c.api.UpdateCartItemQuantity(ctx, &cartv1.UpdateQuantityRequest{
 		ItemId:   itemID,
 		Quantity: quantity,
 	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return resp.GetItem(), nil
 }
 
 func (c *Client) RemoveFromCart(ctx context.Context, userID int64, itemID string) error {
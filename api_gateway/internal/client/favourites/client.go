@@ -136,17 +136,20 @@ func (c *Client) RemoveFromFavourites(ctx context.Context, userID, sneakerID int
 	return nil
 }
 
-func (c *Client) GetFavourites(ctx context.Context, userID int64) ([]*favv1.FavouriteItem, error) {
+func (c *Client) GetFavourites(ctx context.Context, userID int64, pageSize int32, pageToken string) (*favv1.GetFavouritesResponse, error) {
 	const op = "favourites.grpc.GetFavourites"
 
 	ctx = attachUserMD(ctx, userID)
 
-	resp, err := c.api.GetFavourites(ctx, &favv1.GetFavouritesRequest{})
+	resp, err := c.api.GetFavourites(ctx, &favv1.GetFavouritesRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return resp.Items, nil
+	return resp, nil
 }
 
 func (c *Client) IsFavourite(ctx context.Context, userID, sneakerID int64) (bool, error) {
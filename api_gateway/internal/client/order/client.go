@@ -18,9 +18,10 @@ import (
 )
 
 type Client struct {
-	api  orderv1.OrderServiceClient
-	conn *grpc.ClientConn
-	log  *slog.Logger
+	api   orderv1.OrderServiceClient
+	promo orderv1.PromotionsClient
+	conn  *grpc.ClientConn
+	log   *slog.Logger
 }
 
 func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
@@ -49,9 +50,10 @@ func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Durati
 	}
 
 	return &Client{
-		api:  orderv1.NewOrderServiceClient(cc),
-		conn: cc,
-		log:  log,
+		api:   orderv1.NewOrderServiceClient(cc),
+		promo: orderv1.NewPromotionsClient(cc),
+		conn:  cc,
+		log:   log,
 	}, nil
 }
 
@@ -100,15 +102,11 @@ func attachUserMD(ctx context.Context, userID int64) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
-func (c *Client) CreateOrder(ctx context.Context, userID int64, items []*orderv1.OrderItem) (*orderv1.Order, error) {
+func (c *Client) CreateOrder(ctx context.Context, userID int64, req *orderv1.CreateOrderRequest) (*orderv1.Order, error) {
 	const op = "order.CreateOrder"
 
 	ctx = attachUserMD(ctx, userID)
-
-	req := &orderv1.CreateOrderRequest{
-		UserId: userID,
-		Items:  items,
-	}
+	req.UserId = userID
 
 	resp, err := c.api.CreateOrder(ctx, req)
 	if err != nil {
@@ -119,9 +117,12 @@ func (c *Client) CreateOrder(ctx context.Context, userID int64, items []*orderv1
 	return resp.GetOrder(), nil
 }
 
-func (c *Client) GetOrder(ctx context.Context, orderID int64) (*orderv1.Order, error) {
+// GetOrder возвращает заказ пользователя userID вместе с историей статусов.
+func (c *Client) GetOrder(ctx context.Context, userID, orderID int64) (*orderv1.Order, error) {
 	const op = "order.GetOrder"
 
+	ctx = attachUserMD(ctx, userID)
+
 	req := &orderv1.GetOrderRequest{
 		OrderId: orderID,
 	}
@@ -135,13 +136,15 @@ func (c *Client) GetOrder(ctx context.Context, orderID int64) (*orderv1.Order, e
 	return resp.Order, nil
 }
 
-func (c *Client) GetUserOrders(ctx context.Context, userID int64) ([]*orderv1.Order, error) {
+func (c *Client) GetUserOrders(ctx context.Context, userID int64, pageSize int32, pageToken string) (*orderv1.GetUserOrdersResponse, error) {
 	const op = "order.GetUserOrders"
 
 	ctx = attachUserMD(ctx, userID)
 
 	req := &orderv1.GetUserOrdersRequest{
-		UserId: userID,
+		UserId:    userID,
+		PageSize:  pageSize,
+		PageToken: pageToken,
 	}
 
 	resp, err := c.api.GetUserOrders(ctx, req)
@@ -150,5 +153,189 @@ func (c *Client) GetUserOrders(ctx context.Context, userID int64) ([]*orderv1.Or
 		return nil, err
 	}
 
-	return resp.Orders, nil
+	return resp, nil
+}
+
+func (c *Client) CancelOrder(ctx context.Context, userID, orderID int64) (*orderv1.Order, error) {
+	const op = "order.CancelOrder"
+
+	ctx = attachUserMD(ctx, userID)
+
+	req := &orderv1.CancelOrderRequest{
+		OrderId: orderID,
+		Reason:  orderv1.ReasonCode_REASON_CODE_CUSTOMER_REQUEST,
+	}
+
+	resp, err := c.api.CancelOrder(ctx, req)
+	if err != nil {
+		c.log.Error("failed to cancel order", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetOrder(), nil
+}
+
+// RetryPayment создаёт новый платёж по заказу в PAYMENT_FAILED.
+func (c *Client) RetryPayment(ctx context.Context, userID, orderID int64) (*orderv1.Order, error) {
+	const op = "order.RetryPayment"
+
+	ctx = attachUserMD(ctx, userID)
+
+	resp, err := c.api.RetryPayment(ctx, &orderv1.RetryPaymentRequest{OrderId: orderID})
+	if err != nil {
+		c.log.Error("failed to retry payment", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetOrder(), nil
+}
+
+// RefundOrder оформляет возврат от имени администратора adminID.
+func (c *Client) RefundOrder(ctx context.Context, adminID int64, req *orderv1.RefundOrderRequest) (*orderv1.RefundOrderResponse, error) {
+	const op = "order.RefundOrder"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.api.RefundOrder(ctx, req)
+	if err != nil {
+		c.log.Error("failed to refund order", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// MarkShipment меняет статус выполнения заказа от имени администратора adminID.
+func (c *Client) MarkShipment(ctx context.Context, adminID int64, req *orderv1.MarkShipmentRequest) (*orderv1.Order, error) {
+	const op = "order.MarkShipment"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.api.MarkShipment(ctx, req)
+	if err != nil {
+		c.log.Error("failed to mark shipment", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetOrder(), nil
+}
+
+// ListOrders отдаёт страницу всех заказов по фильтру от имени администратора adminID.
+func (c *Client) ListOrders(ctx context.Context, adminID int64, req *orderv1.ListOrdersRequest) (*orderv1.ListOrdersResponse, error) {
+	const op = "order.ListOrders"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.api.ListOrders(ctx, req)
+	if err != nil {
+		c.log.Error("failed to list orders", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListReconciliationMismatches отдаёт страницу расхождений сверки платежей
+// от имени администратора adminID.
+func (c *Client) ListReconciliationMismatches(ctx context.Context, adminID int64, req *orderv1.ListReconciliationMismatchesRequest) (*orderv1.ListReconciliationMismatchesResponse, error) {
+	const op = "order.ListReconciliationMismatches"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.api.ListReconciliationMismatches(ctx, req)
+	if err != nil {
+		c.log.Error("failed to list reconciliation mismatches", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AdminGetOrder возвращает любой заказ с историей статусов от имени администратора adminID.
+func (c *Client) AdminGetOrder(ctx context.Context, adminID, orderID int64) (*orderv1.Order, error) {
+	const op = "order.AdminGetOrder"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.api.AdminGetOrder(ctx, &orderv1.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		c.log.Error("failed to get order", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetOrder(), nil
+}
+
+// UpdateOrderStatus меняет статус заказа от имени администратора adminID.
+func (c *Client) UpdateOrderStatus(ctx context.Context, adminID, orderID int64, status string) error {
+	const op = "order.UpdateOrderStatus"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	_, err := c.api.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{OrderId: orderID, Status: status})
+	if err != nil {
+		c.log.Error("failed to update order status", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Промокоды управляются администратором: adminID уходит в метаданные, как у остальных админских вызовов.
+// Повтор CreatePromoCode после таймаута безопасен: занятый код вернёт AlreadyExists.
+
+func (c *Client) CreatePromoCode(ctx context.Context, adminID int64, promo *orderv1.PromoCode) (*orderv1.PromoCode, error) {
+	const op = "order.CreatePromoCode"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.promo.CreatePromoCode(ctx, &orderv1.CreatePromoCodeRequest{PromoCode: promo})
+	if err != nil {
+		c.log.Error("failed to create promo code", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetPromoCode(), nil
+}
+
+func (c *Client) UpdatePromoCode(ctx context.Context, adminID int64, promo *orderv1.PromoCode) (*orderv1.PromoCode, error) {
+	const op = "order.UpdatePromoCode"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.promo.UpdatePromoCode(ctx, &orderv1.UpdatePromoCodeRequest{PromoCode: promo})
+	if err != nil {
+		c.log.Error("failed to update promo code", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetPromoCode(), nil
+}
+
+func (c *Client) GetPromoCode(ctx context.Context, adminID, id int64) (*orderv1.PromoCode, error) {
+	const op = "order.GetPromoCode"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.promo.GetPromoCode(ctx, &orderv1.GetPromoCodeRequest{Id: id})
+	if err != nil {
+		c.log.Error("failed to get promo code", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetPromoCode(), nil
+}
+
+func (c *Client) ListPromoCodes(ctx context.Context, adminID int64, activeOnly bool) ([]*orderv1.PromoCode, error) {
+	const op = "order.ListPromoCodes"
+
+	ctx = attachUserMD(ctx, adminID)
+
+	resp, err := c.promo.ListPromoCodes(ctx, &orderv1.ListPromoCodesRequest{ActiveOnly: activeOnly})
+	if err != nil {
+		c.log.Error("failed to list promo codes", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp.GetPromoCodes(), nil
 }
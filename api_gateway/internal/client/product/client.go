@@ -92,19 +92,30 @@ func requestIDInterceptor() grpc.UnaryClientInterceptor {
 	}
 }
 
-func (c *Client) GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*productv1.Sneaker, error) {
+func (c *Client) GetAllSneakers(ctx context.Context, limit uint64, pageToken string) (*productv1.GetAllSneakersResponse, error) {
 	const op = "product.GetAllSneakers"
 
 	req := &productv1.GetAllSneakersRequest{
-		Limit:  limit,
-		Offset: offset,
+		Limit:     limit,
+		PageToken: pageToken,
 	}
 	resp, err := c.api.GetAllSneakers(ctx, req)
 	if err != nil {
 		c.log.Error("failed to get all sneakers", slog.String("error", err.Error()))
 		return nil, err
 	}
-	return resp.GetSneakers(), nil
+	return resp, nil
+}
+
+func (c *Client) SearchSneakers(ctx context.Context, req *productv1.SearchSneakersRequest) (*productv1.SearchSneakersResponse, error) {
+	const op = "product.SearchSneakers"
+
+	resp, err := c.api.SearchSneakers(ctx, req)
+	if err != nil {
+		c.log.Error("failed to search sneakers", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (c *Client) GetSneakerByID(ctx context.Context, id int64) (*productv1.Sneaker, error) {
@@ -121,13 +132,9 @@ func (c *Client) GetSneakerByID(ctx context.Context, id int64) (*productv1.Sneak
 	return resp, nil
 }
 
-func (c *Client) AddSneaker(ctx context.Context, title string, priceKopecks int64) (*productv1.Sneaker, error) {
+func (c *Client) AddSneaker(ctx context.Context, req *productv1.AddSneakerRequest) (*productv1.Sneaker, error) {
 	const op = "product.AddSneaker"
 
-	req := &productv1.AddSneakerRequest{
-		Title:        title,
-		PriceKopecks: priceKopecks,
-	}
 	resp, err := c.api.AddSneaker(ctx, req)
 	if err != nil {
 		c.log.Error("failed to add sneaker", slog.String("error", err.Error()))
@@ -137,6 +144,18 @@ func (c *Client) AddSneaker(ctx context.Context, title string, priceKopecks int6
 	return resp, nil
 }
 
+func (c *Client) UpdateSneaker(ctx context.Context, req *productv1.UpdateSneakerRequest) (*productv1.Sneaker, error) {
+	const op = "product.UpdateSneaker"
+
+	resp, err := c.api.UpdateSneaker(ctx, req)
+	if err != nil {
+		c.log.Error("failed to update sneaker", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *Client) DeleteSneaker(ctx context.Context, id int64) error {
 	const op = "product.DeleteSneaker"
 
@@ -152,6 +171,21 @@ func (c *Client) DeleteSneaker(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (c *Client) RestoreSneaker(ctx context.Context, id int64) error {
+	const op = "product.RestoreSneaker"
+
+	req := &productv1.RestoreSneakerRequest{
+		Id: id,
+	}
+	_, err := c.api.RestoreSneaker(ctx, req)
+	if err != nil {
+		c.log.Error("failed to restore sneaker", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
 func (c *Client) GenerateUploadURL(ctx context.Context, originalFilename, contentType string) (*productv1.GenerateUploadURLResponse, error) {
 	const op = "product.GenerateUploadURL"
 
@@ -194,3 +228,54 @@ func (c *Client) GetSneakersByIDs(ctx context.Context, ids []int64) ([]*productv
 	}
 	return resp.Sneakers, nil
 }
+
+func (c *Client) CreateVariant(ctx context.Context, req *productv1.CreateVariantRequest) (*productv1.SneakerVariant, error) {
+	resp, err := c.api.CreateVariant(ctx, req)
+	if err != nil {
+		c.log.Error("failed to create variant", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) ListVariants(ctx context.Context, sneakerID int64) ([]*productv1.SneakerVariant, error) {
+	resp, err := c.api.ListVariants(ctx, &productv1.ListVariantsRequest{SneakerId: sneakerID})
+	if err != nil {
+		c.log.Error("failed to list variants", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return resp.GetVariants(), nil
+}
+
+func (c *Client) UpdateVariant(ctx context.Context, req *productv1.UpdateVariantRequest) (*productv1.SneakerVariant, error) {
+	resp, err := c.api.UpdateVariant(ctx, req)
+	if err != nil {
+		c.log.Error("failed to update variant", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*productv1.StockLevel, error) {
+	resp, err := c.api.SetStock(ctx, &productv1.SetStockRequest{
+		SneakerId: sneakerID,
+		VariantId: variantID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		c.log.Error("failed to set stock", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetStock(ctx context.Context, sneakerID, variantID int64) (*productv1.StockLevel, error) {
+	resp, err := c.api.GetStock(ctx, &productv1.GetStockRequest{
+		SneakerId: sneakerID,
+		VariantId: variantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
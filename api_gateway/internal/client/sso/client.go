@@ -18,9 +18,10 @@ import (
 )
 
 type Client struct {
-	api  ssov1.AuthClient
-	conn *grpc.ClientConn
-	log  *slog.Logger
+	api     ssov1.AuthClient
+	profile ssov1.ProfileClient
+	conn    *grpc.ClientConn
+	log     *slog.Logger
 }
 
 func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
@@ -49,9 +50,10 @@ func New(ctx context.Context, log *slog.Logger, addr string, timeout time.Durati
 	}
 
 	return &Client{
-		api:  ssov1.NewAuthClient(cc),
-		conn: cc,
-		log:  log,
+		api:     ssov1.NewAuthClient(cc),
+		profile: ssov1.NewProfileClient(cc),
+		conn:    cc,
+		log:     log,
 	}, nil
 }
 
@@ -100,6 +102,58 @@ func (c *Client) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	return resp.IsAdmin, nil
 }
 
+// Вызовы адресной книги идут без ретраев: NotFound здесь — окончательный ответ,
+// а повтор CreateAddress после таймаута мог бы создать дубликат.
+
+func (c *Client) CreateAddress(ctx context.Context, userID int64, addr *ssov1.Address) (*ssov1.Address, error) {
+	const op = "grpc.CreateAddress"
+
+	resp, err := c.profile.CreateAddress(ctx, &ssov1.CreateAddressRequest{
+		UserId:  userID,
+		Address: addr,
+	}, grpcretry.Disable())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return resp.GetAddress(), nil
+}
+
+func (c *Client) ListAddresses(ctx context.Context, userID int64) ([]*ssov1.Address, error) {
+	const op = "grpc.ListAddresses"
+
+	resp, err := c.profile.ListAddresses(ctx, &ssov1.ListAddressesRequest{UserId: userID}, grpcretry.Disable())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return resp.GetAddresses(), nil
+}
+
+func (c *Client) UpdateAddress(ctx context.Context, userID int64, addr *ssov1.Address) (*ssov1.Address, error) {
+	const op = "grpc.UpdateAddress"
+
+	resp, err := c.profile.UpdateAddress(ctx, &ssov1.UpdateAddressRequest{
+		UserId:  userID,
+		Address: addr,
+	}, grpcretry.Disable())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return resp.GetAddress(), nil
+}
+
+func (c *Client) DeleteAddress(ctx context.Context, userID, addressID int64) error {
+	const op = "grpc.DeleteAddress"
+
+	_, err := c.profile.DeleteAddress(ctx, &ssov1.DeleteAddressRequest{
+		UserId:    userID,
+		AddressId: addressID,
+	}, grpcretry.Disable())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // GetAppSecret удалён — секрет подписи JWT передаётся через APP_SECRET.
 
 // deadlineInterceptor добавляет общий таймаут к каждому gRPC-вызову.
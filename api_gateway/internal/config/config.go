@@ -13,14 +13,15 @@ type Config struct {
 }
 
 type DownstreamConfig struct {
-	Cart           string `mapstructure:"cart"`
-	CartgRPC       string `mapstructure:"cart_grpc"`
-	Favourites     string `mapstructure:"favourites"`
-	FavouritesgRPC string `mapstructure:"favourites_grpc"`
-	Backend        string `mapstructure:"backend"`
-	ProductgRPC    string `mapstructure:"product_grpc"`
-	SSOgRPC        string `mapstructure:"sso_grpc"`
-	OrdergRPC      string `mapstructure:"order_grpc"`
+	Cart             string `mapstructure:"cart"`
+	CartgRPC         string `mapstructure:"cart_grpc"`
+	Favourites       string `mapstructure:"favourites"`
+	FavouritesgRPC   string `mapstructure:"favourites_grpc"`
+	Backend          string `mapstructure:"backend"`
+	ProductgRPC      string `mapstructure:"product_grpc"`
+	SSOgRPC          string `mapstructure:"sso_grpc"`
+	OrdergRPC        string `mapstructure:"order_grpc"`
+	NotificationgRPC string `mapstructure:"notification_grpc"`
 }
 
 func Load(path string) (*Config, error) {
@@ -0,0 +1,164 @@
+package address
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ssov1 "github.com/stpnv0/protos/gen/go/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api_gateway/internal/middleware"
+)
+
+type AddressClient interface {
+	CreateAddress(ctx context.Context, userID int64, addr *ssov1.Address) (*ssov1.Address, error)
+	ListAddresses(ctx context.Context, userID int64) ([]*ssov1.Address, error)
+	UpdateAddress(ctx context.Context, userID int64, addr *ssov1.Address) (*ssov1.Address, error)
+	DeleteAddress(ctx context.Context, userID, addressID int64) error
+}
+
+type Handler struct {
+	client AddressClient
+	log    *slog.Logger
+}
+
+func NewHandler(client AddressClient, log *slog.Logger) *Handler {
+	return &Handler{client: client, log: log}
+}
+
+type AddressRequest struct {
+	RecipientName string `json:"recipient_name" binding:"required,max=255"`
+	Phone         string `json:"phone" binding:"required,max=32"`
+	City          string `json:"city" binding:"required,max=255"`
+	AddressLine   string `json:"address_line" binding:"required,max=500"`
+	PostalCode    string `json:"postal_code" binding:"omitempty,numeric,max=16"`
+	Comment       string `json:"comment" binding:"max=500"`
+}
+
+func (r AddressRequest) toProto() *ssov1.Address {
+	return &ssov1.Address{
+		RecipientName: r.RecipientName,
+		Phone:         r.Phone,
+		City:          r.City,
+		AddressLine:   r.AddressLine,
+		PostalCode:    r.PostalCode,
+		Comment:       r.Comment,
+	}
+}
+
+// ListAddresses - GET /addresses
+func (h *Handler) ListAddresses(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	addrs, err := h.client.ListAddresses(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err, "failed to list addresses")
+		return
+	}
+
+	if addrs == nil {
+		addrs = []*ssov1.Address{}
+	}
+	c.JSON(http.StatusOK, addrs)
+}
+
+// CreateAddress - POST /addresses
+func (h *Handler) CreateAddress(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addr, err := h.client.CreateAddress(c.Request.Context(), userID, req.toProto())
+	if err != nil {
+		h.writeError(c, err, "failed to create address")
+		return
+	}
+
+	c.JSON(http.StatusCreated, addr)
+}
+
+// UpdateAddress - PUT /addresses/:id
+func (h *Handler) UpdateAddress(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	addressID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || addressID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address id"})
+		return
+	}
+
+	var req AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	in := req.toProto()
+	in.Id = addressID
+
+	addr, err := h.client.UpdateAddress(c.Request.Context(), userID, in)
+	if err != nil {
+		h.writeError(c, err, "failed to update address")
+		return
+	}
+
+	c.JSON(http.StatusOK, addr)
+}
+
+// DeleteAddress - DELETE /addresses/:id
+func (h *Handler) DeleteAddress(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	addressID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || addressID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address id"})
+		return
+	}
+
+	if err := h.client.DeleteAddress(c.Request.Context(), userID, addressID); err != nil {
+		h.writeError(c, err, "failed to delete address")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) writeError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		return
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "address not found"})
+		return
+	case codes.FailedPrecondition:
+		c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+}
@@ -14,9 +14,9 @@ import (
 )
 
 type CartClient interface {
-	AddToCart(ctx context.Context, userID int64, sneakerID int64, quantity int32) error
+	AddToCart(ctx context.Context, userID int64, sneakerID, variantID int64, quantity int32) (*cartv1.CartItem, error)
 	GetCart(ctx context.Context, userID int64) (*cartv1.Cart, error)
-	UpdateCartItemQuantity(ctx context.Context, userID int64, itemID string, quantity int32) error
+	UpdateCartItemQuantity(ctx context.Context, userID int64, itemID string, quantity int32) (*cartv1.CartItem, error)
 	RemoveFromCart(ctx context.Context, userID int64, itemID string) error
 	ClearCart(ctx context.Context, userID int64) error
 }
@@ -40,6 +40,7 @@ func (h *Handler) AddToCart(c *gin.Context) {
 
 	var req struct {
 		SneakerID int64 `json:"sneaker_id" binding:"required"`
+		VariantID int64 `json:"variant_id" binding:"omitempty,min=1"`
 		Quantity  int32 `json:"quantity" binding:"required,min=1"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -47,13 +48,21 @@ func (h *Handler) AddToCart(c *gin.Context) {
 		return
 	}
 
-	if err := h.cartClient.AddToCart(c.Request.Context(), userID, req.SneakerID, req.Quantity); err != nil {
+	item, err := h.cartClient.AddToCart(c.Request.Context(), userID, req.SneakerID, req.VariantID, req.Quantity)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+			return
+		}
 		h.log.Error("failed to add to cart", slog.String("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add item to cart"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "item added to cart successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "item added to cart successfully",
+		"item":    convertItemToJSON(item),
+	})
 }
 
 // GetCart - GET /api/v1/cart/
@@ -101,13 +110,17 @@ func (h *Handler) UpdateCartItemQuantity(c *gin.Context) {
 		return
 	}
 
-	if err := h.cartClient.UpdateCartItemQuantity(c.Request.Context(), userID, itemID, req.Quantity); err != nil {
+	item, err := h.cartClient.UpdateCartItemQuantity(c.Request.Context(), userID, itemID, req.Quantity)
+	if err != nil {
 		h.log.Error("failed to update cart item", slog.String("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update item quantity"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "item quantity updated successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "item quantity updated successfully",
+		"item":    convertItemToJSON(item),
+	})
 }
 
 // RemoveFromCart - DELETE /api/v1/cart/:id
@@ -136,12 +149,7 @@ func (h *Handler) RemoveFromCart(c *gin.Context) {
 func convertCartToJSON(cart *cartv1.Cart) map[string]interface{} {
 	items := make([]map[string]interface{}, 0, len(cart.GetItems()))
 	for _, item := range cart.GetItems() {
-		items = append(items, map[string]interface{}{
-			"id":         item.GetId(),
-			"sneaker_id": item.GetSneakerId(),
-			"quantity":   item.GetQuantity(),
-			"added_at":   item.GetAddedAt(),
-		})
+		items = append(items, convertItemToJSON(item))
 	}
 	return map[string]interface{}{
 		"user_sso_id": cart.GetUserId(),
@@ -149,3 +157,13 @@ func convertCartToJSON(cart *cartv1.Cart) map[string]interface{} {
 		"updated_at":  cart.GetUpdatedAt(),
 	}
 }
+
+func convertItemToJSON(item *cartv1.CartItem) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         item.GetId(),
+		"sneaker_id": item.GetSneakerId(),
+		"variant_id": item.GetVariantId(),
+		"quantity":   item.GetQuantity(),
+		"added_at":   item.GetAddedAt(),
+	}
+}
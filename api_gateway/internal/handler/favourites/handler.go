@@ -17,10 +17,16 @@ import (
 type FavouritesClient interface {
 	AddToFavourites(ctx context.Context, userID, sneakerID int64) error
 	RemoveFromFavourites(ctx context.Context, userID, sneakerID int64) error
-	GetFavourites(ctx context.Context, userID int64) ([]*favv1.FavouriteItem, error)
+	GetFavourites(ctx context.Context, userID int64, pageSize int32, pageToken string) (*favv1.GetFavouritesResponse, error)
 	IsFavourite(ctx context.Context, userID, sneakerID int64) (bool, error)
 }
 
+// nextPageTokenHeader — заголовок с токеном следующей страницы. Тело ответа
+// остаётся массивом, чтобы не ломать существующих клиентов.
+const nextPageTokenHeader = "X-Next-Page-Token"
+
+const maxPageSize = 100
+
 type Handler struct {
 	client FavouritesClient
 	log    *slog.Logger
@@ -108,18 +114,35 @@ func (h *Handler) GetAllFavourites(c *gin.Context) {
 		return
 	}
 
-	items, err := h.client.GetFavourites(c.Request.Context(), userID)
+	pageSize, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || pageSize < 0 || pageSize > maxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	resp, err := h.client.GetFavourites(c.Request.Context(), userID, int32(pageSize), c.Query("page_token"))
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok && st.Code() == codes.Unauthenticated {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
 			return
 		}
+		if ok && st.Code() == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		}
 		h.log.Error("failed to get favourites", slog.String("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get favourites"})
 		return
 	}
 
+	items := resp.GetItems()
+	if items == nil {
+		items = make([]*favv1.FavouriteItem, 0)
+	}
+	if next := resp.GetNextPageToken(); next != "" {
+		c.Header(nextPageTokenHeader, next)
+	}
 	c.JSON(http.StatusOK, items)
 }
 
@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	notificationv1 "github.com/stpnv0/protos/gen/go/notification"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api_gateway/internal/middleware"
+)
+
+type NotificationClient interface {
+	GetPreferences(ctx context.Context, userID int64) (*notificationv1.Preferences, error)
+	UpdatePreferences(ctx context.Context, userID int64, prefs *notificationv1.Preferences) (*notificationv1.Preferences, error)
+}
+
+// Handler — настройки email-уведомлений о заказах.
+type Handler struct {
+	client NotificationClient
+	log    *slog.Logger
+}
+
+func NewHandler(client NotificationClient, log *slog.Logger) *Handler {
+	return &Handler{client: client, log: log}
+}
+
+// PreferencesRequest — настройки уведомлений; сохраняются целиком, поэтому
+// все переключатели обязательны.
+type PreferencesRequest struct {
+	// Locale — язык писем: ru или en; пустой — язык по умолчанию.
+	Locale          string `json:"locale" binding:"omitempty,oneof=ru en"`
+	OrderUpdates    *bool  `json:"order_updates" binding:"required"`
+	PaymentUpdates  *bool  `json:"payment_updates" binding:"required"`
+	ShippingUpdates *bool  `json:"shipping_updates" binding:"required"`
+}
+
+// GetPreferences - GET /notifications/preferences
+func (h *Handler) GetPreferences(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := h.client.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err, "failed to get notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences - PUT /notifications/preferences
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req PreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := h.client.UpdatePreferences(c.Request.Context(), userID, &notificationv1.Preferences{
+		Locale:          req.Locale,
+		OrderUpdates:    *req.OrderUpdates,
+		PaymentUpdates:  *req.PaymentUpdates,
+		ShippingUpdates: *req.ShippingUpdates,
+	})
+	if err != nil {
+		h.writeError(c, err, "failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// writeError переводит ошибку notification_service в HTTP-ответ.
+func (h *Handler) writeError(c *gin.Context, err error, msg string) {
+	if status.Code(err) == codes.InvalidArgument {
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+}
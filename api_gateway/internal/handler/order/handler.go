@@ -5,51 +5,85 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	orderv1 "github.com/stpnv0/protos/gen/go/order"
-	productv1 "github.com/stpnv0/protos/gen/go/product"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"api_gateway/internal/middleware"
 )
 
 type OrderClient interface {
-	CreateOrder(ctx context.Context, userID int64, items []*orderv1.OrderItem) (*orderv1.Order, error)
-	GetOrder(ctx context.Context, orderID int64) (*orderv1.Order, error)
-	GetUserOrders(ctx context.Context, userID int64) ([]*orderv1.Order, error)
+	CreateOrder(ctx context.Context, userID int64, req *orderv1.CreateOrderRequest) (*orderv1.Order, error)
+	GetOrder(ctx context.Context, userID, orderID int64) (*orderv1.Order, error)
+	GetUserOrders(ctx context.Context, userID int64, pageSize int32, pageToken string) (*orderv1.GetUserOrdersResponse, error)
+	CancelOrder(ctx context.Context, userID, orderID int64) (*orderv1.Order, error)
+	RetryPayment(ctx context.Context, userID, orderID int64) (*orderv1.Order, error)
+	RefundOrder(ctx context.Context, adminID int64, req *orderv1.RefundOrderRequest) (*orderv1.RefundOrderResponse, error)
+	MarkShipment(ctx context.Context, adminID int64, req *orderv1.MarkShipmentRequest) (*orderv1.Order, error)
+	ListOrders(ctx context.Context, adminID int64, req *orderv1.ListOrdersRequest) (*orderv1.ListOrdersResponse, error)
+	AdminGetOrder(ctx context.Context, adminID, orderID int64) (*orderv1.Order, error)
+	UpdateOrderStatus(ctx context.Context, adminID, orderID int64, status string) error
+	ListReconciliationMismatches(ctx context.Context, adminID int64, req *orderv1.ListReconciliationMismatchesRequest) (*orderv1.ListReconciliationMismatchesResponse, error)
 }
 
-type ProductLookup interface {
-	GetSneakerByID(ctx context.Context, id int64) (*productv1.Sneaker, error)
-	GetSneakersByIDs(ctx context.Context, ids []int64) ([]*productv1.Sneaker, error)
-}
+// nextPageTokenHeader — заголовок с токеном следующей страницы. Тело ответа
+// остаётся массивом заказов, чтобы не ломать существующих клиентов.
+const nextPageTokenHeader = "X-Next-Page-Token"
+
+const maxPageSize = 100
+
+// idempotencyKeyHeader — заголовок, по которому order_service отличает повтор
+// оформления заказа (двойной клик, ретрай сети) от нового заказа.
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 128
+)
 
 type CartClearer interface {
 	ClearCart(ctx context.Context, userID int64) error
 }
 
 type Handler struct {
-	orderClient   OrderClient
-	productClient ProductLookup
-	cartClient    CartClearer
-	log           *slog.Logger
+	orderClient OrderClient
+	cartClient  CartClearer
+	log         *slog.Logger
 }
 
-func New(orderClient OrderClient, productClient ProductLookup, cartClient CartClearer, log *slog.Logger) *Handler {
+func New(orderClient OrderClient, cartClient CartClearer, log *slog.Logger) *Handler {
 	return &Handler{
-		orderClient:   orderClient,
-		productClient: productClient,
-		cartClient:    cartClient,
-		log:           log,
+		orderClient: orderClient,
+		cartClient:  cartClient,
+		log:         log,
 	}
 }
 
 type CreateOrderRequest struct {
 	Items []OrderItemRequest `json:"items" binding:"required"`
+	// DeliveryMethod — pickup (по умолчанию), courier или post.
+	DeliveryMethod string `json:"delivery_method" binding:"omitempty,oneof=pickup courier post"`
+	// Для courier и post — id адреса из адресной книги или адрес целиком.
+	AddressID int64                   `json:"address_id" binding:"omitempty,min=1"`
+	Address   *ShippingAddressRequest `json:"address"`
+	// PromoCode — промокод на скидку; регистр не важен.
+	PromoCode string `json:"promo_code" binding:"max=32"`
+}
+
+type ShippingAddressRequest struct {
+	RecipientName string `json:"recipient_name" binding:"required,max=255"`
+	Phone         string `json:"phone" binding:"required,max=32"`
+	City          string `json:"city" binding:"required,max=255"`
+	AddressLine   string `json:"address_line" binding:"required,max=500"`
+	PostalCode    string `json:"postal_code" binding:"omitempty,numeric,max=16"`
+	Comment       string `json:"comment" binding:"max=500"`
 }
 
 type OrderItemRequest struct {
 	SneakerID int64 `json:"sneaker_id" binding:"required"`
+	VariantID int64 `json:"variant_id" binding:"omitempty,min=1"`
 	Quantity  int32 `json:"quantity" binding:"required,min=1"`
 }
 
@@ -60,46 +94,61 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLen {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 128 characters"})
+		return
+	}
+
 	var req CreateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Собираем все ID товаров для batch
-	sneakerIDs := make([]int64, len(req.Items))
+	// Цены не передаём: order_service сам берёт их из каталога и считает сумму.
+	items := make([]*orderv1.OrderItem, len(req.Items))
 	for i, item := range req.Items {
-		sneakerIDs[i] = item.SneakerID
-	}
-
-	sneakers, err := h.productClient.GetSneakersByIDs(c.Request.Context(), sneakerIDs)
-	if err != nil {
-		h.log.Error("failed to get sneakers", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sneaker_id"})
-		return
+		items[i] = &orderv1.OrderItem{
+			SneakerId: item.SneakerID,
+			VariantId: item.VariantID,
+			Quantity:  item.Quantity,
+		}
 	}
 
-	priceMap := make(map[int64]int64, len(sneakers))
-	for _, s := range sneakers {
-		priceMap[s.GetId()] = s.GetPriceKopecks()
+	in := &orderv1.CreateOrderRequest{
+		Items:          items,
+		CustomerEmail:  middleware.GetUserEmailFromContext(c),
+		IdempotencyKey: idempotencyKey,
+		DeliveryMethod: req.DeliveryMethod,
+		AddressId:      req.AddressID,
+		PromoCode:      req.PromoCode,
 	}
-
-	var items []*orderv1.OrderItem
-	for _, item := range req.Items {
-		price, ok := priceMap[item.SneakerID]
-		if !ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sneaker_id"})
-			return
+	if a := req.Address; a != nil {
+		in.Address = &orderv1.ShippingAddress{
+			RecipientName: a.RecipientName,
+			Phone:         a.Phone,
+			City:          a.City,
+			AddressLine:   a.AddressLine,
+			PostalCode:    a.PostalCode,
+			Comment:       a.Comment,
 		}
-		items = append(items, &orderv1.OrderItem{
-			SneakerId:              item.SneakerID,
-			Quantity:               item.Quantity,
-			PriceAtPurchaseKopecks: price,
-		})
 	}
 
-	order, err := h.orderClient.CreateOrder(c.Request.Context(), userID, items)
+	order, err := h.orderClient.CreateOrder(c.Request.Context(), userID, in)
 	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+			return
+		case codes.FailedPrecondition:
+			c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+			return
+		case codes.Unavailable:
+			// Заказ создан, но без платежа: повтор с тем же Idempotency-Key создаст платёж.
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": status.Convert(err).Message()})
+			return
+		}
 		h.log.Error("failed to create order", slog.String("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create order"})
 		return
@@ -110,9 +159,14 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"order_id":    order.GetId(),
-		"payment_url": order.GetPaymentUrl(),
-		"status":      order.GetStatus(),
+		"order_id":              order.GetId(),
+		"payment_url":           order.GetPaymentUrl(),
+		"status":                order.GetStatus(),
+		"total_amount_kopecks":  order.GetTotalAmountKopecks(),
+		"delivery_method":       order.GetDeliveryMethod(),
+		"delivery_cost_kopecks": order.GetDeliveryCostKopecks(),
+		"promo_code":            order.GetPromoCode(),
+		"discount_kopecks":      order.GetDiscountKopecks(),
 	})
 }
 
@@ -123,13 +177,30 @@ func (h *Handler) GetUserOrders(c *gin.Context) {
 		return
 	}
 
-	orders, err := h.orderClient.GetUserOrders(c.Request.Context(), userID)
+	pageSize, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || pageSize < 0 || pageSize > maxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	resp, err := h.orderClient.GetUserOrders(c.Request.Context(), userID, int32(pageSize), c.Query("page_token"))
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page token"})
+			return
+		}
 		h.log.Error("failed to get user orders", slog.String("error", err.Error()))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get user orders"})
 		return
 	}
 
+	orders := resp.GetOrders()
+	if orders == nil {
+		orders = make([]*orderv1.Order, 0)
+	}
+	if next := resp.GetNextPageToken(); next != "" {
+		c.Header(nextPageTokenHeader, next)
+	}
 	c.JSON(http.StatusOK, orders)
 }
 
@@ -146,10 +217,9 @@ func (h *Handler) GetOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := h.orderClient.GetOrder(c.Request.Context(), orderID)
+	order, err := h.orderClient.GetOrder(c.Request.Context(), userID, orderID)
 	if err != nil {
-		h.log.Error("failed to get order", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get order"})
+		h.writeError(c, err, "failed to get order")
 		return
 	}
 
@@ -161,3 +231,331 @@ func (h *Handler) GetOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, order)
 }
+
+// CancelOrder отменяет неоплаченный заказ пользователя. Оплаченный заказ
+// отменить нельзя (409) — деньги по нему возвращает администратор.
+func (h *Handler) CancelOrder(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	order, err := h.orderClient.CancelOrder(c.Request.Context(), userID, orderID)
+	if err != nil {
+		h.writeError(c, err, "failed to cancel order")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// RetryPayment создаёт новый платёж по заказу, оплата которого не прошла.
+// В ответе заказ с payment_url новой попытки; для заказа не в PAYMENT_FAILED — 409.
+func (h *Handler) RetryPayment(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	order, err := h.orderClient.RetryPayment(c.Request.Context(), userID, orderID)
+	if err != nil {
+		h.writeError(c, err, "failed to retry payment")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+type RefundOrderRequest struct {
+	// AmountKopecks — сумма возврата, 0 или пусто — весь невозвращённый остаток.
+	AmountKopecks int64  `json:"amount_kopecks" binding:"min=0"`
+	Reason        string `json:"reason" binding:"required"`
+	Comment       string `json:"comment" binding:"max=1000"`
+}
+
+// RefundOrder возвращает деньги по оплаченному или отправленному заказу (админ).
+func (h *Handler) RefundOrder(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req RefundOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reason, ok := orderv1.ReasonCode_value["REASON_CODE_"+strings.ToUpper(req.Reason)]
+	if !ok || reason == int32(orderv1.ReasonCode_REASON_CODE_UNSPECIFIED) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown refund reason"})
+		return
+	}
+
+	resp, err := h.orderClient.RefundOrder(c.Request.Context(), adminID, &orderv1.RefundOrderRequest{
+		OrderId:       orderID,
+		AmountKopecks: req.AmountKopecks,
+		Reason:        orderv1.ReasonCode(reason),
+		Comment:       req.Comment,
+	})
+	if err != nil {
+		h.writeError(c, err, "failed to refund order")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"refund": resp.GetRefund(),
+		"order":  resp.GetOrder(),
+	})
+}
+
+type MarkShipmentRequest struct {
+	// Status — PROCESSING, SHIPPED, DELIVERED или RETURNED.
+	Status string `json:"status" binding:"required"`
+	// Carrier и TrackingNumber обязательны для SHIPPED и запрещены для остальных статусов.
+	Carrier        string `json:"carrier" binding:"max=32"`
+	TrackingNumber string `json:"tracking_number" binding:"max=64"`
+}
+
+// MarkShipment двигает оплаченный заказ по цепочке выполнения (админ).
+// Недопустимый для текущего статуса заказа переход — 409.
+func (h *Handler) MarkShipment(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req MarkShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderClient.MarkShipment(c.Request.Context(), adminID, &orderv1.MarkShipmentRequest{
+		OrderId:        orderID,
+		Status:         strings.ToUpper(req.Status),
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	})
+	if err != nil {
+		h.writeError(c, err, "failed to update shipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ListOrdersQuery — фильтры админского списка заказов; пустые параметры не ограничивают выборку.
+type ListOrdersQuery struct {
+	Status string `form:"status"`
+	UserID int64  `form:"user_id" binding:"omitempty,min=1"`
+	// CreatedFrom и CreatedTo — полуинтервал [from, to) в RFC 3339.
+	CreatedFrom      time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo        time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinAmountKopecks int64     `form:"min_amount_kopecks" binding:"min=0"`
+	MaxAmountKopecks int64     `form:"max_amount_kopecks" binding:"min=0"`
+	Limit            int32     `form:"limit" binding:"min=0,max=100"`
+	PageToken        string    `form:"page_token"`
+}
+
+// ListOrders — все заказы магазина с фильтрами, от новых к старым (админ).
+// Токен следующей страницы — в заголовке X-Next-Page-Token.
+func (h *Handler) ListOrders(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var q ListOrdersQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &orderv1.ListOrdersRequest{
+		Status:           strings.ToUpper(q.Status),
+		UserId:           q.UserID,
+		MinAmountKopecks: q.MinAmountKopecks,
+		MaxAmountKopecks: q.MaxAmountKopecks,
+		PageSize:         q.Limit,
+		PageToken:        q.PageToken,
+	}
+	if !q.CreatedFrom.IsZero() {
+		req.CreatedFrom = q.CreatedFrom.Unix()
+	}
+	if !q.CreatedTo.IsZero() {
+		req.CreatedTo = q.CreatedTo.Unix()
+	}
+
+	resp, err := h.orderClient.ListOrders(c.Request.Context(), adminID, req)
+	if err != nil {
+		h.writeError(c, err, "failed to list orders")
+		return
+	}
+
+	orders := resp.GetOrders()
+	if orders == nil {
+		orders = make([]*orderv1.Order, 0)
+	}
+	if next := resp.GetNextPageToken(); next != "" {
+		c.Header(nextPageTokenHeader, next)
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+// ListMismatchesQuery — фильтры списка расхождений сверки платежей.
+type ListMismatchesQuery struct {
+	Kind           string `form:"kind" binding:"omitempty,oneof=status amount missing provider_error"`
+	UnresolvedOnly bool   `form:"unresolved_only"`
+	Limit          int32  `form:"limit" binding:"min=0,max=100"`
+	PageToken      string `form:"page_token"`
+}
+
+// ListReconciliationMismatches — расхождения, найденные сверкой платежей с провайдером,
+// от новых к старым (админ). Токен следующей страницы — в заголовке X-Next-Page-Token.
+func (h *Handler) ListReconciliationMismatches(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var q ListMismatchesQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.orderClient.ListReconciliationMismatches(c.Request.Context(), adminID, &orderv1.ListReconciliationMismatchesRequest{
+		Kind:           q.Kind,
+		UnresolvedOnly: q.UnresolvedOnly,
+		PageSize:       q.Limit,
+		PageToken:      q.PageToken,
+	})
+	if err != nil {
+		h.writeError(c, err, "failed to list reconciliation mismatches")
+		return
+	}
+
+	mismatches := resp.GetMismatches()
+	if mismatches == nil {
+		mismatches = make([]*orderv1.ReconciliationMismatch, 0)
+	}
+	if next := resp.GetNextPageToken(); next != "" {
+		c.Header(nextPageTokenHeader, next)
+	}
+	c.JSON(http.StatusOK, mismatches)
+}
+
+// AdminGetOrder — любой заказ с историей статусов (админ).
+func (h *Handler) AdminGetOrder(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	order, err := h.orderClient.AdminGetOrder(c.Request.Context(), adminID, orderID)
+	if err != nil {
+		h.writeError(c, err, "failed to get order")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// UpdateOrderStatus меняет статус заказа по правилам переходов (админ); в ответе
+// обновлённый заказ. Недопустимый переход — 409, возврат денег — только через /refund,
+// статусы выполнения — только через /shipment.
+func (h *Handler) UpdateOrderStatus(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.orderClient.UpdateOrderStatus(ctx, adminID, orderID, strings.ToUpper(req.Status)); err != nil {
+		h.writeError(c, err, "failed to update order status")
+		return
+	}
+
+	order, err := h.orderClient.AdminGetOrder(ctx, adminID, orderID)
+	if err != nil {
+		h.writeError(c, err, "failed to get order")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// writeError переводит ошибку order_service в HTTP-ответ.
+func (h *Handler) writeError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		return
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		return
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	case codes.FailedPrecondition:
+		c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+}
@@ -11,15 +11,25 @@ import (
 	productv1 "github.com/stpnv0/protos/gen/go/product"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type ProductClient interface {
-	GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*productv1.Sneaker, error)
+	GetAllSneakers(ctx context.Context, limit uint64, pageToken string) (*productv1.GetAllSneakersResponse, error)
 	GetSneakerByID(ctx context.Context, id int64) (*productv1.Sneaker, error)
-	AddSneaker(ctx context.Context, title string, priceKopecks int64) (*productv1.Sneaker, error)
+	SearchSneakers(ctx context.Context, req *productv1.SearchSneakersRequest) (*productv1.SearchSneakersResponse, error)
+	AddSneaker(ctx context.Context, req *productv1.AddSneakerRequest) (*productv1.Sneaker, error)
+	UpdateSneaker(ctx context.Context, req *productv1.UpdateSneakerRequest) (*productv1.Sneaker, error)
+	DeleteSneaker(ctx context.Context, id int64) error
+	RestoreSneaker(ctx context.Context, id int64) error
 	GenerateUploadURL(ctx context.Context, originalFilename, contentType string) (*productv1.GenerateUploadURLResponse, error)
 	UpdateProductImage(ctx context.Context, productID int64, imageKey string) error
 	GetSneakersByIDs(ctx context.Context, ids []int64) ([]*productv1.Sneaker, error)
+	CreateVariant(ctx context.Context, req *productv1.CreateVariantRequest) (*productv1.SneakerVariant, error)
+	ListVariants(ctx context.Context, sneakerID int64) ([]*productv1.SneakerVariant, error)
+	UpdateVariant(ctx context.Context, req *productv1.UpdateVariantRequest) (*productv1.SneakerVariant, error)
+	SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*productv1.StockLevel, error)
+	GetStock(ctx context.Context, sneakerID, variantID int64) (*productv1.StockLevel, error)
 }
 
 type Handler struct {
@@ -97,34 +107,118 @@ func (h *Handler) GetAllSneakers(c *gin.Context) {
 		limit = maxLimit
 	}
 
-	offset, err := strconv.ParseUint(c.DefaultQuery("offset", "0"), 10, 64)
+	resp, err := h.client.GetAllSneakers(c.Request.Context(), limit, c.Query("page_token"))
 	if err != nil {
-		offset = 0
+		handleGRPCError(c, h.log, err, "failed to get all products")
+		return
+	}
+	sneakers := resp.GetSneakers()
+	if sneakers == nil {
+		sneakers = make([]*productv1.Sneaker, 0)
 	}
 
-	sneakers, err := h.client.GetAllSneakers(c.Request.Context(), limit, offset)
+	c.JSON(http.StatusOK, gin.H{"sneakers": sneakers, "next_page_token": resp.GetNextPageToken()})
+}
+
+var searchSorts = map[string]productv1.SearchSort{
+	"":           productv1.SearchSort_SEARCH_SORT_RELEVANCE,
+	"relevance":  productv1.SearchSort_SEARCH_SORT_RELEVANCE,
+	"price_asc":  productv1.SearchSort_SEARCH_SORT_PRICE_ASC,
+	"price_desc": productv1.SearchSort_SEARCH_SORT_PRICE_DESC,
+	"newest":     productv1.SearchSort_SEARCH_SORT_NEWEST,
+	"popularity": productv1.SearchSort_SEARCH_SORT_POPULARITY,
+}
+
+// SearchSneakers - GET /api/v1/products/search
+func (h *Handler) SearchSneakers(c *gin.Context) {
+	var query struct {
+		Q         string   `form:"q" binding:"max=256"`
+		MinPrice  int64    `form:"min_price" binding:"gte=0"`
+		MaxPrice  int64    `form:"max_price" binding:"gte=0"`
+		Brand     []string `form:"brand"`
+		Category  []string `form:"category"`
+		Sort      string   `form:"sort"`
+		Limit     uint64   `form:"limit"`
+		PageToken string   `form:"page_token"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sort, ok := searchSorts[query.Sort]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort, expected one of: relevance, price_asc, price_desc, newest, popularity"})
+		return
+	}
+	if query.MaxPrice > 0 && query.MinPrice > query.MaxPrice {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_price must not exceed max_price"})
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	resp, err := h.client.SearchSneakers(c.Request.Context(), &productv1.SearchSneakersRequest{
+		Query:           query.Q,
+		MinPriceKopecks: query.MinPrice,
+		MaxPriceKopecks: query.MaxPrice,
+		Brands:          splitList(query.Brand),
+		Categories:      splitList(query.Category),
+		Sort:            sort,
+		Limit:           limit,
+		PageToken:       query.PageToken,
+	})
 	if err != nil {
-		handleGRPCError(c, h.log, err, "failed to get all products")
+		handleGRPCError(c, h.log, err, "failed to search products")
 		return
 	}
+	sneakers := resp.GetSneakers()
 	if sneakers == nil {
 		sneakers = make([]*productv1.Sneaker, 0)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"sneakers": sneakers})
+	c.JSON(http.StatusOK, gin.H{"sneakers": sneakers, "next_page_token": resp.GetNextPageToken()})
+}
+
+// splitList принимает как повторяющиеся параметры (?brand=a&brand=b), так и список через запятую.
+func splitList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
 }
 
 func (h *Handler) AddSneaker(c *gin.Context) {
 	var reqBody struct {
 		Title        string `json:"title" binding:"required"`
 		PriceKopecks int64  `json:"price_kopecks" binding:"required,gt=0"`
+		Brand        string `json:"brand" binding:"max=64"`
+		Category     string `json:"category" binding:"max=64"`
+		Description  string `json:"description" binding:"max=4000"`
 	}
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	sneaker, err := h.client.AddSneaker(c.Request.Context(), reqBody.Title, reqBody.PriceKopecks)
+	sneaker, err := h.client.AddSneaker(c.Request.Context(), &productv1.AddSneakerRequest{
+		Title:        reqBody.Title,
+		PriceKopecks: reqBody.PriceKopecks,
+		Brand:        reqBody.Brand,
+		Category:     reqBody.Category,
+		Description:  reqBody.Description,
+	})
 	if err != nil {
 		handleGRPCError(c, h.log, err, "failed to add product")
 		return
@@ -132,6 +226,98 @@ func (h *Handler) AddSneaker(c *gin.Context) {
 	c.JSON(http.StatusCreated, sneaker)
 }
 
+// UpdateSneaker - PATCH /api/v1/products/:id
+// Меняются только переданные в теле поля; version — версия карточки,
+// которую видел клиент. Если товар успели изменить, вернётся 409.
+func (h *Handler) UpdateSneaker(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	var reqBody struct {
+		Title        *string `json:"title" binding:"omitempty,min=1,max=255"`
+		PriceKopecks *int64  `json:"price_kopecks" binding:"omitempty,gt=0"`
+		Description  *string `json:"description" binding:"omitempty,max=4000"`
+		Brand        *string `json:"brand" binding:"omitempty,max=64"`
+		Category     *string `json:"category" binding:"omitempty,max=64"`
+		Version      int64   `json:"version" binding:"required,gt=0"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &productv1.UpdateSneakerRequest{
+		Id:              id,
+		UpdateMask:      &fieldmaskpb.FieldMask{},
+		ExpectedVersion: reqBody.Version,
+	}
+	if reqBody.Title != nil {
+		req.Title = *reqBody.Title
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "title")
+	}
+	if reqBody.PriceKopecks != nil {
+		req.PriceKopecks = *reqBody.PriceKopecks
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "price_kopecks")
+	}
+	if reqBody.Description != nil {
+		req.Description = *reqBody.Description
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
+	}
+	if reqBody.Brand != nil {
+		req.Brand = *reqBody.Brand
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "brand")
+	}
+	if reqBody.Category != nil {
+		req.Category = *reqBody.Category
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "category")
+	}
+	if len(req.UpdateMask.Paths) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+		return
+	}
+
+	sneaker, err := h.client.UpdateSneaker(c.Request.Context(), req)
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to update product")
+		return
+	}
+	c.JSON(http.StatusOK, sneaker)
+}
+
+// DeleteSneaker - DELETE /api/v1/products/:id
+// Товар архивируется: пропадает из каталога, но остаётся в истории заказов.
+func (h *Handler) DeleteSneaker(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	if err := h.client.DeleteSneaker(c.Request.Context(), id); err != nil {
+		handleGRPCError(c, h.log, err, "failed to delete product")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// RestoreSneaker - POST /api/v1/products/:id/restore
+func (h *Handler) RestoreSneaker(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	if err := h.client.RestoreSneaker(c.Request.Context(), id); err != nil {
+		handleGRPCError(c, h.log, err, "failed to restore product")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
 func (h *Handler) GenerateUploadURL(c *gin.Context) {
 	var reqBody struct {
 		OriginalFilename string `json:"original_filename" binding:"required"`
@@ -172,6 +358,148 @@ func (h *Handler) UpdateProductImage(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "success"})
 }
 
+type variantRequest struct {
+	SKU          string `json:"sku" binding:"required,max=64"`
+	Size         string `json:"size" binding:"required,max=16"`
+	Color        string `json:"color" binding:"required,max=64"`
+	PriceKopecks int64  `json:"price_kopecks" binding:"gte=0"`
+}
+
+// ListVariants - GET /api/v1/products/:id/variants
+func (h *Handler) ListVariants(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	variants, err := h.client.ListVariants(c.Request.Context(), id)
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to list variants")
+		return
+	}
+	if variants == nil {
+		variants = make([]*productv1.SneakerVariant, 0)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"variants": variants})
+}
+
+// CreateVariant - POST /api/v1/products/:id/variants
+func (h *Handler) CreateVariant(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	var reqBody variantRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.client.CreateVariant(c.Request.Context(), &productv1.CreateVariantRequest{
+		SneakerId:    id,
+		Sku:          reqBody.SKU,
+		Size:         reqBody.Size,
+		Color:        reqBody.Color,
+		PriceKopecks: reqBody.PriceKopecks,
+	})
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to create variant")
+		return
+	}
+	c.JSON(http.StatusCreated, variant)
+}
+
+// UpdateVariant - PUT /api/v1/products/:id/variants/:variant_id
+func (h *Handler) UpdateVariant(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	variantID, err := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+	if err != nil || variantID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid variant ID"})
+		return
+	}
+
+	var reqBody variantRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.client.UpdateVariant(c.Request.Context(), &productv1.UpdateVariantRequest{
+		Id:           variantID,
+		SneakerId:    id,
+		Sku:          reqBody.SKU,
+		Size:         reqBody.Size,
+		Color:        reqBody.Color,
+		PriceKopecks: reqBody.PriceKopecks,
+	})
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to update variant")
+		return
+	}
+	c.JSON(http.StatusOK, variant)
+}
+
+// GetStock - GET /api/v1/products/:id/stock?variant_id=
+func (h *Handler) GetStock(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	variantID, err := strconv.ParseInt(c.DefaultQuery("variant_id", "0"), 10, 64)
+	if err != nil || variantID < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid variant ID"})
+		return
+	}
+
+	level, err := h.client.GetStock(c.Request.Context(), id, variantID)
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to get stock")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sneaker_id": level.GetSneakerId(),
+		"variant_id": level.GetVariantId(),
+		"available":  level.GetAvailable(),
+	})
+}
+
+// SetStock - PUT /api/v1/products/:id/stock
+func (h *Handler) SetStock(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		return
+	}
+
+	var reqBody struct {
+		VariantID int64  `json:"variant_id" binding:"gte=0"`
+		Quantity  *int64 `json:"quantity" binding:"required,gte=0"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	level, err := h.client.SetStock(c.Request.Context(), id, reqBody.VariantID, *reqBody.Quantity)
+	if err != nil {
+		handleGRPCError(c, h.log, err, "failed to set stock")
+		return
+	}
+	c.JSON(http.StatusOK, level)
+}
+
 func handleGRPCError(c *gin.Context, log *slog.Logger, err error, message string) {
 	st, ok := status.FromError(err)
 	if !ok {
@@ -185,6 +513,8 @@ func handleGRPCError(c *gin.Context, log *slog.Logger, err error, message string
 		c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
 	case codes.InvalidArgument:
 		c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
 	case codes.Unauthenticated:
 		c.JSON(http.StatusUnauthorized, gin.H{"error": st.Message()})
 	default:
@@ -0,0 +1,193 @@
+package promo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	orderv1 "github.com/stpnv0/protos/gen/go/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api_gateway/internal/middleware"
+)
+
+type PromoClient interface {
+	CreatePromoCode(ctx context.Context, adminID int64, promo *orderv1.PromoCode) (*orderv1.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, adminID int64, promo *orderv1.PromoCode) (*orderv1.PromoCode, error)
+	GetPromoCode(ctx context.Context, adminID, id int64) (*orderv1.PromoCode, error)
+	ListPromoCodes(ctx context.Context, adminID int64, activeOnly bool) ([]*orderv1.PromoCode, error)
+}
+
+// Handler — управление промокодами (только для администраторов).
+type Handler struct {
+	client PromoClient
+	log    *slog.Logger
+}
+
+func NewHandler(client PromoClient, log *slog.Logger) *Handler {
+	return &Handler{client: client, log: log}
+}
+
+// PromoCodeRequest — параметры промокода. При обновлении Code игнорируется,
+// остальные поля перезаписываются целиком.
+type PromoCodeRequest struct {
+	Code string `json:"code" binding:"max=32"`
+	// Kind — percent (Value — процент, 1–99) или fixed (Value — скидка в копейках).
+	Kind                  string `json:"kind" binding:"required,oneof=percent fixed"`
+	Value                 int64  `json:"value" binding:"required,min=1"`
+	MinOrderAmountKopecks int64  `json:"min_order_amount_kopecks" binding:"min=0"`
+	MaxUsesPerUser        int32  `json:"max_uses_per_user" binding:"min=0"`
+	// ValidFrom и ValidTo — полуинтервал [from, to) действия кода; пустые — без ограничения.
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidTo    *time.Time `json:"valid_to"`
+	ProductIDs []int64    `json:"product_ids" binding:"dive,min=1"`
+	Brands     []string   `json:"brands" binding:"dive,required,max=255"`
+	// Active — nil при создании означает включённый промокод.
+	Active *bool `json:"active"`
+}
+
+func (r PromoCodeRequest) toProto() *orderv1.PromoCode {
+	promo := &orderv1.PromoCode{
+		Code:                  r.Code,
+		Kind:                  r.Kind,
+		Value:                 r.Value,
+		MinOrderAmountKopecks: r.MinOrderAmountKopecks,
+		MaxUsesPerUser:        r.MaxUsesPerUser,
+		ProductIds:            r.ProductIDs,
+		Brands:                r.Brands,
+		Active:                r.Active == nil || *r.Active,
+	}
+	if r.ValidFrom != nil {
+		promo.ValidFrom = r.ValidFrom.Unix()
+	}
+	if r.ValidTo != nil {
+		promo.ValidTo = r.ValidTo.Unix()
+	}
+	return promo
+}
+
+// ListPromoCodes - GET /admin/promo-codes?active=true
+func (h *Handler) ListPromoCodes(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	promos, err := h.client.ListPromoCodes(c.Request.Context(), adminID, activeOnly)
+	if err != nil {
+		h.writeError(c, err, "failed to list promo codes")
+		return
+	}
+
+	if promos == nil {
+		promos = make([]*orderv1.PromoCode, 0)
+	}
+	c.JSON(http.StatusOK, promos)
+}
+
+// CreatePromoCode - POST /admin/promo-codes. Занятый код — 409.
+func (h *Handler) CreatePromoCode(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req PromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	promo, err := h.client.CreatePromoCode(c.Request.Context(), adminID, req.toProto())
+	if err != nil {
+		h.writeError(c, err, "failed to create promo code")
+		return
+	}
+
+	c.JSON(http.StatusCreated, promo)
+}
+
+// GetPromoCode - GET /admin/promo-codes/:id
+func (h *Handler) GetPromoCode(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid promo code id"})
+		return
+	}
+
+	promo, err := h.client.GetPromoCode(c.Request.Context(), adminID, id)
+	if err != nil {
+		h.writeError(c, err, "failed to get promo code")
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+// UpdatePromoCode - PUT /admin/promo-codes/:id. Выключить код — "active": false.
+func (h *Handler) UpdatePromoCode(c *gin.Context) {
+	adminID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid promo code id"})
+		return
+	}
+
+	var req PromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	in := req.toProto()
+	in.Id = id
+
+	promo, err := h.client.UpdatePromoCode(c.Request.Context(), adminID, in)
+	if err != nil {
+		h.writeError(c, err, "failed to update promo code")
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+// writeError переводит ошибку order_service в HTTP-ответ.
+func (h *Handler) writeError(c *gin.Context, err error, msg string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		return
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "promo code not found"})
+		return
+	case codes.AlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "promo code already exists"})
+		return
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+}
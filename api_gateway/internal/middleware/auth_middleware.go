@@ -14,6 +14,7 @@ import (
 const (
 	authorizationHeader = "Authorization"
 	userCtx             = "user_sso_id"
+	userEmailCtx        = "user_email"
 )
 
 func AuthMiddleware(appSecret string, log *slog.Logger) gin.HandlerFunc {
@@ -72,6 +73,10 @@ func AuthMiddleware(appSecret string, log *slog.Logger) gin.HandlerFunc {
 		}
 
 		c.Set(userCtx, int64(userID))
+		// email в токене необязателен: старые токены выпускались без него.
+		if email, ok := claims["email"].(string); ok {
+			c.Set(userEmailCtx, email)
+		}
 		c.Next()
 	}
 }
@@ -87,3 +92,8 @@ func GetUserIDFromContext(c *gin.Context) (int64, error) {
 	}
 	return uid, nil
 }
+
+// GetUserEmailFromContext возвращает email из токена или пустую строку, если его там нет.
+func GetUserEmailFromContext(c *gin.Context) string {
+	return c.GetString(userEmailCtx)
+}
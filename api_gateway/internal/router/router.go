@@ -3,10 +3,13 @@ package router
 import (
 	"log/slog"
 
+	address_handler "api_gateway/internal/handler/address"
 	cart_handler "api_gateway/internal/handler/cart"
 	fav_handler "api_gateway/internal/handler/favourites"
+	notification_handler "api_gateway/internal/handler/notification"
 	order_handler "api_gateway/internal/handler/order"
 	product_handler "api_gateway/internal/handler/product"
+	promo_handler "api_gateway/internal/handler/promo"
 	auth_handler "api_gateway/internal/handler/sso"
 	"api_gateway/internal/middleware"
 
@@ -14,11 +17,14 @@ import (
 )
 
 type Handlers struct {
-	Product    *product_handler.Handler
-	Auth       *auth_handler.Handler
-	Cart       *cart_handler.Handler
-	Favourites *fav_handler.Handler
-	Order      *order_handler.Handler
+	Product      *product_handler.Handler
+	Auth         *auth_handler.Handler
+	Cart         *cart_handler.Handler
+	Favourites   *fav_handler.Handler
+	Order        *order_handler.Handler
+	Address      *address_handler.Handler
+	Promo        *promo_handler.Handler
+	Notification *notification_handler.Handler
 }
 
 func New(appSecret string, log *slog.Logger, h Handlers, adminChecker middleware.AdminChecker) *gin.Engine {
@@ -41,8 +47,11 @@ func New(appSecret string, log *slog.Logger, h Handlers, adminChecker middleware
 		productsPublic := apiV1.Group("/products")
 		{
 			productsPublic.GET("", h.Product.GetAllSneakers)
+			productsPublic.GET("/search", h.Product.SearchSneakers)
 			productsPublic.GET("/:id", h.Product.GetSneakerByID)
 			productsPublic.GET("/batch", h.Product.GetSneakersByIDs)
+			productsPublic.GET("/:id/variants", h.Product.ListVariants)
+			productsPublic.GET("/:id/stock", h.Product.GetStock)
 		}
 
 		authPublic := apiV1.Group("/auth")
@@ -59,7 +68,13 @@ func New(appSecret string, log *slog.Logger, h Handlers, adminChecker middleware
 			productsAdmin.Use(adminMW)
 			{
 				productsAdmin.POST("", h.Product.AddSneaker)
+				productsAdmin.PATCH("/:id", h.Product.UpdateSneaker)
+				productsAdmin.DELETE("/:id", h.Product.DeleteSneaker)
+				productsAdmin.POST("/:id/restore", h.Product.RestoreSneaker)
 				productsAdmin.POST("/:id/image", h.Product.UpdateProductImage)
+				productsAdmin.POST("/:id/variants", h.Product.CreateVariant)
+				productsAdmin.PUT("/:id/variants/:variant_id", h.Product.UpdateVariant)
+				productsAdmin.PUT("/:id/stock", h.Product.SetStock)
 			}
 
 			auth.POST("/images/generate-upload-url", h.Product.GenerateUploadURL)
@@ -81,11 +96,50 @@ func New(appSecret string, log *slog.Logger, h Handlers, adminChecker middleware
 				favRoutes.GET("/batch", h.Favourites.GetFavouritesByIDs)
 			}
 
+			addressRoutes := auth.Group("/addresses")
+			{
+				addressRoutes.GET("/", h.Address.ListAddresses)
+				addressRoutes.POST("/", h.Address.CreateAddress)
+				addressRoutes.PUT("/:id", h.Address.UpdateAddress)
+				addressRoutes.DELETE("/:id", h.Address.DeleteAddress)
+			}
+
 			orderRoutes := auth.Group("/orders")
 			{
 				orderRoutes.POST("/", h.Order.CreateOrder)
 				orderRoutes.GET("/", h.Order.GetUserOrders)
 				orderRoutes.GET("/:id", h.Order.GetOrder)
+				orderRoutes.POST("/:id/cancel", h.Order.CancelOrder)
+				orderRoutes.POST("/:id/pay", h.Order.RetryPayment)
+				orderRoutes.POST("/:id/refund", adminMW, h.Order.RefundOrder)
+				orderRoutes.POST("/:id/shipment", adminMW, h.Order.MarkShipment)
+			}
+
+			notificationRoutes := auth.Group("/notifications")
+			{
+				notificationRoutes.GET("/preferences", h.Notification.GetPreferences)
+				notificationRoutes.PUT("/preferences", h.Notification.UpdatePreferences)
+			}
+
+			// Управление заказами всех пользователей (только для администраторов).
+			ordersAdmin := auth.Group("/admin/orders")
+			ordersAdmin.Use(adminMW)
+			{
+				ordersAdmin.GET("", h.Order.ListOrders)
+				ordersAdmin.GET("/:id", h.Order.AdminGetOrder)
+				ordersAdmin.PUT("/:id/status", h.Order.UpdateOrderStatus)
+			}
+
+			// Расхождения сверки платежей с провайдером (только для администраторов).
+			auth.GET("/admin/reconciliation/mismatches", adminMW, h.Order.ListReconciliationMismatches)
+
+			promoAdmin := auth.Group("/admin/promo-codes")
+			promoAdmin.Use(adminMW)
+			{
+				promoAdmin.GET("", h.Promo.ListPromoCodes)
+				promoAdmin.POST("", h.Promo.CreatePromoCode)
+				promoAdmin.GET("/:id", h.Promo.GetPromoCode)
+				promoAdmin.PUT("/:id", h.Promo.UpdatePromoCode)
 			}
 		}
 	}
@@ -11,8 +11,11 @@ import (
 
 	"github.com/go-redis/redis/v8"
 
+	"cart_service/internal/abandoned"
+	productclient "cart_service/internal/client/product"
 	"cart_service/internal/config"
 	grpcapp "cart_service/internal/grpc"
+	"cart_service/internal/kafka"
 	"cart_service/internal/repository"
 	"cart_service/internal/services"
 )
@@ -61,6 +64,17 @@ func run() error {
 	defer db.Close()
 	log.Info("connected to postgres")
 
+	// Kafka
+	producer := kafka.NewProducer(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Kafka.QueueSize, log)
+	defer producer.Close()
+
+	// product_service
+	productClient, err := productclient.New(cfg.Product.Addr, cfg.Product.Timeout)
+	if err != nil {
+		return err
+	}
+	defer productClient.Close()
+
 	// Связывание зависимостей
 	redisRepo := repository.NewRedisRepository(redisClient)
 	pgRepo := repository.NewPostgresRepository(db)
@@ -69,7 +83,14 @@ func run() error {
 	if err != nil {
 		expiration = 24 * time.Hour
 	}
-	cartService := services.NewCartCacheAsideService(pgRepo, redisRepo, log, expiration)
+	cartService := services.NewCartCacheAsideService(pgRepo, redisRepo, productClient, producer, log, expiration)
+
+	abandonedWorker := abandoned.NewWorker(cartService, abandoned.Config{
+		IdleAfter: cfg.Abandoned.IdleAfter,
+		Interval:  cfg.Abandoned.Interval,
+		BatchSize: cfg.Abandoned.BatchSize,
+	}, log)
+	go abandonedWorker.Run(ctx)
 
 	grpcApp := grpcapp.New(log, cartService, cfg.GRPC.Port)
 
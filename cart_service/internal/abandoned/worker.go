@@ -0,0 +1,92 @@
+package abandoned
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Detector публикует события о брошенных корзинах.
+type Detector interface {
+	PublishAbandonedCarts(ctx context.Context, idleBefore time.Time, limit int) (found, published int, err error)
+}
+
+// Config — настройки планировщика.
+type Config struct {
+	// IdleAfter — сколько корзина должна пролежать без изменений.
+	IdleAfter time.Duration
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Worker раз в Interval ищет корзины с товарами, не менявшиеся дольше IdleAfter,
+// и публикует о них CartAbandoned. Рассчитан на одну реплику: при нескольких
+// событие может уйти дважды, но с тем же id — потребители отбросят повтор.
+type Worker struct {
+	detector Detector
+	cfg      Config
+	log      *slog.Logger
+}
+
+func NewWorker(detector Detector, cfg Config, log *slog.Logger) *Worker {
+	return &Worker{
+		detector: detector,
+		cfg:      cfg,
+		log:      log,
+	}
+}
+
+// Run ищет брошенные корзины раз в Interval, пока не отменён ctx.
+func (w *Worker) Run(ctx context.Context) {
+	const op = "abandoned.Worker.Run"
+
+	w.log.Info("abandoned cart detector started",
+		slog.String("op", op),
+		slog.Duration("idle_after", w.cfg.IdleAfter),
+		slog.Duration("interval", w.cfg.Interval),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("abandoned cart detector stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		w.drain(ctx)
+	}
+}
+
+// drain разбирает пачки, пока они приходят полными. Если часть корзин опубликовать
+// не удалось, проход заканчивается: они вернутся в следующей пачке, и без паузы
+// Worker крутился бы на них, пока Kafka недоступна.
+func (w *Worker) drain(ctx context.Context) {
+	const op = "abandoned.Worker.drain"
+
+	for ctx.Err() == nil {
+		found, published, err := w.detector.PublishAbandonedCarts(ctx, time.Now().Add(-w.cfg.IdleAfter), w.cfg.BatchSize)
+		if err != nil {
+			w.log.Error("failed to detect abandoned carts",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+
+		if found > 0 {
+			w.log.Info("abandoned carts published",
+				slog.String("op", op),
+				slog.Int("found", found),
+				slog.Int("published", published),
+			)
+		}
+
+		if found < w.cfg.BatchSize || published < found {
+			return
+		}
+	}
+}
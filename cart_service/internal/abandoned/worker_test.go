@@ -0,0 +1,72 @@
+package abandoned
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDetector отдаёт корзины пачками по limit; failing первых корзин не публикуются.
+type fakeDetector struct {
+	pending int
+	failing int
+	err     error
+	calls   int
+	cutoffs []time.Time
+}
+
+func (d *fakeDetector) PublishAbandonedCarts(_ context.Context, idleBefore time.Time, limit int) (int, int, error) {
+	d.calls++
+	d.cutoffs = append(d.cutoffs, idleBefore)
+	if d.err != nil {
+		return 0, 0, d.err
+	}
+	found := min(d.pending, limit)
+	failed := min(d.failing, found)
+	d.pending -= found - failed
+	return found, found - failed, nil
+}
+
+func newTestWorker(d Detector, batchSize int) *Worker {
+	return NewWorker(d, Config{IdleAfter: 24 * time.Hour, Interval: time.Minute, BatchSize: batchSize},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWorker_DrainsFullBatches(t *testing.T) {
+	d := &fakeDetector{pending: 5}
+
+	newTestWorker(d, 2).drain(context.Background())
+
+	assert.Equal(t, 0, d.pending)
+	assert.Equal(t, 3, d.calls, "2 + 2 + 1: stops after a partial batch")
+}
+
+func TestWorker_UsesIdleAfterAsCutoff(t *testing.T) {
+	d := &fakeDetector{}
+
+	newTestWorker(d, 10).drain(context.Background())
+
+	assert.Len(t, d.cutoffs, 1)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), d.cutoffs[0], time.Second)
+}
+
+func TestWorker_StopsWhenPublishFails(t *testing.T) {
+	d := &fakeDetector{pending: 10, failing: 1}
+
+	newTestWorker(d, 2).drain(context.Background())
+
+	assert.Equal(t, 1, d.calls)
+}
+
+func TestWorker_StopsOnError(t *testing.T) {
+	d := &fakeDetector{pending: 10, err: errors.New("db down")}
+
+	newTestWorker(d, 2).drain(context.Background())
+
+	assert.Equal(t, 1, d.calls)
+}
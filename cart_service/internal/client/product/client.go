@@ -0,0 +1,78 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	productv1 "github.com/stpnv0/protos/gen/go/product"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"cart_service/internal/models"
+)
+
+// Client — gRPC-клиент product_service: проверяет товары перед добавлением в корзину.
+type Client struct {
+	api     productv1.ProductClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+}
+
+func New(addr string, timeout time.Duration) (*Client, error) {
+	const op = "product.New"
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		api:     productv1.NewProductClient(cc),
+		conn:    cc,
+		timeout: timeout,
+	}, nil
+}
+
+// Close закрывает gRPC-соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ValidateItem проверяет, что товар sneakerID есть в каталоге и не снят с продажи,
+// а вариант variantID (0 — без варианта) принадлежит этому товару.
+// Иначе — models.ErrProductUnavailable.
+func (c *Client) ValidateItem(ctx context.Context, sneakerID, variantID int) error {
+	const op = "product.Client.ValidateItem"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	sneaker, err := c.api.GetSneakerByID(ctx, &productv1.GetSneakerByIDRequest{Id: int64(sneakerID)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%s: %w: sneaker %d not found", op, models.ErrProductUnavailable, sneakerID)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if sneaker.GetArchived() {
+		return fmt.Errorf("%s: %w: sneaker %d is archived", op, models.ErrProductUnavailable, sneakerID)
+	}
+	if variantID == 0 {
+		return nil
+	}
+
+	resp, err := c.api.GetVariantsByIDs(ctx, &productv1.GetVariantsByIDsRequest{Ids: []int64{int64(variantID)}})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, v := range resp.GetVariants() {
+		if v.GetId() == int64(variantID) && v.GetSneakerId() == int64(sneakerID) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w: variant %d of sneaker %d not found",
+		op, models.ErrProductUnavailable, variantID, sneakerID)
+}
@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config содержит всю конфигурацию сервиса корзины.
 type Config struct {
-	Env      string         `yaml:"env"`
-	GRPC     GRPCConfig     `yaml:"grpc"`
-	Redis    RedisConfig    `yaml:"redis"`
-	Postgres PostgresConfig `yaml:"postgres"`
+	Env       string          `yaml:"env"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Postgres  PostgresConfig  `yaml:"postgres"`
+	Kafka     KafkaConfig     `yaml:"kafka"`
+	Product   ProductConfig   `yaml:"product"`
+	Abandoned AbandonedConfig `yaml:"abandoned"`
 }
 
 // GRPCConfig содержит настройки gRPC-сервера.
@@ -42,6 +46,28 @@ type PostgresConfig struct {
 	ConnectionTimeoutS int    `yaml:"connection_timeout"`
 }
 
+// KafkaConfig содержит параметры публикации событий корзины.
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+	// QueueSize — сколько изменений корзины ждут отправки в брокер; сверх этого события отбрасываются.
+	QueueSize int `yaml:"queue_size"`
+}
+
+// ProductConfig содержит адрес product_service, в котором проверяются добавляемые товары.
+type ProductConfig struct {
+	Addr    string        `yaml:"addr"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// AbandonedConfig содержит настройки поиска брошенных корзин.
+type AbandonedConfig struct {
+	// IdleAfter — сколько корзина с товарами должна пролежать без изменений, чтобы считаться брошенной.
+	IdleAfter time.Duration `yaml:"idle_after"`
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
 // DSN возвращает строку подключения к PostgreSQL.
 func (p PostgresConfig) DSN() string {
 	host := p.Host
@@ -76,5 +102,31 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("config: parse yaml: %w", err)
 	}
 
+	cfg.setDefaults()
+
 	return &cfg, nil
 }
+
+func (c *Config) setDefaults() {
+	if c.Kafka.Topic == "" {
+		c.Kafka.Topic = "carts"
+	}
+	if c.Kafka.QueueSize == 0 {
+		c.Kafka.QueueSize = 1000
+	}
+	if c.Product.Addr == "" {
+		c.Product.Addr = "product_service:44045"
+	}
+	if c.Product.Timeout == 0 {
+		c.Product.Timeout = 3 * time.Second
+	}
+	if c.Abandoned.IdleAfter == 0 {
+		c.Abandoned.IdleAfter = 24 * time.Hour
+	}
+	if c.Abandoned.Interval == 0 {
+		c.Abandoned.Interval = 15 * time.Minute
+	}
+	if c.Abandoned.BatchSize == 0 {
+		c.Abandoned.BatchSize = 100
+	}
+}
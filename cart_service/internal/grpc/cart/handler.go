@@ -3,6 +3,7 @@ package cart
 import (
 	"cart_service/internal/models"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -15,9 +16,9 @@ import (
 
 // CartService interface for business logic
 type CartService interface {
-	AddToCart(ctx context.Context, userSSOID, sneakerID, quantity int) error
+	AddToCart(ctx context.Context, userSSOID, sneakerID, variantID, quantity int) (*models.CartItem, error)
 	GetCart(ctx context.Context, userSSOID int) (*models.Cart, error)
-	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error
+	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error)
 	RemoveFromCart(ctx context.Context, userSSOID int, itemID string) error
 	ClearCart(ctx context.Context, userSSOID int) error
 }
@@ -48,6 +49,9 @@ func (s *serverAPI) AddToCart(
 	if req.GetSneakerId() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "sneaker_id must be positive")
 	}
+	if req.GetVariantId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "variant_id must not be negative")
+	}
 	if req.GetQuantity() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "quantity must be positive")
 	}
@@ -59,8 +63,11 @@ func (s *serverAPI) AddToCart(
 	}
 
 	// Call business logic
-	err = s.cartService.AddToCart(ctx, userID, int(req.GetSneakerId()), int(req.GetQuantity()))
+	item, err := s.cartService.AddToCart(ctx, userID, int(req.GetSneakerId()), int(req.GetVariantId()), int(req.GetQuantity()))
 	if err != nil {
+		if errors.Is(err, models.ErrProductUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "product or variant is unavailable")
+		}
 		s.log.Error("failed to add to cart", slog.String("op", op), slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to add item to cart")
 	}
@@ -68,6 +75,7 @@ func (s *serverAPI) AddToCart(
 	return &cartv1.AddToCartResponse{
 		Success: true,
 		Message: "Item added to cart successfully",
+		Item:    convertToProtoCartItem(*item),
 	}, nil
 }
 
@@ -121,7 +129,7 @@ func (s *serverAPI) UpdateCartItemQuantity(
 	}
 
 	// Update quantity
-	err = s.cartService.UpdateCartItemQuantity(ctx, userID, req.GetItemId(), int(req.GetQuantity()))
+	item, err := s.cartService.UpdateCartItemQuantity(ctx, userID, req.GetItemId(), int(req.GetQuantity()))
 	if err != nil {
 		s.log.Error("failed to update quantity", slog.String("op", op), slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to update item quantity")
@@ -130,6 +138,7 @@ func (s *serverAPI) UpdateCartItemQuantity(
 	return &cartv1.UpdateQuantityResponse{
 		Success: true,
 		Message: "Item quantity updated successfully",
+		Item:    convertToProtoCartItem(*item),
 	}, nil
 }
 
@@ -223,12 +232,7 @@ func convertToProtoCart(cart *models.Cart) *cartv1.Cart {
 
 	protoItems := make([]*cartv1.CartItem, 0, len(cart.Items))
 	for _, item := range cart.Items {
-		protoItems = append(protoItems, &cartv1.CartItem{
-			Id:        item.ID,
-			SneakerId: int64(item.SneakerID),
-			Quantity:  int32(item.Quantity),
-			AddedAt:   item.AddedAt.Unix(),
-		})
+		protoItems = append(protoItems, convertToProtoCartItem(item))
 	}
 
 	return &cartv1.Cart{
@@ -237,3 +241,13 @@ func convertToProtoCart(cart *models.Cart) *cartv1.Cart {
 		UpdatedAt: cart.UpdatedAt.Unix(),
 	}
 }
+
+func convertToProtoCartItem(item models.CartItem) *cartv1.CartItem {
+	return &cartv1.CartItem{
+		Id:        item.ID,
+		SneakerId: int64(item.SneakerID),
+		VariantId: int64(item.VariantID),
+		Quantity:  int32(item.Quantity),
+		AddedAt:   item.AddedAt.Unix(),
+	}
+}
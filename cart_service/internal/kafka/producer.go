@@ -0,0 +1,235 @@
+package kafka
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stpnv0/protos/events"
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cart_service/internal/models"
+)
+
+var (
+	// ErrQueueFull — очередь событий заполнена (брокер долго недоступен), событие отброшено.
+	ErrQueueFull = errors.New("cart event queue is full")
+	// ErrClosed — продюсер уже закрыт.
+	ErrClosed = errors.New("producer is closed")
+)
+
+const (
+	// maxBatch — сколько событий из очереди уходит в брокер одной записью.
+	maxBatch = 100
+	// closeTimeout — сколько Close ждёт, пока очередь уйдёт в брокер.
+	closeTimeout = 10 * time.Second
+)
+
+// Producer публикует события корзины. Ключ сообщения — cart-<user_id>, поэтому
+// события одной корзины попадают в одну партицию и читаются по порядку.
+//
+// Изменения корзины не ждут брокера: они встают в ограниченную очередь, которую
+// вычитывает фоновая горутина. CartAbandoned публикуется синхронно — воркеру нужно
+// знать, дошло ли событие, прежде чем отметить корзину.
+type Producer struct {
+	writer *kafka.Writer
+	log    *slog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan kafka.Message
+	done   chan struct{}
+}
+
+// NewProducer создаёт продюсер с очередью на queueSize событий и запускает её публикацию.
+func NewProducer(brokers []string, topic string, queueSize int, log *slog.Logger) *Producer {
+	p := newProducer(&kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    maxBatch,
+		BatchTimeout: 10 * time.Millisecond,
+		// При недоступном брокере пачка отбрасывается после трёх попыток,
+		// а не после десяти по умолчанию, чтобы очередь не стояла.
+		MaxAttempts:  3,
+		WriteTimeout: 5 * time.Second,
+	}, queueSize, log)
+	go p.run()
+	return p
+}
+
+func newProducer(writer *kafka.Writer, queueSize int, log *slog.Logger) *Producer {
+	return &Producer{
+		writer: writer,
+		log:    log,
+		queue:  make(chan kafka.Message, queueSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// PublishCartEvent ставит изменение корзины со случайным id события в очередь
+// и сразу возвращается. Очередь заполнена — ErrQueueFull, событие теряется.
+func (p *Producer) PublishCartEvent(_ context.Context, event models.CartEvent) error {
+	const op = "kafka.Producer.PublishCartEvent"
+
+	id, err := newEventID()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg, err := buildCartEventMessage(id, event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+	select {
+	case p.queue <- msg:
+		return nil
+	default:
+		return fmt.Errorf("%s: %w", op, ErrQueueFull)
+	}
+}
+
+// PublishCartAbandoned публикует CartAbandoned. id события выводится из корзины и времени
+// её изменения, так что повторная публикация того же состояния отбрасывается потребителями.
+func (p *Producer) PublishCartAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	const op = "kafka.Producer.PublishCartAbandoned"
+
+	msg, err := buildAbandonedMessage(cart)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("%s: write message: %w", op, err)
+	}
+	return nil
+}
+
+// Close перестаёт принимать события, ждёт до closeTimeout, пока очередь уйдёт
+// в брокер, и закрывает writer.
+func (p *Producer) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-time.After(closeTimeout):
+		p.log.Warn("cart events left unpublished on shutdown", slog.Int("events", len(p.queue)))
+	}
+	return p.writer.Close()
+}
+
+// run публикует события из очереди пачками до maxBatch, пока очередь не закрыта
+// и не вычитана. Пачку, которую брокер не принял, только пишет в лог.
+func (p *Producer) run() {
+	const op = "kafka.Producer.run"
+	defer close(p.done)
+
+	batch := make([]kafka.Message, 0, maxBatch)
+	for msg := range p.queue {
+		batch = append(batch[:0], msg)
+	fill:
+		for len(batch) < maxBatch {
+			select {
+			case msg, ok := <-p.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, msg)
+			default:
+				break fill
+			}
+		}
+
+		if err := p.writer.WriteMessages(context.Background(), batch...); err != nil {
+			p.log.Warn("failed to publish cart events",
+				slog.String("op", op),
+				slog.Int("events", len(batch)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+func buildCartEventMessage(id string, event models.CartEvent) (kafka.Message, error) {
+	return buildMessage(id, event.EventType, event.UserSSOID, event.Timestamp, &eventsv1.CartEvent{
+		UserId:    int64(event.UserSSOID),
+		ItemId:    event.ItemID,
+		SneakerId: int64(event.SneakerID),
+		VariantId: int64(event.VariantID),
+		Quantity:  int32(event.Quantity),
+	})
+}
+
+func buildAbandonedMessage(cart models.AbandonedCart) (kafka.Message, error) {
+	data := &eventsv1.CartAbandoned{
+		UserId:    int64(cart.UserSSOID),
+		UpdatedAt: timestamppb.New(cart.UpdatedAt),
+	}
+	for _, item := range cart.Items {
+		data.Items = append(data.Items, &eventsv1.CartLine{
+			ItemId:    item.ID,
+			SneakerId: int64(item.SneakerID),
+			VariantId: int64(item.VariantID),
+			Quantity:  int32(item.Quantity),
+			AddedAt:   timestamppb.New(item.AddedAt),
+		})
+	}
+
+	id := fmt.Sprintf("cart-%d-abandoned-%d", cart.UserSSOID, cart.UpdatedAt.UnixMicro())
+	return buildMessage(id, models.EventCartAbandoned, cart.UserSSOID, time.Now(), data)
+}
+
+// buildMessage упаковывает данные в конверт CloudEvents текущей версии схемы.
+func buildMessage(id, eventType string, userSSOID int, at time.Time, data proto.Message) (kafka.Message, error) {
+	subject := "cart-" + strconv.Itoa(userSSOID)
+
+	value, err := events.Marshal(events.Meta{
+		ID:      id,
+		Source:  events.SourceCartService,
+		Type:    eventType,
+		Subject: subject,
+		Time:    at,
+	}, data)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	return kafka.Message{
+		Key:   []byte(subject),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: events.HeaderEventID, Value: []byte(id)},
+			{Key: events.HeaderEventType, Value: []byte(eventType)},
+			{Key: events.HeaderSchemaVersion, Value: []byte(events.SchemaVersion)},
+			{Key: events.HeaderContentType, Value: []byte(events.ContentType)},
+		},
+	}, nil
+}
+
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate event id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
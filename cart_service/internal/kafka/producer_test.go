@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stpnv0/protos/events"
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cart_service/internal/models"
+)
+
+func headers(msg kafka.Message) map[string]string {
+	h := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		h[header.Key] = string(header.Value)
+	}
+	return h
+}
+
+func TestBuildCartEventMessage(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg, err := buildCartEventMessage("abc", models.CartEvent{
+		EventType: models.EventCartItemAdded,
+		UserSSOID: 3,
+		ItemID:    "7",
+		SneakerID: 10,
+		VariantID: 42,
+		Quantity:  2,
+		Timestamp: at,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cart-3", string(msg.Key))
+	assert.Equal(t, map[string]string{
+		"event_id":       "abc",
+		"event_type":     "CartItemAdded",
+		"schema_version": "1",
+		"content-type":   "application/cloudevents+protobuf",
+	}, headers(msg))
+
+	env, err := events.Decode(events.SchemaVersion, msg.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", env.GetId())
+	assert.Equal(t, "cart_service", env.GetSource())
+	assert.Equal(t, "CartItemAdded", env.GetType())
+	assert.Equal(t, "cart-3", env.GetSubject())
+	assert.True(t, at.Equal(env.GetTime().AsTime()))
+
+	var data eventsv1.CartEvent
+	require.NoError(t, events.UnmarshalData(env, &data))
+	assert.Equal(t, int64(3), data.GetUserId())
+	assert.Equal(t, "7", data.GetItemId())
+	assert.Equal(t, int64(10), data.GetSneakerId())
+	assert.Equal(t, int64(42), data.GetVariantId())
+	assert.Equal(t, int32(2), data.GetQuantity())
+}
+
+func TestBuildAbandonedMessage(t *testing.T) {
+	updatedAt := time.Date(2025, 3, 1, 12, 0, 0, 123456000, time.UTC)
+	cart := models.AbandonedCart{
+		UserSSOID: 3,
+		Items: []models.CartItem{
+			{ID: "7", SneakerID: 10, VariantID: 42, Quantity: 2, AddedAt: updatedAt.Add(-time.Hour)},
+			{ID: "8", SneakerID: 11, Quantity: 1, AddedAt: updatedAt},
+		},
+		UpdatedAt: updatedAt,
+	}
+
+	msg, err := buildAbandonedMessage(cart)
+	require.NoError(t, err)
+
+	// Одно и то же состояние корзины — один и тот же id события.
+	again, err := buildAbandonedMessage(cart)
+	require.NoError(t, err)
+	assert.Equal(t, headers(msg)["event_id"], headers(again)["event_id"])
+	assert.Equal(t, "cart-3-abandoned-1740830400123456", headers(msg)["event_id"])
+	assert.Equal(t, "CartAbandoned", headers(msg)["event_type"])
+
+	env, err := events.Decode(events.SchemaVersion, msg.Value)
+	require.NoError(t, err)
+	var data eventsv1.CartAbandoned
+	require.NoError(t, events.UnmarshalData(env, &data))
+	assert.Equal(t, int64(3), data.GetUserId())
+	assert.True(t, updatedAt.Equal(data.GetUpdatedAt().AsTime()))
+	require.Len(t, data.GetItems(), 2)
+	assert.Equal(t, "7", data.GetItems()[0].GetItemId())
+	assert.Equal(t, int64(42), data.GetItems()[0].GetVariantId())
+	assert.Equal(t, int32(1), data.GetItems()[1].GetQuantity())
+}
+
+func TestPublishCartEvent_DoesNotWaitForBroker(t *testing.T) {
+	// Очередь никто не вычитывает: публикация не должна ждать брокера.
+	p := newProducer(&kafka.Writer{}, 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	event := models.CartEvent{EventType: models.EventCartItemAdded, UserSSOID: 3, Timestamp: time.Now()}
+
+	require.NoError(t, p.PublishCartEvent(context.Background(), event))
+	assert.Len(t, p.queue, 1)
+
+	err := p.PublishCartEvent(context.Background(), event)
+	require.ErrorIs(t, err, ErrQueueFull)
+}
+
+func TestPublishCartEvent_AfterClose(t *testing.T) {
+	p := NewProducer([]string{"127.0.0.1:1"}, "carts", 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, p.Close())
+
+	err := p.PublishCartEvent(context.Background(), models.CartEvent{EventType: models.EventCartCleared, UserSSOID: 3})
+	require.ErrorIs(t, err, ErrClosed)
+}
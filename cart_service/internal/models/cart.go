@@ -1,11 +1,19 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrProductUnavailable — товара нет в каталоге, он снят с продажи или вариант
+// принадлежит другому товару.
+var ErrProductUnavailable = errors.New("product unavailable")
 
 type CartItem struct {
 	ID           string    `json:"id"`
 	UserSSOID    int       `json:"user_sso_id"`
 	SneakerID    int       `json:"sneaker_id"`
+	VariantID    int       `json:"variant_id,omitempty"` // 0 — товар без вариантов
 	Quantity     int       `json:"quantity"`
 	AddedAt      time.Time `json:"added_at"`
 	Synchronized bool      `json:"synchronized"`
@@ -2,11 +2,30 @@ package models
 
 import "time"
 
+// Типы событий корзины в топике Kafka.
+const (
+	EventCartItemAdded   = "CartItemAdded"
+	EventCartItemUpdated = "CartItemUpdated"
+	EventCartItemRemoved = "CartItemRemoved"
+	EventCartCleared     = "CartCleared"
+	EventCartAbandoned   = "CartAbandoned"
+)
+
+// CartEvent — изменение корзины пользователя. У EventCartCleared заполнен только UserSSOID.
 type CartEvent struct {
-	EventType string    `json:"event_type"` // "item_added", "item_updated", "item_removed"
-	UserSSOID int       `json:"user_sso_id"`
-	ItemID    string    `json:"item_id"` // Используем UUID
-	SneakerID int       `json:"sneaker_id,omitempty"`
-	Quantity  int       `json:"quantity,omitempty"`
-	Timestamp time.Time `json:"timestamp"`
+	EventType string
+	UserSSOID int
+	ItemID    string
+	SneakerID int
+	VariantID int
+	// Quantity — количество после изменения; у EventCartItemRemoved — сколько было удалено.
+	Quantity  int
+	Timestamp time.Time
+}
+
+// AbandonedCart — корзина с товарами, которую не меняли дольше порога.
+type AbandonedCart struct {
+	UserSSOID int
+	Items     []CartItem
+	UpdatedAt time.Time
 }
@@ -4,6 +4,7 @@ import (
 	"cart_service/internal/models"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -77,9 +78,9 @@ func (r *PostgresRepository) SaveCart(ctx context.Context, cart *models.Cart) er
 	// Вставляем элементы корзины
 	for _, item := range cart.Items {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO cart_items (cart_id, user_sso_id, sneaker_id, quantity, added_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`, cart.UserSSOID, item.UserSSOID, item.SneakerID, item.Quantity, item.AddedAt, time.Now())
+			INSERT INTO cart_items (cart_id, user_sso_id, sneaker_id, variant_id, quantity, added_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, cart.UserSSOID, item.UserSSOID, item.SneakerID, item.VariantID, item.Quantity, item.AddedAt, time.Now())
 		if err != nil {
 			return fmt.Errorf("error inserting cart item: %w", err)
 		}
@@ -115,7 +116,7 @@ func (r *PostgresRepository) GetCart(ctx context.Context, userSSOID int) (*model
 
 	// Получаем элементы корзины
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, sneaker_id, quantity, added_at, updated_at
+		SELECT id, sneaker_id, variant_id, quantity, added_at, updated_at
 		FROM cart_items
 		WHERE cart_id = $1
 	`, userSSOID)
@@ -132,7 +133,7 @@ func (r *PostgresRepository) GetCart(ctx context.Context, userSSOID int) (*model
 	for rows.Next() {
 		var item models.CartItem
 		var id int
-		if err := rows.Scan(&id, &item.SneakerID, &item.Quantity, &item.AddedAt, &cart.UpdatedAt); err != nil {
+		if err := rows.Scan(&id, &item.SneakerID, &item.VariantID, &item.Quantity, &item.AddedAt, &cart.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("error scanning cart item: %w", err)
 		}
 
@@ -150,7 +151,9 @@ func (r *PostgresRepository) GetCart(ctx context.Context, userSSOID int) (*model
 	return cart, nil
 }
 
-// AddCartItem добавляет элемент в корзину
+// AddCartItem добавляет элемент в корзину. Если товар с тем же вариантом уже лежит
+// в корзине, количество прибавляется к существующей строке. В item записываются
+// id строки, итоговое количество и время первого добавления.
 func (r *PostgresRepository) AddCartItem(ctx context.Context, item *models.CartItem) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -164,44 +167,30 @@ func (r *PostgresRepository) AddCartItem(ctx context.Context, item *models.CartI
 		}
 	}()
 
-	// Проверяем существование корзины и создаем если нет
-	var exists bool
-	err = tx.QueryRowContext(ctx, `
-		SELECT EXISTS(SELECT 1 FROM carts WHERE user_sso_id = $1)
-	`, item.UserSSOID).Scan(&exists)
+	// Создаём корзину, если её нет, и обновляем время последнего изменения
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO carts (user_sso_id, updated_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_sso_id)
+		DO UPDATE SET updated_at = $2
+	`, item.UserSSOID, now)
 	if err != nil {
-		return fmt.Errorf("error checking cart existence: %w", err)
-	}
-
-	if !exists {
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO carts (user_sso_id, updated_at)
-			VALUES ($1, $2)
-		`, item.UserSSOID, time.Now())
-		if err != nil {
-			return fmt.Errorf("error creating cart: %w", err)
-		}
+		return fmt.Errorf("error upserting cart: %w", err)
 	}
 
-	// Добавляем элемент в корзину
+	// Добавляем строку или увеличиваем количество существующей
 	var itemID int
 	err = tx.QueryRowContext(ctx, `
-		INSERT INTO cart_items (cart_id, user_sso_id, sneaker_id, quantity, added_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
-	`, item.UserSSOID, item.UserSSOID, item.SneakerID, item.Quantity, item.AddedAt, time.Now()).Scan(&itemID)
+		INSERT INTO cart_items (cart_id, user_sso_id, sneaker_id, variant_id, quantity, added_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (cart_id, sneaker_id, variant_id)
+		DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+		RETURNING id, quantity, added_at
+	`, item.UserSSOID, item.UserSSOID, item.SneakerID, item.VariantID, item.Quantity, item.AddedAt, now).
+		Scan(&itemID, &item.Quantity, &item.AddedAt)
 	if err != nil {
-		return fmt.Errorf("error inserting cart item: %w", err)
-	}
-
-	// Обновляем время последнего изменения корзины
-	_, err = tx.ExecContext(ctx, `
-		UPDATE carts
-		SET updated_at = $1
-		WHERE user_sso_id = $2
-	`, time.Now(), item.UserSSOID)
-	if err != nil {
-		return fmt.Errorf("error updating cart timestamp: %w", err)
+		return fmt.Errorf("error upserting cart item: %w", err)
 	}
 
 	// Завершаем транзакцию
@@ -215,25 +204,21 @@ func (r *PostgresRepository) AddCartItem(ctx context.Context, item *models.CartI
 	return nil
 }
 
-// UpdateCartItemQuantity обновляет количество элемента в корзине
-func (r *PostgresRepository) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error {
+// UpdateCartItemQuantity обновляет количество элемента в корзине и возвращает элемент
+func (r *PostgresRepository) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error) {
 	// Обновляем количество элемента
-	result, err := r.db.ExecContext(ctx, `
+	item := &models.CartItem{ID: itemID, UserSSOID: userSSOID, Quantity: quantity}
+	err := r.db.QueryRowContext(ctx, `
 		UPDATE cart_items
 		SET quantity = $1, updated_at = $2
 		WHERE id = $3 AND cart_id = $4
-	`, quantity, time.Now(), itemID, userSSOID)
-	if err != nil {
-		return fmt.Errorf("error updating cart item quantity: %w", err)
+		RETURNING sneaker_id, variant_id, added_at
+	`, quantity, time.Now(), itemID, userSSOID).Scan(&item.SneakerID, &item.VariantID, &item.AddedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("cart item not found")
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("error getting rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("cart item not found")
+		return nil, fmt.Errorf("error updating cart item quantity: %w", err)
 	}
 
 	// Обновляем время последнего изменения корзины
@@ -243,30 +228,26 @@ func (r *PostgresRepository) UpdateCartItemQuantity(ctx context.Context, userSSO
 		WHERE user_sso_id = $2
 	`, time.Now(), userSSOID)
 	if err != nil {
-		return fmt.Errorf("error updating cart timestamp: %w", err)
+		return nil, fmt.Errorf("error updating cart timestamp: %w", err)
 	}
 
-	return nil
+	return item, nil
 }
 
-// RemoveCartItem удаляет элемент из корзины
-func (r *PostgresRepository) RemoveCartItem(ctx context.Context, userSSOID int, itemID string) error {
+// RemoveCartItem удаляет элемент из корзины и возвращает удалённый элемент
+func (r *PostgresRepository) RemoveCartItem(ctx context.Context, userSSOID int, itemID string) (*models.CartItem, error) {
 	// Удаляем элемент из корзины
-	result, err := r.db.ExecContext(ctx, `
+	item := &models.CartItem{ID: itemID, UserSSOID: userSSOID}
+	err := r.db.QueryRowContext(ctx, `
 		DELETE FROM cart_items
 		WHERE id = $1 AND cart_id = $2
-	`, itemID, userSSOID)
-	if err != nil {
-		return fmt.Errorf("error removing cart item: %w", err)
+		RETURNING sneaker_id, variant_id, quantity, added_at
+	`, itemID, userSSOID).Scan(&item.SneakerID, &item.VariantID, &item.Quantity, &item.AddedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("cart item not found")
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("error getting rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("cart item not found")
+		return nil, fmt.Errorf("error removing cart item: %w", err)
 	}
 
 	// Обновляем время последнего изменения корзины
@@ -276,10 +257,10 @@ func (r *PostgresRepository) RemoveCartItem(ctx context.Context, userSSOID int,
 		WHERE user_sso_id = $2
 	`, time.Now(), userSSOID)
 	if err != nil {
-		return fmt.Errorf("error updating cart timestamp: %w", err)
+		return nil, fmt.Errorf("error updating cart timestamp: %w", err)
 	}
 
-	return nil
+	return item, nil
 }
 
 // ClearCart очищает корзину пользователя
@@ -322,3 +303,70 @@ func (r *PostgresRepository) ClearCart(ctx context.Context, userSSOID int) error
 
 	return nil
 }
+
+// FindAbandonedCarts возвращает до limit корзин с товарами, которые не менялись с idleBefore
+// и о которых ещё не отправлено CartAbandoned, — самые старые первыми.
+func (r *PostgresRepository) FindAbandonedCarts(ctx context.Context, idleBefore time.Time, limit int) ([]models.AbandonedCart, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.user_sso_id, c.updated_at, i.id, i.sneaker_id, i.variant_id, i.quantity, i.added_at
+		FROM (
+			SELECT user_sso_id, updated_at
+			FROM carts
+			WHERE updated_at < $1
+			  AND abandoned_at IS DISTINCT FROM updated_at
+			  AND EXISTS (SELECT 1 FROM cart_items WHERE cart_id = carts.user_sso_id)
+			ORDER BY updated_at
+			LIMIT $2
+		) c
+		JOIN cart_items i ON i.cart_id = c.user_sso_id
+		ORDER BY c.updated_at, c.user_sso_id, i.id
+	`, idleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying abandoned carts: %w", err)
+	}
+	defer rows.Close()
+
+	var carts []models.AbandonedCart
+	for rows.Next() {
+		var (
+			userSSOID int
+			updatedAt time.Time
+			id        int
+			item      models.CartItem
+		)
+		if err := rows.Scan(&userSSOID, &updatedAt, &id, &item.SneakerID, &item.VariantID, &item.Quantity, &item.AddedAt); err != nil {
+			return nil, fmt.Errorf("error scanning abandoned cart: %w", err)
+		}
+
+		item.ID = fmt.Sprintf("%d", id)
+		item.UserSSOID = userSSOID
+		item.Synchronized = true
+
+		if n := len(carts); n == 0 || carts[n-1].UserSSOID != userSSOID {
+			carts = append(carts, models.AbandonedCart{UserSSOID: userSSOID, UpdatedAt: updatedAt})
+		}
+		last := &carts[len(carts)-1]
+		last.Items = append(last.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating abandoned carts: %w", err)
+	}
+
+	return carts, nil
+}
+
+// MarkCartAbandoned отмечает, что о корзине в состоянии на updatedAt отправлено CartAbandoned.
+// Если корзину успели изменить, отметка не ставится.
+func (r *PostgresRepository) MarkCartAbandoned(ctx context.Context, userSSOID int, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE carts
+		SET abandoned_at = updated_at
+		WHERE user_sso_id = $1 AND updated_at = $2
+	`, userSSOID, updatedAt)
+	if err != nil {
+		return fmt.Errorf("error marking cart abandoned: %w", err)
+	}
+
+	return nil
+}
@@ -13,6 +13,9 @@ import (
 
 var ErrCacheMiss = errors.New("cache miss")
 
+// defaultCartTTL — сколько живёт закэшированная корзина после последнего изменения.
+const defaultCartTTL = 24 * time.Hour
+
 type RedisRepository struct {
 	client *redis.Client
 }
@@ -40,11 +43,34 @@ func (r *RedisRepository) AddToCart(ctx context.Context, userSSOID, sneakerID in
 		Synchronized: false,
 	}
 
-	return r.AddToCartItem(ctx, CartItem)
+	return r.AddToCartItem(ctx, CartItem, CartItem.Quantity, defaultCartTTL)
 }
 
-// AddToCartItem - новый метод, который принимает объект CartItem
-func (r *RedisRepository) AddToCartItem(ctx context.Context, item models.CartItem) error {
+// addToCartItemScript прибавляет ARGV[3] к количеству строки ARGV[1] или, если строки нет,
+// кладёт её как есть (ARGV[2]) и продлевает TTL корзины до ARGV[4] секунд. Закэшированную
+// корзину скрипт меняет только целиком: если ключа нет, он не создаётся, иначе в кэше
+// оказалась бы корзина из одной строки.
+var addToCartItemScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+local current = redis.call('HGET', KEYS[1], ARGV[1])
+if current then
+	local item = cjson.decode(current)
+	item.quantity = item.quantity + tonumber(ARGV[3])
+	item.synchronized = false
+	redis.call('HSET', KEYS[1], ARGV[1], cjson.encode(item))
+else
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// AddToCartItem атомарно прибавляет delta к количеству строки item.ID в закэшированной
+// корзине; если строки в кэше нет, добавляет item целиком. TTL корзины продлевается
+// до ttl (не больше нуля — defaultCartTTL).
+func (r *RedisRepository) AddToCartItem(ctx context.Context, item models.CartItem, delta int, ttl time.Duration) error {
 	key := getCartKey(item.UserSSOID)
 
 	// Проверяем, что у объекта есть ID, если нет - генерируем
@@ -57,13 +83,13 @@ func (r *RedisRepository) AddToCartItem(ctx context.Context, item models.CartIte
 		return fmt.Errorf("marshal cart item: %w", err)
 	}
 
-	err = r.client.HSet(ctx, key, item.ID, itemJSON).Err()
-	if err != nil {
-		return fmt.Errorf("set cart item in redis: %w", err)
+	if ttl <= 0 {
+		ttl = defaultCartTTL
 	}
 
-	if err := r.client.Expire(ctx, key, 24*time.Hour).Err(); err != nil {
-		return fmt.Errorf("set cart ttl: %w", err)
+	if err := addToCartItemScript.Run(ctx, r.client, []string{key}, item.ID, itemJSON, delta,
+		int(ttl.Seconds())).Err(); err != nil {
+		return fmt.Errorf("add cart item in redis: %w", err)
 	}
 
 	return nil
@@ -127,7 +153,7 @@ func (r *RedisRepository) SetCart(ctx context.Context, userSSOID int, cart *mode
 	// Устанавливаем TTL для корзины
 	expiry := ttl
 	if expiry <= 0 {
-		expiry = 24 * time.Hour
+		expiry = defaultCartTTL
 	}
 	if err := r.client.Expire(ctx, key, expiry).Err(); err != nil {
 		return fmt.Errorf("set cart ttl: %w", err)
@@ -13,6 +13,8 @@ import (
 type CartCacheAsideService struct {
 	repo     CartRepository
 	cache    CartCache
+	catalog  Catalog
+	events   EventPublisher
 	logger   *slog.Logger
 	cacheTTL time.Duration
 }
@@ -20,12 +22,16 @@ type CartCacheAsideService struct {
 func NewCartCacheAsideService(
 	repo CartRepository,
 	cache CartCache,
+	catalog Catalog,
+	events EventPublisher,
 	logger *slog.Logger,
 	cacheTTL time.Duration,
 ) *CartCacheAsideService {
 	return &CartCacheAsideService{
 		repo:     repo,
 		cache:    cache,
+		catalog:  catalog,
+		events:   events,
 		logger:   logger,
 		cacheTTL: cacheTTL,
 	}
@@ -55,40 +61,58 @@ func (s *CartCacheAsideService) GetCart(ctx context.Context, userSSOID int) (*mo
 	return cart, nil
 }
 
-// AddItemToCart добавляет товар в корзину с обновлением БД и кэша
-func (s *CartCacheAsideService) AddToCart(ctx context.Context, userSSOID, sneakerID, quantity int) error {
+// AddToCart добавляет товар в корзину с обновлением БД и кэша и возвращает строку корзины.
+// Повторно добавленный товар увеличивает количество существующей строки. Товар и вариант
+// сначала проверяются в каталоге: чужой вариант — models.ErrProductUnavailable.
+func (s *CartCacheAsideService) AddToCart(ctx context.Context, userSSOID, sneakerID, variantID, quantity int) (*models.CartItem, error) {
 	const op = "service.AddToCart"
 	log := s.logger.With(slog.String("op", op), slog.Int("user_id", userSSOID))
 
+	if err := s.catalog.ValidateItem(ctx, sneakerID, variantID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	item := &models.CartItem{
 		UserSSOID: userSSOID,
 		SneakerID: sneakerID,
+		VariantID: variantID,
 		Quantity:  quantity,
 		AddedAt:   time.Now(),
 	}
 
 	if err := s.repo.AddCartItem(ctx, item); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := s.cache.AddToCartItem(ctx, *item); err != nil {
+	if err := s.cache.AddToCartItem(ctx, *item, quantity, s.cacheTTL); err != nil {
 		log.Warn("failed to update cache, invalidating", slog.String("error", err.Error()))
 		if invErr := s.cache.InvalidateCart(ctx, userSSOID); invErr != nil {
 			log.Warn("failed to invalidate cache", slog.String("error", invErr.Error()))
 		}
 	}
 
+	s.publish(ctx, log, models.CartEvent{
+		EventType: models.EventCartItemAdded,
+		UserSSOID: userSSOID,
+		ItemID:    item.ID,
+		SneakerID: sneakerID,
+		VariantID: variantID,
+		Quantity:  item.Quantity,
+		Timestamp: time.Now(),
+	})
+
 	log.Info("item added to cart")
-	return nil
+	return item, nil
 }
 
-// UpdateCartItemQuantity обновляет количество товара в корзине
-func (s *CartCacheAsideService) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error {
+// UpdateCartItemQuantity обновляет количество товара в корзине и возвращает строку корзины
+func (s *CartCacheAsideService) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error) {
 	const op = "service.UpdateCartItemQuantity"
 	log := s.logger.With(slog.String("op", op), slog.Int("user_id", userSSOID))
 
-	if err := s.repo.UpdateCartItemQuantity(ctx, userSSOID, itemID, quantity); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	item, err := s.repo.UpdateCartItemQuantity(ctx, userSSOID, itemID, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if err := s.cache.UpdateCartItemQuantity(ctx, userSSOID, itemID, quantity); err != nil {
@@ -98,8 +122,18 @@ func (s *CartCacheAsideService) UpdateCartItemQuantity(ctx context.Context, user
 		}
 	}
 
+	s.publish(ctx, log, models.CartEvent{
+		EventType: models.EventCartItemUpdated,
+		UserSSOID: userSSOID,
+		ItemID:    itemID,
+		SneakerID: item.SneakerID,
+		VariantID: item.VariantID,
+		Quantity:  quantity,
+		Timestamp: time.Now(),
+	})
+
 	log.Info("item quantity updated")
-	return nil
+	return item, nil
 }
 
 // RemoveCartItem удаляет товар из корзины
@@ -107,7 +141,8 @@ func (s *CartCacheAsideService) RemoveFromCart(ctx context.Context, userSSOID in
 	const op = "service.RemoveFromCart"
 	log := s.logger.With(slog.String("op", op), slog.Int("user_id", userSSOID))
 
-	if err := s.repo.RemoveCartItem(ctx, userSSOID, itemID); err != nil {
+	item, err := s.repo.RemoveCartItem(ctx, userSSOID, itemID)
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -118,6 +153,16 @@ func (s *CartCacheAsideService) RemoveFromCart(ctx context.Context, userSSOID in
 		}
 	}
 
+	s.publish(ctx, log, models.CartEvent{
+		EventType: models.EventCartItemRemoved,
+		UserSSOID: userSSOID,
+		ItemID:    itemID,
+		SneakerID: item.SneakerID,
+		VariantID: item.VariantID,
+		Quantity:  item.Quantity,
+		Timestamp: time.Now(),
+	})
+
 	log.Info("item removed from cart")
 	return nil
 }
@@ -135,6 +180,54 @@ func (s *CartCacheAsideService) ClearCart(ctx context.Context, userSSOID int) er
 		log.Warn("failed to invalidate cache", slog.String("error", err.Error()))
 	}
 
+	s.publish(ctx, log, models.CartEvent{
+		EventType: models.EventCartCleared,
+		UserSSOID: userSSOID,
+		Timestamp: time.Now(),
+	})
+
 	log.Info("cart cleared")
 	return nil
 }
+
+// PublishAbandonedCarts публикует CartAbandoned для корзин с товарами, которые не менялись
+// с idleBefore, и отмечает их, чтобы не напоминать повторно, пока корзину снова не изменят.
+// Возвращает, сколько корзин найдено и сколько из них опубликовано. Корзина, которую
+// не удалось опубликовать или отметить, останется брошенной и будет взята повторно.
+func (s *CartCacheAsideService) PublishAbandonedCarts(ctx context.Context, idleBefore time.Time, limit int) (found, published int, err error) {
+	const op = "service.PublishAbandonedCarts"
+	log := s.logger.With(slog.String("op", op))
+
+	carts, err := s.repo.FindAbandonedCarts(ctx, idleBefore, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, cart := range carts {
+		cartLog := log.With(slog.Int("user_id", cart.UserSSOID))
+
+		if err := s.events.PublishCartAbandoned(ctx, cart); err != nil {
+			cartLog.Warn("failed to publish abandoned cart", slog.String("error", err.Error()))
+			continue
+		}
+		if err := s.repo.MarkCartAbandoned(ctx, cart.UserSSOID, cart.UpdatedAt); err != nil {
+			cartLog.Warn("failed to mark cart abandoned", slog.String("error", err.Error()))
+			continue
+		}
+		published++
+	}
+
+	return len(carts), published, nil
+}
+
+// publish ставит событие корзины в очередь на публикацию, не дожидаясь брокера.
+// Ошибка (очередь заполнена) операцию не отменяет: изменение уже сохранено,
+// событие теряется и остаётся только в логе.
+func (s *CartCacheAsideService) publish(ctx context.Context, log *slog.Logger, event models.CartEvent) {
+	if err := s.events.PublishCartEvent(ctx, event); err != nil {
+		log.Warn("failed to publish cart event",
+			slog.String("event_type", event.EventType),
+			slog.String("error", err.Error()),
+		)
+	}
+}
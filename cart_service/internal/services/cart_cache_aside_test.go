@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
@@ -24,6 +25,21 @@ func newTestLogger() *slog.Logger {
 
 const testTTL = 24 * time.Hour
 
+// anyEvents принимает любые события — для тестов, которые их не проверяют.
+// anyCatalog считает любой товар доступным.
+func anyCatalog() *mocks.MockCatalog {
+	catalog := new(mocks.MockCatalog)
+	catalog.On("ValidateItem", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return catalog
+}
+
+func anyEvents() *mocks.MockEventPublisher {
+	events := new(mocks.MockEventPublisher)
+	events.On("PublishCartEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	events.On("PublishCartAbandoned", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return events
+}
+
 // ---------------------------------------------------------------------------
 // GetCart
 // ---------------------------------------------------------------------------
@@ -31,7 +47,7 @@ const testTTL = 24 * time.Hour
 func TestGetCart_CacheHit(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	cached := &models.Cart{
 		UserSSOID: 1,
@@ -48,7 +64,7 @@ func TestGetCart_CacheHit(t *testing.T) {
 func TestGetCart_CacheHitEmpty(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	emptyCart := &models.Cart{UserSSOID: 1, Items: []models.CartItem{}}
 	cache.On("GetCart", mock.Anything, 1).Return(emptyCart, nil)
@@ -62,7 +78,7 @@ func TestGetCart_CacheHitEmpty(t *testing.T) {
 func TestGetCart_CacheMiss_LoadsFromDB(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	cache.On("GetCart", mock.Anything, 1).Return(nil, repository.ErrCacheMiss)
 
@@ -83,7 +99,7 @@ func TestGetCart_CacheMiss_LoadsFromDB(t *testing.T) {
 func TestGetCart_CacheMiss_SetCacheFails_StillSucceeds(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	cache.On("GetCart", mock.Anything, 1).Return(nil, repository.ErrCacheMiss)
 
@@ -99,7 +115,7 @@ func TestGetCart_CacheMiss_SetCacheFails_StillSucceeds(t *testing.T) {
 func TestGetCart_DBError(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	cache.On("GetCart", mock.Anything, 1).Return(nil, repository.ErrCacheMiss)
 	repo.On("GetCart", mock.Anything, 1).Return(nil, errors.New("db connection lost"))
@@ -117,26 +133,72 @@ func TestGetCart_DBError(t *testing.T) {
 func TestAddToCart_Success(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	repo.On("AddCartItem", mock.Anything, mock.AnythingOfType("*models.CartItem")).Return(nil)
-	cache.On("AddToCartItem", mock.Anything, mock.AnythingOfType("models.CartItem")).Return(nil)
+	cache.On("AddToCartItem", mock.Anything, mock.AnythingOfType("models.CartItem"), 2, testTTL).Return(nil)
+
+	_, err := svc.AddToCart(context.Background(), 1, 10, 0, 2)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestAddToCart_WithVariant(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
+
+	repo.On("AddCartItem", mock.Anything, mock.MatchedBy(func(item *models.CartItem) bool {
+		return item.SneakerID == 10 && item.VariantID == 42 && item.Quantity == 2
+	})).Return(nil)
+	cache.On("AddToCartItem", mock.Anything, mock.MatchedBy(func(item models.CartItem) bool {
+		return item.VariantID == 42
+	}), 2, testTTL).Return(nil)
 
-	err := svc.AddToCart(context.Background(), 1, 10, 2)
+	_, err := svc.AddToCart(context.Background(), 1, 10, 42, 2)
 	require.NoError(t, err)
 	repo.AssertExpectations(t)
 	cache.AssertExpectations(t)
 }
 
+func TestAddToCart_RefreshesConfiguredTTL(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), 30*time.Minute)
+
+	repo.On("AddCartItem", mock.Anything, mock.AnythingOfType("*models.CartItem")).Return(nil)
+	cache.On("AddToCartItem", mock.Anything, mock.AnythingOfType("models.CartItem"), 2, 30*time.Minute).Return(nil)
+
+	_, err := svc.AddToCart(context.Background(), 1, 10, 0, 2)
+	require.NoError(t, err)
+	cache.AssertExpectations(t)
+}
+
+func TestAddToCart_ForeignVariantRejected(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	catalog := new(mocks.MockCatalog)
+	svc := services.NewCartCacheAsideService(repo, cache, catalog, anyEvents(), newTestLogger(), testTTL)
+
+	catalog.On("ValidateItem", mock.Anything, 10, 99).
+		Return(fmt.Errorf("variant 99 of sneaker 10: %w", models.ErrProductUnavailable))
+
+	_, err := svc.AddToCart(context.Background(), 1, 10, 99, 2)
+	require.ErrorIs(t, err, models.ErrProductUnavailable)
+	repo.AssertNotCalled(t, "AddCartItem", mock.Anything, mock.Anything)
+	cache.AssertNotCalled(t, "AddToCartItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestAddToCart_RepoError(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	repo.On("AddCartItem", mock.Anything, mock.AnythingOfType("*models.CartItem")).
 		Return(errors.New("duplicate key"))
 
-	err := svc.AddToCart(context.Background(), 1, 10, 2)
+	_, err := svc.AddToCart(context.Background(), 1, 10, 0, 2)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "duplicate key")
 	cache.AssertNotCalled(t, "AddToCartItem")
@@ -145,18 +207,46 @@ func TestAddToCart_RepoError(t *testing.T) {
 func TestAddToCart_CacheUpdateFail_Invalidates(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	repo.On("AddCartItem", mock.Anything, mock.AnythingOfType("*models.CartItem")).Return(nil)
-	cache.On("AddToCartItem", mock.Anything, mock.AnythingOfType("models.CartItem")).
+	cache.On("AddToCartItem", mock.Anything, mock.AnythingOfType("models.CartItem"), 2, testTTL).
 		Return(errors.New("redis error"))
 	cache.On("InvalidateCart", mock.Anything, 1).Return(nil)
 
-	err := svc.AddToCart(context.Background(), 1, 10, 2)
+	_, err := svc.AddToCart(context.Background(), 1, 10, 0, 2)
 	require.NoError(t, err)
 	cache.AssertCalled(t, "InvalidateCart", mock.Anything, 1)
 }
 
+func TestAddToCart_ExistingLine_ReturnsMergedItem(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	// Строка с этим товаром уже есть: репозиторий возвращает её id и суммарное количество.
+	repo.On("AddCartItem", mock.Anything, mock.AnythingOfType("*models.CartItem")).Run(func(args mock.Arguments) {
+		item := args.Get(1).(*models.CartItem)
+		item.ID = "7"
+		item.Quantity = 5
+	}).Return(nil)
+	// В кэш уходит прибавка, а не итог: скрипт сам прибавит её к закэшированной строке.
+	cache.On("AddToCartItem", mock.Anything, mock.MatchedBy(func(item models.CartItem) bool {
+		return item.ID == "7" && item.Quantity == 5
+	}), 2, testTTL).Return(nil)
+	events.On("PublishCartEvent", mock.Anything, mock.MatchedBy(func(e models.CartEvent) bool {
+		return e.EventType == models.EventCartItemAdded && e.ItemID == "7" && e.Quantity == 5
+	})).Return(nil)
+
+	item, err := svc.AddToCart(context.Background(), 1, 10, 42, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "7", item.ID)
+	assert.Equal(t, 5, item.Quantity)
+	cache.AssertExpectations(t)
+	events.AssertExpectations(t)
+}
+
 // ---------------------------------------------------------------------------
 // RemoveFromCart
 // ---------------------------------------------------------------------------
@@ -164,9 +254,10 @@ func TestAddToCart_CacheUpdateFail_Invalidates(t *testing.T) {
 func TestRemoveFromCart_Success(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
-	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").Return(nil)
+	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").
+		Return(&models.CartItem{ID: "item-1", SneakerID: 10, Quantity: 2}, nil)
 	cache.On("RemoveFromCart", mock.Anything, 1, "item-1").Return(nil)
 
 	err := svc.RemoveFromCart(context.Background(), 1, "item-1")
@@ -178,9 +269,9 @@ func TestRemoveFromCart_Success(t *testing.T) {
 func TestRemoveFromCart_RepoError(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
-	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").Return(errors.New("not found"))
+	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").Return(nil, errors.New("not found"))
 
 	err := svc.RemoveFromCart(context.Background(), 1, "item-1")
 	require.Error(t, err)
@@ -194,7 +285,7 @@ func TestRemoveFromCart_RepoError(t *testing.T) {
 func TestClearCart_Success(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	repo.On("ClearCart", mock.Anything, 1).Return(nil)
 	cache.On("InvalidateCart", mock.Anything, 1).Return(nil)
@@ -208,7 +299,7 @@ func TestClearCart_Success(t *testing.T) {
 func TestClearCart_RepoError(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
 	repo.On("ClearCart", mock.Anything, 1).Return(errors.New("db error"))
 
@@ -224,26 +315,173 @@ func TestClearCart_RepoError(t *testing.T) {
 func TestUpdateCartItemQuantity_Success(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
-	repo.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).Return(nil)
+	repo.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).
+		Return(&models.CartItem{ID: "item-1", SneakerID: 10, Quantity: 5}, nil)
 	cache.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).Return(nil)
 
-	err := svc.UpdateCartItemQuantity(context.Background(), 1, "item-1", 5)
+	item, err := svc.UpdateCartItemQuantity(context.Background(), 1, "item-1", 5)
 	require.NoError(t, err)
+	assert.Equal(t, "item-1", item.ID)
+	assert.Equal(t, 5, item.Quantity)
 }
 
 func TestUpdateCartItemQuantity_CacheFail_Invalidates(t *testing.T) {
 	cache := new(mocks.MockCartCache)
 	repo := new(mocks.MockCartRepository)
-	svc := services.NewCartCacheAsideService(repo, cache, newTestLogger(), testTTL)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), anyEvents(), newTestLogger(), testTTL)
 
-	repo.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).Return(nil)
+	repo.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).
+		Return(&models.CartItem{ID: "item-1", SneakerID: 10, Quantity: 5}, nil)
 	cache.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).
 		Return(errors.New("redis error"))
 	cache.On("InvalidateCart", mock.Anything, 1).Return(nil)
 
-	err := svc.UpdateCartItemQuantity(context.Background(), 1, "item-1", 5)
+	_, err := svc.UpdateCartItemQuantity(context.Background(), 1, "item-1", 5)
 	require.NoError(t, err)
 	cache.AssertCalled(t, "InvalidateCart", mock.Anything, 1)
 }
+
+// ---------------------------------------------------------------------------
+// События
+// ---------------------------------------------------------------------------
+
+func TestAddToCart_PublishesEvent(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("AddCartItem", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.CartItem).ID = "7"
+	}).Return(nil)
+	cache.On("AddToCartItem", mock.Anything, mock.Anything, 2, testTTL).Return(nil)
+	events.On("PublishCartEvent", mock.Anything, mock.MatchedBy(func(e models.CartEvent) bool {
+		return e.EventType == models.EventCartItemAdded && e.UserSSOID == 1 && e.ItemID == "7" &&
+			e.SneakerID == 10 && e.VariantID == 42 && e.Quantity == 2
+	})).Return(nil)
+
+	_, err := svc.AddToCart(context.Background(), 1, 10, 42, 2)
+	require.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestRemoveFromCart_PublishesRemovedItem(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").
+		Return(&models.CartItem{ID: "item-1", SneakerID: 10, VariantID: 42, Quantity: 3}, nil)
+	cache.On("RemoveFromCart", mock.Anything, 1, "item-1").Return(nil)
+	// Время события проставляет сервис — сравниваем без него.
+	events.On("PublishCartEvent", mock.Anything, mock.MatchedBy(func(e models.CartEvent) bool {
+		e.Timestamp = time.Time{}
+		return e == models.CartEvent{
+			EventType: models.EventCartItemRemoved, UserSSOID: 1, ItemID: "item-1", SneakerID: 10, VariantID: 42, Quantity: 3,
+		}
+	})).Return(nil)
+
+	err := svc.RemoveFromCart(context.Background(), 1, "item-1")
+	require.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestClearCart_PublishesEvent(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("ClearCart", mock.Anything, 1).Return(nil)
+	cache.On("InvalidateCart", mock.Anything, 1).Return(nil)
+	events.On("PublishCartEvent", mock.Anything, mock.MatchedBy(func(e models.CartEvent) bool {
+		return e.EventType == models.EventCartCleared && e.UserSSOID == 1
+	})).Return(nil)
+
+	err := svc.ClearCart(context.Background(), 1)
+	require.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestUpdateCartItemQuantity_PublishFailureIgnored(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).
+		Return(&models.CartItem{ID: "item-1", SneakerID: 10, Quantity: 5}, nil)
+	cache.On("UpdateCartItemQuantity", mock.Anything, 1, "item-1", 5).Return(nil)
+	events.On("PublishCartEvent", mock.Anything, mock.MatchedBy(func(e models.CartEvent) bool {
+		return e.EventType == models.EventCartItemUpdated && e.SneakerID == 10 && e.Quantity == 5
+	})).Return(errors.New("kafka unavailable"))
+
+	_, err := svc.UpdateCartItemQuantity(context.Background(), 1, "item-1", 5)
+	require.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestRemoveFromCart_RepoErrorPublishesNothing(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("RemoveCartItem", mock.Anything, 1, "item-1").Return(nil, errors.New("not found"))
+
+	err := svc.RemoveFromCart(context.Background(), 1, "item-1")
+	require.Error(t, err)
+	events.AssertNotCalled(t, "PublishCartEvent", mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// PublishAbandonedCarts
+// ---------------------------------------------------------------------------
+
+func TestPublishAbandonedCarts(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	idleBefore := time.Now().Add(-24 * time.Hour)
+	first := models.AbandonedCart{
+		UserSSOID: 1,
+		Items:     []models.CartItem{{ID: "5", SneakerID: 10, Quantity: 1}},
+		UpdatedAt: idleBefore.Add(-time.Hour),
+	}
+	second := models.AbandonedCart{
+		UserSSOID: 2,
+		Items:     []models.CartItem{{ID: "6", SneakerID: 11, Quantity: 2}},
+		UpdatedAt: idleBefore.Add(-time.Minute),
+	}
+	repo.On("FindAbandonedCarts", mock.Anything, idleBefore, 10).
+		Return([]models.AbandonedCart{first, second}, nil)
+	events.On("PublishCartAbandoned", mock.Anything, first).Return(nil)
+	events.On("PublishCartAbandoned", mock.Anything, second).Return(errors.New("kafka unavailable"))
+	repo.On("MarkCartAbandoned", mock.Anything, 1, first.UpdatedAt).Return(nil)
+
+	found, published, err := svc.PublishAbandonedCarts(context.Background(), idleBefore, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found)
+	assert.Equal(t, 1, published)
+	// Неопубликованная корзина не отмечается и будет найдена снова.
+	repo.AssertNotCalled(t, "MarkCartAbandoned", mock.Anything, 2, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestPublishAbandonedCarts_RepoError(t *testing.T) {
+	cache := new(mocks.MockCartCache)
+	repo := new(mocks.MockCartRepository)
+	events := new(mocks.MockEventPublisher)
+	svc := services.NewCartCacheAsideService(repo, cache, anyCatalog(), events, newTestLogger(), testTTL)
+
+	repo.On("FindAbandonedCarts", mock.Anything, mock.Anything, 10).Return(nil, errors.New("db error"))
+
+	_, _, err := svc.PublishAbandonedCarts(context.Background(), time.Now(), 10)
+	require.Error(t, err)
+	events.AssertNotCalled(t, "PublishCartAbandoned", mock.Anything, mock.Anything)
+}
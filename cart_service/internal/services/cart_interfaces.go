@@ -10,8 +10,8 @@ import (
 // CartService определяет интерфейс для работы с корзиной
 type CartService interface {
 	GetCart(ctx context.Context, userSSOID int) (*models.Cart, error)
-	AddToCart(ctx context.Context, userSSOID, sneakerID, quantity int) error
-	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error
+	AddToCart(ctx context.Context, userSSOID, sneakerID, variantID, quantity int) (*models.CartItem, error)
+	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error)
 	RemoveFromCart(ctx context.Context, userSSOID int, itemID string) error
 	ClearCart(ctx context.Context, userSSOID int) error
 }
@@ -22,9 +22,11 @@ type CartService interface {
 type CartRepository interface {
 	GetCart(ctx context.Context, userSSOID int) (*models.Cart, error)
 	AddCartItem(ctx context.Context, item *models.CartItem) error
-	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error
-	RemoveCartItem(ctx context.Context, userSSOID int, itemID string) error
+	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error)
+	RemoveCartItem(ctx context.Context, userSSOID int, itemID string) (*models.CartItem, error)
 	ClearCart(ctx context.Context, userSSOID int) error
+	FindAbandonedCarts(ctx context.Context, idleBefore time.Time, limit int) ([]models.AbandonedCart, error)
+	MarkCartAbandoned(ctx context.Context, userSSOID int, updatedAt time.Time) error
 }
 
 // CartCache — интерфейс кэширования (Redis).
@@ -34,7 +36,26 @@ type CartCache interface {
 	GetCart(ctx context.Context, userSSOID int) (*models.Cart, error)
 	SetCart(ctx context.Context, userSSOID int, cart *models.Cart, ttl time.Duration) error
 	InvalidateCart(ctx context.Context, userSSOID int) error
-	AddToCartItem(ctx context.Context, item models.CartItem) error
+	// AddToCartItem прибавляет delta к строке закэшированной корзины и продлевает её TTL до ttl.
+	AddToCartItem(ctx context.Context, item models.CartItem, delta int, ttl time.Duration) error
 	UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, newQuantity int) error
 	RemoveFromCart(ctx context.Context, userSSOID int, itemID string) error
 }
+
+// Catalog — проверка товаров в каталоге (product_service).
+//
+//go:generate mockery --name=Catalog --output=mocks --outpkg=mocks --filename=mock_catalog.go
+type Catalog interface {
+	// ValidateItem возвращает models.ErrProductUnavailable, если товара нет, он в архиве
+	// или вариант variantID (0 — без варианта) принадлежит другому товару.
+	ValidateItem(ctx context.Context, sneakerID, variantID int) error
+}
+
+// EventPublisher — публикация событий корзины (Kafka).
+//
+//go:generate mockery --name=EventPublisher --output=mocks --outpkg=mocks --filename=mock_event_publisher.go
+type EventPublisher interface {
+	// PublishCartEvent не блокирует запрос: событие уходит в брокер в фоне.
+	PublishCartEvent(ctx context.Context, event models.CartEvent) error
+	PublishCartAbandoned(ctx context.Context, cart models.AbandonedCart) error
+}
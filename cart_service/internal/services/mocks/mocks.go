@@ -153,6 +153,80 @@ func (_c *MockCartRepository_ClearCart_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// FindAbandonedCarts provides a mock function for the type MockCartRepository
+func (_mock *MockCartRepository) FindAbandonedCarts(ctx context.Context, idleBefore time.Time, limit int) ([]models.AbandonedCart, error) {
+	ret := _mock.Called(ctx, idleBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAbandonedCarts")
+	}
+
+	var r0 []models.AbandonedCart
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]models.AbandonedCart, error)); ok {
+		return returnFunc(ctx, idleBefore, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []models.AbandonedCart); ok {
+		r0 = returnFunc(ctx, idleBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AbandonedCart)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, idleBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCartRepository_FindAbandonedCarts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAbandonedCarts'
+type MockCartRepository_FindAbandonedCarts_Call struct {
+	*mock.Call
+}
+
+// FindAbandonedCarts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idleBefore time.Time
+//   - limit int
+func (_e *MockCartRepository_Expecter) FindAbandonedCarts(ctx interface{}, idleBefore interface{}, limit interface{}) *MockCartRepository_FindAbandonedCarts_Call {
+	return &MockCartRepository_FindAbandonedCarts_Call{Call: _e.mock.On("FindAbandonedCarts", ctx, idleBefore, limit)}
+}
+
+func (_c *MockCartRepository_FindAbandonedCarts_Call) Run(run func(ctx context.Context, idleBefore time.Time, limit int)) *MockCartRepository_FindAbandonedCarts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCartRepository_FindAbandonedCarts_Call) Return(abandonedCarts []models.AbandonedCart, err error) *MockCartRepository_FindAbandonedCarts_Call {
+	_c.Call.Return(abandonedCarts, err)
+	return _c
+}
+
+func (_c *MockCartRepository_FindAbandonedCarts_Call) RunAndReturn(run func(ctx context.Context, idleBefore time.Time, limit int) ([]models.AbandonedCart, error)) *MockCartRepository_FindAbandonedCarts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCart provides a mock function for the type MockCartRepository
 func (_mock *MockCartRepository) GetCart(ctx context.Context, userSSOID int) (*models.Cart, error) {
 	ret := _mock.Called(ctx, userSSOID)
@@ -221,21 +295,95 @@ func (_c *MockCartRepository_GetCart_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// MarkCartAbandoned provides a mock function for the type MockCartRepository
+func (_mock *MockCartRepository) MarkCartAbandoned(ctx context.Context, userSSOID int, updatedAt time.Time) error {
+	ret := _mock.Called(ctx, userSSOID, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCartAbandoned")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = returnFunc(ctx, userSSOID, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCartRepository_MarkCartAbandoned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkCartAbandoned'
+type MockCartRepository_MarkCartAbandoned_Call struct {
+	*mock.Call
+}
+
+// MarkCartAbandoned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userSSOID int
+//   - updatedAt time.Time
+func (_e *MockCartRepository_Expecter) MarkCartAbandoned(ctx interface{}, userSSOID interface{}, updatedAt interface{}) *MockCartRepository_MarkCartAbandoned_Call {
+	return &MockCartRepository_MarkCartAbandoned_Call{Call: _e.mock.On("MarkCartAbandoned", ctx, userSSOID, updatedAt)}
+}
+
+func (_c *MockCartRepository_MarkCartAbandoned_Call) Run(run func(ctx context.Context, userSSOID int, updatedAt time.Time)) *MockCartRepository_MarkCartAbandoned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCartRepository_MarkCartAbandoned_Call) Return(err error) *MockCartRepository_MarkCartAbandoned_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCartRepository_MarkCartAbandoned_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, updatedAt time.Time) error) *MockCartRepository_MarkCartAbandoned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveCartItem provides a mock function for the type MockCartRepository
-func (_mock *MockCartRepository) RemoveCartItem(ctx context.Context, userSSOID int, itemID string) error {
+func (_mock *MockCartRepository) RemoveCartItem(ctx context.Context, userSSOID int, itemID string) (*models.CartItem, error) {
 	ret := _mock.Called(ctx, userSSOID, itemID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveCartItem")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+	var r0 *models.CartItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) (*models.CartItem, error)); ok {
+		return returnFunc(ctx, userSSOID, itemID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) *models.CartItem); ok {
 		r0 = returnFunc(ctx, userSSOID, itemID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CartItem)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = returnFunc(ctx, userSSOID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockCartRepository_RemoveCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveCartItem'
@@ -274,31 +422,42 @@ func (_c *MockCartRepository_RemoveCartItem_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockCartRepository_RemoveCartItem_Call) Return(err error) *MockCartRepository_RemoveCartItem_Call {
-	_c.Call.Return(err)
+func (_c *MockCartRepository_RemoveCartItem_Call) Return(cartItem *models.CartItem, err error) *MockCartRepository_RemoveCartItem_Call {
+	_c.Call.Return(cartItem, err)
 	return _c
 }
 
-func (_c *MockCartRepository_RemoveCartItem_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, itemID string) error) *MockCartRepository_RemoveCartItem_Call {
+func (_c *MockCartRepository_RemoveCartItem_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, itemID string) (*models.CartItem, error)) *MockCartRepository_RemoveCartItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateCartItemQuantity provides a mock function for the type MockCartRepository
-func (_mock *MockCartRepository) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) error {
+func (_mock *MockCartRepository) UpdateCartItemQuantity(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error) {
 	ret := _mock.Called(ctx, userSSOID, itemID, quantity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCartItemQuantity")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int) error); ok {
+	var r0 *models.CartItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int) (*models.CartItem, error)); ok {
+		return returnFunc(ctx, userSSOID, itemID, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int) *models.CartItem); ok {
 		r0 = returnFunc(ctx, userSSOID, itemID, quantity)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CartItem)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = returnFunc(ctx, userSSOID, itemID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockCartRepository_UpdateCartItemQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCartItemQuantity'
@@ -343,12 +502,12 @@ func (_c *MockCartRepository_UpdateCartItemQuantity_Call) Run(run func(ctx conte
 	return _c
 }
 
-func (_c *MockCartRepository_UpdateCartItemQuantity_Call) Return(err error) *MockCartRepository_UpdateCartItemQuantity_Call {
-	_c.Call.Return(err)
+func (_c *MockCartRepository_UpdateCartItemQuantity_Call) Return(cartItem *models.CartItem, err error) *MockCartRepository_UpdateCartItemQuantity_Call {
+	_c.Call.Return(cartItem, err)
 	return _c
 }
 
-func (_c *MockCartRepository_UpdateCartItemQuantity_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, itemID string, quantity int) error) *MockCartRepository_UpdateCartItemQuantity_Call {
+func (_c *MockCartRepository_UpdateCartItemQuantity_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, itemID string, quantity int) (*models.CartItem, error)) *MockCartRepository_UpdateCartItemQuantity_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -381,16 +540,16 @@ func (_m *MockCartCache) EXPECT() *MockCartCache_Expecter {
 }
 
 // AddToCartItem provides a mock function for the type MockCartCache
-func (_mock *MockCartCache) AddToCartItem(ctx context.Context, item models.CartItem) error {
-	ret := _mock.Called(ctx, item)
+func (_mock *MockCartCache) AddToCartItem(ctx context.Context, item models.CartItem, delta int, ttl time.Duration) error {
+	ret := _mock.Called(ctx, item, delta, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddToCartItem")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.CartItem) error); ok {
-		r0 = returnFunc(ctx, item)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.CartItem, int, time.Duration) error); ok {
+		r0 = returnFunc(ctx, item, delta, ttl)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -405,11 +564,13 @@ type MockCartCache_AddToCartItem_Call struct {
 // AddToCartItem is a helper method to define mock.On call
 //   - ctx context.Context
 //   - item models.CartItem
-func (_e *MockCartCache_Expecter) AddToCartItem(ctx interface{}, item interface{}) *MockCartCache_AddToCartItem_Call {
-	return &MockCartCache_AddToCartItem_Call{Call: _e.mock.On("AddToCartItem", ctx, item)}
+//   - delta int
+//   - ttl time.Duration
+func (_e *MockCartCache_Expecter) AddToCartItem(ctx interface{}, item interface{}, delta interface{}, ttl interface{}) *MockCartCache_AddToCartItem_Call {
+	return &MockCartCache_AddToCartItem_Call{Call: _e.mock.On("AddToCartItem", ctx, item, delta, ttl)}
 }
 
-func (_c *MockCartCache_AddToCartItem_Call) Run(run func(ctx context.Context, item models.CartItem)) *MockCartCache_AddToCartItem_Call {
+func (_c *MockCartCache_AddToCartItem_Call) Run(run func(ctx context.Context, item models.CartItem, delta int, ttl time.Duration)) *MockCartCache_AddToCartItem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -419,9 +580,19 @@ func (_c *MockCartCache_AddToCartItem_Call) Run(run func(ctx context.Context, it
 		if args[1] != nil {
 			arg1 = args[1].(models.CartItem)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -432,7 +603,7 @@ func (_c *MockCartCache_AddToCartItem_Call) Return(err error) *MockCartCache_Add
 	return _c
 }
 
-func (_c *MockCartCache_AddToCartItem_Call) RunAndReturn(run func(ctx context.Context, item models.CartItem) error) *MockCartCache_AddToCartItem_Call {
+func (_c *MockCartCache_AddToCartItem_Call) RunAndReturn(run func(ctx context.Context, item models.CartItem, delta int, ttl time.Duration) error) *MockCartCache_AddToCartItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -762,3 +933,234 @@ func (_c *MockCartCache_UpdateCartItemQuantity_Call) RunAndReturn(run func(ctx c
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockCatalog creates a new instance of MockCatalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalog {
+	mock := &MockCatalog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCatalog is an autogenerated mock type for the Catalog type
+type MockCatalog struct {
+	mock.Mock
+}
+
+type MockCatalog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalog) EXPECT() *MockCatalog_Expecter {
+	return &MockCatalog_Expecter{mock: &_m.Mock}
+}
+
+// ValidateItem provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) ValidateItem(ctx context.Context, sneakerID int, variantID int) error {
+	ret := _mock.Called(ctx, sneakerID, variantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = returnFunc(ctx, sneakerID, variantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCatalog_ValidateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateItem'
+type MockCatalog_ValidateItem_Call struct {
+	*mock.Call
+}
+
+// ValidateItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sneakerID int
+//   - variantID int
+func (_e *MockCatalog_Expecter) ValidateItem(ctx interface{}, sneakerID interface{}, variantID interface{}) *MockCatalog_ValidateItem_Call {
+	return &MockCatalog_ValidateItem_Call{Call: _e.mock.On("ValidateItem", ctx, sneakerID, variantID)}
+}
+
+func (_c *MockCatalog_ValidateItem_Call) Run(run func(ctx context.Context, sneakerID int, variantID int)) *MockCatalog_ValidateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_ValidateItem_Call) Return(err error) *MockCatalog_ValidateItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCatalog_ValidateItem_Call) RunAndReturn(run func(ctx context.Context, sneakerID int, variantID int) error) *MockCatalog_ValidateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEventPublisher creates a new instance of MockEventPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEventPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEventPublisher {
+	mock := &MockEventPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEventPublisher is an autogenerated mock type for the EventPublisher type
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+type MockEventPublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEventPublisher) EXPECT() *MockEventPublisher_Expecter {
+	return &MockEventPublisher_Expecter{mock: &_m.Mock}
+}
+
+// PublishCartAbandoned provides a mock function for the type MockEventPublisher
+func (_mock *MockEventPublisher) PublishCartAbandoned(ctx context.Context, cart models.AbandonedCart) error {
+	ret := _mock.Called(ctx, cart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishCartAbandoned")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.AbandonedCart) error); ok {
+		r0 = returnFunc(ctx, cart)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventPublisher_PublishCartAbandoned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishCartAbandoned'
+type MockEventPublisher_PublishCartAbandoned_Call struct {
+	*mock.Call
+}
+
+// PublishCartAbandoned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cart models.AbandonedCart
+func (_e *MockEventPublisher_Expecter) PublishCartAbandoned(ctx interface{}, cart interface{}) *MockEventPublisher_PublishCartAbandoned_Call {
+	return &MockEventPublisher_PublishCartAbandoned_Call{Call: _e.mock.On("PublishCartAbandoned", ctx, cart)}
+}
+
+func (_c *MockEventPublisher_PublishCartAbandoned_Call) Run(run func(ctx context.Context, cart models.AbandonedCart)) *MockEventPublisher_PublishCartAbandoned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.AbandonedCart
+		if args[1] != nil {
+			arg1 = args[1].(models.AbandonedCart)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventPublisher_PublishCartAbandoned_Call) Return(err error) *MockEventPublisher_PublishCartAbandoned_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventPublisher_PublishCartAbandoned_Call) RunAndReturn(run func(ctx context.Context, cart models.AbandonedCart) error) *MockEventPublisher_PublishCartAbandoned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishCartEvent provides a mock function for the type MockEventPublisher
+func (_mock *MockEventPublisher) PublishCartEvent(ctx context.Context, event models.CartEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishCartEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.CartEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventPublisher_PublishCartEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishCartEvent'
+type MockEventPublisher_PublishCartEvent_Call struct {
+	*mock.Call
+}
+
+// PublishCartEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event models.CartEvent
+func (_e *MockEventPublisher_Expecter) PublishCartEvent(ctx interface{}, event interface{}) *MockEventPublisher_PublishCartEvent_Call {
+	return &MockEventPublisher_PublishCartEvent_Call{Call: _e.mock.On("PublishCartEvent", ctx, event)}
+}
+
+func (_c *MockEventPublisher_PublishCartEvent_Call) Run(run func(ctx context.Context, event models.CartEvent)) *MockEventPublisher_PublishCartEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.CartEvent
+		if args[1] != nil {
+			arg1 = args[1].(models.CartEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventPublisher_PublishCartEvent_Call) Return(err error) *MockEventPublisher_PublishCartEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventPublisher_PublishCartEvent_Call) RunAndReturn(run func(ctx context.Context, event models.CartEvent) error) *MockEventPublisher_PublishCartEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
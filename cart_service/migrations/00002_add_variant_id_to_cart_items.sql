@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS variant_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_cart_items_variant_id ON cart_items(variant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cart_items_variant_id;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS variant_id;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- abandoned_at — updated_at корзины, о которой уже отправлен CartAbandoned.
+-- Когда корзину меняют, updated_at расходится с ним и корзина снова может быть брошенной.
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS abandoned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_carts_updated_at ON carts(updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_carts_updated_at;
+ALTER TABLE carts DROP COLUMN IF EXISTS abandoned_at;
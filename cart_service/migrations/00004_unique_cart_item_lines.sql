@@ -0,0 +1,26 @@
+-- +goose Up
+-- Товар (с вариантом) занимает в корзине одну строку. Повторяющиеся строки сливаются
+-- в самую раннюю: количество суммируется, время добавления — самое раннее.
+UPDATE cart_items AS keep
+SET quantity = dup.quantity,
+    added_at = dup.added_at,
+    updated_at = dup.updated_at
+FROM (
+    SELECT MIN(id) AS id, SUM(quantity) AS quantity, MIN(added_at) AS added_at, MAX(updated_at) AS updated_at
+    FROM cart_items
+    GROUP BY cart_id, sneaker_id, variant_id
+    HAVING COUNT(*) > 1
+) AS dup
+WHERE keep.id = dup.id;
+
+DELETE FROM cart_items AS d
+USING cart_items AS k
+WHERE d.cart_id = k.cart_id
+  AND d.sneaker_id = k.sneaker_id
+  AND d.variant_id = k.variant_id
+  AND d.id > k.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_items_line ON cart_items(cart_id, sneaker_id, variant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cart_items_line;
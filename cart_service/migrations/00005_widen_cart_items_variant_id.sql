@@ -0,0 +1,6 @@
+-- +goose Up
+-- variant_id ссылается на sneaker_variants.id в product_service, а это BIGSERIAL.
+ALTER TABLE cart_items ALTER COLUMN variant_id TYPE BIGINT;
+
+-- +goose Down
+ALTER TABLE cart_items ALTER COLUMN variant_id TYPE INTEGER;
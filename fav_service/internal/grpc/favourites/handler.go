@@ -2,6 +2,7 @@ package favourites
 
 import (
 	"context"
+	"errors"
 	"fav_service/internal/models"
 	"fmt"
 	"log/slog"
@@ -17,7 +18,7 @@ import (
 type FavouritesService interface {
 	AddToFavourite(ctx context.Context, userSSOID, sneakerID int) error
 	RemoveFromFavourite(ctx context.Context, userSSOID, sneakerID int) error
-	GetAllFavourites(ctx context.Context, userSSOID int) ([]models.Favourite, error)
+	GetFavouritesPage(ctx context.Context, userSSOID, pageSize int, pageToken string) (*models.FavouritePage, error)
 	IsFavourite(ctx context.Context, userSSOID, sneakerID int) (bool, error)
 	GetByIDs(ctx context.Context, ids []int) ([]models.Favourite, error)
 	ParseIDsString(idsParam string) ([]int, error)
@@ -114,15 +115,18 @@ func (s *serverAPI) GetFavourites(
 	}
 
 	// Get favourites from service
-	items, err := s.favService.GetAllFavourites(ctx, userID)
+	page, err := s.favService.GetFavouritesPage(ctx, userID, int(req.GetPageSize()), req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 		s.log.Error("failed to get favourites", slog.String("op", op), slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to get favourites")
 	}
 
 	// Convert to proto items
-	protoItems := make([]*favv1.FavouriteItem, 0, len(items))
-	for _, item := range items {
+	protoItems := make([]*favv1.FavouriteItem, 0, len(page.Items))
+	for _, item := range page.Items {
 		protoItems = append(protoItems, &favv1.FavouriteItem{
 			Id:        int64(item.ID),
 			UserId:    int64(item.UserSSOID),
@@ -132,7 +136,8 @@ func (s *serverAPI) GetFavourites(
 	}
 
 	return &favv1.GetFavouritesResponse{
-		Items: protoItems,
+		Items:         protoItems,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
 
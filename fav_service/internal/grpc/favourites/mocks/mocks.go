@@ -101,55 +101,55 @@ func (_c *MockFavouritesService_AddToFavourite_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// GetAllFavourites provides a mock function for the type MockFavouritesService
-func (_mock *MockFavouritesService) GetAllFavourites(ctx context.Context, userSSOID int) ([]models.Favourite, error) {
-	ret := _mock.Called(ctx, userSSOID)
+// GetByIDs provides a mock function for the type MockFavouritesService
+func (_mock *MockFavouritesService) GetByIDs(ctx context.Context, ids []int) ([]models.Favourite, error) {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllFavourites")
+		panic("no return value specified for GetByIDs")
 	}
 
 	var r0 []models.Favourite
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Favourite, error)); ok {
-		return returnFunc(ctx, userSSOID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) ([]models.Favourite, error)); ok {
+		return returnFunc(ctx, ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Favourite); ok {
-		r0 = returnFunc(ctx, userSSOID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) []models.Favourite); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Favourite)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userSSOID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = returnFunc(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFavouritesService_GetAllFavourites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllFavourites'
-type MockFavouritesService_GetAllFavourites_Call struct {
+// MockFavouritesService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockFavouritesService_GetByIDs_Call struct {
 	*mock.Call
 }
 
-// GetAllFavourites is a helper method to define mock.On call
+// GetByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userSSOID int
-func (_e *MockFavouritesService_Expecter) GetAllFavourites(ctx interface{}, userSSOID interface{}) *MockFavouritesService_GetAllFavourites_Call {
-	return &MockFavouritesService_GetAllFavourites_Call{Call: _e.mock.On("GetAllFavourites", ctx, userSSOID)}
+//   - ids []int
+func (_e *MockFavouritesService_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockFavouritesService_GetByIDs_Call {
+	return &MockFavouritesService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
 }
 
-func (_c *MockFavouritesService_GetAllFavourites_Call) Run(run func(ctx context.Context, userSSOID int)) *MockFavouritesService_GetAllFavourites_Call {
+func (_c *MockFavouritesService_GetByIDs_Call) Run(run func(ctx context.Context, ids []int)) *MockFavouritesService_GetByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 []int
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].([]int)
 		}
 		run(
 			arg0,
@@ -159,80 +159,92 @@ func (_c *MockFavouritesService_GetAllFavourites_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockFavouritesService_GetAllFavourites_Call) Return(favourites []models.Favourite, err error) *MockFavouritesService_GetAllFavourites_Call {
+func (_c *MockFavouritesService_GetByIDs_Call) Return(favourites []models.Favourite, err error) *MockFavouritesService_GetByIDs_Call {
 	_c.Call.Return(favourites, err)
 	return _c
 }
 
-func (_c *MockFavouritesService_GetAllFavourites_Call) RunAndReturn(run func(ctx context.Context, userSSOID int) ([]models.Favourite, error)) *MockFavouritesService_GetAllFavourites_Call {
+func (_c *MockFavouritesService_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int) ([]models.Favourite, error)) *MockFavouritesService_GetByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByIDs provides a mock function for the type MockFavouritesService
-func (_mock *MockFavouritesService) GetByIDs(ctx context.Context, ids []int) ([]models.Favourite, error) {
-	ret := _mock.Called(ctx, ids)
+// GetFavouritesPage provides a mock function for the type MockFavouritesService
+func (_mock *MockFavouritesService) GetFavouritesPage(ctx context.Context, userSSOID int, pageSize int, pageToken string) (*models.FavouritePage, error) {
+	ret := _mock.Called(ctx, userSSOID, pageSize, pageToken)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByIDs")
+		panic("no return value specified for GetFavouritesPage")
 	}
 
-	var r0 []models.Favourite
+	var r0 *models.FavouritePage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) ([]models.Favourite, error)); ok {
-		return returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) (*models.FavouritePage, error)); ok {
+		return returnFunc(ctx, userSSOID, pageSize, pageToken)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) []models.Favourite); ok {
-		r0 = returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) *models.FavouritePage); ok {
+		r0 = returnFunc(ctx, userSSOID, pageSize, pageToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Favourite)
+			r0 = ret.Get(0).(*models.FavouritePage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []int) error); ok {
-		r1 = returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = returnFunc(ctx, userSSOID, pageSize, pageToken)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFavouritesService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
-type MockFavouritesService_GetByIDs_Call struct {
+// MockFavouritesService_GetFavouritesPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFavouritesPage'
+type MockFavouritesService_GetFavouritesPage_Call struct {
 	*mock.Call
 }
 
-// GetByIDs is a helper method to define mock.On call
+// GetFavouritesPage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ids []int
-func (_e *MockFavouritesService_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockFavouritesService_GetByIDs_Call {
-	return &MockFavouritesService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+//   - userSSOID int
+//   - pageSize int
+//   - pageToken string
+func (_e *MockFavouritesService_Expecter) GetFavouritesPage(ctx interface{}, userSSOID interface{}, pageSize interface{}, pageToken interface{}) *MockFavouritesService_GetFavouritesPage_Call {
+	return &MockFavouritesService_GetFavouritesPage_Call{Call: _e.mock.On("GetFavouritesPage", ctx, userSSOID, pageSize, pageToken)}
 }
 
-func (_c *MockFavouritesService_GetByIDs_Call) Run(run func(ctx context.Context, ids []int)) *MockFavouritesService_GetByIDs_Call {
+func (_c *MockFavouritesService_GetFavouritesPage_Call) Run(run func(ctx context.Context, userSSOID int, pageSize int, pageToken string)) *MockFavouritesService_GetFavouritesPage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []int
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].([]int)
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFavouritesService_GetByIDs_Call) Return(favourites []models.Favourite, err error) *MockFavouritesService_GetByIDs_Call {
-	_c.Call.Return(favourites, err)
+func (_c *MockFavouritesService_GetFavouritesPage_Call) Return(favouritePage *models.FavouritePage, err error) *MockFavouritesService_GetFavouritesPage_Call {
+	_c.Call.Return(favouritePage, err)
 	return _c
 }
 
-func (_c *MockFavouritesService_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int) ([]models.Favourite, error)) *MockFavouritesService_GetByIDs_Call {
+func (_c *MockFavouritesService_GetFavouritesPage_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, pageSize int, pageToken string) (*models.FavouritePage, error)) *MockFavouritesService_GetFavouritesPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,35 @@
+package pagetoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidToken = errors.New("invalid page token")
+
+// Encode упаковывает курсор в непрозрачный токен страницы.
+// Клиенты не должны разбирать токен: формат курсора может меняться.
+func Encode(cursor any) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// Decode распаковывает токен в курсор. Пустой токен означает первую страницу
+// и оставляет курсор нетронутым — вызывающий проверяет это сам.
+func Decode(token string, cursor any) error {
+	if token == "" {
+		return nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err := json.Unmarshal(raw, cursor); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
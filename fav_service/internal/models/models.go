@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type Favourite struct {
 	ID        int       `json:"id" db:"id"`
@@ -8,3 +11,12 @@ type Favourite struct {
 	SneakerID int       `json:"sneaker_id" db:"sneaker_id"`
 	AddedAt   time.Time `json:"added_at" db:"added_at"`
 }
+
+// ErrInvalidPageToken — токен страницы повреждён или не разбирается.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// FavouritePage — страница избранного в порядке sneaker_id.
+type FavouritePage struct {
+	Items         []Favourite
+	NextPageToken string // пусто — страниц больше нет
+}
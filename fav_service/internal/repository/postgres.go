@@ -68,7 +68,7 @@ func (p *PostgresRepo) RemoveFromFavourite(ctx context.Context, userSSOID, sneak
 }
 
 func (p *PostgresRepo) GetAllFavourites(ctx context.Context, userSSOID int) ([]models.Favourite, error) {
-	query := `SELECT id, user_sso_id, sneaker_id, created_at FROM favourites_items WHERE user_sso_id = $1`
+	query := `SELECT id, user_sso_id, sneaker_id, created_at FROM favourites_items WHERE user_sso_id = $1 ORDER BY sneaker_id`
 	rows, err := p.db.QueryContext(ctx, query, userSSOID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get favourites: %w", err)
@@ -91,6 +91,34 @@ func (p *PostgresRepo) GetAllFavourites(ctx context.Context, userSSOID int) ([]m
 	return favourites, nil
 }
 
+// GetFavouritesAfter возвращает до limit товаров избранного с sneaker_id больше afterSneakerID
+// по возрастанию sneaker_id. Поиск идёт по индексу ограничения unique_user_sneaker.
+func (p *PostgresRepo) GetFavouritesAfter(ctx context.Context, userSSOID, afterSneakerID, limit int) ([]models.Favourite, error) {
+	query := `SELECT id, user_sso_id, sneaker_id, created_at FROM favourites_items
+		WHERE user_sso_id = $1 AND sneaker_id > $2
+		ORDER BY sneaker_id
+		LIMIT $3`
+	rows, err := p.db.QueryContext(ctx, query, userSSOID, afterSneakerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favourites page: %w", err)
+	}
+	defer rows.Close()
+
+	favourites := make([]models.Favourite, 0, limit)
+	for rows.Next() {
+		var item models.Favourite
+		if err := rows.Scan(&item.ID, &item.UserSSOID, &item.SneakerID, &item.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan favourite: %w", err)
+		}
+		favourites = append(favourites, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating favourites: %w", err)
+	}
+
+	return favourites, nil
+}
+
 func (p *PostgresRepo) GetByIDs(ctx context.Context, ids []int) ([]models.Favourite, error) {
 	if len(ids) == 0 {
 		return []models.Favourite{}, nil
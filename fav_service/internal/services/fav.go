@@ -8,11 +8,25 @@ import (
 	"strings"
 	"time"
 
+	"fav_service/internal/lib/pagetoken"
 	"fav_service/internal/models"
 )
 
+// Размеры страницы избранного.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// favouriteCursor — позиция в избранном: последний отданный sneaker_id.
+// Пара (user, sneaker_id) уникальна, поэтому порядок стабилен при вставках.
+type favouriteCursor struct {
+	SneakerID int `json:"sneaker_id"`
+}
+
 type FavouritesRepo interface {
 	GetAllFavourites(ctx context.Context, userSSOID int) ([]models.Favourite, error)
+	GetFavouritesAfter(ctx context.Context, userSSOID, afterSneakerID, limit int) ([]models.Favourite, error)
 	AddToFavourite(ctx context.Context, userSSOID, sneakerID int) error
 	RemoveFromFavourite(ctx context.Context, userSSOID, sneakerID int) error
 	IsFavourite(ctx context.Context, userSSOID, sneakerID int) (bool, error)
@@ -68,6 +82,41 @@ func (s *FavService) GetAllFavourites(ctx context.Context, userSSOID int) ([]mod
 	return favourites, nil
 }
 
+// GetFavouritesPage отдаёт страницу избранного. Курсор уходит в запрос к базе,
+// поэтому глубокие страницы стоят столько же, сколько первая.
+func (s *FavService) GetFavouritesPage(ctx context.Context, userSSOID, pageSize int, pageToken string) (*models.FavouritePage, error) {
+	const op = "service.GetFavouritesPage"
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var cursor favouriteCursor
+	if err := pagetoken.Decode(pageToken, &cursor); err != nil || cursor.SneakerID < 0 {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrInvalidPageToken)
+	}
+
+	// Запрашиваем на одну строку больше, чтобы узнать, есть ли следующая страница.
+	favourites, err := s.repo.GetFavouritesAfter(ctx, userSSOID, cursor.SneakerID, pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page := &models.FavouritePage{Items: favourites}
+	if len(favourites) > pageSize {
+		page.Items = favourites[:pageSize]
+		next, err := pagetoken.Encode(favouriteCursor{SneakerID: page.Items[pageSize-1].SneakerID})
+		if err != nil {
+			return nil, fmt.Errorf("%s: encode page token: %w", op, err)
+		}
+		page.NextPageToken = next
+	}
+	return page, nil
+}
+
 func (s *FavService) AddToFavourite(ctx context.Context, userSSOID, sneakerID int) error {
 	const op = "service.AddToFavourite"
 
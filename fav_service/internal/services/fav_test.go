@@ -235,3 +235,58 @@ func TestParseIDsString_Invalid(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid ID format")
 }
+
+// --- GetFavouritesPage ---
+
+func TestGetFavouritesPage_PaginatesBySneakerID(t *testing.T) {
+	repo := new(mocks.MockFavouritesRepo)
+	cache := new(mocks.MockCacheRepo)
+	svc := newTestService(repo, cache)
+
+	// Страница запрашивается с запасом в одну строку — по ней видно, что есть продолжение.
+	repo.On("GetFavouritesAfter", mock.Anything, 42, 0, 3).Return([]models.Favourite{
+		{SneakerID: 10}, {SneakerID: 20}, {SneakerID: 30},
+	}, nil)
+	repo.On("GetFavouritesAfter", mock.Anything, 42, 20, 3).Return([]models.Favourite{
+		{SneakerID: 30},
+	}, nil)
+
+	page, err := svc.GetFavouritesPage(context.Background(), 42, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, 10, page.Items[0].SneakerID)
+	assert.Equal(t, 20, page.Items[1].SneakerID)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = svc.GetFavouritesPage(context.Background(), 42, 2, page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 30, page.Items[0].SneakerID)
+	assert.Empty(t, page.NextPageToken)
+	repo.AssertExpectations(t)
+	cache.AssertNotCalled(t, "GetAllFavourites", mock.Anything, mock.Anything)
+}
+
+func TestGetFavouritesPage_ClampsPageSize(t *testing.T) {
+	repo := new(mocks.MockFavouritesRepo)
+	cache := new(mocks.MockCacheRepo)
+	svc := newTestService(repo, cache)
+
+	repo.On("GetFavouritesAfter", mock.Anything, 42, 0, MaxPageSize+1).Return([]models.Favourite{}, nil)
+
+	page, err := svc.GetFavouritesPage(context.Background(), 42, 1000, "")
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Empty(t, page.NextPageToken)
+	repo.AssertExpectations(t)
+}
+
+func TestGetFavouritesPage_InvalidPageToken(t *testing.T) {
+	repo := new(mocks.MockFavouritesRepo)
+	cache := new(mocks.MockCacheRepo)
+	svc := newTestService(repo, cache)
+
+	_, err := svc.GetFavouritesPage(context.Background(), 42, 0, "!!")
+	assert.ErrorIs(t, err, models.ErrInvalidPageToken)
+	repo.AssertNotCalled(t, "GetFavouritesAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
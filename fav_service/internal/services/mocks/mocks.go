@@ -238,6 +238,86 @@ func (_c *MockFavouritesRepo_GetByIDs_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// GetFavouritesAfter provides a mock function for the type MockFavouritesRepo
+func (_mock *MockFavouritesRepo) GetFavouritesAfter(ctx context.Context, userSSOID int, afterSneakerID int, limit int) ([]models.Favourite, error) {
+	ret := _mock.Called(ctx, userSSOID, afterSneakerID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFavouritesAfter")
+	}
+
+	var r0 []models.Favourite
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) ([]models.Favourite, error)); ok {
+		return returnFunc(ctx, userSSOID, afterSneakerID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) []models.Favourite); ok {
+		r0 = returnFunc(ctx, userSSOID, afterSneakerID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Favourite)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = returnFunc(ctx, userSSOID, afterSneakerID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFavouritesRepo_GetFavouritesAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFavouritesAfter'
+type MockFavouritesRepo_GetFavouritesAfter_Call struct {
+	*mock.Call
+}
+
+// GetFavouritesAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userSSOID int
+//   - afterSneakerID int
+//   - limit int
+func (_e *MockFavouritesRepo_Expecter) GetFavouritesAfter(ctx interface{}, userSSOID interface{}, afterSneakerID interface{}, limit interface{}) *MockFavouritesRepo_GetFavouritesAfter_Call {
+	return &MockFavouritesRepo_GetFavouritesAfter_Call{Call: _e.mock.On("GetFavouritesAfter", ctx, userSSOID, afterSneakerID, limit)}
+}
+
+func (_c *MockFavouritesRepo_GetFavouritesAfter_Call) Run(run func(ctx context.Context, userSSOID int, afterSneakerID int, limit int)) *MockFavouritesRepo_GetFavouritesAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFavouritesRepo_GetFavouritesAfter_Call) Return(favourites []models.Favourite, err error) *MockFavouritesRepo_GetFavouritesAfter_Call {
+	_c.Call.Return(favourites, err)
+	return _c
+}
+
+func (_c *MockFavouritesRepo_GetFavouritesAfter_Call) RunAndReturn(run func(ctx context.Context, userSSOID int, afterSneakerID int, limit int) ([]models.Favourite, error)) *MockFavouritesRepo_GetFavouritesAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsFavourite provides a mock function for the type MockFavouritesRepo
 func (_mock *MockFavouritesRepo) IsFavourite(ctx context.Context, userSSOID int, sneakerID int) (bool, error) {
 	ret := _mock.Called(ctx, userSSOID, sneakerID)
@@ -1,4 +1,4 @@
-import React, { useContext, useState } from 'react';
+import React, { useContext, useRef, useState } from 'react';
 import { useNavigate } from 'react-router-dom';
 import { CartContext } from '../context/CartContext';
 import { ItemsContext } from '../context/ItemsContext';
@@ -22,6 +22,9 @@ export const Drawer = ({ onClose }) => {
   const { items: allItemsData } = useContext(ItemsContext);
   const navigate = useNavigate();
   const [isCreatingOrder, setIsCreatingOrder] = useState(false);
+  // Ключ идемпотентности живёт до успешного оформления: повторная отправка
+  // (двойной клик, ошибка сети) вернёт тот же заказ, а не создаст новый.
+  const checkoutKeyRef = useRef(null);
 
   const handleCartAction = async (action, sneakerId) => {
     console.log(`Выполняем действие ${action} для товара с ID=${sneakerId}`);
@@ -64,6 +67,9 @@ export const Drawer = ({ onClose }) => {
     }
 
     setIsCreatingOrder(true);
+    if (!checkoutKeyRef.current) {
+      checkoutKeyRef.current = crypto.randomUUID();
+    }
 
     try {
       // Подготовка данных заказа
@@ -73,9 +79,12 @@ export const Drawer = ({ onClose }) => {
       }));
 
       // Создание заказа
-      const response = await axios.post('/api/v1/orders', { items: orderItems });
+      const response = await axios.post('/api/v1/orders', { items: orderItems }, {
+        headers: { 'Idempotency-Key': checkoutKeyRef.current }
+      });
 
       console.log('Заказ создан:', response.data);
+      checkoutKeyRef.current = null;
 
       // Успешно создан заказ — обновляем корзину (бэкенд её уже очистил)
       await refreshCart();
@@ -87,6 +96,11 @@ export const Drawer = ({ onClose }) => {
       navigate('/orders');
     } catch (error) {
       console.error('Ошибка при создании заказа:', error);
+      // Сервер ответил отказом — следующая попытка будет новым заказом.
+      // Ключ сохраняем только при сетевой ошибке, когда исход неизвестен.
+      if (error.response) {
+        checkoutKeyRef.current = null;
+      }
       if (error.response?.status === 401) {
         alert('Необходимо авторизоваться');
         navigate('/login');
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	pb "github.com/stpnv0/protos/gen/go/notification"
+
+	"notification_service/internal/config"
+	"notification_service/internal/dedup"
+	grpcserver "notification_service/internal/grpc"
+	prefshandler "notification_service/internal/grpc/preferences"
+	"notification_service/internal/kafka"
+	"notification_service/internal/repository"
+	"notification_service/internal/sender"
+	"notification_service/internal/service"
+	"notification_service/internal/templates"
+)
+
+func main() {
+	if err := run(); err != nil {
+		slog.Error("fatal error", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	log := setupLogger("notification_service")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// ---- Инфраструктура ----
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := pgxpool.New(ctx, cfg.Postgres.DSN())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	log.Info("connected to postgres")
+
+	// ---- Зависимости ----
+
+	prefsRepo := repository.NewPreferencesRepository(pool)
+	eventRepo := repository.NewEventRepository(pool)
+
+	renderer, err := templates.New(cfg.Notifications.ShopName, cfg.Notifications.DefaultLocale)
+	if err != nil {
+		return fmt.Errorf("notifications: %w", err)
+	}
+
+	mailSender, err := newSender(cfg, log)
+	if err != nil {
+		return err
+	}
+	log.Info("notification sender selected", slog.String("sender", cfg.Sender.Kind))
+
+	notificationService := service.NewNotificationService(
+		prefsRepo, eventRepo, renderer, mailSender, cfg.Notifications.DefaultLocale, log,
+	)
+
+	reader := kafka.NewReader(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Kafka.GroupID)
+	dlqWriter := kafka.NewDLQWriter(cfg.Kafka.Brokers, cfg.Kafka.DLQTopic)
+	consumer := kafka.NewConsumer(reader, dlqWriter, notificationService, kafka.Config{
+		MaxAttempts:  cfg.Consumer.MaxAttempts,
+		RetryBackoff: cfg.Consumer.RetryBackoff,
+	}, log)
+
+	cleanupWorker := dedup.NewWorker(notificationService, dedup.Config{
+		Retention: cfg.Dedup.Retention,
+		Interval:  cfg.Dedup.CleanupInterval,
+	}, log)
+
+	// ---- gRPC-сервер ----
+
+	grpcSrv := grpcserver.NewServer(log)
+	pb.RegisterNotificationsServer(grpcSrv.GRPCServer(), prefshandler.NewHandler(notificationService, log))
+
+	// ---- HTTP-сервер (healthz и метрики) ----
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler: router,
+	}
+
+	// ---- Запуск ----
+
+	errCh := make(chan error, 2)
+
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		consumer.Run(ctx)
+	}()
+
+	cleanupDone := make(chan struct{})
+	go func() {
+		defer close(cleanupDone)
+		cleanupWorker.Run(ctx)
+	}()
+
+	go func() {
+		if err := grpcSrv.Run(cfg.GRPC.Port); err != nil {
+			errCh <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	go func() {
+		log.Info("http server started", slog.String("addr", httpServer.Addr))
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	// ---- Ожидание сигнала завершения ----
+
+	select {
+	case <-ctx.Done():
+		log.Info("shutdown signal received")
+	case err := <-errCh:
+		log.Error("component failed, initiating shutdown", slog.String("error", err.Error()))
+		stop()
+	}
+
+	// ---- Плавное завершение с таймаутом ----
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+	defer shutdownCancel()
+
+	log.Info("shutting down grpc server")
+	grpcSrv.Stop()
+
+	log.Info("shutting down http server")
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("http server shutdown error", slog.String("error", err.Error()))
+	}
+
+	// Consumer останавливается по отмене ctx; reader и writer закрываем только после него.
+	<-consumerDone
+	<-cleanupDone
+
+	log.Info("closing kafka reader and dlq writer")
+	if err := reader.Close(); err != nil {
+		log.Error("kafka reader close error", slog.String("error", err.Error()))
+	}
+	if err := dlqWriter.Close(); err != nil {
+		log.Error("kafka dlq writer close error", slog.String("error", err.Error()))
+	}
+
+	// pool.Close() вызывается через defer выше.
+
+	log.Info("shutdown complete")
+	return nil
+}
+
+// newSender создаёт отправителя писем, выбранного параметром sender.kind.
+func newSender(cfg *config.Config, log *slog.Logger) (service.Sender, error) {
+	switch cfg.Sender.Kind {
+	case sender.NameSMTP:
+		if cfg.Sender.SMTP.Host == "" {
+			return nil, fmt.Errorf("sender.smtp.host is required")
+		}
+		return sender.NewSMTP(sender.SMTPConfig{
+			Host:     cfg.Sender.SMTP.Host,
+			Port:     cfg.Sender.SMTP.Port,
+			Username: cfg.Sender.SMTP.Username,
+			Password: cfg.Sender.SMTP.Password,
+			StartTLS: cfg.Sender.SMTP.StartTLS,
+			Timeout:  cfg.Sender.SMTP.Timeout,
+		}, cfg.Sender.From), nil
+	case sender.NameFile:
+		return sender.NewFile(cfg.Sender.File.Dir, cfg.Sender.From)
+	case sender.NameLog:
+		return sender.NewLog(log), nil
+	default:
+		return nil, fmt.Errorf("sender.kind: unknown sender %q", cfg.Sender.Kind)
+	}
+}
+
+func setupLogger(serviceName string) *slog.Logger {
+	env := os.Getenv("ENV")
+
+	var level slog.Level
+	switch env {
+	case "prod":
+		level = slog.LevelInfo
+	default:
+		level = slog.LevelDebug
+	}
+
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})).
+		With(slog.String("service", serviceName))
+}
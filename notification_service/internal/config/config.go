@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config содержит всю конфигурацию приложения.
+type Config struct {
+	Env           string              `yaml:"env"`
+	GRPC          GRPCConfig          `yaml:"grpc"`
+	HTTP          HTTPConfig          `yaml:"http"`
+	Postgres      PostgresConfig      `yaml:"postgres"`
+	Kafka         KafkaConfig         `yaml:"kafka"`
+	Consumer      ConsumerConfig      `yaml:"consumer"`
+	Dedup         DedupConfig         `yaml:"dedup"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Sender        SenderConfig        `yaml:"sender"`
+	Shutdown      ShutdownConfig      `yaml:"shutdown"`
+}
+
+// GRPCConfig содержит настройки gRPC-сервера (настройки уведомлений).
+type GRPCConfig struct {
+	Port    int           `yaml:"port"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// HTTPConfig содержит настройки HTTP-сервера (/healthz и /metrics).
+type HTTPConfig struct {
+	Port int `yaml:"port"`
+}
+
+// PostgresConfig содержит параметры подключения к PostgreSQL.
+type PostgresConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"dbname"`
+	SSLMode  string `yaml:"sslmode"`
+}
+
+// KafkaConfig содержит настройки брокера Kafka.
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	// Topic — топик событий заказов order_service.
+	Topic   string `yaml:"topic"`
+	GroupID string `yaml:"group_id"`
+	// DLQTopic — куда уходят события, которые не удалось обработать.
+	DLQTopic string `yaml:"dlq_topic"`
+}
+
+// ConsumerConfig содержит настройки повторов при обработке событий.
+type ConsumerConfig struct {
+	// MaxAttempts — сколько раз обработать событие, прежде чем отправить его в DLQ.
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff — пауза перед первым повтором, дальше удваивается.
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+}
+
+// DedupConfig содержит настройки защиты от повторной доставки событий.
+type DedupConfig struct {
+	// Retention — сколько помнить обработанные события; больше срока хранения топика.
+	Retention       time.Duration `yaml:"retention"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval"`
+}
+
+// NotificationsConfig содержит общие параметры писем.
+type NotificationsConfig struct {
+	// DefaultLocale — язык писем, если пользователь не выбрал его в настройках.
+	DefaultLocale string `yaml:"default_locale"`
+	// ShopName — название магазина в подписи письма.
+	ShopName string `yaml:"shop_name"`
+}
+
+// SenderConfig выбирает способ отправки писем.
+type SenderConfig struct {
+	// Kind — "smtp", "file" (письма .eml в каталог) или "log" (только в лог).
+	Kind string `yaml:"kind"`
+	// From — адрес отправителя, можно с именем: "Sneakers <noreply@example.com>".
+	From string           `yaml:"from"`
+	SMTP SMTPSenderConfig `yaml:"smtp"`
+	File FileSenderConfig `yaml:"file"`
+}
+
+// SMTPSenderConfig содержит параметры почтового сервера.
+type SMTPSenderConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// StartTLS — требовать шифрование соединения.
+	StartTLS bool          `yaml:"starttls"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// FileSenderConfig содержит настройки отправки в файлы.
+type FileSenderConfig struct {
+	Dir string `yaml:"dir"`
+}
+
+// ShutdownConfig управляет поведением graceful shutdown.
+type ShutdownConfig struct {
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DSN возвращает строку подключения к PostgreSQL.
+func (p *PostgresConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		p.Host, p.Port, p.User, p.Password, p.DBName, p.SSLMode,
+	)
+}
+
+// Load читает конфигурацию из файла, указанного в CONFIG_PATH (или
+// пути по умолчанию).
+func Load() (*Config, error) {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "./config/config.yaml"
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config: read file %s: %w", configPath, err)
+	}
+
+	// STARTTLS включён, если в файле не сказано обратного.
+	cfg := Config{Sender: SenderConfig{SMTP: SMTPSenderConfig{StartTLS: true}}}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("config: parse yaml: %w", err)
+	}
+
+	if cfg.Kafka.Topic == "" {
+		cfg.Kafka.Topic = "orders"
+	}
+	if cfg.Kafka.GroupID == "" {
+		cfg.Kafka.GroupID = "notification_service"
+	}
+	if cfg.Kafka.DLQTopic == "" {
+		cfg.Kafka.DLQTopic = "orders.notifications.dlq"
+	}
+	if cfg.Consumer.MaxAttempts == 0 {
+		cfg.Consumer.MaxAttempts = 5
+	}
+	if cfg.Consumer.RetryBackoff == 0 {
+		cfg.Consumer.RetryBackoff = time.Second
+	}
+	if cfg.Dedup.Retention == 0 {
+		cfg.Dedup.Retention = 30 * 24 * time.Hour
+	}
+	if cfg.Dedup.CleanupInterval == 0 {
+		cfg.Dedup.CleanupInterval = time.Hour
+	}
+	if cfg.Notifications.DefaultLocale == "" {
+		cfg.Notifications.DefaultLocale = "ru"
+	}
+	if cfg.Notifications.ShopName == "" {
+		cfg.Notifications.ShopName = "Sneakers"
+	}
+	if cfg.Sender.Kind == "" {
+		cfg.Sender.Kind = "log"
+	}
+	if cfg.Sender.SMTP.Port == 0 {
+		cfg.Sender.SMTP.Port = 587
+	}
+	if cfg.Sender.SMTP.Timeout == 0 {
+		cfg.Sender.SMTP.Timeout = 10 * time.Second
+	}
+	if cfg.Sender.File.Dir == "" {
+		cfg.Sender.File.Dir = "./mail"
+	}
+	if cfg.Shutdown.Timeout == 0 {
+		cfg.Shutdown.Timeout = 15 * time.Second
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,72 @@
+// Package dedup периодически удаляет отметки об обработанных событиях,
+// повторная доставка которых уже не ожидается.
+package dedup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Cleaner удаляет отметки о событиях, обработанных раньше before.
+type Cleaner interface {
+	CleanupProcessedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Config — настройки очистки.
+type Config struct {
+	// Retention — сколько помнить обработанное событие; должно быть больше
+	// срока хранения топика заказов, иначе старое сообщение отправит письмо повторно.
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+// Worker раз в Interval удаляет отметки старше Retention.
+type Worker struct {
+	cleaner Cleaner
+	cfg     Config
+	log     *slog.Logger
+}
+
+func NewWorker(cleaner Cleaner, cfg Config, log *slog.Logger) *Worker {
+	return &Worker{
+		cleaner: cleaner,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+// Run очищает отметки раз в Interval, пока не отменён ctx.
+func (w *Worker) Run(ctx context.Context) {
+	const op = "dedup.Worker.Run"
+
+	w.log.Info("processed events cleanup started",
+		slog.String("op", op),
+		slog.Duration("retention", w.cfg.Retention),
+		slog.Duration("interval", w.cfg.Interval),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("processed events cleanup stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := w.cleaner.CleanupProcessedEvents(ctx, time.Now().Add(-w.cfg.Retention))
+		if err != nil {
+			w.log.Error("failed to clean up processed events",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if deleted > 0 {
+			w.log.Info("processed events cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+		}
+	}
+}
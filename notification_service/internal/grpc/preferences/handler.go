@@ -0,0 +1,106 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	pb "github.com/stpnv0/protos/gen/go/notification"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcserver "notification_service/internal/grpc"
+	"notification_service/internal/models"
+)
+
+//go:generate mockery --name=Service --output=mocks --outpkg=mocks --filename=mock_service.go
+type Service interface {
+	GetPreferences(ctx context.Context, userID int) (*models.Preferences, error)
+	UpdatePreferences(ctx context.Context, prefs *models.Preferences) (*models.Preferences, error)
+}
+
+// Handler реализует gRPC-сервис Notifications: настройки уведомлений текущего пользователя.
+type Handler struct {
+	pb.UnimplementedNotificationsServer
+	svc Service
+	log *slog.Logger
+}
+
+func NewHandler(svc Service, log *slog.Logger) *Handler {
+	return &Handler{svc: svc, log: log}
+}
+
+func (h *Handler) GetPreferences(ctx context.Context, _ *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	prefs, err := h.svc.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, h.mapError("get preferences failed", err)
+	}
+
+	return &pb.GetPreferencesResponse{Preferences: preferencesToProto(prefs)}, nil
+}
+
+func (h *Handler) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.GetPreferences() == nil {
+		return nil, status.Error(codes.InvalidArgument, "preferences is required")
+	}
+
+	in := req.GetPreferences()
+	prefs, err := h.svc.UpdatePreferences(ctx, &models.Preferences{
+		UserID:          userID,
+		Locale:          in.GetLocale(),
+		OrderUpdates:    in.GetOrderUpdates(),
+		PaymentUpdates:  in.GetPaymentUpdates(),
+		ShippingUpdates: in.GetShippingUpdates(),
+	})
+	if err != nil {
+		return nil, h.mapError("update preferences failed", err)
+	}
+
+	return &pb.UpdatePreferencesResponse{Preferences: preferencesToProto(prefs)}, nil
+}
+
+func (h *Handler) mapError(msg string, err error) error {
+	if errors.Is(err, models.ErrInvalidPreferences) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	return status.Error(codes.Internal, msg)
+}
+
+func preferencesToProto(p *models.Preferences) *pb.Preferences {
+	out := &pb.Preferences{
+		Locale:          p.Locale,
+		OrderUpdates:    p.OrderUpdates,
+		PaymentUpdates:  p.PaymentUpdates,
+		ShippingUpdates: p.ShippingUpdates,
+	}
+	if !p.UpdatedAt.IsZero() {
+		out.UpdatedAt = p.UpdatedAt.Unix()
+	}
+	return out
+}
+
+func getUserIDFromContext(ctx context.Context) (int, error) {
+	userIDStr := grpcserver.UserIDFromContext(ctx)
+	if userIDStr == "" {
+		return 0, fmt.Errorf("user_id не найден в контексте")
+	}
+
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return 0, fmt.Errorf("невалидный формат user_id: %w", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,114 @@
+package preferences_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/stpnv0/protos/gen/go/notification"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcserver "notification_service/internal/grpc"
+	handler "notification_service/internal/grpc/preferences"
+	handlerMocks "notification_service/internal/grpc/preferences/mocks"
+	"notification_service/internal/models"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func ctxWithUserID(userID string) context.Context {
+	return grpcserver.ContextWithUserID(context.Background(), userID)
+}
+
+func TestGetPreferences_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("GetPreferences", mock.Anything, 42).Return(models.DefaultPreferences(42, models.LocaleRU), nil)
+
+	resp, err := h.GetPreferences(ctxWithUserID("42"), &pb.GetPreferencesRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.LocaleRU, resp.GetPreferences().GetLocale())
+	assert.True(t, resp.GetPreferences().GetShippingUpdates())
+	assert.Equal(t, int64(0), resp.GetPreferences().GetUpdatedAt())
+}
+
+func TestGetPreferences_Unauthenticated(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.GetPreferences(context.Background(), &pb.GetPreferencesRequest{})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	svc.AssertNotCalled(t, "GetPreferences", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePreferences_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	updatedAt := time.Unix(1_800_000_000, 0)
+	svc.On("UpdatePreferences", mock.Anything, &models.Preferences{
+		UserID: 42, Locale: models.LocaleEN, OrderUpdates: true,
+	}).Return(&models.Preferences{
+		UserID: 42, Locale: models.LocaleEN, OrderUpdates: true, UpdatedAt: updatedAt,
+	}, nil)
+
+	resp, err := h.UpdatePreferences(ctxWithUserID("42"), &pb.UpdatePreferencesRequest{
+		Preferences: &pb.Preferences{Locale: models.LocaleEN, OrderUpdates: true},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.GetPreferences().GetPaymentUpdates())
+	assert.Equal(t, updatedAt.Unix(), resp.GetPreferences().GetUpdatedAt())
+	svc.AssertExpectations(t)
+}
+
+func TestUpdatePreferences_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "invalid", err: models.ErrInvalidPreferences, code: codes.InvalidArgument},
+		{name: "internal", err: errors.New("db down"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("UpdatePreferences", mock.Anything, mock.Anything).
+				Return(nil, fmt.Errorf("update preferences: %w", tt.err))
+
+			_, err := h.UpdatePreferences(ctxWithUserID("42"), &pb.UpdatePreferencesRequest{
+				Preferences: &pb.Preferences{Locale: "de"},
+			})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestUpdatePreferences_MissingBody(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.UpdatePreferences(ctxWithUserID("42"), &pb.UpdatePreferencesRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	svc.AssertNotCalled(t, "UpdatePreferences", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,271 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stpnv0/protos/events"
+
+	"notification_service/internal/models"
+)
+
+// maxBackoff ограничивает паузу между повторами, сколько бы их ни было.
+const maxBackoff = 30 * time.Second
+
+// Reader — чтение топика в consumer group с ручным коммитом смещений.
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Writer пишет сообщения в dead-letter топик.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Handler обрабатывает событие заказа.
+type Handler interface {
+	HandleEvent(ctx context.Context, eventID string, event *models.OrderEvent) error
+}
+
+// Config — настройки обработки сообщений.
+type Config struct {
+	// MaxAttempts — сколько раз обработать сообщение, прежде чем отправить его в DLQ.
+	MaxAttempts int
+	// RetryBackoff — пауза перед первым повтором; дальше удваивается до maxBackoff.
+	RetryBackoff time.Duration
+}
+
+// Consumer читает события заказов и передаёт их в Handler.
+//
+// Смещение коммитится только после обработки сообщения или его записи в
+// dead-letter топик, поэтому при падении сервиса сообщение будет прочитано
+// снова — повторы отсекает Handler по event_id. Сообщения партиции
+// обрабатываются строго по очереди: пока не исчерпаны повторы, следующее
+// не читается, и письма об одном заказе не приходят в обратном порядке.
+type Consumer struct {
+	reader  Reader
+	dlq     Writer
+	handler Handler
+	cfg     Config
+	log     *slog.Logger
+}
+
+func NewConsumer(reader Reader, dlq Writer, handler Handler, cfg Config, log *slog.Logger) *Consumer {
+	return &Consumer{
+		reader:  reader,
+		dlq:     dlq,
+		handler: handler,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+// NewReader создаёт читателя топика в consumer group groupID.
+func NewReader(brokers []string, topic, groupID string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: groupID,
+	})
+}
+
+// NewDLQWriter создаёт писателя в dead-letter топик.
+func NewDLQWriter(brokers []string, topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    1,
+		BatchTimeout: 10 * time.Millisecond,
+	}
+}
+
+// Run читает и обрабатывает сообщения, пока не отменён ctx.
+func (c *Consumer) Run(ctx context.Context) {
+	const op = "kafka.Consumer.Run"
+
+	c.log.Info("order events consumer started",
+		slog.String("op", op),
+		slog.Int("max_attempts", c.cfg.MaxAttempts),
+	)
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.log.Error("failed to fetch message", slog.String("op", op), slog.String("error", err.Error()))
+			if !sleep(ctx, c.cfg.RetryBackoff) {
+				break
+			}
+			continue
+		}
+
+		// Обработка прервана остановкой сервиса: без коммита сообщение прочитается снова.
+		if !c.process(ctx, msg) {
+			break
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.log.Error("failed to commit message",
+				slog.String("op", op),
+				slog.Int64("offset", msg.Offset),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	c.log.Info("order events consumer stopped", slog.String("op", op))
+}
+
+// process обрабатывает сообщение с повторами, а исчерпав их, пишет его в DLQ.
+// false — ctx отменён до того, как сообщение было обработано или отложено в DLQ.
+func (c *Consumer) process(ctx context.Context, msg kafka.Message) bool {
+	const op = "kafka.Consumer.process"
+
+	eventID, event, err := decodeEvent(msg)
+	log := c.log.With(
+		slog.String("op", op),
+		slog.String("event_id", eventID),
+		slog.Int("partition", msg.Partition),
+		slog.Int64("offset", msg.Offset),
+	)
+
+	attempts := 0
+	if err == nil {
+		backoff := c.cfg.RetryBackoff
+		for attempts = 1; ; attempts++ {
+			err = c.handler.HandleEvent(ctx, eventID, event)
+			if err == nil {
+				return true
+			}
+			if ctx.Err() != nil {
+				return false
+			}
+			if attempts >= c.cfg.MaxAttempts {
+				break
+			}
+
+			log.Warn("failed to handle event, retrying",
+				slog.Int("attempt", attempts),
+				slog.Duration("backoff", backoff),
+				slog.String("error", err.Error()),
+			)
+			retriesTotal.Inc()
+			if !sleep(ctx, backoff) {
+				return false
+			}
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}
+
+	log.Error("moving event to dead-letter topic",
+		slog.Int("attempts", attempts),
+		slog.String("error", err.Error()),
+	)
+	return c.deadLetter(ctx, msg, err, attempts, log)
+}
+
+// deadLetter пишет сообщение в DLQ, повторяя запись, пока она не удастся:
+// закоммитить сообщение, не сохранив его, значит потерять событие.
+func (c *Consumer) deadLetter(ctx context.Context, msg kafka.Message, cause error, attempts int, log *slog.Logger) bool {
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "dlq_error", Value: []byte(cause.Error())},
+		kafka.Header{Key: "dlq_attempts", Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: "original_topic", Value: []byte(msg.Topic)},
+		kafka.Header{Key: "original_partition", Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: "original_offset", Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+	dead := kafka.Message{Key: msg.Key, Value: msg.Value, Headers: headers}
+
+	backoff := c.cfg.RetryBackoff
+	for {
+		err := c.dlq.WriteMessages(ctx, dead)
+		if err == nil {
+			deadLettersTotal.Inc()
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		log.Error("failed to write to dead-letter topic", slog.String("error", err.Error()))
+		if !sleep(ctx, backoff) {
+			return false
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// decodeEvent разбирает событие из конверта версии, указанной в заголовке schema_version,
+// а без заголовка — из JSON прежнего формата. Вместе с событием возвращает его id;
+// ошибка всегда оборачивает models.ErrInvalidEvent.
+func decodeEvent(msg kafka.Message) (string, *models.OrderEvent, error) {
+	env, data, err := events.DecodeOrderEvent(headerValue(msg, events.HeaderSchemaVersion), msg.Value)
+	if err != nil {
+		return eventIDOf(msg), nil, fmt.Errorf("%w: %w", models.ErrInvalidEvent, err)
+	}
+
+	eventID := env.GetId()
+	if eventID == "" {
+		eventID = eventIDOf(msg)
+	}
+
+	event := &models.OrderEvent{
+		EventType:      env.GetType(),
+		OrderID:        int(data.GetOrderId()),
+		UserID:         int(data.GetUserId()),
+		Status:         data.GetStatus(),
+		TotalAmount:    int(data.GetTotalAmount()),
+		CustomerEmail:  data.GetCustomerEmail(),
+		Reason:         data.GetReason(),
+		Carrier:        data.GetCarrier(),
+		TrackingNumber: data.GetTrackingNumber(),
+		DiscountAmount: int(data.GetDiscountAmount()),
+		PaymentURL:     data.GetPaymentUrl(),
+	}
+	if err := event.Validate(); err != nil {
+		return eventID, nil, err
+	}
+	return eventID, event, nil
+}
+
+// eventIDOf возвращает id события из заголовка event_id. Без заголовка
+// идентификатором служит позиция сообщения в топике — она тоже не меняется
+// при повторной доставке.
+func eventIDOf(msg kafka.Message) string {
+	if id := headerValue(msg, events.HeaderEventID); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// sleep ждёт d; false — ctx отменён раньше.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
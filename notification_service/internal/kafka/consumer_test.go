@@ -0,0 +1,255 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	segkafka "github.com/segmentio/kafka-go"
+	"github.com/stpnv0/protos/events"
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification_service/internal/kafka"
+	"notification_service/internal/models"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// fakeReader отдаёт сообщения по очереди, а когда они кончаются, останавливает Consumer.
+type fakeReader struct {
+	msgs      []segkafka.Message
+	committed []int64
+	stop      context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (segkafka.Message, error) {
+	if len(r.msgs) == 0 {
+		r.stop()
+		return segkafka.Message{}, ctx.Err()
+	}
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...segkafka.Message) error {
+	for _, m := range msgs {
+		r.committed = append(r.committed, m.Offset)
+	}
+	return nil
+}
+
+type fakeWriter struct {
+	failures int
+	written  []segkafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...segkafka.Message) error {
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("broker unavailable")
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+// fakeHandler падает первые failures вызовов.
+type fakeHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    []string
+	events   []*models.OrderEvent
+}
+
+func (h *fakeHandler) HandleEvent(_ context.Context, eventID string, event *models.OrderEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, eventID)
+	h.events = append(h.events, event)
+	if h.failures > 0 {
+		h.failures--
+		return errors.New("smtp: connection refused")
+	}
+	return nil
+}
+
+// orderMessage — событие в JSON прежнего формата, без заголовка schema_version.
+func orderMessage(offset int64, eventID string) segkafka.Message {
+	return segkafka.Message{
+		Topic:     "orders",
+		Partition: 1,
+		Offset:    offset,
+		Key:       []byte("order-7"),
+		Value:     []byte(`{"event_type":"OrderCreated","order_id":7,"user_id":42,"total_amount":1000}`),
+		Headers:   []segkafka.Header{{Key: "event_id", Value: []byte(eventID)}},
+	}
+}
+
+// envelopeMessage — событие в конверте текущей версии схемы, как его публикует order_service.
+func envelopeMessage(t *testing.T, offset int64, eventID string) segkafka.Message {
+	t.Helper()
+	value, err := events.Marshal(events.Meta{
+		ID:      eventID,
+		Source:  events.SourceOrderService,
+		Type:    models.EventOrderShipped,
+		Subject: "order-7",
+	}, &eventsv1.OrderEvent{
+		OrderId:        7,
+		UserId:         42,
+		Status:         "SHIPPED",
+		TotalAmount:    1000,
+		CustomerEmail:  "user@example.com",
+		Carrier:        "cdek",
+		TrackingNumber: "TR-1",
+	})
+	require.NoError(t, err)
+
+	return segkafka.Message{
+		Topic:     "orders",
+		Partition: 1,
+		Offset:    offset,
+		Key:       []byte("order-7"),
+		Value:     value,
+		Headers: []segkafka.Header{
+			{Key: events.HeaderEventID, Value: []byte(eventID)},
+			{Key: events.HeaderSchemaVersion, Value: []byte(events.SchemaVersion)},
+		},
+	}
+}
+
+func runConsumer(t *testing.T, handler *fakeHandler, dlq *fakeWriter, msgs ...segkafka.Message) *fakeReader {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reader := &fakeReader{msgs: msgs, stop: cancel}
+	consumer := kafka.NewConsumer(reader, dlq, handler, kafka.Config{
+		MaxAttempts:  3,
+		RetryBackoff: time.Millisecond,
+	}, newTestLogger())
+	consumer.Run(ctx)
+	return reader
+}
+
+func header(msg segkafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestConsumer_RetriesThenCommits(t *testing.T) {
+	handler := &fakeHandler{failures: 2}
+	dlq := &fakeWriter{}
+
+	reader := runConsumer(t, handler, dlq, orderMessage(5, "101"))
+
+	assert.Equal(t, []string{"101", "101", "101"}, handler.calls)
+	assert.Equal(t, []int64{5}, reader.committed)
+	assert.Empty(t, dlq.written)
+}
+
+func TestConsumer_ExhaustedAttemptsGoToDLQ(t *testing.T) {
+	handler := &fakeHandler{failures: 10}
+	dlq := &fakeWriter{}
+
+	reader := runConsumer(t, handler, dlq, orderMessage(5, "101"), orderMessage(6, "102"))
+
+	assert.Len(t, handler.calls, 6)
+	assert.Equal(t, []int64{5, 6}, reader.committed)
+	require.Len(t, dlq.written, 2)
+
+	dead := dlq.written[0]
+	assert.Equal(t, []byte("order-7"), dead.Key)
+	assert.Equal(t, "101", header(dead, "event_id"))
+	assert.Equal(t, "3", header(dead, "dlq_attempts"))
+	assert.Equal(t, "orders", header(dead, "original_topic"))
+	assert.Equal(t, "1", header(dead, "original_partition"))
+	assert.Equal(t, "5", header(dead, "original_offset"))
+	assert.Contains(t, header(dead, "dlq_error"), "connection refused")
+}
+
+func TestConsumer_InvalidEventSkipsRetries(t *testing.T) {
+	handler := &fakeHandler{}
+	dlq := &fakeWriter{}
+
+	broken := orderMessage(5, "101")
+	broken.Value = []byte(`{"event_type":"OrderCreated"`)
+	noOrder := orderMessage(6, "102")
+	noOrder.Value = []byte(`{"event_type":"OrderCreated","user_id":42}`)
+
+	reader := runConsumer(t, handler, dlq, broken, noOrder)
+
+	assert.Empty(t, handler.calls)
+	assert.Equal(t, []int64{5, 6}, reader.committed)
+	require.Len(t, dlq.written, 2)
+	assert.Equal(t, "0", header(dlq.written[0], "dlq_attempts"))
+	assert.Contains(t, header(dlq.written[1], "dlq_error"), "order_id")
+}
+
+func TestConsumer_RetriesDLQWrite(t *testing.T) {
+	handler := &fakeHandler{failures: 10}
+	dlq := &fakeWriter{failures: 2}
+
+	reader := runConsumer(t, handler, dlq, orderMessage(5, "101"))
+
+	assert.Len(t, dlq.written, 1)
+	assert.Equal(t, []int64{5}, reader.committed)
+}
+
+func TestConsumer_EventIDFallsBackToPosition(t *testing.T) {
+	handler := &fakeHandler{}
+	msg := orderMessage(5, "")
+	msg.Headers = nil
+
+	runConsumer(t, handler, &fakeWriter{}, msg)
+
+	assert.Equal(t, []string{"orders/1/5"}, handler.calls)
+}
+
+func TestConsumer_DecodesEnvelope(t *testing.T) {
+	handler := &fakeHandler{}
+	msg := envelopeMessage(t, 5, "101")
+	// id берётся из конверта, заголовок — только запасной вариант.
+	msg.Headers[0].Value = []byte("stale")
+
+	reader := runConsumer(t, handler, &fakeWriter{}, msg)
+
+	assert.Equal(t, []string{"101"}, handler.calls)
+	assert.Equal(t, []*models.OrderEvent{{
+		EventType:      models.EventOrderShipped,
+		OrderID:        7,
+		UserID:         42,
+		Status:         "SHIPPED",
+		TotalAmount:    1000,
+		CustomerEmail:  "user@example.com",
+		Carrier:        "cdek",
+		TrackingNumber: "TR-1",
+	}}, handler.events)
+	assert.Equal(t, []int64{5}, reader.committed)
+}
+
+func TestConsumer_UnsupportedSchemaGoesToDLQ(t *testing.T) {
+	handler := &fakeHandler{}
+	dlq := &fakeWriter{}
+	msg := envelopeMessage(t, 5, "101")
+	msg.Headers[1].Value = []byte("2")
+
+	reader := runConsumer(t, handler, dlq, msg)
+
+	assert.Empty(t, handler.calls)
+	assert.Equal(t, []int64{5}, reader.committed)
+	require.Len(t, dlq.written, 1)
+	assert.Equal(t, "0", header(dlq.written[0], "dlq_attempts"))
+	assert.Contains(t, header(dlq.written[0], "dlq_error"), "unsupported event schema version")
+}
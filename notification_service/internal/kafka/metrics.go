@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	retriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "notification_consumer_retries_total",
+		Help: "Repeated attempts to handle an order event after an error.",
+	})
+	deadLettersTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "notification_consumer_dead_letters_total",
+		Help: "Order events moved to the dead-letter topic.",
+	})
+)
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Типы событий заказа из топика order_service.
+const (
+	EventOrderCreated        = "OrderCreated"
+	EventOrderPaymentUpdated = "OrderPaymentUpdated"
+	EventOrderPaymentCreated = "OrderPaymentCreated"
+	EventOrderShipped        = "OrderShipped"
+	EventOrderDelivered      = "OrderDelivered"
+	EventOrderReturned       = "OrderReturned"
+)
+
+// Статусы заказа, по которым различаются письма об оплате.
+const (
+	OrderStatusPaid          = "PAID"
+	OrderStatusPaymentFailed = "PAYMENT_FAILED"
+)
+
+// ErrInvalidEvent — сообщение не разбирается как событие заказа. Повтор не поможет,
+// такое сообщение сразу уходит в dead-letter топик.
+var ErrInvalidEvent = errors.New("invalid order event")
+
+// OrderEvent — событие заказа order_service, разобранное из конверта.
+type OrderEvent struct {
+	EventType      string
+	OrderID        int
+	UserID         int
+	Status         string
+	TotalAmount    int // в копейках
+	CustomerEmail  string
+	Reason         string
+	Carrier        string
+	TrackingNumber string
+	DiscountAmount int
+	PaymentURL     string
+}
+
+// Validate проверяет поля, без которых письмо не собрать.
+func (e *OrderEvent) Validate() error {
+	switch {
+	case e.EventType == "":
+		return fmt.Errorf("%w: event_type is empty", ErrInvalidEvent)
+	case e.OrderID <= 0:
+		return fmt.Errorf("%w: order_id must be positive", ErrInvalidEvent)
+	case e.UserID <= 0:
+		return fmt.Errorf("%w: user_id must be positive", ErrInvalidEvent)
+	}
+	return nil
+}
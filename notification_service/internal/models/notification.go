@@ -0,0 +1,75 @@
+package models
+
+// Kind — вид письма; по нему выбирается шаблон.
+type Kind string
+
+const (
+	KindOrderCreated     Kind = "order_created"
+	KindPaymentLink      Kind = "payment_link"
+	KindPaymentSucceeded Kind = "payment_succeeded"
+	KindPaymentFailed    Kind = "payment_failed"
+	KindOrderShipped     Kind = "order_shipped"
+	KindOrderDelivered   Kind = "order_delivered"
+	KindOrderReturned    Kind = "order_returned"
+)
+
+// Kinds — все виды писем; для каждого нужен шаблон на каждом языке.
+var Kinds = []Kind{
+	KindOrderCreated, KindPaymentLink, KindPaymentSucceeded, KindPaymentFailed,
+	KindOrderShipped, KindOrderDelivered, KindOrderReturned,
+}
+
+// Category — группа писем, которую пользователь включает и выключает в настройках.
+type Category string
+
+const (
+	CategoryOrder    Category = "order"
+	CategoryPayment  Category = "payment"
+	CategoryShipping Category = "shipping"
+)
+
+// Category возвращает группу, к которой относится письмо.
+func (k Kind) Category() Category {
+	switch k {
+	case KindPaymentLink, KindPaymentSucceeded, KindPaymentFailed:
+		return CategoryPayment
+	case KindOrderShipped, KindOrderDelivered, KindOrderReturned:
+		return CategoryShipping
+	default:
+		return CategoryOrder
+	}
+}
+
+// KindFor определяет письмо по событию заказа. false — событие без уведомления
+// (например, смена статуса администратором или оплата, которая ещё не завершилась).
+func KindFor(event *OrderEvent) (Kind, bool) {
+	switch event.EventType {
+	case EventOrderCreated:
+		return KindOrderCreated, true
+	case EventOrderPaymentCreated:
+		if event.PaymentURL != "" {
+			return KindPaymentLink, true
+		}
+	case EventOrderPaymentUpdated:
+		switch event.Status {
+		case OrderStatusPaid:
+			return KindPaymentSucceeded, true
+		case OrderStatusPaymentFailed:
+			return KindPaymentFailed, true
+		}
+	case EventOrderShipped:
+		return KindOrderShipped, true
+	case EventOrderDelivered:
+		return KindOrderDelivered, true
+	case EventOrderReturned:
+		return KindOrderReturned, true
+	}
+	return "", false
+}
+
+// Message — готовое письмо для отправки.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
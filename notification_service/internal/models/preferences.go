@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Языки писем.
+const (
+	LocaleRU = "ru"
+	LocaleEN = "en"
+)
+
+// Locales — языки, для которых есть шаблоны.
+var Locales = []string{LocaleRU, LocaleEN}
+
+var (
+	ErrInvalidPreferences  = errors.New("invalid notification preferences")
+	ErrPreferencesNotFound = errors.New("notification preferences not found")
+)
+
+// Preferences — настройки уведомлений пользователя. Пока пользователь их
+// не менял, действуют DefaultPreferences: все письма включены.
+type Preferences struct {
+	UserID          int       `db:"user_id"`
+	Locale          string    `db:"locale"`
+	OrderUpdates    bool      `db:"order_updates"`
+	PaymentUpdates  bool      `db:"payment_updates"`
+	ShippingUpdates bool      `db:"shipping_updates"`
+	UpdatedAt       time.Time `db:"updated_at"` // нулевое — настройки по умолчанию
+}
+
+// DefaultPreferences — настройки пользователя, который их не менял.
+func DefaultPreferences(userID int, locale string) *Preferences {
+	return &Preferences{
+		UserID:          userID,
+		Locale:          locale,
+		OrderUpdates:    true,
+		PaymentUpdates:  true,
+		ShippingUpdates: true,
+	}
+}
+
+// Allows сообщает, включены ли письма категории c.
+func (p *Preferences) Allows(c Category) bool {
+	switch c {
+	case CategoryPayment:
+		return p.PaymentUpdates
+	case CategoryShipping:
+		return p.ShippingUpdates
+	default:
+		return p.OrderUpdates
+	}
+}
+
+// ValidLocale сообщает, есть ли шаблоны на языке locale.
+func ValidLocale(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EventRepository помнит обработанные события, чтобы повторная доставка
+// из Kafka не отправляла письмо второй раз.
+type EventRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEventRepository(pool *pgxpool.Pool) *EventRepository {
+	return &EventRepository{pool: pool}
+}
+
+// IsProcessed сообщает, обработано ли уже событие eventID.
+func (r *EventRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	const op = "repository.EventRepository.IsProcessed"
+
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM processed_events WHERE event_id = $1)`,
+		eventID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return exists, nil
+}
+
+// MarkProcessed запоминает событие; повторная отметка ничего не меняет.
+func (r *EventRepository) MarkProcessed(ctx context.Context, eventID, eventType string) error {
+	const op = "repository.EventRepository.MarkProcessed"
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO processed_events (event_id, event_type)
+		 VALUES ($1, $2)
+		 ON CONFLICT (event_id) DO NOTHING`,
+		eventID, eventType,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteProcessedBefore удаляет отметки старше before и возвращает их число.
+func (r *EventRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "repository.EventRepository.DeleteProcessedBefore"
+
+	tag, err := r.pool.Exec(ctx,
+		`DELETE FROM processed_events WHERE processed_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return tag.RowsAffected(), nil
+}
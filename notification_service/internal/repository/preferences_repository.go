@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"notification_service/internal/models"
+)
+
+type PreferencesRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPreferencesRepository(pool *pgxpool.Pool) *PreferencesRepository {
+	return &PreferencesRepository{pool: pool}
+}
+
+// Get возвращает настройки пользователя; пользователь их не сохранял —
+// models.ErrPreferencesNotFound.
+func (r *PreferencesRepository) Get(ctx context.Context, userID int) (*models.Preferences, error) {
+	const op = "repository.PreferencesRepository.Get"
+
+	var p models.Preferences
+	err := r.pool.QueryRow(ctx,
+		`SELECT user_id, locale, order_updates, payment_updates, shipping_updates, updated_at
+		 FROM notification_preferences
+		 WHERE user_id = $1`,
+		userID,
+	).Scan(&p.UserID, &p.Locale, &p.OrderUpdates, &p.PaymentUpdates, &p.ShippingUpdates, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrPreferencesNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &p, nil
+}
+
+// Upsert сохраняет настройки целиком и заполняет UpdatedAt.
+func (r *PreferencesRepository) Upsert(ctx context.Context, p *models.Preferences) error {
+	const op = "repository.PreferencesRepository.Upsert"
+
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO notification_preferences (user_id, locale, order_updates, payment_updates, shipping_updates)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (user_id) DO UPDATE
+		 SET locale           = EXCLUDED.locale,
+		     order_updates    = EXCLUDED.order_updates,
+		     payment_updates  = EXCLUDED.payment_updates,
+		     shipping_updates = EXCLUDED.shipping_updates,
+		     updated_at       = NOW()
+		 RETURNING updated_at`,
+		p.UserID, p.Locale, p.OrderUpdates, p.PaymentUpdates, p.ShippingUpdates,
+	).Scan(&p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
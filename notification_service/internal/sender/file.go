@@ -0,0 +1,44 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"notification_service/internal/models"
+)
+
+// File складывает письма в каталог файлами .eml — их открывает любой почтовый
+// клиент. Для локального запуска, когда проверить нужно текст, а не доставку.
+type File struct {
+	dir  string
+	from string
+}
+
+func NewFile(dir, from string) (*File, error) {
+	const op = "sender.NewFile"
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &File{dir: dir, from: from}, nil
+}
+
+func (f *File) Send(_ context.Context, msg models.Message) error {
+	const op = "sender.File.Send"
+
+	now := time.Now()
+	file, err := os.CreateTemp(f.dir, now.Format("20060102-150405")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := file.Write(buildMessage(f.from, msg, now)); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: write: %w", op, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("%s: close: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification_service/internal/models"
+	"notification_service/internal/sender"
+)
+
+func TestFile_WritesEML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	s, err := sender.NewFile(dir, "Sneakers <noreply@example.com>")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), models.Message{
+		To:      "user@example.com",
+		Subject: "Заказ №3 оплачен",
+		Body:    "Оплата прошла успешно.\n",
+	}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+
+	msg, err := mail.ReadMessage(f)
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Заказ №3 оплачен", subject)
+	assert.Equal(t, "user@example.com", msg.Header.Get("To"))
+
+	raw, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(raw), "\r\n", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "Оплата прошла успешно.\n", string(body))
+}
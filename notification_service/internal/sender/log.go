@@ -0,0 +1,26 @@
+package sender
+
+import (
+	"context"
+	"log/slog"
+
+	"notification_service/internal/models"
+)
+
+// Log пишет письма в лог вместо отправки.
+type Log struct {
+	log *slog.Logger
+}
+
+func NewLog(log *slog.Logger) *Log {
+	return &Log{log: log}
+}
+
+func (l *Log) Send(_ context.Context, msg models.Message) error {
+	l.log.Info("notification email",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+	return nil
+}
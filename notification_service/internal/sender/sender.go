@@ -0,0 +1,52 @@
+// Package sender отправляет готовые письма. Реализация выбирается параметром
+// sender.kind: SMTP для боевого окружения, файлы .eml или лог — для локального запуска.
+package sender
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/mail"
+	"time"
+
+	"notification_service/internal/models"
+)
+
+// Имена отправителей в конфигурации.
+const (
+	NameSMTP = "smtp"
+	NameFile = "file"
+	NameLog  = "log"
+)
+
+// buildMessage собирает письмо в формате RFC 5322: тема в MIME-кодировке,
+// текст в UTF-8 и base64, чтобы кириллица проходила через любой релей.
+func buildMessage(from string, msg models.Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
+
+// address возвращает адрес без отображаемого имени: "Shop <a@b.c>" → "a@b.c".
+func address(s string) (string, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return "", err
+	}
+	return addr.Address, nil
+}
@@ -0,0 +1,102 @@
+package sender
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"notification_service/internal/models"
+)
+
+// SMTPConfig — параметры подключения к почтовому серверу.
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username — логин; пустой — сервер принимает письма без авторизации.
+	Username string
+	Password string
+	// StartTLS — требовать шифрование; без него письма уходят открытым текстом.
+	StartTLS bool
+	Timeout  time.Duration
+}
+
+// SMTP отправляет письма через почтовый сервер, по соединению на письмо.
+type SMTP struct {
+	cfg  SMTPConfig
+	from string
+}
+
+func NewSMTP(cfg SMTPConfig, from string) *SMTP {
+	return &SMTP{cfg: cfg, from: from}
+}
+
+func (s *SMTP) Send(ctx context.Context, msg models.Message) error {
+	const op = "sender.SMTP.Send"
+
+	from, err := address(s.from)
+	if err != nil {
+		return fmt.Errorf("%s: from address: %w", op, err)
+	}
+	to, err := address(msg.To)
+	if err != nil {
+		return fmt.Errorf("%s: recipient address: %w", op, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%s: dial %s: %w", op, addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("%s: handshake: %w", op, err)
+	}
+	defer client.Close()
+
+	if s.cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s: server does not support STARTTLS", op)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("%s: starttls: %w", op, err)
+		}
+	}
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("%s: auth: %w", op, err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("%s: mail from: %w", op, err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("%s: rcpt to: %w", op, err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("%s: data: %w", op, err)
+	}
+	if _, err := w.Write(buildMessage(s.from, msg, time.Now())); err != nil {
+		return fmt.Errorf("%s: write message: %w", op, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%s: close data: %w", op, err)
+	}
+
+	return client.Quit()
+}
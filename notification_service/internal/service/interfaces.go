@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"notification_service/internal/models"
+)
+
+type PreferencesRepository interface {
+	// Get возвращает сохранённые настройки; их нет — models.ErrPreferencesNotFound.
+	Get(ctx context.Context, userID int) (*models.Preferences, error)
+	// Upsert сохраняет настройки целиком и заполняет UpdatedAt.
+	Upsert(ctx context.Context, prefs *models.Preferences) error
+}
+
+type EventRepository interface {
+	IsProcessed(ctx context.Context, eventID string) (bool, error)
+	MarkProcessed(ctx context.Context, eventID, eventType string) error
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Renderer собирает тему и текст письма по шаблону.
+type Renderer interface {
+	Render(kind models.Kind, locale string, event *models.OrderEvent) (subject, body string, err error)
+}
+
+// Sender доставляет готовое письмо: SMTP, файл или лог.
+type Sender interface {
+	Send(ctx context.Context, msg models.Message) error
+}
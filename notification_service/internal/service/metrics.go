@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Исходы обработки события для notification_events_total.
+const (
+	resultSent        = "sent"
+	resultDuplicate   = "duplicate"
+	resultUnsupported = "unsupported"
+	resultDisabled    = "disabled"
+	resultNoEmail     = "no_email"
+)
+
+var eventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_events_total",
+	Help: "Order events handled by the notification service, by result.",
+}, []string{"result"})
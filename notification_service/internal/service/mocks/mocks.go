@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"notification_service/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// --- MockPreferencesRepository ---
+
+type MockPreferencesRepository struct{ mock.Mock }
+
+func (m *MockPreferencesRepository) Get(ctx context.Context, userID int) (*models.Preferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Preferences), args.Error(1)
+}
+func (m *MockPreferencesRepository) Upsert(ctx context.Context, prefs *models.Preferences) error {
+	return m.Called(ctx, prefs).Error(0)
+}
+
+// --- MockEventRepository ---
+
+type MockEventRepository struct{ mock.Mock }
+
+func (m *MockEventRepository) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	args := m.Called(ctx, eventID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockEventRepository) MarkProcessed(ctx context.Context, eventID, eventType string) error {
+	return m.Called(ctx, eventID, eventType).Error(0)
+}
+func (m *MockEventRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// --- MockRenderer ---
+
+type MockRenderer struct{ mock.Mock }
+
+func (m *MockRenderer) Render(kind models.Kind, locale string, event *models.OrderEvent) (string, string, error) {
+	args := m.Called(kind, locale, event)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+// --- MockSender ---
+
+type MockSender struct{ mock.Mock }
+
+func (m *MockSender) Send(ctx context.Context, msg models.Message) error {
+	return m.Called(ctx, msg).Error(0)
+}
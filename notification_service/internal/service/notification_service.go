@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"notification_service/internal/models"
+)
+
+type NotificationServiceImpl struct {
+	prefsRepo PreferencesRepository
+	eventRepo EventRepository
+	renderer  Renderer
+	sender    Sender
+	// defaultLocale — язык писем пользователя, который не выбирал его в настройках.
+	defaultLocale string
+	log           *slog.Logger
+}
+
+func NewNotificationService(
+	prefsRepo PreferencesRepository,
+	eventRepo EventRepository,
+	renderer Renderer,
+	sender Sender,
+	defaultLocale string,
+	log *slog.Logger,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		prefsRepo:     prefsRepo,
+		eventRepo:     eventRepo,
+		renderer:      renderer,
+		sender:        sender,
+		defaultLocale: defaultLocale,
+		log:           log,
+	}
+}
+
+// HandleEvent отправляет письмо по событию заказа, если оно положено и пользователь
+// его не отключил. Событие отмечается обработанным после отправки, поэтому повтор
+// того же eventID письмо не дублирует, а ошибка отправки возвращается для повтора.
+// Если сбой случился между отправкой и отметкой, письмо уйдёт ещё раз: доставка
+// at-least-once, как и у outbox order_service.
+func (s *NotificationServiceImpl) HandleEvent(ctx context.Context, eventID string, event *models.OrderEvent) error {
+	const op = "service.NotificationService.HandleEvent"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("event_id", eventID),
+		slog.String("event_type", event.EventType),
+		slog.Int("order_id", event.OrderID),
+	)
+
+	processed, err := s.eventRepo.IsProcessed(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if processed {
+		log.Debug("event already processed")
+		eventsTotal.WithLabelValues(resultDuplicate).Inc()
+		return nil
+	}
+
+	result, err := s.notify(ctx, event, log)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.eventRepo.MarkProcessed(ctx, eventID, event.EventType); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	eventsTotal.WithLabelValues(result).Inc()
+	return nil
+}
+
+// notify отправляет письмо и возвращает исход для метрик.
+func (s *NotificationServiceImpl) notify(ctx context.Context, event *models.OrderEvent, log *slog.Logger) (string, error) {
+	kind, ok := models.KindFor(event)
+	if !ok {
+		log.Debug("event does not trigger a notification", slog.String("status", event.Status))
+		return resultUnsupported, nil
+	}
+	// События, записанные до появления email в заказе, отправить некуда.
+	if event.CustomerEmail == "" {
+		log.Warn("order has no customer email, notification skipped", slog.String("kind", string(kind)))
+		return resultNoEmail, nil
+	}
+
+	prefs, err := s.preferences(ctx, event.UserID)
+	if err != nil {
+		return "", err
+	}
+	if !prefs.Allows(kind.Category()) {
+		log.Debug("notification disabled by user", slog.String("kind", string(kind)))
+		return resultDisabled, nil
+	}
+
+	subject, body, err := s.renderer.Render(kind, prefs.Locale, event)
+	if err != nil {
+		return "", err
+	}
+	if err := s.sender.Send(ctx, models.Message{To: event.CustomerEmail, Subject: subject, Body: body}); err != nil {
+		return "", err
+	}
+
+	log.Info("notification sent", slog.String("kind", string(kind)), slog.String("locale", prefs.Locale))
+	return resultSent, nil
+}
+
+// GetPreferences возвращает настройки пользователя; если он их не менял — настройки по умолчанию.
+func (s *NotificationServiceImpl) GetPreferences(ctx context.Context, userID int) (*models.Preferences, error) {
+	const op = "service.NotificationService.GetPreferences"
+
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences сохраняет настройки целиком. Пустой язык — язык по умолчанию,
+// неизвестный — models.ErrInvalidPreferences.
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, prefs *models.Preferences) (*models.Preferences, error) {
+	const op = "service.NotificationService.UpdatePreferences"
+
+	if prefs.Locale == "" {
+		prefs.Locale = s.defaultLocale
+	}
+	if !models.ValidLocale(prefs.Locale) {
+		return nil, fmt.Errorf("%s: %w: unsupported locale %q", op, models.ErrInvalidPreferences, prefs.Locale)
+	}
+
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("notification preferences updated",
+		slog.String("op", op),
+		slog.Int("user_id", prefs.UserID),
+	)
+	return prefs, nil
+}
+
+// CleanupProcessedEvents забывает события, обработанные раньше before: повторная
+// доставка из Kafka после этого срока уже не ожидается.
+func (s *NotificationServiceImpl) CleanupProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	const op = "service.NotificationService.CleanupProcessedEvents"
+
+	deleted, err := s.eventRepo.DeleteProcessedBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
+
+func (s *NotificationServiceImpl) preferences(ctx context.Context, userID int) (*models.Preferences, error) {
+	prefs, err := s.prefsRepo.Get(ctx, userID)
+	if errors.Is(err, models.ErrPreferencesNotFound) {
+		return models.DefaultPreferences(userID, s.defaultLocale), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
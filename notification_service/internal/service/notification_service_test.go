@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"notification_service/internal/models"
+	"notification_service/internal/service"
+	"notification_service/internal/service/mocks"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+type testDeps struct {
+	prefsRepo *mocks.MockPreferencesRepository
+	eventRepo *mocks.MockEventRepository
+	renderer  *mocks.MockRenderer
+	sender    *mocks.MockSender
+}
+
+func newTestService() (*service.NotificationServiceImpl, testDeps) {
+	d := testDeps{
+		prefsRepo: new(mocks.MockPreferencesRepository),
+		eventRepo: new(mocks.MockEventRepository),
+		renderer:  new(mocks.MockRenderer),
+		sender:    new(mocks.MockSender),
+	}
+	svc := service.NewNotificationService(d.prefsRepo, d.eventRepo, d.renderer, d.sender, models.LocaleRU, newTestLogger())
+	return svc, d
+}
+
+func paidEvent() *models.OrderEvent {
+	return &models.OrderEvent{
+		EventType:     models.EventOrderPaymentUpdated,
+		OrderID:       7,
+		UserID:        42,
+		Status:        models.OrderStatusPaid,
+		TotalAmount:   1500000,
+		CustomerEmail: "user@example.com",
+	}
+}
+
+func TestHandleEvent_SendsWithDefaultPreferences(t *testing.T) {
+	svc, d := newTestService()
+	event := paidEvent()
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(nil, models.ErrPreferencesNotFound)
+	d.renderer.On("Render", models.KindPaymentSucceeded, models.LocaleRU, event).Return("Заказ оплачен", "Текст", nil)
+	d.sender.On("Send", mock.Anything, models.Message{To: "user@example.com", Subject: "Заказ оплачен", Body: "Текст"}).
+		Return(nil)
+	d.eventRepo.On("MarkProcessed", mock.Anything, "10", models.EventOrderPaymentUpdated).Return(nil)
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "10", event))
+	d.sender.AssertExpectations(t)
+	d.eventRepo.AssertExpectations(t)
+}
+
+func TestHandleEvent_UsesUserLocale(t *testing.T) {
+	svc, d := newTestService()
+	event := paidEvent()
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+	prefs := models.DefaultPreferences(42, models.LocaleEN)
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(prefs, nil)
+	d.renderer.On("Render", models.KindPaymentSucceeded, models.LocaleEN, event).Return("Order paid", "Text", nil)
+	d.sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+	d.eventRepo.On("MarkProcessed", mock.Anything, "10", mock.Anything).Return(nil)
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "10", event))
+	d.renderer.AssertExpectations(t)
+}
+
+func TestHandleEvent_Duplicate(t *testing.T) {
+	svc, d := newTestService()
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(true, nil)
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "10", paidEvent()))
+	d.sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	d.eventRepo.AssertNotCalled(t, "MarkProcessed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEvent_Skipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  func() *models.OrderEvent
+		prefs  *models.Preferences
+		lookup bool
+	}{
+		{
+			name: "payment pending",
+			event: func() *models.OrderEvent {
+				e := paidEvent()
+				e.Status = "PENDING"
+				return e
+			},
+		},
+		{
+			name: "status changed by admin",
+			event: func() *models.OrderEvent {
+				e := paidEvent()
+				e.EventType = "OrderStatusChanged"
+				return e
+			},
+		},
+		{
+			name: "no customer email",
+			event: func() *models.OrderEvent {
+				e := paidEvent()
+				e.CustomerEmail = ""
+				return e
+			},
+		},
+		{
+			name:   "payment notifications disabled",
+			event:  paidEvent,
+			prefs:  &models.Preferences{UserID: 42, Locale: models.LocaleRU, OrderUpdates: true, ShippingUpdates: true},
+			lookup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, d := newTestService()
+			event := tt.event()
+
+			d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+			if tt.lookup {
+				d.prefsRepo.On("Get", mock.Anything, 42).Return(tt.prefs, nil)
+			}
+			// Пропущенное событие тоже отмечается, чтобы не разбирать его повторно.
+			d.eventRepo.On("MarkProcessed", mock.Anything, "10", event.EventType).Return(nil)
+
+			require.NoError(t, svc.HandleEvent(context.Background(), "10", event))
+			d.sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			d.eventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleEvent_SendFailureNotMarked(t *testing.T) {
+	svc, d := newTestService()
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(nil, models.ErrPreferencesNotFound)
+	d.renderer.On("Render", mock.Anything, mock.Anything, mock.Anything).Return("s", "b", nil)
+	d.sender.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp: connection refused"))
+
+	err := svc.HandleEvent(context.Background(), "10", paidEvent())
+	require.Error(t, err)
+	d.eventRepo.AssertNotCalled(t, "MarkProcessed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEvent_PaymentFailedKind(t *testing.T) {
+	svc, d := newTestService()
+	event := paidEvent()
+	event.Status = models.OrderStatusPaymentFailed
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(nil, models.ErrPreferencesNotFound)
+	d.renderer.On("Render", models.KindPaymentFailed, models.LocaleRU, event).Return("s", "b", nil)
+	d.sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+	d.eventRepo.On("MarkProcessed", mock.Anything, "10", mock.Anything).Return(nil)
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "10", event))
+	d.renderer.AssertExpectations(t)
+}
+
+func TestHandleEvent_PaymentLinkKind(t *testing.T) {
+	svc, d := newTestService()
+	event := paidEvent()
+	event.EventType = models.EventOrderPaymentCreated
+	event.Status = "PENDING_PAYMENT"
+	event.PaymentURL = "https://pay.example/7"
+
+	d.eventRepo.On("IsProcessed", mock.Anything, "10").Return(false, nil)
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(nil, models.ErrPreferencesNotFound)
+	d.renderer.On("Render", models.KindPaymentLink, models.LocaleRU, event).Return("s", "b", nil)
+	d.sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+	d.eventRepo.On("MarkProcessed", mock.Anything, "10", mock.Anything).Return(nil)
+
+	require.NoError(t, svc.HandleEvent(context.Background(), "10", event))
+	d.renderer.AssertExpectations(t)
+}
+
+// ---------------------------------------------------------------------------
+// Настройки
+// ---------------------------------------------------------------------------
+
+func TestGetPreferences_Defaults(t *testing.T) {
+	svc, d := newTestService()
+
+	d.prefsRepo.On("Get", mock.Anything, 42).Return(nil, models.ErrPreferencesNotFound)
+
+	prefs, err := svc.GetPreferences(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultPreferences(42, models.LocaleRU), prefs)
+}
+
+func TestUpdatePreferences_DefaultLocale(t *testing.T) {
+	svc, d := newTestService()
+
+	d.prefsRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *models.Preferences) bool {
+		return p.UserID == 42 && p.Locale == models.LocaleRU && !p.PaymentUpdates
+	})).Return(nil)
+
+	_, err := svc.UpdatePreferences(context.Background(), &models.Preferences{UserID: 42, OrderUpdates: true})
+	require.NoError(t, err)
+	d.prefsRepo.AssertExpectations(t)
+}
+
+func TestUpdatePreferences_UnknownLocale(t *testing.T) {
+	svc, d := newTestService()
+
+	_, err := svc.UpdatePreferences(context.Background(), &models.Preferences{UserID: 42, Locale: "de"})
+	require.ErrorIs(t, err, models.ErrInvalidPreferences)
+	d.prefsRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,125 @@
+// Package templates рендерит письма из встроенных шаблонов: по файлу
+// <locale>/<kind>.tmpl на каждый вид письма и язык. В файле два блока —
+// "subject" и "body".
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"notification_service/internal/models"
+)
+
+//go:embed ru/*.tmpl en/*.tmpl
+var files embed.FS
+
+// Data — поля, доступные в шаблоне.
+type Data struct {
+	ShopName       string
+	OrderID        int
+	Total          string // сумма заказа, отформатированная для языка письма
+	Discount       string // скидка по промокоду; пусто — без скидки
+	Carrier        string
+	TrackingNumber string
+	PaymentURL     string
+}
+
+// Renderer хранит разобранные шаблоны всех писем.
+type Renderer struct {
+	shopName      string
+	defaultLocale string
+	templates     map[string]*template.Template // "<locale>/<kind>"
+}
+
+// New разбирает шаблоны всех видов писем на всех языках из models.Locales.
+// Отсутствующий или битый шаблон — ошибка: это выясняется при запуске, а не на письме.
+func New(shopName, defaultLocale string) (*Renderer, error) {
+	const op = "templates.New"
+
+	if !models.ValidLocale(defaultLocale) {
+		return nil, fmt.Errorf("%s: unsupported default locale %q", op, defaultLocale)
+	}
+
+	r := &Renderer{
+		shopName:      shopName,
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]*template.Template),
+	}
+	for _, locale := range models.Locales {
+		for _, kind := range models.Kinds {
+			name := key(locale, kind)
+			tmpl, err := template.ParseFS(files, name+".tmpl")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			for _, block := range []string{"subject", "body"} {
+				if tmpl.Lookup(block) == nil {
+					return nil, fmt.Errorf("%s: %s: block %q is missing", op, name, block)
+				}
+			}
+			r.templates[name] = tmpl
+		}
+	}
+	return r, nil
+}
+
+// Render собирает тему и текст письма kind по событию на языке locale;
+// неизвестный язык заменяется языком по умолчанию.
+func (r *Renderer) Render(kind models.Kind, locale string, event *models.OrderEvent) (subject, body string, err error) {
+	const op = "templates.Renderer.Render"
+
+	if !models.ValidLocale(locale) {
+		locale = r.defaultLocale
+	}
+	tmpl, ok := r.templates[key(locale, kind)]
+	if !ok {
+		return "", "", fmt.Errorf("%s: no template for %s", op, key(locale, kind))
+	}
+
+	data := Data{
+		ShopName:       r.shopName,
+		OrderID:        event.OrderID,
+		Total:          FormatAmount(event.TotalAmount, locale),
+		Carrier:        event.Carrier,
+		TrackingNumber: event.TrackingNumber,
+		PaymentURL:     event.PaymentURL,
+	}
+	if event.DiscountAmount > 0 {
+		data.Discount = FormatAmount(event.DiscountAmount, locale)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.ExecuteTemplate(&sb, "subject", data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	subject = strings.TrimSpace(sb.String())
+
+	sb.Reset()
+	if err := tmpl.ExecuteTemplate(&sb, "body", data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	return subject, strings.TrimSpace(sb.String()) + "\n", nil
+}
+
+// FormatAmount форматирует копейки по правилам языка: "1 234,50 ₽" или "RUB 1,234.50".
+func FormatAmount(kopecks int, locale string) string {
+	if locale == models.LocaleEN {
+		return fmt.Sprintf("RUB %s.%02d", groupThousands(kopecks/100, ","), kopecks%100)
+	}
+	return fmt.Sprintf("%s,%02d ₽", groupThousands(kopecks/100, " "), kopecks%100)
+}
+
+func groupThousands(n int, sep string) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + sep + s[i:]
+	}
+	return s
+}
+
+func key(locale string, kind models.Kind) string {
+	return locale + "/" + string(kind)
+}
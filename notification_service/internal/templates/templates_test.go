@@ -0,0 +1,70 @@
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification_service/internal/models"
+	"notification_service/internal/templates"
+)
+
+func TestRender_AllKindsAndLocales(t *testing.T) {
+	r, err := templates.New("Sneakers", models.LocaleRU)
+	require.NoError(t, err)
+
+	event := &models.OrderEvent{OrderID: 15, TotalAmount: 1234550, Carrier: "cdek", TrackingNumber: "TRK-1"}
+	for _, locale := range models.Locales {
+		for _, kind := range models.Kinds {
+			t.Run(locale+"/"+string(kind), func(t *testing.T) {
+				subject, body, err := r.Render(kind, locale, event)
+				require.NoError(t, err)
+				assert.Contains(t, subject, "15")
+				assert.Contains(t, body, "15")
+				assert.Contains(t, body, "Sneakers")
+				assert.NotContains(t, body, "<no value>")
+			})
+		}
+	}
+}
+
+func TestRender_Details(t *testing.T) {
+	r, err := templates.New("Sneakers", models.LocaleRU)
+	require.NoError(t, err)
+
+	subject, body, err := r.Render(models.KindOrderCreated, models.LocaleRU, &models.OrderEvent{
+		OrderID: 3, TotalAmount: 1234550, DiscountAmount: 50000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Заказ №3 оформлен", subject)
+	assert.Contains(t, body, "на сумму 12 345,50 ₽")
+	assert.Contains(t, body, "Скидка по промокоду: 500,00 ₽")
+
+	_, body, err = r.Render(models.KindOrderShipped, models.LocaleEN, &models.OrderEvent{
+		OrderID: 3, Carrier: "cdek", TrackingNumber: "TRK-1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "Carrier: cdek, tracking number: TRK-1.")
+
+	_, body, err = r.Render(models.KindPaymentLink, models.LocaleRU, &models.OrderEvent{
+		OrderID: 3, TotalAmount: 1234550, PaymentURL: "https://pay.example/3",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "https://pay.example/3")
+}
+
+func TestRender_UnknownLocaleFallsBack(t *testing.T) {
+	r, err := templates.New("Sneakers", models.LocaleEN)
+	require.NoError(t, err)
+
+	subject, _, err := r.Render(models.KindOrderDelivered, "de", &models.OrderEvent{OrderID: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "Order #3 delivered", subject)
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "0,99 ₽", templates.FormatAmount(99, models.LocaleRU))
+	assert.Equal(t, "1 000 000,00 ₽", templates.FormatAmount(100000000, models.LocaleRU))
+	assert.Equal(t, "RUB 1,234.05", templates.FormatAmount(123405, models.LocaleEN))
+}
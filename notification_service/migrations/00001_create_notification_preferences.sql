@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY,
+    locale VARCHAR(8) NOT NULL,
+    order_updates BOOLEAN NOT NULL DEFAULT TRUE,
+    payment_updates BOOLEAN NOT NULL DEFAULT TRUE,
+    shipping_updates BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Обработанные события заказов: повторная доставка из Kafka не шлёт письмо второй раз.
+CREATE TABLE IF NOT EXISTS processed_events (
+    event_id VARCHAR(64) PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events(processed_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS processed_events;
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,14 +12,24 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	pb "github.com/stpnv0/protos/gen/go/order"
 
 	"order_service/internal/api"
+	"order_service/internal/carrier"
+	productclient "order_service/internal/client/product"
+	ssoclient "order_service/internal/client/sso"
 	"order_service/internal/config"
+	"order_service/internal/expiry"
+	"order_service/internal/fulfilment"
 	grpcserver "order_service/internal/grpc"
 	orderhandler "order_service/internal/grpc/order"
+	promohandler "order_service/internal/grpc/promo"
 	"order_service/internal/kafka"
+	"order_service/internal/models"
+	"order_service/internal/outbox"
 	"order_service/internal/provider"
+	"order_service/internal/reconciliation"
 	"order_service/internal/repository"
 	"order_service/internal/service"
 )
@@ -54,36 +65,115 @@ func run() error {
 
 	orderRepo := repository.NewOrderRepository(pool)
 	paymentRepo := repository.NewPaymentRepository(pool)
+	refundRepo := repository.NewRefundRepository(pool)
+	promoRepo := repository.NewPromoRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
+
+	// Секрет нужен и провайдеру, и обработчику вебхуков: задаём его до сборки провайдера.
+	var fakeWebhookSecret string
+	if cfg.Payment.Provider == provider.NameFake {
+		if cfg.Payment.Fake.WebhookSecret == "" {
+			cfg.Payment.Fake.WebhookSecret = rand.Text()
+		}
+		fakeWebhookSecret = cfg.Payment.Fake.WebhookSecret
+	}
 
-	yooProvider := provider.NewYooKassaProvider(
-		cfg.YooKassa.ShopID,
-		cfg.YooKassa.SecretKey,
-		cfg.YooKassa.ReturnURL,
-		cfg.YooKassa.NotificationURL,
-		cfg.HTTP.Timeout,
-		log,
-	)
+	paymentProvider, err := newPaymentProviders(cfg, log).Build(cfg.Payment.Provider)
+	if err != nil {
+		return err
+	}
+	log.Info("payment provider selected", slog.String("provider", cfg.Payment.Provider))
 
-	// Авто-регистрация вебхуков в ЮKassa (best-effort, ошибки логируются).
-	yooProvider.RegisterWebhooks(ctx)
+	// Подготовка провайдера, например авто-регистрация вебхуков в ЮKassa
+	// (best-effort, ошибки логируются).
+	if starter, ok := paymentProvider.(provider.Starter); ok {
+		starter.Start(ctx)
+	}
+
+	productClient, err := productclient.New(cfg.Product.Addr, cfg.Product.Timeout, cfg.Product.ReservationTTL, log)
+	if err != nil {
+		return err
+	}
+	defer productClient.Close()
+
+	ssoClient, err := ssoclient.New(cfg.SSO.Addr, cfg.SSO.Timeout, log)
+	if err != nil {
+		return err
+	}
+	defer ssoClient.Close()
 
 	producer := kafka.NewProducer(cfg.Kafka.Brokers, cfg.Kafka.Topic, log)
-	orderService := service.NewOrderService(orderRepo, paymentRepo, yooProvider, producer, log)
+	relay := outbox.NewRelay(outboxRepo, producer, outbox.Config{
+		PollInterval:    cfg.Outbox.PollInterval,
+		BatchSize:       cfg.Outbox.BatchSize,
+		Retention:       cfg.Outbox.Retention,
+		MaxAttempts:     cfg.Outbox.MaxAttempts,
+		RetryBackoff:    cfg.Outbox.RetryBackoff,
+		MaxRetryBackoff: cfg.Outbox.MaxRetryBackoff,
+	}, log)
+
+	carriers, err := newCarriers(cfg)
+	if err != nil {
+		return err
+	}
+	log.Info("carriers enabled", slog.Any("carriers", carriers.Names()))
+
+	orderService := service.NewOrderService(
+		orderRepo, paymentRepo, refundRepo, promoRepo, paymentProvider, productClient, productClient,
+		ssoClient, carriers, cfg.Delivery.Costs,
+		models.ReceiptSettings{
+			Enabled:       cfg.Receipt.Enabled,
+			VATCode:       cfg.Receipt.VATCode,
+			PaymentMode:   cfg.Receipt.PaymentMode,
+			TaxSystemCode: cfg.Receipt.TaxSystemCode,
+			DeliveryTitle: cfg.Receipt.DeliveryTitle,
+		},
+		cfg.Orders.IdempotencyTTL, log,
+	)
+
+	expiryWorker := expiry.NewWorker(orderService, expiry.Config{
+		Timeout:   cfg.Expiry.PaymentTimeout,
+		Interval:  cfg.Expiry.Interval,
+		BatchSize: cfg.Expiry.BatchSize,
+	}, log)
+
+	reconciler := reconciliation.NewWorker(orderService, reconciliation.Config{
+		Interval:         cfg.Reconciliation.Interval,
+		PendingThreshold: cfg.Reconciliation.PendingThreshold,
+		BatchSize:        cfg.Reconciliation.BatchSize,
+	}, log)
+
+	trackingWorker := fulfilment.NewWorker(orderService, fulfilment.Config{
+		Interval:  cfg.Fulfilment.PollInterval,
+		BatchSize: cfg.Fulfilment.BatchSize,
+	}, log)
 
 	// ---- gRPC-сервер ----
 
 	grpcSrv := grpcserver.NewServer(log)
 	pb.RegisterOrderServiceServer(grpcSrv.GRPCServer(), orderhandler.NewHandler(orderService, log))
+	pb.RegisterPromotionsServer(grpcSrv.GRPCServer(), promohandler.NewHandler(orderService, log))
 
 	// ---- HTTP-сервер (вебхуки YooKassa) ----
 
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		return fmt.Errorf("http.trusted_proxies: %w", err)
+	}
 
-	adminAPIKey := os.Getenv("ADMIN_API_KEY")
-	webhookHandler := api.NewWebhookHandler(orderService, log, adminAPIKey)
+	webhookNetworks, err := api.ParseNetworks(cfg.YooKassa.WebhookAllowedNetworks)
+	if err != nil {
+		return fmt.Errorf("yookassa.webhook_allowed_networks: %w", err)
+	}
+
+	webhookHandler := api.NewWebhookHandler(orderService, log, fakeWebhookSecret, webhookNetworks)
 	webhookHandler.RegisterRoutes(router)
+	if registrar, ok := paymentProvider.(provider.RouteRegistrar); ok {
+		registrar.RegisterRoutes(router)
+	}
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.HTTP.Port),
@@ -94,6 +184,30 @@ func run() error {
 
 	errCh := make(chan error, 2)
 
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
+	}()
+
+	expiryDone := make(chan struct{})
+	go func() {
+		defer close(expiryDone)
+		expiryWorker.Run(ctx)
+	}()
+
+	reconcilerDone := make(chan struct{})
+	go func() {
+		defer close(reconcilerDone)
+		reconciler.Run(ctx)
+	}()
+
+	trackingDone := make(chan struct{})
+	go func() {
+		defer close(trackingDone)
+		trackingWorker.Run(ctx)
+	}()
+
 	go func() {
 		if err := grpcSrv.Run(cfg.GRPC.Port); err != nil {
 			errCh <- fmt.Errorf("grpc server: %w", err)
@@ -130,6 +244,12 @@ func run() error {
 		log.Error("http server shutdown error", slog.String("error", err.Error()))
 	}
 
+	// Relay останавливается по отмене ctx; producer закрываем только после него.
+	<-relayDone
+	<-expiryDone
+	<-reconcilerDone
+	<-trackingDone
+
 	log.Info("closing kafka producer")
 	if err := producer.Close(); err != nil {
 		log.Error("kafka producer close error", slog.String("error", err.Error()))
@@ -141,6 +261,52 @@ func run() error {
 	return nil
 }
 
+// newCarriers подключает перевозчиков из fulfilment.carriers.
+func newCarriers(cfg *config.Config) (*carrier.Registry, error) {
+	registry := carrier.NewRegistry()
+	for _, name := range cfg.Fulfilment.Carriers {
+		switch name {
+		case carrier.NameManual:
+			registry.RegisterUntracked(name)
+		case carrier.NameFake:
+			registry.Register(name, carrier.NewFake(cfg.Fulfilment.Fake.TransitTime))
+		default:
+			return nil, fmt.Errorf("fulfilment.carriers: unknown carrier %q", name)
+		}
+	}
+	return registry, nil
+}
+
+// newPaymentProviders регистрирует все доступные платёжные провайдеры;
+// используемый выбирается параметром payment.provider.
+func newPaymentProviders(cfg *config.Config, log *slog.Logger) *provider.Registry {
+	registry := provider.NewRegistry()
+
+	registry.Register(provider.NameYooKassa, func() (provider.Provider, error) {
+		return provider.NewYooKassaProvider(
+			cfg.YooKassa.BaseURL,
+			cfg.YooKassa.ShopID,
+			cfg.YooKassa.SecretKey,
+			cfg.YooKassa.ReturnURL,
+			cfg.YooKassa.NotificationURL,
+			cfg.HTTP.Timeout,
+			log,
+		), nil
+	})
+
+	registry.Register(provider.NameFake, func() (provider.Provider, error) {
+		return provider.NewFakeProvider(provider.FakeConfig{
+			PublicURL:     cfg.Payment.Fake.PublicURL,
+			ReturnURL:     cfg.Payment.Fake.ReturnURL,
+			WebhookURL:    cfg.Payment.Fake.WebhookURL,
+			WebhookSecret: cfg.Payment.Fake.WebhookSecret,
+			HTTPTimeout:   cfg.HTTP.Timeout,
+		}, log), nil
+	})
+
+	return registry
+}
+
 func setupLogger(serviceName string) *slog.Logger {
 	env := os.Getenv("ENV")
 
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Причины отказа, которые определяет сам обработчик; остальные —
+// models.WebhookReject*, их возвращает проверка в сервисе.
+const (
+	rejectIPNotAllowed     = "ip_not_allowed"
+	rejectInvalidSignature = "invalid_signature"
+	rejectInvalidPayload   = "invalid_payload"
+)
+
+var webhookRejectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "order_webhook_rejected_total",
+	Help: "Payment provider webhooks rejected before processing, by reason.",
+}, []string{"reason"})
@@ -2,40 +2,82 @@ package api
 
 import (
 	"context"
-	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"order_service/internal/lib/money"
+	"order_service/internal/lib/webhooksign"
+	"order_service/internal/models"
 )
 
 type WebhookService interface {
+	VerifyPaymentWebhook(ctx context.Context, n models.PaymentNotification) error
 	ProcessWebhook(ctx context.Context, yookassaID, status string) error
+	VerifyRefundWebhook(ctx context.Context, n models.RefundNotification) error
+	ProcessRefundWebhook(ctx context.Context, providerRefundID, status string) error
 }
 
 type WebhookHandler struct {
-	svc         WebhookService
-	log         *slog.Logger
-	validate    *validator.Validate
-	adminAPIKey string
+	svc      WebhookService
+	log      *slog.Logger
+	validate *validator.Validate
+	// fakeWebhookSecret — ключ подписи вебхуков fake-провайдера;
+	// пустой — маршрут /webhook/fake не регистрируется.
+	fakeWebhookSecret string
+	// allowedNetworks — откуда принимаются вебхуки ЮKassa.
+	allowedNetworks []netip.Prefix
 }
 
-func NewWebhookHandler(svc WebhookService, log *slog.Logger, adminAPIKey string) *WebhookHandler {
+func NewWebhookHandler(
+	svc WebhookService, log *slog.Logger, fakeWebhookSecret string, allowedNetworks []netip.Prefix,
+) *WebhookHandler {
 	return &WebhookHandler{
-		svc:         svc,
-		log:         log,
-		validate:    validator.New(),
-		adminAPIKey: adminAPIKey,
+		svc:               svc,
+		log:               log,
+		validate:          validator.New(),
+		fakeWebhookSecret: fakeWebhookSecret,
+		allowedNetworks:   allowedNetworks,
 	}
 }
 
+// ParseNetworks разбирает список подсетей и отдельных адресов ("10.0.0.0/8", "10.1.2.3").
+func ParseNetworks(entries []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("parse address %q: %w", entry, err)
+			}
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("parse network %q: %w", entry, err)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	return networks, nil
+}
+
 func (h *WebhookHandler) RegisterRoutes(router *gin.Engine) {
 	router.GET("/healthz", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 	router.POST("/webhook/yookassa", h.HandleWebhook)
-	router.POST("/api/manual-status-update", h.ManualStatusUpdate)
+	if h.fakeWebhookSecret != "" {
+		router.POST("/webhook/fake", h.HandleFakeWebhook)
+	}
 }
 
 type yooKassaWebhook struct {
@@ -51,22 +93,48 @@ type yooKassaWebhook struct {
 	} `json:"object"`
 }
 
-type manualUpdateRequest struct {
-	PaymentID string `json:"payment_id" binding:"required" validate:"required"`
-	Status    string `json:"status"     binding:"required" validate:"required,oneof=succeeded canceled"`
-}
-
+// HandleWebhook принимает уведомления ЮKassa только с адресов из allowedNetworks.
 func (h *WebhookHandler) HandleWebhook(c *gin.Context) {
+	if !h.isAllowedSource(c.ClientIP()) {
+		h.reject(c, http.StatusForbidden, rejectIPNotAllowed, "source ip "+c.ClientIP())
+		return
+	}
+
 	var webhook yooKassaWebhook
 	if err := c.ShouldBindJSON(&webhook); err != nil {
-		h.log.Error("failed to bind webhook", slog.String("error", err.Error()))
+		h.reject(c, http.StatusBadRequest, rejectInvalidPayload, err.Error())
+		return
+	}
+
+	h.processWebhook(c, webhook)
+}
+
+// HandleFakeWebhook принимает вебхуки fake-провайдера. Формат тела тот же,
+// что у ЮKassa, но запрос должен быть подписан (заголовок webhooksign.Header).
+func (h *WebhookHandler) HandleFakeWebhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
+	if !webhooksign.Verify(h.fakeWebhookSecret, body, c.GetHeader(webhooksign.Header)) {
+		h.reject(c, http.StatusUnauthorized, rejectInvalidSignature, "fake webhook signature mismatch")
+		return
+	}
+
+	var webhook yooKassaWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		h.reject(c, http.StatusBadRequest, rejectInvalidPayload, err.Error())
+		return
+	}
+
+	h.processWebhook(c, webhook)
+}
+
+func (h *WebhookHandler) processWebhook(c *gin.Context, webhook yooKassaWebhook) {
 	if err := h.validate.Struct(webhook); err != nil {
-		h.log.Warn("webhook validation failed", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "validation failed"})
+		h.reject(c, http.StatusBadRequest, rejectInvalidPayload, err.Error())
 		return
 	}
 
@@ -76,50 +144,105 @@ func (h *WebhookHandler) HandleWebhook(c *gin.Context) {
 		slog.String("status", webhook.Object.Status),
 	)
 
-	if webhook.Event == "payment.succeeded" || webhook.Event == "payment.canceled" {
-		if err := h.svc.ProcessWebhook(c.Request.Context(), webhook.Object.ID, webhook.Object.Status); err != nil {
-			h.log.Error("failed to process webhook", slog.String("error", err.Error()))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	var err error
+	switch webhook.Event {
+	case "payment.succeeded", "payment.canceled":
+		if !h.verifyPayment(c, webhook) {
+			return
+		}
+		err = h.svc.ProcessWebhook(c.Request.Context(), webhook.Object.ID, webhook.Object.Status)
+	case "refund.succeeded":
+		// Для возврата object.id — id возврата у провайдера.
+		if !h.verifyRefund(c, webhook) {
 			return
 		}
+		err = h.svc.ProcessRefundWebhook(c.Request.Context(), webhook.Object.ID, webhook.Object.Status)
+	}
+	if err != nil {
+		h.log.Error("failed to process webhook", slog.String("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
 	}
 
 	c.Status(http.StatusOK)
 }
 
-func (h *WebhookHandler) ManualStatusUpdate(c *gin.Context) {
-	if h.adminAPIKey == "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": "endpoint disabled"})
-		return
+// verifyPayment сверяет уведомление о платеже с сохранённым платежом и провайдером.
+// false — ответ клиенту уже отправлен.
+func (h *WebhookHandler) verifyPayment(c *gin.Context, webhook yooKassaWebhook) bool {
+	amount, err := money.Parse(webhook.Object.Amount.Value)
+	if err != nil {
+		h.reject(c, http.StatusBadRequest, rejectInvalidPayload, err.Error())
+		return false
 	}
 
-	providedKey := c.GetHeader("X-Admin-API-Key")
-	if subtle.ConstantTimeCompare([]byte(providedKey), []byte(h.adminAPIKey)) != 1 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing admin API key"})
-		return
+	err = h.svc.VerifyPaymentWebhook(c.Request.Context(), models.PaymentNotification{
+		PaymentID: webhook.Object.ID,
+		Status:    webhook.Object.Status,
+		Amount:    amount,
+		Currency:  webhook.Object.Amount.Currency,
+	})
+	return h.verified(c, webhook, err)
+}
+
+// verifyRefund сверяет уведомление о возврате с сохранённым возвратом и провайдером.
+// false — ответ клиенту уже отправлен.
+func (h *WebhookHandler) verifyRefund(c *gin.Context, webhook yooKassaWebhook) bool {
+	amount, err := money.Parse(webhook.Object.Amount.Value)
+	if err != nil {
+		h.reject(c, http.StatusBadRequest, rejectInvalidPayload, err.Error())
+		return false
 	}
 
-	var req manualUpdateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
+	err = h.svc.VerifyRefundWebhook(c.Request.Context(), models.RefundNotification{
+		RefundID: webhook.Object.ID,
+		Status:   webhook.Object.Status,
+		Amount:   amount,
+		Currency: webhook.Object.Amount.Currency,
+	})
+	return h.verified(c, webhook, err)
+}
+
+// verified отвечает клиенту по результату проверки уведомления: отклонённое —
+// 400, прочие ошибки — 500, чтобы провайдер повторил его позже.
+func (h *WebhookHandler) verified(c *gin.Context, webhook yooKassaWebhook, err error) bool {
+	if err == nil {
+		return true
 	}
 
-	if err := h.validate.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	var rejected *models.WebhookRejectedError
+	if errors.As(err, &rejected) {
+		h.reject(c, http.StatusBadRequest, rejected.Reason, webhook.Object.ID+": "+rejected.Detail)
+		return false
 	}
 
-	h.log.Info("manual status update",
-		slog.String("payment_id", req.PaymentID),
-		slog.String("status", req.Status),
-	)
+	// Провайдер недоступен или возврат ещё не сохранён — просим повторить уведомление позже.
+	h.log.Error("failed to verify webhook", slog.String("error", err.Error()))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	return false
+}
 
-	if err := h.svc.ProcessWebhook(c.Request.Context(), req.PaymentID, req.Status); err != nil {
-		h.log.Error("failed to process status update", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
-		return
+func (h *WebhookHandler) isAllowedSource(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, network := range h.allowedNetworks {
+		if network.Contains(addr) {
+			return true
+		}
 	}
+	return false
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "status updated successfully"})
+// reject логирует и считает отклонённый вебхук.
+func (h *WebhookHandler) reject(c *gin.Context, code int, reason, detail string) {
+	webhookRejectedTotal.WithLabelValues(reason).Inc()
+	h.log.Warn("webhook rejected",
+		slog.String("reason", reason),
+		slog.String("detail", detail),
+		slog.String("remote_ip", c.ClientIP()),
+	)
+	c.JSON(code, gin.H{"error": "webhook rejected"})
 }
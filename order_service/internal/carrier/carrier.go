@@ -0,0 +1,91 @@
+package carrier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"order_service/internal/models"
+)
+
+// Имена перевозчиков (поле carrier заказа и fulfilment.carriers в конфиге).
+const (
+	// NameManual — перевозчик без отслеживания: статус отправления меняет только администратор.
+	NameManual = "manual"
+	NameFake   = "fake"
+)
+
+// Tracker — служба доставки, у которой можно узнать состояние отправления.
+type Tracker interface {
+	// Track возвращает состояние отправления trackingNumber.
+	Track(ctx context.Context, trackingNumber string) (*models.TrackingInfo, error)
+}
+
+// Registry хранит подключённые службы доставки по имени перевозчика.
+type Registry struct {
+	trackers map[string]Tracker
+	// untracked — перевозчики, которых не опрашивают: их отправления
+	// завершает только администратор.
+	untracked map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		trackers:  make(map[string]Tracker),
+		untracked: make(map[string]struct{}),
+	}
+}
+
+func (r *Registry) Register(name string, tracker Tracker) {
+	r.trackers[name] = tracker
+}
+
+// RegisterUntracked подключает перевозчика без отслеживания.
+func (r *Registry) RegisterUntracked(name string) {
+	r.untracked[name] = struct{}{}
+}
+
+// Supports сообщает, подключён ли перевозчик name.
+func (r *Registry) Supports(name string) bool {
+	_, tracked := r.trackers[name]
+	_, untracked := r.untracked[name]
+	return tracked || untracked
+}
+
+// Names возвращает имена подключённых перевозчиков по алфавиту.
+func (r *Registry) Names() []string {
+	names := r.Tracked()
+	for n := range r.untracked {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tracked возвращает по алфавиту имена перевозчиков, у которых можно узнать
+// состояние отправления.
+func (r *Registry) Tracked() []string {
+	names := make([]string, 0, len(r.trackers))
+	for n := range r.trackers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Track запрашивает состояние отправления у перевозчика carrier;
+// неподключённый перевозчик — models.ErrUnknownCarrier.
+func (r *Registry) Track(ctx context.Context, carrier, trackingNumber string) (*models.TrackingInfo, error) {
+	const op = "carrier.Registry.Track"
+
+	tracker, ok := r.trackers[carrier]
+	if !ok {
+		return nil, fmt.Errorf("%s: %q: %w", op, carrier, models.ErrUnknownCarrier)
+	}
+
+	info, err := tracker.Track(ctx, trackingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, carrier, err)
+	}
+	return info, nil
+}
@@ -0,0 +1,53 @@
+package carrier
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"order_service/internal/models"
+)
+
+// FakeReturnPrefix — трек-номера с этим префиксом fake-перевозчик «возвращает»
+// отправителю вместо вручения.
+const FakeReturnPrefix = "RET"
+
+// FakeTracker — перевозчик для локального запуска и CI. Отправление считается
+// в пути TransitTime с первого запроса, затем — вручённым (или возвращённым,
+// если трек-номер начинается с FakeReturnPrefix). Состояние хранится в памяти.
+type FakeTracker struct {
+	transitTime time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	firstSeen map[string]time.Time
+}
+
+func NewFake(transitTime time.Duration) *FakeTracker {
+	return &FakeTracker{
+		transitTime: transitTime,
+		now:         time.Now,
+		firstSeen:   make(map[string]time.Time),
+	}
+}
+
+func (f *FakeTracker) Track(_ context.Context, trackingNumber string) (*models.TrackingInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	seen, ok := f.firstSeen[trackingNumber]
+	if !ok {
+		f.firstSeen[trackingNumber] = now
+		seen = now
+	}
+
+	if now.Sub(seen) < f.transitTime {
+		return &models.TrackingInfo{Status: models.TrackingInTransit, Description: "в пути"}, nil
+	}
+	if strings.HasPrefix(trackingNumber, FakeReturnPrefix) {
+		return &models.TrackingInfo{Status: models.TrackingReturned, Description: "возвращено отправителю"}, nil
+	}
+	return &models.TrackingInfo{Status: models.TrackingDelivered, Description: "вручено получателю"}, nil
+}
@@ -0,0 +1,63 @@
+package carrier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+func TestFakeTracker_DeliversAfterTransitTime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	f := NewFake(time.Hour)
+	f.now = func() time.Time { return now }
+
+	info, err := f.Track(context.Background(), "TRK-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.TrackingInTransit, info.Status)
+
+	now = now.Add(59 * time.Minute)
+	info, _ = f.Track(context.Background(), "TRK-1")
+	assert.Equal(t, models.TrackingInTransit, info.Status)
+
+	now = now.Add(time.Minute)
+	info, _ = f.Track(context.Background(), "TRK-1")
+	assert.Equal(t, models.TrackingDelivered, info.Status)
+}
+
+func TestFakeTracker_ReturnsByPrefix(t *testing.T) {
+	f := NewFake(0)
+
+	info, err := f.Track(context.Background(), FakeReturnPrefix+"-42")
+	require.NoError(t, err)
+	assert.Equal(t, models.TrackingReturned, info.Status)
+}
+
+func TestRegistry_UnknownCarrier(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NameFake, NewFake(0))
+
+	assert.True(t, r.Supports(NameFake))
+	assert.False(t, r.Supports("cdek"))
+	assert.Equal(t, []string{NameFake}, r.Names())
+
+	_, err := r.Track(context.Background(), "cdek", "TRK-1")
+	assert.ErrorIs(t, err, models.ErrUnknownCarrier)
+}
+
+func TestRegistry_UntrackedCarrier(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NameFake, NewFake(0))
+	r.RegisterUntracked(NameManual)
+
+	assert.True(t, r.Supports(NameManual))
+	assert.Equal(t, []string{NameFake, NameManual}, r.Names())
+	assert.Equal(t, []string{NameFake}, r.Tracked())
+
+	_, err := r.Track(context.Background(), NameManual, "TRK-1")
+	assert.ErrorIs(t, err, models.ErrUnknownCarrier)
+}
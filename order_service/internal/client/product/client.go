@@ -0,0 +1,177 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	productv1 "github.com/stpnv0/protos/gen/go/product"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"order_service/internal/models"
+)
+
+// Client — gRPC-клиент product_service (цены, остатки и резервы под заказы).
+type Client struct {
+	api            productv1.ProductClient
+	conn           *grpc.ClientConn
+	timeout        time.Duration
+	reservationTTL time.Duration
+	log            *slog.Logger
+}
+
+func New(addr string, timeout, reservationTTL time.Duration, log *slog.Logger) (*Client, error) {
+	const op = "product.New"
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		api:            productv1.NewProductClient(cc),
+		conn:           cc,
+		timeout:        timeout,
+		reservationTTL: reservationTTL,
+		log:            log,
+	}, nil
+}
+
+// Close закрывает gRPC-соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// PriceItems проставляет позициям текущие цены каталога: цену варианта,
+// если она задана, иначе цену модели, а также бренд и название товара
+// (с размером варианта) для промокодов и чека.
+func (c *Client) PriceItems(ctx context.Context, items []models.OrderItem) ([]models.OrderItem, error) {
+	const op = "product.Client.PriceItems"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	sneakerIDs := make([]int64, 0, len(items))
+	var variantIDs []int64
+	for _, it := range items {
+		sneakerIDs = append(sneakerIDs, int64(it.SneakerID))
+		if it.VariantID != 0 {
+			variantIDs = append(variantIDs, int64(it.VariantID))
+		}
+	}
+
+	sneakersResp, err := c.api.GetSneakersByIDs(ctx, &productv1.GetSneakersByIDsRequest{Ids: sneakerIDs})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	sneakers := make(map[int64]*productv1.Sneaker, len(sneakersResp.GetSneakers()))
+	for _, s := range sneakersResp.GetSneakers() {
+		sneakers[s.GetId()] = s
+	}
+
+	variants := make(map[int64]*productv1.SneakerVariant, len(variantIDs))
+	if len(variantIDs) > 0 {
+		variantsResp, err := c.api.GetVariantsByIDs(ctx, &productv1.GetVariantsByIDsRequest{Ids: variantIDs})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, v := range variantsResp.GetVariants() {
+			variants[v.GetId()] = v
+		}
+	}
+
+	priced := make([]models.OrderItem, len(items))
+	for i, it := range items {
+		s, ok := sneakers[int64(it.SneakerID)]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w: sneaker %d not found", op, models.ErrProductUnavailable, it.SneakerID)
+		}
+		if s.GetArchived() {
+			return nil, fmt.Errorf("%s: %w: sneaker %d is archived", op, models.ErrProductUnavailable, it.SneakerID)
+		}
+
+		price := s.GetPriceKopecks()
+		title := s.GetTitle()
+		if it.VariantID != 0 {
+			v, ok := variants[int64(it.VariantID)]
+			if !ok || v.GetSneakerId() != s.GetId() {
+				return nil, fmt.Errorf("%s: %w: variant %d of sneaker %d not found",
+					op, models.ErrProductUnavailable, it.VariantID, it.SneakerID)
+			}
+			if v.GetPriceKopecks() > 0 {
+				price = v.GetPriceKopecks()
+			}
+			if v.GetSize() != "" {
+				title += ", размер " + v.GetSize()
+			}
+		}
+
+		priced[i] = it
+		priced[i].PriceAtPurchase = int(price)
+		priced[i].Brand = s.GetBrand()
+		priced[i].Title = title
+	}
+	return priced, nil
+}
+
+func (c *Client) ReserveStock(ctx context.Context, orderID int, items []models.OrderItem) error {
+	const op = "product.Client.ReserveStock"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	stockItems := make([]*productv1.StockItem, len(items))
+	for i, it := range items {
+		stockItems[i] = &productv1.StockItem{
+			SneakerId: int64(it.SneakerID),
+			VariantId: int64(it.VariantID),
+			Quantity:  int64(it.Quantity),
+		}
+	}
+
+	_, err := c.api.ReserveStock(ctx, &productv1.ReserveStockRequest{
+		OrderId:    int64(orderID),
+		Items:      stockItems,
+		TtlSeconds: int64(c.reservationTTL.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, mapError(err))
+	}
+	return nil
+}
+
+func (c *Client) ReleaseStock(ctx context.Context, orderID int) error {
+	const op = "product.Client.ReleaseStock"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if _, err := c.api.ReleaseStock(ctx, &productv1.StockOrderRequest{OrderId: int64(orderID)}); err != nil {
+		return fmt.Errorf("%s: %w", op, mapError(err))
+	}
+	return nil
+}
+
+func (c *Client) CommitStock(ctx context.Context, orderID int) error {
+	const op = "product.Client.CommitStock"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if _, err := c.api.CommitStock(ctx, &productv1.StockOrderRequest{OrderId: int64(orderID)}); err != nil {
+		return fmt.Errorf("%s: %w", op, mapError(err))
+	}
+	return nil
+}
+
+// mapError переводит gRPC-статусы product_service в доменные ошибки.
+func mapError(err error) error {
+	if status.Code(err) == codes.FailedPrecondition {
+		return fmt.Errorf("%w: %s", models.ErrInsufficientStock, status.Convert(err).Message())
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	ssov1 "github.com/stpnv0/protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"order_service/internal/models"
+)
+
+// Client — gRPC-клиент sso_service (адресная книга пользователей).
+type Client struct {
+	api     ssov1.ProfileClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+	log     *slog.Logger
+}
+
+func New(addr string, timeout time.Duration, log *slog.Logger) (*Client, error) {
+	const op = "sso.New"
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		api:     ssov1.NewProfileClient(cc),
+		conn:    cc,
+		timeout: timeout,
+		log:     log,
+	}, nil
+}
+
+// Close закрывает gRPC-соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetAddress возвращает адрес из адресной книги пользователя.
+func (c *Client) GetAddress(ctx context.Context, userID, addressID int) (*models.ShippingAddress, error) {
+	const op = "sso.Client.GetAddress"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.GetAddress(ctx, &ssov1.GetAddressRequest{
+		UserId:    int64(userID),
+		AddressId: int64(addressID),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrAddressNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	addr := resp.GetAddress()
+	return &models.ShippingAddress{
+		RecipientName: addr.GetRecipientName(),
+		Phone:         addr.GetPhone(),
+		City:          addr.GetCity(),
+		AddressLine:   addr.GetAddressLine(),
+		PostalCode:    addr.GetPostalCode(),
+		Comment:       addr.GetComment(),
+	}, nil
+}
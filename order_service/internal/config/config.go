@@ -10,13 +10,23 @@ import (
 
 // Config содержит всю конфигурацию приложения.
 type Config struct {
-	Env      string         `yaml:"env"`
-	GRPC     GRPCConfig     `yaml:"grpc"`
-	HTTP     HTTPConfig     `yaml:"http"`
-	Postgres PostgresConfig `yaml:"postgres"`
-	Kafka    KafkaConfig    `yaml:"kafka"`
-	YooKassa YooKassaConfig `yaml:"yookassa"`
-	Shutdown ShutdownConfig `yaml:"shutdown"`
+	Env            string               `yaml:"env"`
+	GRPC           GRPCConfig           `yaml:"grpc"`
+	HTTP           HTTPConfig           `yaml:"http"`
+	Postgres       PostgresConfig       `yaml:"postgres"`
+	Kafka          KafkaConfig          `yaml:"kafka"`
+	Payment        PaymentConfig        `yaml:"payment"`
+	YooKassa       YooKassaConfig       `yaml:"yookassa"`
+	Product        ProductConfig        `yaml:"product"`
+	SSO            SSOConfig            `yaml:"sso"`
+	Delivery       DeliveryConfig       `yaml:"delivery"`
+	Receipt        ReceiptConfig        `yaml:"receipt"`
+	Orders         OrdersConfig         `yaml:"orders"`
+	Outbox         OutboxConfig         `yaml:"outbox"`
+	Expiry         ExpiryConfig         `yaml:"expiry"`
+	Reconciliation ReconciliationConfig `yaml:"reconciliation"`
+	Fulfilment     FulfilmentConfig     `yaml:"fulfilment"`
+	Shutdown       ShutdownConfig       `yaml:"shutdown"`
 }
 
 // GRPCConfig содержит настройки gRPC-сервера.
@@ -29,6 +39,9 @@ type GRPCConfig struct {
 type HTTPConfig struct {
 	Port    int           `yaml:"port"`
 	Timeout time.Duration `yaml:"timeout"`
+	// TrustedProxies — прокси, которым можно верить в X-Forwarded-For.
+	// Пустой список — адрес клиента берётся из соединения.
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 // PostgresConfig содержит параметры подключения к PostgreSQL.
@@ -47,12 +60,142 @@ type KafkaConfig struct {
 	Topic   string   `yaml:"topic"`
 }
 
+// PaymentConfig выбирает платёжный провайдер.
+type PaymentConfig struct {
+	// Provider — имя провайдера: "yookassa" или "fake".
+	Provider string            `yaml:"provider"`
+	Fake     FakePaymentConfig `yaml:"fake"`
+}
+
+// FakePaymentConfig содержит настройки fake-провайдера для локального запуска и CI.
+type FakePaymentConfig struct {
+	// PublicURL — адрес HTTP-сервера order_service, доступный браузеру.
+	PublicURL string `yaml:"public_url"`
+	ReturnURL string `yaml:"return_url"`
+	// WebhookURL — куда fake-провайдер шлёт вебхуки (по умолчанию — в этот же сервис).
+	WebhookURL string `yaml:"webhook_url"`
+	// WebhookSecret — ключ подписи вебхуков; пустой — генерируется при запуске.
+	WebhookSecret string `yaml:"webhook_secret"`
+}
+
 // YooKassaConfig содержит учётные данные платёжного шлюза.
 type YooKassaConfig struct {
+	// BaseURL — адрес API; можно направить на локальную заглушку.
+	BaseURL         string `yaml:"base_url"`
 	ShopID          string `yaml:"shop_id"`
 	SecretKey       string `yaml:"secret_key"`
 	ReturnURL       string `yaml:"return_url"`
 	NotificationURL string `yaml:"notification_url"`
+	// WebhookAllowedNetworks — подсети и адреса, с которых принимаются вебхуки.
+	WebhookAllowedNetworks []string `yaml:"webhook_allowed_networks"`
+}
+
+// defaultYooKassaNetworks — адреса, с которых ЮKassa отправляет уведомления
+// (https://yookassa.ru/developers/using-api/webhooks#ip).
+var defaultYooKassaNetworks = []string{
+	"185.71.76.0/27",
+	"185.71.77.0/27",
+	"77.75.153.0/25",
+	"77.75.156.11",
+	"77.75.156.35",
+	"77.75.154.128/25",
+	"2a02:5180::/32",
+}
+
+// ProductConfig содержит настройки клиента product_service (остатки).
+type ProductConfig struct {
+	Addr           string        `yaml:"addr"`
+	Timeout        time.Duration `yaml:"timeout"`
+	ReservationTTL time.Duration `yaml:"reservation_ttl"`
+}
+
+// SSOConfig содержит настройки клиента sso_service (адресная книга).
+type SSOConfig struct {
+	Addr    string        `yaml:"addr"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DeliveryConfig содержит стоимость доставки.
+type DeliveryConfig struct {
+	// Costs — стоимость в копейках по способам (pickup, courier, post).
+	// Способ, которого нет в списке, недоступен при оформлении.
+	Costs map[string]int `yaml:"costs"`
+}
+
+// defaultDeliveryCosts — стоимость доставки, если delivery.costs не задан.
+var defaultDeliveryCosts = map[string]int{
+	"pickup":  0,
+	"courier": 50000,
+	"post":    35000,
+}
+
+// ReceiptConfig содержит параметры чеков по 54-ФЗ, которые отправляются вместе
+// с платежами и возвратами.
+type ReceiptConfig struct {
+	// Enabled — прикладывать чеки; выключают, если фискализация идёт не через ЮKassa.
+	Enabled bool `yaml:"enabled"`
+	// VATCode — код ставки НДС по справочнику ЮKassa: 1 — без НДС, 4 — 20% и т.д.
+	VATCode int `yaml:"vat_code"`
+	// PaymentMode — признак способа расчёта: full_prepayment или full_payment.
+	PaymentMode string `yaml:"payment_mode"`
+	// TaxSystemCode — система налогообложения (1–6); 0 — не передаётся.
+	TaxSystemCode int `yaml:"tax_system_code"`
+	// DeliveryTitle — название позиции доставки в чеке.
+	DeliveryTitle string `yaml:"delivery_title"`
+}
+
+// OrdersConfig содержит настройки создания заказов.
+type OrdersConfig struct {
+	// IdempotencyTTL — сколько повтор CreateOrder с тем же Idempotency-Key
+	// возвращает исходный заказ.
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl"`
+}
+
+// OutboxConfig содержит настройки relay, публикующего события из outbox в Kafka.
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	// Retention — сколько хранить уже опубликованные сообщения.
+	Retention time.Duration `yaml:"retention"`
+	// MaxAttempts — после стольких неудачных попыток сообщение уходит в dead letter.
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff — пауза перед первым повтором, дальше удваивается до MaxRetryBackoff.
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff"`
+}
+
+// ExpiryConfig содержит настройки автоматической отмены неоплаченных заказов.
+type ExpiryConfig struct {
+	// PaymentTimeout — сколько заказ может ждать оплаты.
+	PaymentTimeout time.Duration `yaml:"payment_timeout"`
+	Interval       time.Duration `yaml:"interval"`
+	BatchSize      int           `yaml:"batch_size"`
+}
+
+// ReconciliationConfig содержит настройки сверки платежей с провайдером.
+type ReconciliationConfig struct {
+	Interval time.Duration `yaml:"interval"`
+	// PendingThreshold — через сколько после создания платежа без вебхука
+	// его статус запрашивается у провайдера.
+	PendingThreshold time.Duration `yaml:"pending_threshold"`
+	BatchSize        int           `yaml:"batch_size"`
+}
+
+// FulfilmentConfig содержит настройки отслеживания отправлений у перевозчиков.
+type FulfilmentConfig struct {
+	// Carriers — подключённые перевозчики; при отправке заказа указывается одно из этих имён.
+	// "manual" не отслеживается, "fake" — только для локального запуска и CI.
+	Carriers []string `yaml:"carriers"`
+	// PollInterval — как часто запрашивать статус; один заказ проверяется не чаще.
+	PollInterval time.Duration     `yaml:"poll_interval"`
+	BatchSize    int               `yaml:"batch_size"`
+	Fake         FakeCarrierConfig `yaml:"fake"`
+}
+
+// FakeCarrierConfig содержит настройки fake-перевозчика для локального запуска и CI.
+type FakeCarrierConfig struct {
+	// TransitTime — через сколько после первого запроса отправление считается вручённым.
+	TransitTime time.Duration `yaml:"transit_time"`
 }
 
 // ShutdownConfig управляет поведением graceful shutdown.
@@ -81,7 +224,8 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("config: read file %s: %w", configPath, err)
 	}
 
-	var cfg Config
+	// Чеки включены, если в файле не сказано обратного.
+	cfg := Config{Receipt: ReceiptConfig{Enabled: true}}
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("config: parse yaml: %w", err)
 	}
@@ -89,6 +233,102 @@ func Load() (*Config, error) {
 	if cfg.HTTP.Timeout == 0 {
 		cfg.HTTP.Timeout = 10 * time.Second
 	}
+	if cfg.Payment.Provider == "" {
+		cfg.Payment.Provider = "yookassa"
+	}
+	if cfg.Payment.Fake.PublicURL == "" {
+		cfg.Payment.Fake.PublicURL = fmt.Sprintf("http://localhost:%d", cfg.HTTP.Port)
+	}
+	if cfg.Payment.Fake.ReturnURL == "" {
+		cfg.Payment.Fake.ReturnURL = "http://localhost/"
+	}
+	if cfg.Payment.Fake.WebhookURL == "" {
+		cfg.Payment.Fake.WebhookURL = fmt.Sprintf("http://localhost:%d/webhook/fake", cfg.HTTP.Port)
+	}
+	if cfg.YooKassa.BaseURL == "" {
+		cfg.YooKassa.BaseURL = "https://api.yookassa.ru/v3"
+	}
+	if len(cfg.YooKassa.WebhookAllowedNetworks) == 0 {
+		cfg.YooKassa.WebhookAllowedNetworks = defaultYooKassaNetworks
+	}
+	if cfg.Product.Addr == "" {
+		cfg.Product.Addr = "product_service:44045"
+	}
+	if cfg.Product.Timeout == 0 {
+		cfg.Product.Timeout = 5 * time.Second
+	}
+	if cfg.Product.ReservationTTL == 0 {
+		cfg.Product.ReservationTTL = 30 * time.Minute
+	}
+	if cfg.SSO.Addr == "" {
+		cfg.SSO.Addr = "sneakers_sso:44044"
+	}
+	if cfg.SSO.Timeout == 0 {
+		cfg.SSO.Timeout = 5 * time.Second
+	}
+	if len(cfg.Delivery.Costs) == 0 {
+		cfg.Delivery.Costs = defaultDeliveryCosts
+	}
+	if cfg.Receipt.VATCode == 0 {
+		cfg.Receipt.VATCode = 1
+	}
+	if cfg.Receipt.PaymentMode == "" {
+		cfg.Receipt.PaymentMode = "full_prepayment"
+	}
+	if cfg.Receipt.DeliveryTitle == "" {
+		cfg.Receipt.DeliveryTitle = "Доставка"
+	}
+	if cfg.Orders.IdempotencyTTL == 0 {
+		cfg.Orders.IdempotencyTTL = 24 * time.Hour
+	}
+	if cfg.Outbox.PollInterval == 0 {
+		cfg.Outbox.PollInterval = time.Second
+	}
+	if cfg.Outbox.BatchSize == 0 {
+		cfg.Outbox.BatchSize = 100
+	}
+	if cfg.Outbox.Retention == 0 {
+		cfg.Outbox.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.Outbox.MaxAttempts == 0 {
+		cfg.Outbox.MaxAttempts = 20
+	}
+	if cfg.Outbox.RetryBackoff == 0 {
+		cfg.Outbox.RetryBackoff = time.Second
+	}
+	if cfg.Outbox.MaxRetryBackoff == 0 {
+		cfg.Outbox.MaxRetryBackoff = 5 * time.Minute
+	}
+	if cfg.Expiry.PaymentTimeout == 0 {
+		cfg.Expiry.PaymentTimeout = 30 * time.Minute
+	}
+	if cfg.Expiry.Interval == 0 {
+		cfg.Expiry.Interval = time.Minute
+	}
+	if cfg.Expiry.BatchSize == 0 {
+		cfg.Expiry.BatchSize = 50
+	}
+	if cfg.Reconciliation.Interval == 0 {
+		cfg.Reconciliation.Interval = 5 * time.Minute
+	}
+	if cfg.Reconciliation.PendingThreshold == 0 {
+		cfg.Reconciliation.PendingThreshold = 10 * time.Minute
+	}
+	if cfg.Reconciliation.BatchSize == 0 {
+		cfg.Reconciliation.BatchSize = 100
+	}
+	if len(cfg.Fulfilment.Carriers) == 0 {
+		cfg.Fulfilment.Carriers = []string{"manual"}
+	}
+	if cfg.Fulfilment.PollInterval == 0 {
+		cfg.Fulfilment.PollInterval = 10 * time.Minute
+	}
+	if cfg.Fulfilment.BatchSize == 0 {
+		cfg.Fulfilment.BatchSize = 50
+	}
+	if cfg.Fulfilment.Fake.TransitTime == 0 {
+		cfg.Fulfilment.Fake.TransitTime = 30 * time.Minute
+	}
 	if cfg.Shutdown.Timeout == 0 {
 		cfg.Shutdown.Timeout = 15 * time.Second
 	}
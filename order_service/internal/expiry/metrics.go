@@ -0,0 +1,17 @@
+package expiry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	expiredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_expired_total",
+		Help: "Unpaid orders cancelled after the payment timeout.",
+	})
+	expireErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_expire_errors_total",
+		Help: "Claimed unpaid orders that could not be cancelled and will be retried.",
+	})
+)
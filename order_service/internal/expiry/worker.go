@@ -0,0 +1,85 @@
+package expiry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Expirer отменяет заказы, не оплаченные вовремя.
+type Expirer interface {
+	ExpireUnpaidOrders(ctx context.Context, pendingBefore time.Time, limit int) (claimed, expired int, err error)
+}
+
+// Config — настройки планировщика.
+type Config struct {
+	// Timeout — сколько заказ может ждать оплаты.
+	Timeout   time.Duration
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Worker раз в Interval отменяет заказы, ожидающие оплаты дольше Timeout.
+// Заказы разбираются под FOR UPDATE SKIP LOCKED, поэтому Worker можно
+// запускать на всех репликах одновременно.
+type Worker struct {
+	expirer Expirer
+	cfg     Config
+	log     *slog.Logger
+}
+
+func NewWorker(expirer Expirer, cfg Config, log *slog.Logger) *Worker {
+	return &Worker{
+		expirer: expirer,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+// Run отменяет просроченные заказы раз в Interval, пока не отменён ctx.
+func (w *Worker) Run(ctx context.Context) {
+	const op = "expiry.Worker.Run"
+
+	w.log.Info("order expiry worker started",
+		slog.String("op", op),
+		slog.Duration("timeout", w.cfg.Timeout),
+		slog.Duration("interval", w.cfg.Interval),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("order expiry worker stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		w.drain(ctx)
+	}
+}
+
+// drain разбирает пачки, пока они приходят полными.
+func (w *Worker) drain(ctx context.Context) {
+	const op = "expiry.Worker.drain"
+
+	for ctx.Err() == nil {
+		claimed, expired, err := w.expirer.ExpireUnpaidOrders(ctx, time.Now().Add(-w.cfg.Timeout), w.cfg.BatchSize)
+		if err != nil {
+			w.log.Error("failed to expire unpaid orders",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+
+		expiredTotal.Add(float64(expired))
+		expireErrorsTotal.Add(float64(claimed - expired))
+
+		if claimed < w.cfg.BatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExpirer отдаёт заказы пачками по limit, как OrderService.
+type fakeExpirer struct {
+	pending int
+	err     error
+	calls   int
+	cutoffs []time.Time
+}
+
+func (e *fakeExpirer) ExpireUnpaidOrders(_ context.Context, pendingBefore time.Time, limit int) (int, int, error) {
+	e.calls++
+	e.cutoffs = append(e.cutoffs, pendingBefore)
+	if e.err != nil {
+		return 0, 0, e.err
+	}
+	n := min(e.pending, limit)
+	e.pending -= n
+	return n, n, nil
+}
+
+func newTestWorker(e Expirer, batchSize int) *Worker {
+	return NewWorker(e, Config{Timeout: 30 * time.Minute, Interval: time.Minute, BatchSize: batchSize},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWorker_DrainsFullBatches(t *testing.T) {
+	e := &fakeExpirer{pending: 5}
+
+	newTestWorker(e, 2).drain(context.Background())
+
+	assert.Equal(t, 0, e.pending)
+	assert.Equal(t, 3, e.calls, "2 + 2 + 1: stops after a partial batch")
+}
+
+func TestWorker_UsesTimeoutAsCutoff(t *testing.T) {
+	e := &fakeExpirer{}
+
+	newTestWorker(e, 10).drain(context.Background())
+
+	assert.Len(t, e.cutoffs, 1)
+	assert.WithinDuration(t, time.Now().Add(-30*time.Minute), e.cutoffs[0], time.Second)
+}
+
+func TestWorker_StopsOnError(t *testing.T) {
+	e := &fakeExpirer{pending: 10, err: errors.New("db down")}
+
+	newTestWorker(e, 2).drain(context.Background())
+
+	assert.Equal(t, 1, e.calls)
+}
@@ -0,0 +1,17 @@
+package fulfilment
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	polledTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_shipments_polled_total",
+		Help: "Shipped orders checked with their carrier.",
+	})
+	advancedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_shipments_advanced_total",
+		Help: "Shipped orders moved to DELIVERED or RETURNED by carrier tracking.",
+	})
+)
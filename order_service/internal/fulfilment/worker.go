@@ -0,0 +1,84 @@
+package fulfilment
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Poller сверяет отправленные заказы с перевозчиками.
+type Poller interface {
+	PollShipments(ctx context.Context, polledBefore time.Time, limit int) (polled, advanced int, err error)
+}
+
+// Config — настройки опроса перевозчиков.
+type Config struct {
+	// Interval — как часто опрашивать перевозчиков; один заказ проверяется
+	// не чаще раза в Interval.
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Worker раз в Interval запрашивает у перевозчиков состояние отправленных заказов.
+// Заказы разбираются под FOR UPDATE SKIP LOCKED, поэтому Worker можно
+// запускать на всех репликах одновременно.
+type Worker struct {
+	poller Poller
+	cfg    Config
+	log    *slog.Logger
+}
+
+func NewWorker(poller Poller, cfg Config, log *slog.Logger) *Worker {
+	return &Worker{
+		poller: poller,
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+// Run опрашивает перевозчиков раз в Interval, пока не отменён ctx.
+func (w *Worker) Run(ctx context.Context) {
+	const op = "fulfilment.Worker.Run"
+
+	w.log.Info("shipment tracking started",
+		slog.String("op", op),
+		slog.Duration("interval", w.cfg.Interval),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("shipment tracking stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		w.drain(ctx)
+	}
+}
+
+// drain разбирает пачки, пока они приходят полными.
+func (w *Worker) drain(ctx context.Context) {
+	const op = "fulfilment.Worker.drain"
+
+	for ctx.Err() == nil {
+		polled, advanced, err := w.poller.PollShipments(ctx, time.Now().Add(-w.cfg.Interval), w.cfg.BatchSize)
+		if err != nil {
+			w.log.Error("failed to poll shipments",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+
+		polledTotal.Add(float64(polled))
+		advancedTotal.Add(float64(advanced))
+
+		if polled < w.cfg.BatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package fulfilment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePoller отдаёт заказы пачками по limit, как OrderService.
+type fakePoller struct {
+	shipped int
+	err     error
+	calls   int
+	cutoffs []time.Time
+}
+
+func (p *fakePoller) PollShipments(_ context.Context, polledBefore time.Time, limit int) (int, int, error) {
+	p.calls++
+	p.cutoffs = append(p.cutoffs, polledBefore)
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+	n := min(p.shipped, limit)
+	p.shipped -= n
+	return n, n, nil
+}
+
+func newTestWorker(p Poller, batchSize int) *Worker {
+	return NewWorker(p, Config{Interval: 10 * time.Minute, BatchSize: batchSize},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWorker_DrainsFullBatches(t *testing.T) {
+	p := &fakePoller{shipped: 5}
+
+	newTestWorker(p, 2).drain(context.Background())
+
+	assert.Equal(t, 0, p.shipped)
+	assert.Equal(t, 3, p.calls, "2 + 2 + 1: stops after a partial batch")
+}
+
+func TestWorker_UsesIntervalAsCutoff(t *testing.T) {
+	p := &fakePoller{}
+
+	newTestWorker(p, 10).drain(context.Background())
+
+	assert.Len(t, p.cutoffs, 1)
+	assert.WithinDuration(t, time.Now().Add(-10*time.Minute), p.cutoffs[0], time.Second)
+}
+
+func TestWorker_StopsOnError(t *testing.T) {
+	p := &fakePoller{shipped: 10, err: errors.New("db down")}
+
+	newTestWorker(p, 2).drain(context.Background())
+
+	assert.Equal(t, 1, p.calls)
+}
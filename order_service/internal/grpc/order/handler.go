@@ -2,9 +2,12 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	pb "github.com/stpnv0/protos/gen/go/order"
@@ -17,22 +20,54 @@ import (
 
 //go:generate mockery --name=Service --output=mocks --outpkg=mocks --filename=mock_service.go
 type Service interface {
-	CreateOrder(ctx context.Context, userID int, items []models.OrderItem) (*models.OrderWithItems, error)
+	CreateOrder(ctx context.Context, userID int, customerEmail string, items []models.OrderItem, delivery models.DeliveryRequest, promoCode, idempotencyKey string) (*models.OrderWithItems, error)
 	GetOrder(ctx context.Context, orderID int) (*models.OrderWithItems, error)
-	GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error)
-	UpdateOrderStatus(ctx context.Context, orderID int, status string) error
+	GetUserOrders(ctx context.Context, userID, pageSize int, pageToken string) (*models.OrderPage, error)
+	ListOrders(ctx context.Context, filter models.OrderFilter, pageSize int, pageToken string) (*models.OrderPage, error)
+	UpdateOrderStatus(ctx context.Context, orderID int, status string, actorID int) error
 	ProcessWebhook(ctx context.Context, yookassaID, status string) error
+	CancelOrder(ctx context.Context, orderID int, reason string) (*models.OrderWithItems, error)
+	RefundOrder(ctx context.Context, orderID, amount int, reason, comment string, actorID int) (*models.Refund, error)
+	RetryPayment(ctx context.Context, orderID int) (*models.OrderWithItems, error)
+	MarkShipment(ctx context.Context, orderID int, status, carrier, trackingNumber string, actorID int) (*models.OrderWithItems, error)
+	ListReconciliationMismatches(ctx context.Context, filter models.MismatchFilter, pageSize int, pageToken string) (*models.MismatchPage, error)
+}
+
+// reasonCodes сопоставляет причины из proto с причинами в модели.
+var reasonCodes = map[pb.ReasonCode]string{
+	pb.ReasonCode_REASON_CODE_CUSTOMER_REQUEST: models.ReasonCustomerRequest,
+	pb.ReasonCode_REASON_CODE_OUT_OF_STOCK:     models.ReasonOutOfStock,
+	pb.ReasonCode_REASON_CODE_PAYMENT_ISSUE:    models.ReasonPaymentIssue,
+	pb.ReasonCode_REASON_CODE_FRAUD_SUSPECTED:  models.ReasonFraudSuspected,
+	pb.ReasonCode_REASON_CODE_DAMAGED_GOODS:    models.ReasonDamagedGoods,
+	pb.ReasonCode_REASON_CODE_DELIVERY_FAILED:  models.ReasonDeliveryFailed,
+	pb.ReasonCode_REASON_CODE_OTHER:            models.ReasonOther,
 }
 
 type createOrderInput struct {
-	UserID int              `validate:"required,gt=0"`
-	Items  []orderItemInput `validate:"required,min=1,dive"`
+	UserID         int              `validate:"required,gt=0"`
+	CustomerEmail  string           `validate:"omitempty,email,max=255"`
+	Items          []orderItemInput `validate:"required,min=1,dive"`
+	IdempotencyKey string           `validate:"max=128"`
+	DeliveryMethod string           `validate:"omitempty,oneof=pickup courier post"`
+	AddressID      int              `validate:"gte=0"`
+	Address        *addressInput    `validate:"omitempty"`
+	PromoCode      string           `validate:"max=32"`
+}
+
+type addressInput struct {
+	RecipientName string `validate:"required,max=255"`
+	Phone         string `validate:"required,max=32"`
+	City          string `validate:"required,max=255"`
+	AddressLine   string `validate:"required,max=500"`
+	PostalCode    string `validate:"omitempty,numeric,max=16"`
+	Comment       string `validate:"max=500"`
 }
 
 type orderItemInput struct {
-	SneakerID       int `validate:"required,gt=0"`
-	Quantity        int `validate:"required,gt=0,lte=100"`
-	PriceAtPurchase int `validate:"required,gt=0"`
+	SneakerID int `validate:"required,gt=0"`
+	VariantID int `validate:"gte=0"`
+	Quantity  int `validate:"required,gt=0,lte=100"`
 }
 
 type Handler struct {
@@ -57,14 +92,30 @@ func (h *Handler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (
 	}
 
 	input := createOrderInput{
-		UserID: userID,
-		Items:  make([]orderItemInput, len(req.GetItems())),
+		UserID:         userID,
+		CustomerEmail:  strings.TrimSpace(req.GetCustomerEmail()),
+		Items:          make([]orderItemInput, len(req.GetItems())),
+		IdempotencyKey: req.GetIdempotencyKey(),
+		DeliveryMethod: req.GetDeliveryMethod(),
+		AddressID:      int(req.GetAddressId()),
+		PromoCode:      strings.TrimSpace(req.GetPromoCode()),
+	}
+	if addr := req.GetAddress(); addr != nil {
+		input.Address = &addressInput{
+			RecipientName: addr.GetRecipientName(),
+			Phone:         addr.GetPhone(),
+			City:          addr.GetCity(),
+			AddressLine:   addr.GetAddressLine(),
+			PostalCode:    addr.GetPostalCode(),
+			Comment:       addr.GetComment(),
+		}
 	}
 	for i, item := range req.GetItems() {
+		// price_at_purchase_kopecks от клиента не читаем: цену определяет сервис
 		input.Items[i] = orderItemInput{
-			SneakerID:       int(item.GetSneakerId()),
-			Quantity:        int(item.GetQuantity()),
-			PriceAtPurchase: int(item.GetPriceAtPurchaseKopecks()),
+			SneakerID: int(item.GetSneakerId()),
+			VariantID: int(item.GetVariantId()),
+			Quantity:  int(item.GetQuantity()),
 		}
 	}
 
@@ -75,14 +126,53 @@ func (h *Handler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (
 	items := make([]models.OrderItem, len(input.Items))
 	for i, it := range input.Items {
 		items[i] = models.OrderItem{
-			SneakerID:       it.SneakerID,
-			Quantity:        it.Quantity,
-			PriceAtPurchase: it.PriceAtPurchase,
+			SneakerID: it.SneakerID,
+			VariantID: it.VariantID,
+			Quantity:  it.Quantity,
 		}
 	}
 
-	order, err := h.svc.CreateOrder(ctx, input.UserID, items)
+	delivery := models.DeliveryRequest{
+		Method:    input.DeliveryMethod,
+		AddressID: input.AddressID,
+	}
+	if a := input.Address; a != nil {
+		delivery.Address = &models.ShippingAddress{
+			RecipientName: a.RecipientName,
+			Phone:         a.Phone,
+			City:          a.City,
+			AddressLine:   a.AddressLine,
+			PostalCode:    a.PostalCode,
+			Comment:       a.Comment,
+		}
+	}
+
+	order, err := h.svc.CreateOrder(ctx, input.UserID, input.CustomerEmail, items, delivery, input.PromoCode, input.IdempotencyKey)
 	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidOrder):
+			return nil, status.Error(codes.InvalidArgument, "order items must be unique with positive quantity")
+		case errors.Is(err, models.ErrInvalidDelivery):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, models.ErrAddressNotFound):
+			return nil, status.Error(codes.InvalidArgument, "address not found")
+		case errors.Is(err, models.ErrProductUnavailable):
+			return nil, status.Error(codes.FailedPrecondition, "product is unavailable")
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient stock")
+		case errors.Is(err, models.ErrPromoCodeNotFound):
+			return nil, status.Error(codes.InvalidArgument, "promo code not found")
+		case errors.Is(err, models.ErrPromoUsageExceeded):
+			return nil, status.Error(codes.FailedPrecondition, "promo code usage limit exceeded")
+		case errors.Is(err, models.ErrPromoNotApplicable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrReceiptContactRequired):
+			return nil, status.Error(codes.InvalidArgument, "customer email or recipient phone is required for the receipt")
+		case errors.Is(err, models.ErrIdempotencyKeyReused):
+			return nil, status.Error(codes.FailedPrecondition, "idempotency key was used with a different request")
+		case errors.Is(err, models.ErrPaymentUnavailable):
+			return nil, status.Error(codes.Unavailable, "payment provider unavailable, retry with the same idempotency key")
+		}
 		h.log.Error("create order failed", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to create order")
 	}
@@ -118,19 +208,73 @@ func (h *Handler) GetUserOrders(ctx context.Context, req *pb.GetUserOrdersReques
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	orders, err := h.svc.GetUserOrders(ctx, userID)
+	page, err := h.svc.GetUserOrders(ctx, userID, int(req.GetPageSize()), req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 		return nil, status.Error(codes.Internal, "failed to get orders")
 	}
 
-	out := make([]*pb.Order, len(orders))
-	for i, o := range orders {
+	out := make([]*pb.Order, len(page.Orders))
+	for i, o := range page.Orders {
 		out[i] = orderToProto(o)
 	}
 
-	return &pb.GetUserOrdersResponse{Orders: out}, nil
+	return &pb.GetUserOrdersResponse{Orders: out, NextPageToken: page.NextPageToken}, nil
 }
 
+// ListOrders отдаёт страницу всех заказов по фильтру. Права администратора проверяет gateway.
+func (h *Handler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	filter := models.OrderFilter{
+		Status:    req.GetStatus(),
+		UserID:    int(req.GetUserId()),
+		MinAmount: int(req.GetMinAmountKopecks()),
+		MaxAmount: int(req.GetMaxAmountKopecks()),
+	}
+	if from := req.GetCreatedFrom(); from != 0 {
+		filter.CreatedFrom = time.Unix(from, 0)
+	}
+	if to := req.GetCreatedTo(); to != 0 {
+		filter.CreatedTo = time.Unix(to, 0)
+	}
+
+	page, err := h.svc.ListOrders(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidOrderFilter):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, models.ErrInvalidPageToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		h.log.Error("list orders failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to list orders")
+	}
+
+	out := make([]*pb.Order, len(page.Orders))
+	for i, o := range page.Orders {
+		out[i] = orderToProto(o)
+	}
+
+	return &pb.ListOrdersResponse{Orders: out, NextPageToken: page.NextPageToken}, nil
+}
+
+// AdminGetOrder отдаёт любой заказ с историей статусов. Права администратора проверяет gateway.
+func (h *Handler) AdminGetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+
+	order, err := h.svc.GetOrder(ctx, int(req.GetOrderId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	return &pb.GetOrderResponse{Order: orderToProto(order)}, nil
+}
+
+// UpdateOrderStatus меняет статус заказа. Если в метаданных есть user_id, запрос пришёл
+// от администратора через gateway — источник admin и его id попадут в историю статусов.
 func (h *Handler) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
 	if req.GetOrderId() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
@@ -139,13 +283,206 @@ func (h *Handler) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStat
 		return nil, status.Error(codes.InvalidArgument, "status is required")
 	}
 
-	if err := h.svc.UpdateOrderStatus(ctx, int(req.GetOrderId()), req.GetStatus()); err != nil {
+	actorID, _ := getUserIDFromContext(ctx) // 0 — внутренний вызов
+
+	if _, err := h.svc.GetOrder(ctx, int(req.GetOrderId())); err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	if err := h.svc.UpdateOrderStatus(ctx, int(req.GetOrderId()), req.GetStatus(), actorID); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidStatus):
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		case errors.Is(err, models.ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, "order status cannot be changed to "+req.GetStatus())
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient stock")
+		}
+		h.log.Error("update order status failed", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to update order status")
 	}
 
 	return &pb.UpdateOrderStatusResponse{Success: true}, nil
 }
 
+// CancelOrder отменяет неоплаченный заказ пользователя.
+func (h *Handler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	reason := models.ReasonCustomerRequest
+	if req.GetReason() != pb.ReasonCode_REASON_CODE_UNSPECIFIED {
+		var ok bool
+		if reason, ok = reasonCodes[req.GetReason()]; !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid reason")
+		}
+	}
+
+	order, err := h.svc.GetOrder(ctx, int(req.GetOrderId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if order.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	cancelled, err := h.svc.CancelOrder(ctx, order.ID, reason)
+	if err != nil {
+		if errors.Is(err, models.ErrOrderNotCancellable) {
+			return nil, status.Error(codes.FailedPrecondition, "order cannot be cancelled")
+		}
+		h.log.Error("cancel order failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to cancel order")
+	}
+
+	return &pb.CancelOrderResponse{Order: orderToProto(cancelled)}, nil
+}
+
+// RefundOrder возвращает деньги по заказу. Права администратора проверяет gateway,
+// его user_id из метаданных попадает в историю статусов.
+func (h *Handler) RefundOrder(ctx context.Context, req *pb.RefundOrderRequest) (*pb.RefundOrderResponse, error) {
+	adminID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if req.GetAmountKopecks() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount_kopecks must not be negative")
+	}
+	reason, ok := reasonCodes[req.GetReason()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	if _, err := h.svc.GetOrder(ctx, int(req.GetOrderId())); err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	refund, err := h.svc.RefundOrder(ctx, int(req.GetOrderId()), int(req.GetAmountKopecks()), reason, req.GetComment(), adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrOrderNotRefundable):
+			return nil, status.Error(codes.FailedPrecondition, "order cannot be refunded")
+		case errors.Is(err, models.ErrRefundAmountExceeded):
+			return nil, status.Error(codes.InvalidArgument, "refund amount exceeds refundable amount")
+		case errors.Is(err, models.ErrRefundRejected):
+			return nil, status.Error(codes.FailedPrecondition, "refund rejected by payment provider")
+		}
+		h.log.Error("refund order failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to refund order")
+	}
+
+	order, err := h.svc.GetOrder(ctx, refund.OrderID)
+	if err != nil {
+		h.log.Error("get refunded order failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get order")
+	}
+
+	return &pb.RefundOrderResponse{Refund: refundToProto(refund), Order: orderToProto(order)}, nil
+}
+
+// RetryPayment создаёт новый платёж по заказу пользователя в PAYMENT_FAILED.
+func (h *Handler) RetryPayment(ctx context.Context, req *pb.RetryPaymentRequest) (*pb.RetryPaymentResponse, error) {
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	order, err := h.svc.GetOrder(ctx, int(req.GetOrderId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if order.UserID != userID {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	retried, err := h.svc.RetryPayment(ctx, order.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPaymentNotRetryable):
+			return nil, status.Error(codes.FailedPrecondition, "payment cannot be retried")
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient stock")
+		case errors.Is(err, models.ErrReceiptContactRequired):
+			return nil, status.Error(codes.FailedPrecondition, "order has no customer email or phone for the receipt")
+		}
+		h.log.Error("retry payment failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to retry payment")
+	}
+
+	return &pb.RetryPaymentResponse{Order: orderToProto(retried)}, nil
+}
+
+// MarkShipment меняет статус выполнения заказа. Права администратора проверяет gateway,
+// его user_id из метаданных попадает в историю статусов.
+func (h *Handler) MarkShipment(ctx context.Context, req *pb.MarkShipmentRequest) (*pb.MarkShipmentResponse, error) {
+	adminID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	if req.GetStatus() == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+
+	if _, err := h.svc.GetOrder(ctx, int(req.GetOrderId())); err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	order, err := h.svc.MarkShipment(ctx, int(req.GetOrderId()), req.GetStatus(), req.GetCarrier(), req.GetTrackingNumber(), adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidShipment):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, models.ErrOrderNotShippable):
+			return nil, status.Error(codes.FailedPrecondition, "order status cannot be changed to "+req.GetStatus())
+		}
+		h.log.Error("mark shipment failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to update shipment")
+	}
+
+	return &pb.MarkShipmentResponse{Order: orderToProto(order)}, nil
+}
+
+// ListReconciliationMismatches отдаёт страницу расхождений сверки платежей.
+// Права администратора проверяет gateway.
+func (h *Handler) ListReconciliationMismatches(ctx context.Context, req *pb.ListReconciliationMismatchesRequest) (*pb.ListReconciliationMismatchesResponse, error) {
+	filter := models.MismatchFilter{Kind: req.GetKind(), UnresolvedOnly: req.GetUnresolvedOnly()}
+
+	page, err := h.svc.ListReconciliationMismatches(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidMismatchFilter):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, models.ErrInvalidPageToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		h.log.Error("list reconciliation mismatches failed", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to list reconciliation mismatches")
+	}
+
+	out := make([]*pb.ReconciliationMismatch, len(page.Mismatches))
+	for i, m := range page.Mismatches {
+		out[i] = mismatchToProto(m)
+	}
+
+	return &pb.ListReconciliationMismatchesResponse{Mismatches: out, NextPageToken: page.NextPageToken}, nil
+}
+
 // getUserIDFromContext извлекает user_id, установленный interceptor'ом из gRPC-метаданных.
 func getUserIDFromContext(ctx context.Context) (int, error) {
 	userIDStr := grpcserver.UserIDFromContext(ctx)
@@ -166,19 +503,114 @@ func orderToProto(o *models.OrderWithItems) *pb.Order {
 	for i, item := range o.Items {
 		items[i] = &pb.OrderItem{
 			SneakerId:              int64(item.SneakerID),
+			VariantId:              int64(item.VariantID),
 			Quantity:               int32(item.Quantity),
 			PriceAtPurchaseKopecks: int64(item.PriceAtPurchase),
 		}
 	}
 
 	return &pb.Order{
-		Id:                 int64(o.ID),
-		UserId:             int64(o.UserID),
-		Status:             o.Status,
-		TotalAmountKopecks: int64(o.TotalAmount),
-		Items:              items,
-		CreatedAt:          o.CreatedAt.Unix(),
-		UpdatedAt:          o.UpdatedAt.Unix(),
-		PaymentUrl:         o.PaymentURL,
+		Id:                    int64(o.ID),
+		UserId:                int64(o.UserID),
+		Status:                o.Status,
+		TotalAmountKopecks:    int64(o.TotalAmount),
+		Items:                 items,
+		CreatedAt:             o.CreatedAt.Unix(),
+		UpdatedAt:             o.UpdatedAt.Unix(),
+		PaymentUrl:            o.PaymentURL,
+		RefundedAmountKopecks: int64(o.RefundedAmount),
+		Timeline:              timelineToProto(o.History),
+		DeliveryMethod:        o.DeliveryMethod,
+		DeliveryCostKopecks:   int64(o.DeliveryCost),
+		ShippingAddress:       shippingAddressToProto(o.ShippingAddress),
+		Carrier:               o.Carrier,
+		TrackingNumber:        o.TrackingNumber,
+		PromoCode:             o.PromoCode,
+		DiscountKopecks:       int64(o.DiscountAmount),
+		Discounts:             discountsToProto(o.Discounts),
+	}
+}
+
+func discountsToProto(discounts []models.OrderDiscount) []*pb.OrderDiscount {
+	if len(discounts) == 0 {
+		return nil
+	}
+	out := make([]*pb.OrderDiscount, len(discounts))
+	for i, d := range discounts {
+		out[i] = &pb.OrderDiscount{
+			SneakerId:     int64(d.SneakerID),
+			VariantId:     int64(d.VariantID),
+			AmountKopecks: int64(d.Amount),
+		}
+	}
+	return out
+}
+
+func shippingAddressToProto(a *models.ShippingAddress) *pb.ShippingAddress {
+	if a == nil {
+		return nil
+	}
+	return &pb.ShippingAddress{
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		City:          a.City,
+		AddressLine:   a.AddressLine,
+		PostalCode:    a.PostalCode,
+		Comment:       a.Comment,
+	}
+}
+
+func timelineToProto(history []models.StatusHistoryEntry) []*pb.OrderStatusChange {
+	if len(history) == 0 {
+		return nil
+	}
+	timeline := make([]*pb.OrderStatusChange, len(history))
+	for i, e := range history {
+		timeline[i] = &pb.OrderStatusChange{
+			FromStatus: e.FromStatus,
+			ToStatus:   e.ToStatus,
+			Source:     e.Source,
+			Reason:     e.Reason,
+			ActorId:    int64(e.ActorID),
+			CreatedAt:  e.CreatedAt.Unix(),
+		}
+	}
+	return timeline
+}
+
+func refundToProto(r *models.Refund) *pb.Refund {
+	var reason pb.ReasonCode
+	for code, name := range reasonCodes {
+		if name == r.Reason {
+			reason = code
+			break
+		}
+	}
+
+	return &pb.Refund{
+		Id:            int64(r.ID),
+		OrderId:       int64(r.OrderID),
+		AmountKopecks: int64(r.Amount),
+		Currency:      r.Currency,
+		Status:        r.Status,
+		Reason:        reason,
+		Comment:       r.Comment,
+		CreatedAt:     r.CreatedAt.Unix(),
+	}
+}
+
+func mismatchToProto(m models.ReconciliationMismatch) *pb.ReconciliationMismatch {
+	return &pb.ReconciliationMismatch{
+		Id:                    int64(m.ID),
+		Kind:                  m.Kind,
+		PaymentId:             m.PaymentID,
+		OrderId:               int64(m.OrderID),
+		LocalStatus:           m.LocalStatus,
+		ProviderStatus:        m.ProviderStatus,
+		LocalAmountKopecks:    int64(m.LocalAmount),
+		ProviderAmountKopecks: int64(m.ProviderAmount),
+		Resolved:              m.Resolved,
+		Error:                 m.Error,
+		CreatedAt:             m.CreatedAt.Unix(),
 	}
 }
@@ -3,6 +3,7 @@ package order_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
@@ -54,7 +55,7 @@ func TestCreateOrder_Success(t *testing.T) {
 		},
 	}
 
-	svc.On("CreateOrder", mock.Anything, 42, mock.AnythingOfType("[]models.OrderItem")).
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.AnythingOfType("[]models.OrderItem"), mock.Anything, "", "").
 		Return(created, nil)
 
 	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
@@ -70,6 +71,228 @@ func TestCreateOrder_Success(t *testing.T) {
 	svc.AssertExpectations(t)
 }
 
+func TestCreateOrder_PassesVariantID(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	created := &models.OrderWithItems{
+		Order: models.Order{ID: 2, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 100},
+		Items: []models.OrderItem{{SneakerID: 10, VariantID: 7, Quantity: 1, PriceAtPurchase: 100}},
+	}
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.MatchedBy(func(items []models.OrderItem) bool {
+		return len(items) == 1 && items[0].VariantID == 7
+	}), mock.Anything, "", "").Return(created, nil)
+
+	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items: []*pb.OrderItem{
+			{SneakerId: 10, VariantId: 7, Quantity: 1, PriceAtPurchaseKopecks: 100},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.GetOrder().GetItems()[0].GetVariantId())
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_PassesIdempotencyKey(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	created := &models.OrderWithItems{
+		Order: models.Order{ID: 4, UserID: 42, Status: models.OrderStatusPendingPayment, PaymentURL: "https://pay.example.com/4"},
+		Items: []models.OrderItem{{SneakerID: 10, Quantity: 1, PriceAtPurchase: 100}},
+	}
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, "", "checkout-1").Return(created, nil)
+
+	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items:          []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+		IdempotencyKey: "checkout-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://pay.example.com/4", resp.GetOrder().GetPaymentUrl())
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_PassesDelivery(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	addr := &models.ShippingAddress{RecipientName: "Иван", Phone: "+79991234567", City: "Москва", AddressLine: "Ленина, 1"}
+	created := &models.OrderWithItems{
+		Order: models.Order{
+			ID: 4, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 50100,
+			DeliveryMethod: models.DeliveryMethodCourier, DeliveryCost: 50000, ShippingAddress: addr,
+		},
+	}
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.Anything, models.DeliveryRequest{
+		Method:  models.DeliveryMethodCourier,
+		Address: addr,
+	}, "", "").Return(created, nil)
+
+	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items:          []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+		DeliveryMethod: models.DeliveryMethodCourier,
+		Address: &pb.ShippingAddress{
+			RecipientName: "Иван", Phone: "+79991234567", City: "Москва", AddressLine: "Ленина, 1",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), resp.GetOrder().GetDeliveryCostKopecks())
+	assert.Equal(t, "Москва", resp.GetOrder().GetShippingAddress().GetCity())
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_PassesPromoCode(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	created := &models.OrderWithItems{
+		Order: models.Order{
+			ID: 5, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 900,
+			PromoCodeID: 3, PromoCode: "SALE", DiscountAmount: 100,
+			Discounts: []models.OrderDiscount{{SneakerID: 10, Amount: 100}},
+		},
+	}
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, "sale", "").Return(created, nil)
+
+	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items:     []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+		PromoCode: " sale ",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "SALE", resp.GetOrder().GetPromoCode())
+	assert.Equal(t, int64(100), resp.GetOrder().GetDiscountKopecks())
+	require.Len(t, resp.GetOrder().GetDiscounts(), 1)
+	assert.Equal(t, int64(100), resp.GetOrder().GetDiscounts()[0].GetAmountKopecks())
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_PassesCustomerEmail(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("CreateOrder", mock.Anything, 42, "user@example.com", mock.Anything, mock.Anything, "", "").
+		Return(&models.OrderWithItems{Order: models.Order{ID: 5, UserID: 42}}, nil)
+
+	_, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items:         []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+		CustomerEmail: " user@example.com ",
+	})
+
+	require.NoError(t, err)
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_ReceiptContactRequired(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("CreateOrder", mock.Anything, 42, "", mock.Anything, mock.Anything, "", "").
+		Return(nil, fmt.Errorf("create order: %w", models.ErrReceiptContactRequired))
+
+	_, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items: []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreateOrder_InvalidDeliveryMethod(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items:          []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+		DeliveryMethod: "drone",
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	svc.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_InsufficientStock(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, "", mock.Anything).
+		Return(nil, fmt.Errorf("reserve stock: %w", models.ErrInsufficientStock))
+
+	_, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items: []*pb.OrderItem{
+			{SneakerId: 10, Quantity: 5, PriceAtPurchaseKopecks: 100},
+		},
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCreateOrder_IgnoresClientPrice(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	created := &models.OrderWithItems{
+		Order: models.Order{ID: 3, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 100},
+		Items: []models.OrderItem{{SneakerID: 10, Quantity: 1, PriceAtPurchase: 100}},
+	}
+
+	svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.MatchedBy(func(items []models.OrderItem) bool {
+		return len(items) == 1 && items[0].PriceAtPurchase == 0
+	}), mock.Anything, "", "").Return(created, nil)
+
+	resp, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+		Items: []*pb.OrderItem{
+			{SneakerId: 10, Quantity: 1, PriceAtPurchaseKopecks: 1},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), resp.GetOrder().GetItems()[0].GetPriceAtPurchaseKopecks())
+	svc.AssertExpectations(t)
+}
+
+func TestCreateOrder_ServiceValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "duplicate lines", err: models.ErrInvalidOrder, code: codes.InvalidArgument},
+		{name: "archived product", err: models.ErrProductUnavailable, code: codes.FailedPrecondition},
+		{name: "courier without address", err: models.ErrInvalidDelivery, code: codes.InvalidArgument},
+		{name: "foreign address id", err: models.ErrAddressNotFound, code: codes.InvalidArgument},
+		{name: "unknown promo code", err: models.ErrPromoCodeNotFound, code: codes.InvalidArgument},
+		{name: "promo not applicable", err: models.ErrPromoNotApplicable, code: codes.FailedPrecondition},
+		{name: "promo usage exceeded", err: models.ErrPromoUsageExceeded, code: codes.FailedPrecondition},
+		{name: "idempotency key reused", err: models.ErrIdempotencyKeyReused, code: codes.FailedPrecondition},
+		{name: "payment provider down", err: models.ErrPaymentUnavailable, code: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("CreateOrder", mock.Anything, 42, mock.Anything, mock.Anything, mock.Anything, "", mock.Anything).
+				Return(nil, fmt.Errorf("create order: %w", tt.err))
+
+			_, err := h.CreateOrder(ctxWithUserID("42"), &pb.CreateOrderRequest{
+				Items: []*pb.OrderItem{{SneakerId: 10, Quantity: 1}},
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
 func TestCreateOrder_NoAuth(t *testing.T) {
 	svc := new(handlerMocks.MockService)
 	h := handler.NewHandler(svc, newTestLogger())
@@ -117,7 +340,7 @@ func TestCreateOrder_ServiceError(t *testing.T) {
 	svc := new(handlerMocks.MockService)
 	h := handler.NewHandler(svc, newTestLogger())
 
-	svc.On("CreateOrder", mock.Anything, 1, mock.Anything).
+	svc.On("CreateOrder", mock.Anything, 1, mock.Anything, mock.Anything, mock.Anything, "", mock.Anything).
 		Return(nil, errors.New("boom"))
 
 	_, err := h.CreateOrder(ctxWithUserID("1"), &pb.CreateOrderRequest{
@@ -140,11 +363,31 @@ func TestGetOrder_Success(t *testing.T) {
 	now := time.Now()
 	svc.On("GetOrder", mock.Anything, 5).Return(&models.OrderWithItems{
 		Order: models.Order{ID: 5, UserID: 1, CreatedAt: now, UpdatedAt: now},
+		History: []models.StatusHistoryEntry{
+			{ToStatus: models.OrderStatusPendingPayment, Source: models.StatusSourceUser, CreatedAt: now},
+			{
+				FromStatus: models.OrderStatusPendingPayment, ToStatus: models.OrderStatusCancelled,
+				Source: models.StatusSourceScheduler, Reason: models.ReasonPaymentTimeout, CreatedAt: now,
+			},
+			{
+				FromStatus: models.OrderStatusPaid, ToStatus: models.OrderStatusProcessing,
+				Source: models.StatusSourceAdmin, ActorID: 7, CreatedAt: now,
+			},
+		},
 	}, nil)
 
 	resp, err := h.GetOrder(ctxWithUserID("1"), &pb.GetOrderRequest{OrderId: 5})
 	require.NoError(t, err)
 	assert.Equal(t, int64(5), resp.GetOrder().GetId())
+
+	timeline := resp.GetOrder().GetTimeline()
+	require.Len(t, timeline, 3)
+	assert.Empty(t, timeline[0].GetFromStatus())
+	assert.Equal(t, models.StatusSourceScheduler, timeline[1].GetSource())
+	assert.Equal(t, models.ReasonPaymentTimeout, timeline[1].GetReason())
+	assert.Equal(t, now.Unix(), timeline[1].GetCreatedAt())
+	assert.Zero(t, timeline[1].GetActorId())
+	assert.Equal(t, int64(7), timeline[2].GetActorId())
 }
 
 func TestGetOrder_InvalidID(t *testing.T) {
@@ -179,14 +422,29 @@ func TestGetUserOrders_Success(t *testing.T) {
 	h := handler.NewHandler(svc, newTestLogger())
 
 	now := time.Now()
-	svc.On("GetUserOrders", mock.Anything, 42).Return([]*models.OrderWithItems{
-		{Order: models.Order{ID: 1, UserID: 42, CreatedAt: now, UpdatedAt: now}},
-		{Order: models.Order{ID: 2, UserID: 42, CreatedAt: now, UpdatedAt: now}},
+	svc.On("GetUserOrders", mock.Anything, 42, 10, "").Return(&models.OrderPage{
+		Orders: []*models.OrderWithItems{
+			{Order: models.Order{ID: 2, UserID: 42, CreatedAt: now, UpdatedAt: now}},
+			{Order: models.Order{ID: 1, UserID: 42, CreatedAt: now, UpdatedAt: now}},
+		},
+		NextPageToken: "next",
 	}, nil)
 
-	resp, err := h.GetUserOrders(ctxWithUserID("42"), &pb.GetUserOrdersRequest{UserId: 42})
+	resp, err := h.GetUserOrders(ctxWithUserID("42"), &pb.GetUserOrdersRequest{UserId: 42, PageSize: 10})
 	require.NoError(t, err)
 	assert.Len(t, resp.GetOrders(), 2)
+	assert.Equal(t, "next", resp.GetNextPageToken())
+}
+
+func TestGetUserOrders_InvalidPageToken(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("GetUserOrders", mock.Anything, 42, 0, "bad").Return(nil, models.ErrInvalidPageToken)
+
+	_, err := h.GetUserOrders(ctxWithUserID("42"), &pb.GetUserOrdersRequest{UserId: 42, PageToken: "bad"})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 // ---------------------------------------------------------------------------
@@ -197,7 +455,8 @@ func TestUpdateOrderStatus_Success(t *testing.T) {
 	svc := new(handlerMocks.MockService)
 	h := handler.NewHandler(svc, newTestLogger())
 
-	svc.On("UpdateOrderStatus", mock.Anything, 1, "PAID").Return(nil)
+	svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+	svc.On("UpdateOrderStatus", mock.Anything, 1, "PAID", 0).Return(nil)
 
 	resp, err := h.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{
 		OrderId: 1,
@@ -208,6 +467,46 @@ func TestUpdateOrderStatus_Success(t *testing.T) {
 	assert.True(t, resp.GetSuccess())
 }
 
+func TestUpdateOrderStatus_AdminActor(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+	svc.On("UpdateOrderStatus", mock.Anything, 1, "CANCELLED", 7).Return(nil)
+
+	_, err := h.UpdateOrderStatus(ctxWithUserID("7"), &pb.UpdateOrderStatusRequest{OrderId: 1, Status: "CANCELLED"})
+
+	require.NoError(t, err)
+	svc.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"invalid status", models.ErrInvalidStatus, codes.InvalidArgument},
+		{"invalid transition", models.ErrInvalidTransition, codes.FailedPrecondition},
+		{"insufficient stock", models.ErrInsufficientStock, codes.FailedPrecondition},
+		{"db failure", errors.New("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+			svc.On("UpdateOrderStatus", mock.Anything, 1, "CANCELLED", mock.Anything).Return(fmt.Errorf("wrap: %w", tt.err))
+
+			_, err := h.UpdateOrderStatus(context.Background(), &pb.UpdateOrderStatusRequest{OrderId: 1, Status: "CANCELLED"})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
 func TestUpdateOrderStatus_InvalidID(t *testing.T) {
 	svc := new(handlerMocks.MockService)
 	h := handler.NewHandler(svc, newTestLogger())
@@ -233,3 +532,359 @@ func TestUpdateOrderStatus_EmptyStatus(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
+
+// ---------------------------------------------------------------------------
+// ListOrders / AdminGetOrder
+// ---------------------------------------------------------------------------
+
+func TestListOrders_MapsFilter(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.OrderFilter{
+		Status: models.OrderStatusPaid, UserID: 42, CreatedFrom: from, MinAmount: 100, MaxAmount: 5000,
+	}
+	page := &models.OrderPage{
+		Orders:        []*models.OrderWithItems{{Order: models.Order{ID: 3, UserID: 42, Status: models.OrderStatusPaid}}},
+		NextPageToken: "next",
+	}
+	svc.On("ListOrders", mock.Anything, mock.MatchedBy(func(f models.OrderFilter) bool {
+		return f.Status == filter.Status && f.UserID == filter.UserID && f.CreatedFrom.Equal(from) &&
+			f.CreatedTo.IsZero() && f.MinAmount == filter.MinAmount && f.MaxAmount == filter.MaxAmount
+	}), 10, "").Return(page, nil)
+
+	resp, err := h.ListOrders(context.Background(), &pb.ListOrdersRequest{
+		Status:           models.OrderStatusPaid,
+		UserId:           42,
+		CreatedFrom:      from.Unix(),
+		MinAmountKopecks: 100,
+		MaxAmountKopecks: 5000,
+		PageSize:         10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetOrders(), 1)
+	assert.Equal(t, "next", resp.GetNextPageToken())
+}
+
+func TestListOrders_InvalidFilter(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("ListOrders", mock.Anything, mock.Anything, 0, "").
+		Return(nil, fmt.Errorf("wrap: %w", models.ErrInvalidOrderFilter))
+
+	_, err := h.ListOrders(context.Background(), &pb.ListOrdersRequest{Status: "BOGUS"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdminGetOrder_IgnoresOwner(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 99}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+
+	resp, err := h.AdminGetOrder(ctxWithUserID("1"), &pb.GetOrderRequest{OrderId: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), resp.GetOrder().GetUserId())
+}
+
+// ---------------------------------------------------------------------------
+// CancelOrder
+// ---------------------------------------------------------------------------
+
+func TestCancelOrder_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 42, Status: models.OrderStatusPendingPayment}}
+	cancelled := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 42, Status: models.OrderStatusCancelled}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+	svc.On("CancelOrder", mock.Anything, 1, models.ReasonCustomerRequest).Return(cancelled, nil)
+
+	resp, err := h.CancelOrder(ctxWithUserID("42"), &pb.CancelOrderRequest{OrderId: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, resp.GetOrder().GetStatus())
+	svc.AssertExpectations(t)
+}
+
+func TestCancelOrder_PermissionDenied(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 99, Status: models.OrderStatusPendingPayment}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+
+	_, err := h.CancelOrder(ctxWithUserID("42"), &pb.CancelOrderRequest{OrderId: 1})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	svc.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelOrder_PaidOrder(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 42, Status: models.OrderStatusPaid}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+	svc.On("CancelOrder", mock.Anything, 1, models.ReasonCustomerRequest).
+		Return(nil, fmt.Errorf("wrap: %w", models.ErrOrderNotCancellable))
+
+	_, err := h.CancelOrder(ctxWithUserID("42"), &pb.CancelOrderRequest{
+		OrderId: 1,
+		Reason:  pb.ReasonCode_REASON_CODE_CUSTOMER_REQUEST,
+	})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+// ---------------------------------------------------------------------------
+// RefundOrder
+// ---------------------------------------------------------------------------
+
+func TestRefundOrder_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	refund := &models.Refund{
+		ID: 5, OrderID: 1, Amount: 300, Currency: "RUB",
+		Status: models.RefundStatusSucceeded, Reason: models.ReasonDamagedGoods, Comment: "torn lace",
+	}
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000, RefundedAmount: 300}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+	svc.On("RefundOrder", mock.Anything, 1, 300, models.ReasonDamagedGoods, "torn lace", 7).Return(refund, nil)
+
+	resp, err := h.RefundOrder(ctxWithUserID("7"), &pb.RefundOrderRequest{
+		OrderId:       1,
+		AmountKopecks: 300,
+		Reason:        pb.ReasonCode_REASON_CODE_DAMAGED_GOODS,
+		Comment:       "torn lace",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), resp.GetRefund().GetAmountKopecks())
+	assert.Equal(t, pb.ReasonCode_REASON_CODE_DAMAGED_GOODS, resp.GetRefund().GetReason())
+	assert.Equal(t, int64(300), resp.GetOrder().GetRefundedAmountKopecks())
+	svc.AssertExpectations(t)
+}
+
+func TestRefundOrder_ReasonRequired(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.RefundOrder(ctxWithUserID("7"), &pb.RefundOrderRequest{OrderId: 1})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRefundOrder_Unauthenticated(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.RefundOrder(context.Background(), &pb.RefundOrderRequest{
+		OrderId: 1,
+		Reason:  pb.ReasonCode_REASON_CODE_OTHER,
+	})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	svc.AssertNotCalled(t, "RefundOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not refundable", models.ErrOrderNotRefundable, codes.FailedPrecondition},
+		{"amount exceeded", models.ErrRefundAmountExceeded, codes.InvalidArgument},
+		{"rejected by provider", models.ErrRefundRejected, codes.FailedPrecondition},
+		{"provider failure", errors.New("provider unavailable"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+			svc.On("RefundOrder", mock.Anything, 1, 0, models.ReasonOther, "", 7).Return(nil, fmt.Errorf("wrap: %w", tt.err))
+
+			_, err := h.RefundOrder(ctxWithUserID("7"), &pb.RefundOrderRequest{
+				OrderId: 1,
+				Reason:  pb.ReasonCode_REASON_CODE_OTHER,
+			})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RetryPayment
+// ---------------------------------------------------------------------------
+
+func TestRetryPayment_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 42, Status: models.OrderStatusPaymentFailed}}
+	retried := &models.OrderWithItems{Order: models.Order{
+		ID: 1, UserID: 42, Status: models.OrderStatusPendingPayment, PaymentURL: "https://pay/new",
+	}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+	svc.On("RetryPayment", mock.Anything, 1).Return(retried, nil)
+
+	resp, err := h.RetryPayment(ctxWithUserID("42"), &pb.RetryPaymentRequest{OrderId: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPendingPayment, resp.GetOrder().GetStatus())
+	assert.Equal(t, "https://pay/new", resp.GetOrder().GetPaymentUrl())
+}
+
+func TestRetryPayment_PermissionDenied(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 99, Status: models.OrderStatusPaymentFailed}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+
+	_, err := h.RetryPayment(ctxWithUserID("42"), &pb.RetryPaymentRequest{OrderId: 1})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	svc.AssertNotCalled(t, "RetryPayment", mock.Anything, mock.Anything)
+}
+
+func TestRetryPayment_NotRetryable(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, UserID: 42, Status: models.OrderStatusPaid}}
+	svc.On("GetOrder", mock.Anything, 1).Return(order, nil)
+	svc.On("RetryPayment", mock.Anything, 1).Return(nil, fmt.Errorf("wrap: %w", models.ErrPaymentNotRetryable))
+
+	_, err := h.RetryPayment(ctxWithUserID("42"), &pb.RetryPaymentRequest{OrderId: 1})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+// ---------------------------------------------------------------------------
+// MarkShipment
+// ---------------------------------------------------------------------------
+
+func TestMarkShipment_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	shipped := &models.OrderWithItems{Order: models.Order{
+		ID: 1, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "TRK-1",
+	}}
+	svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+	svc.On("MarkShipment", mock.Anything, 1, models.OrderStatusShipped, "fake", "TRK-1", 7).Return(shipped, nil)
+
+	resp, err := h.MarkShipment(ctxWithUserID("7"), &pb.MarkShipmentRequest{
+		OrderId:        1,
+		Status:         models.OrderStatusShipped,
+		Carrier:        "fake",
+		TrackingNumber: "TRK-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusShipped, resp.GetOrder().GetStatus())
+	assert.Equal(t, "fake", resp.GetOrder().GetCarrier())
+	assert.Equal(t, "TRK-1", resp.GetOrder().GetTrackingNumber())
+}
+
+func TestMarkShipment_OrderNotFound(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("GetOrder", mock.Anything, 1).Return(nil, errors.New("order not found"))
+
+	_, err := h.MarkShipment(ctxWithUserID("7"), &pb.MarkShipmentRequest{OrderId: 1, Status: models.OrderStatusProcessing})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	svc.AssertNotCalled(t, "MarkShipment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMarkShipment_Unauthenticated(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.MarkShipment(context.Background(), &pb.MarkShipmentRequest{OrderId: 1, Status: models.OrderStatusProcessing})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	svc.AssertNotCalled(t, "MarkShipment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMarkShipment_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"invalid shipment", models.ErrInvalidShipment, codes.InvalidArgument},
+		{"not shippable", models.ErrOrderNotShippable, codes.FailedPrecondition},
+		{"db failure", errors.New("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("GetOrder", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+			svc.On("MarkShipment", mock.Anything, 1, models.OrderStatusDelivered, "", "", 7).Return(nil, fmt.Errorf("wrap: %w", tt.err))
+
+			_, err := h.MarkShipment(ctxWithUserID("7"), &pb.MarkShipmentRequest{OrderId: 1, Status: models.OrderStatusDelivered})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ListReconciliationMismatches
+// ---------------------------------------------------------------------------
+
+func TestListReconciliationMismatches_MapsFilter(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	filter := models.MismatchFilter{Kind: models.MismatchAmount, UnresolvedOnly: true}
+	page := &models.MismatchPage{
+		Mismatches: []models.ReconciliationMismatch{{
+			ID: 5, Kind: models.MismatchAmount, PaymentID: "yoo-1", OrderID: 3,
+			LocalStatus: models.PaymentStatusPending, ProviderStatus: models.PaymentStatusSucceeded,
+			LocalAmount: 1000, ProviderAmount: 900,
+		}},
+		NextPageToken: "next",
+	}
+	svc.On("ListReconciliationMismatches", mock.Anything, filter, 10, "").Return(page, nil)
+
+	resp, err := h.ListReconciliationMismatches(context.Background(), &pb.ListReconciliationMismatchesRequest{
+		Kind: models.MismatchAmount, UnresolvedOnly: true, PageSize: 10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetMismatches(), 1)
+	assert.Equal(t, int64(900), resp.GetMismatches()[0].GetProviderAmountKopecks())
+	assert.Equal(t, "next", resp.GetNextPageToken())
+}
+
+func TestListReconciliationMismatches_InvalidFilter(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("ListReconciliationMismatches", mock.Anything, mock.Anything, 0, "").
+		Return(nil, fmt.Errorf("wrap: %w", models.ErrInvalidMismatchFilter))
+
+	_, err := h.ListReconciliationMismatches(context.Background(), &pb.ListReconciliationMismatchesRequest{Kind: "bogus"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -38,9 +38,83 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// CancelOrder provides a mock function for the type MockService
+func (_mock *MockService) CancelOrder(ctx context.Context, orderID int, reason string) (*models.OrderWithItems, error) {
+	ret := _mock.Called(ctx, orderID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOrder")
+	}
+
+	var r0 *models.OrderWithItems
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) (*models.OrderWithItems, error)); ok {
+		return returnFunc(ctx, orderID, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) *models.OrderWithItems); ok {
+		r0 = returnFunc(ctx, orderID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderWithItems)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = returnFunc(ctx, orderID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CancelOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelOrder'
+type MockService_CancelOrder_Call struct {
+	*mock.Call
+}
+
+// CancelOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int
+//   - reason string
+func (_e *MockService_Expecter) CancelOrder(ctx interface{}, orderID interface{}, reason interface{}) *MockService_CancelOrder_Call {
+	return &MockService_CancelOrder_Call{Call: _e.mock.On("CancelOrder", ctx, orderID, reason)}
+}
+
+func (_c *MockService_CancelOrder_Call) Run(run func(ctx context.Context, orderID int, reason string)) *MockService_CancelOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CancelOrder_Call) Return(orderWithItems *models.OrderWithItems, err error) *MockService_CancelOrder_Call {
+	_c.Call.Return(orderWithItems, err)
+	return _c
+}
+
+func (_c *MockService_CancelOrder_Call) RunAndReturn(run func(ctx context.Context, orderID int, reason string) (*models.OrderWithItems, error)) *MockService_CancelOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOrder provides a mock function for the type MockService
-func (_mock *MockService) CreateOrder(ctx context.Context, userID int, items []models.OrderItem) (*models.OrderWithItems, error) {
-	ret := _mock.Called(ctx, userID, items)
+func (_mock *MockService) CreateOrder(ctx context.Context, userID int, customerEmail string, items []models.OrderItem, delivery models.DeliveryRequest, promoCode string, idempotencyKey string) (*models.OrderWithItems, error) {
+	ret := _mock.Called(ctx, userID, customerEmail, items, delivery, promoCode, idempotencyKey)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateOrder")
@@ -48,18 +122,18 @@ func (_mock *MockService) CreateOrder(ctx context.Context, userID int, items []m
 
 	var r0 *models.OrderWithItems
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []models.OrderItem) (*models.OrderWithItems, error)); ok {
-		return returnFunc(ctx, userID, items)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, []models.OrderItem, models.DeliveryRequest, string, string) (*models.OrderWithItems, error)); ok {
+		return returnFunc(ctx, userID, customerEmail, items, delivery, promoCode, idempotencyKey)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []models.OrderItem) *models.OrderWithItems); ok {
-		r0 = returnFunc(ctx, userID, items)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, []models.OrderItem, models.DeliveryRequest, string, string) *models.OrderWithItems); ok {
+		r0 = returnFunc(ctx, userID, customerEmail, items, delivery, promoCode, idempotencyKey)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.OrderWithItems)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, []models.OrderItem) error); ok {
-		r1 = returnFunc(ctx, userID, items)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, []models.OrderItem, models.DeliveryRequest, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, customerEmail, items, delivery, promoCode, idempotencyKey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,12 +148,16 @@ type MockService_CreateOrder_Call struct {
 // CreateOrder is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int
+//   - customerEmail string
 //   - items []models.OrderItem
-func (_e *MockService_Expecter) CreateOrder(ctx interface{}, userID interface{}, items interface{}) *MockService_CreateOrder_Call {
-	return &MockService_CreateOrder_Call{Call: _e.mock.On("CreateOrder", ctx, userID, items)}
+//   - delivery models.DeliveryRequest
+//   - promoCode string
+//   - idempotencyKey string
+func (_e *MockService_Expecter) CreateOrder(ctx interface{}, userID interface{}, customerEmail interface{}, items interface{}, delivery interface{}, promoCode interface{}, idempotencyKey interface{}) *MockService_CreateOrder_Call {
+	return &MockService_CreateOrder_Call{Call: _e.mock.On("CreateOrder", ctx, userID, customerEmail, items, delivery, promoCode, idempotencyKey)}
 }
 
-func (_c *MockService_CreateOrder_Call) Run(run func(ctx context.Context, userID int, items []models.OrderItem)) *MockService_CreateOrder_Call {
+func (_c *MockService_CreateOrder_Call) Run(run func(ctx context.Context, userID int, customerEmail string, items []models.OrderItem, delivery models.DeliveryRequest, promoCode string, idempotencyKey string)) *MockService_CreateOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -89,14 +167,34 @@ func (_c *MockService_CreateOrder_Call) Run(run func(ctx context.Context, userID
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
-		var arg2 []models.OrderItem
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]models.OrderItem)
+			arg2 = args[2].(string)
+		}
+		var arg3 []models.OrderItem
+		if args[3] != nil {
+			arg3 = args[3].([]models.OrderItem)
+		}
+		var arg4 models.DeliveryRequest
+		if args[4] != nil {
+			arg4 = args[4].(models.DeliveryRequest)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -107,7 +205,7 @@ func (_c *MockService_CreateOrder_Call) Return(orderWithItems *models.OrderWithI
 	return _c
 }
 
-func (_c *MockService_CreateOrder_Call) RunAndReturn(run func(ctx context.Context, userID int, items []models.OrderItem) (*models.OrderWithItems, error)) *MockService_CreateOrder_Call {
+func (_c *MockService_CreateOrder_Call) RunAndReturn(run func(ctx context.Context, userID int, customerEmail string, items []models.OrderItem, delivery models.DeliveryRequest, promoCode string, idempotencyKey string) (*models.OrderWithItems, error)) *MockService_CreateOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -181,27 +279,27 @@ func (_c *MockService_GetOrder_Call) RunAndReturn(run func(ctx context.Context,
 }
 
 // GetUserOrders provides a mock function for the type MockService
-func (_mock *MockService) GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error) {
-	ret := _mock.Called(ctx, userID)
+func (_mock *MockService) GetUserOrders(ctx context.Context, userID int, pageSize int, pageToken string) (*models.OrderPage, error) {
+	ret := _mock.Called(ctx, userID, pageSize, pageToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserOrders")
 	}
 
-	var r0 []*models.OrderWithItems
+	var r0 *models.OrderPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*models.OrderWithItems, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) (*models.OrderPage, error)); ok {
+		return returnFunc(ctx, userID, pageSize, pageToken)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*models.OrderWithItems); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) *models.OrderPage); ok {
+		r0 = returnFunc(ctx, userID, pageSize, pageToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.OrderWithItems)
+			r0 = ret.Get(0).(*models.OrderPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = returnFunc(ctx, userID, pageSize, pageToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -216,11 +314,13 @@ type MockService_GetUserOrders_Call struct {
 // GetUserOrders is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int
-func (_e *MockService_Expecter) GetUserOrders(ctx interface{}, userID interface{}) *MockService_GetUserOrders_Call {
-	return &MockService_GetUserOrders_Call{Call: _e.mock.On("GetUserOrders", ctx, userID)}
+//   - pageSize int
+//   - pageToken string
+func (_e *MockService_Expecter) GetUserOrders(ctx interface{}, userID interface{}, pageSize interface{}, pageToken interface{}) *MockService_GetUserOrders_Call {
+	return &MockService_GetUserOrders_Call{Call: _e.mock.On("GetUserOrders", ctx, userID, pageSize, pageToken)}
 }
 
-func (_c *MockService_GetUserOrders_Call) Run(run func(ctx context.Context, userID int)) *MockService_GetUserOrders_Call {
+func (_c *MockService_GetUserOrders_Call) Run(run func(ctx context.Context, userID int, pageSize int, pageToken string)) *MockService_GetUserOrders_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -230,55 +330,239 @@ func (_c *MockService_GetUserOrders_Call) Run(run func(ctx context.Context, user
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetUserOrders_Call) Return(orderWithItemss []*models.OrderWithItems, err error) *MockService_GetUserOrders_Call {
-	_c.Call.Return(orderWithItemss, err)
+func (_c *MockService_GetUserOrders_Call) Return(orderPage *models.OrderPage, err error) *MockService_GetUserOrders_Call {
+	_c.Call.Return(orderPage, err)
 	return _c
 }
 
-func (_c *MockService_GetUserOrders_Call) RunAndReturn(run func(ctx context.Context, userID int) ([]*models.OrderWithItems, error)) *MockService_GetUserOrders_Call {
+func (_c *MockService_GetUserOrders_Call) RunAndReturn(run func(ctx context.Context, userID int, pageSize int, pageToken string) (*models.OrderPage, error)) *MockService_GetUserOrders_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateOrderStatus provides a mock function for the type MockService
-func (_mock *MockService) UpdateOrderStatus(ctx context.Context, orderID int, status string) error {
-	ret := _mock.Called(ctx, orderID, status)
+// ListOrders provides a mock function for the type MockService
+func (_mock *MockService) ListOrders(ctx context.Context, filter models.OrderFilter, pageSize int, pageToken string) (*models.OrderPage, error) {
+	ret := _mock.Called(ctx, filter, pageSize, pageToken)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateOrderStatus")
+		panic("no return value specified for ListOrders")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
-		r0 = returnFunc(ctx, orderID, status)
+	var r0 *models.OrderPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.OrderFilter, int, string) (*models.OrderPage, error)); ok {
+		return returnFunc(ctx, filter, pageSize, pageToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.OrderFilter, int, string) *models.OrderPage); ok {
+		r0 = returnFunc(ctx, filter, pageSize, pageToken)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderPage)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.OrderFilter, int, string) error); ok {
+		r1 = returnFunc(ctx, filter, pageSize, pageToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
-type MockService_UpdateOrderStatus_Call struct {
+// MockService_ListOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrders'
+type MockService_ListOrders_Call struct {
 	*mock.Call
 }
 
-// UpdateOrderStatus is a helper method to define mock.On call
+// ListOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.OrderFilter
+//   - pageSize int
+//   - pageToken string
+func (_e *MockService_Expecter) ListOrders(ctx interface{}, filter interface{}, pageSize interface{}, pageToken interface{}) *MockService_ListOrders_Call {
+	return &MockService_ListOrders_Call{Call: _e.mock.On("ListOrders", ctx, filter, pageSize, pageToken)}
+}
+
+func (_c *MockService_ListOrders_Call) Run(run func(ctx context.Context, filter models.OrderFilter, pageSize int, pageToken string)) *MockService_ListOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.OrderFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.OrderFilter)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListOrders_Call) Return(orderPage *models.OrderPage, err error) *MockService_ListOrders_Call {
+	_c.Call.Return(orderPage, err)
+	return _c
+}
+
+func (_c *MockService_ListOrders_Call) RunAndReturn(run func(ctx context.Context, filter models.OrderFilter, pageSize int, pageToken string) (*models.OrderPage, error)) *MockService_ListOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReconciliationMismatches provides a mock function for the type MockService
+func (_mock *MockService) ListReconciliationMismatches(ctx context.Context, filter models.MismatchFilter, pageSize int, pageToken string) (*models.MismatchPage, error) {
+	ret := _mock.Called(ctx, filter, pageSize, pageToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReconciliationMismatches")
+	}
+
+	var r0 *models.MismatchPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.MismatchFilter, int, string) (*models.MismatchPage, error)); ok {
+		return returnFunc(ctx, filter, pageSize, pageToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.MismatchFilter, int, string) *models.MismatchPage); ok {
+		r0 = returnFunc(ctx, filter, pageSize, pageToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MismatchPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.MismatchFilter, int, string) error); ok {
+		r1 = returnFunc(ctx, filter, pageSize, pageToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListReconciliationMismatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReconciliationMismatches'
+type MockService_ListReconciliationMismatches_Call struct {
+	*mock.Call
+}
+
+// ListReconciliationMismatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.MismatchFilter
+//   - pageSize int
+//   - pageToken string
+func (_e *MockService_Expecter) ListReconciliationMismatches(ctx interface{}, filter interface{}, pageSize interface{}, pageToken interface{}) *MockService_ListReconciliationMismatches_Call {
+	return &MockService_ListReconciliationMismatches_Call{Call: _e.mock.On("ListReconciliationMismatches", ctx, filter, pageSize, pageToken)}
+}
+
+func (_c *MockService_ListReconciliationMismatches_Call) Run(run func(ctx context.Context, filter models.MismatchFilter, pageSize int, pageToken string)) *MockService_ListReconciliationMismatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.MismatchFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.MismatchFilter)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListReconciliationMismatches_Call) Return(mismatchPage *models.MismatchPage, err error) *MockService_ListReconciliationMismatches_Call {
+	_c.Call.Return(mismatchPage, err)
+	return _c
+}
+
+func (_c *MockService_ListReconciliationMismatches_Call) RunAndReturn(run func(ctx context.Context, filter models.MismatchFilter, pageSize int, pageToken string) (*models.MismatchPage, error)) *MockService_ListReconciliationMismatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkShipment provides a mock function for the type MockService
+func (_mock *MockService) MarkShipment(ctx context.Context, orderID int, status string, carrier string, trackingNumber string, actorID int) (*models.OrderWithItems, error) {
+	ret := _mock.Called(ctx, orderID, status, carrier, trackingNumber, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkShipment")
+	}
+
+	var r0 *models.OrderWithItems
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, string, int) (*models.OrderWithItems, error)); ok {
+		return returnFunc(ctx, orderID, status, carrier, trackingNumber, actorID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, string, int) *models.OrderWithItems); ok {
+		r0 = returnFunc(ctx, orderID, status, carrier, trackingNumber, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderWithItems)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, string, int) error); ok {
+		r1 = returnFunc(ctx, orderID, status, carrier, trackingNumber, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_MarkShipment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkShipment'
+type MockService_MarkShipment_Call struct {
+	*mock.Call
+}
+
+// MarkShipment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - orderID int
 //   - status string
-func (_e *MockService_Expecter) UpdateOrderStatus(ctx interface{}, orderID interface{}, status interface{}) *MockService_UpdateOrderStatus_Call {
-	return &MockService_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, orderID, status)}
+//   - carrier string
+//   - trackingNumber string
+//   - actorID int
+func (_e *MockService_Expecter) MarkShipment(ctx interface{}, orderID interface{}, status interface{}, carrier interface{}, trackingNumber interface{}, actorID interface{}) *MockService_MarkShipment_Call {
+	return &MockService_MarkShipment_Call{Call: _e.mock.On("MarkShipment", ctx, orderID, status, carrier, trackingNumber, actorID)}
 }
 
-func (_c *MockService_UpdateOrderStatus_Call) Run(run func(ctx context.Context, orderID int, status string)) *MockService_UpdateOrderStatus_Call {
+func (_c *MockService_MarkShipment_Call) Run(run func(ctx context.Context, orderID int, status string, carrier string, trackingNumber string, actorID int)) *MockService_MarkShipment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -292,21 +576,36 @@ func (_c *MockService_UpdateOrderStatus_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateOrderStatus_Call) Return(err error) *MockService_UpdateOrderStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockService_MarkShipment_Call) Return(orderWithItems *models.OrderWithItems, err error) *MockService_MarkShipment_Call {
+	_c.Call.Return(orderWithItems, err)
 	return _c
 }
 
-func (_c *MockService_UpdateOrderStatus_Call) RunAndReturn(run func(ctx context.Context, orderID int, status string) error) *MockService_UpdateOrderStatus_Call {
+func (_c *MockService_MarkShipment_Call) RunAndReturn(run func(ctx context.Context, orderID int, status string, carrier string, trackingNumber string, actorID int) (*models.OrderWithItems, error)) *MockService_MarkShipment_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -327,3 +626,278 @@ func (_mock *MockService) ProcessWebhook(ctx context.Context, yookassaID string,
 	}
 	return r0
 }
+
+// MockService_ProcessWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessWebhook'
+type MockService_ProcessWebhook_Call struct {
+	*mock.Call
+}
+
+// ProcessWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - yookassaID string
+//   - status string
+func (_e *MockService_Expecter) ProcessWebhook(ctx interface{}, yookassaID interface{}, status interface{}) *MockService_ProcessWebhook_Call {
+	return &MockService_ProcessWebhook_Call{Call: _e.mock.On("ProcessWebhook", ctx, yookassaID, status)}
+}
+
+func (_c *MockService_ProcessWebhook_Call) Run(run func(ctx context.Context, yookassaID string, status string)) *MockService_ProcessWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ProcessWebhook_Call) Return(err error) *MockService_ProcessWebhook_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ProcessWebhook_Call) RunAndReturn(run func(ctx context.Context, yookassaID string, status string) error) *MockService_ProcessWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefundOrder provides a mock function for the type MockService
+func (_mock *MockService) RefundOrder(ctx context.Context, orderID int, amount int, reason string, comment string, actorID int) (*models.Refund, error) {
+	ret := _mock.Called(ctx, orderID, amount, reason, comment, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundOrder")
+	}
+
+	var r0 *models.Refund
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string, string, int) (*models.Refund, error)); ok {
+		return returnFunc(ctx, orderID, amount, reason, comment, actorID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string, string, int) *models.Refund); ok {
+		r0 = returnFunc(ctx, orderID, amount, reason, comment, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Refund)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, string, string, int) error); ok {
+		r1 = returnFunc(ctx, orderID, amount, reason, comment, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RefundOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefundOrder'
+type MockService_RefundOrder_Call struct {
+	*mock.Call
+}
+
+// RefundOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int
+//   - amount int
+//   - reason string
+//   - comment string
+//   - actorID int
+func (_e *MockService_Expecter) RefundOrder(ctx interface{}, orderID interface{}, amount interface{}, reason interface{}, comment interface{}, actorID interface{}) *MockService_RefundOrder_Call {
+	return &MockService_RefundOrder_Call{Call: _e.mock.On("RefundOrder", ctx, orderID, amount, reason, comment, actorID)}
+}
+
+func (_c *MockService_RefundOrder_Call) Run(run func(ctx context.Context, orderID int, amount int, reason string, comment string, actorID int)) *MockService_RefundOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RefundOrder_Call) Return(refund *models.Refund, err error) *MockService_RefundOrder_Call {
+	_c.Call.Return(refund, err)
+	return _c
+}
+
+func (_c *MockService_RefundOrder_Call) RunAndReturn(run func(ctx context.Context, orderID int, amount int, reason string, comment string, actorID int) (*models.Refund, error)) *MockService_RefundOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryPayment provides a mock function for the type MockService
+func (_mock *MockService) RetryPayment(ctx context.Context, orderID int) (*models.OrderWithItems, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryPayment")
+	}
+
+	var r0 *models.OrderWithItems
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.OrderWithItems, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.OrderWithItems); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderWithItems)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RetryPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryPayment'
+type MockService_RetryPayment_Call struct {
+	*mock.Call
+}
+
+// RetryPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int
+func (_e *MockService_Expecter) RetryPayment(ctx interface{}, orderID interface{}) *MockService_RetryPayment_Call {
+	return &MockService_RetryPayment_Call{Call: _e.mock.On("RetryPayment", ctx, orderID)}
+}
+
+func (_c *MockService_RetryPayment_Call) Run(run func(ctx context.Context, orderID int)) *MockService_RetryPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RetryPayment_Call) Return(orderWithItems *models.OrderWithItems, err error) *MockService_RetryPayment_Call {
+	_c.Call.Return(orderWithItems, err)
+	return _c
+}
+
+func (_c *MockService_RetryPayment_Call) RunAndReturn(run func(ctx context.Context, orderID int) (*models.OrderWithItems, error)) *MockService_RetryPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function for the type MockService
+func (_mock *MockService) UpdateOrderStatus(ctx context.Context, orderID int, status string, actorID int) error {
+	ret := _mock.Called(ctx, orderID, status, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int) error); ok {
+		r0 = returnFunc(ctx, orderID, status, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type MockService_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int
+//   - status string
+//   - actorID int
+func (_e *MockService_Expecter) UpdateOrderStatus(ctx interface{}, orderID interface{}, status interface{}, actorID interface{}) *MockService_UpdateOrderStatus_Call {
+	return &MockService_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, orderID, status, actorID)}
+}
+
+func (_c *MockService_UpdateOrderStatus_Call) Run(run func(ctx context.Context, orderID int, status string, actorID int)) *MockService_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateOrderStatus_Call) Return(err error) *MockService_UpdateOrderStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateOrderStatus_Call) RunAndReturn(run func(ctx context.Context, orderID int, status string, actorID int) error) *MockService_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
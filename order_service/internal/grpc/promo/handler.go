@@ -0,0 +1,147 @@
+package promo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	pb "github.com/stpnv0/protos/gen/go/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"order_service/internal/models"
+)
+
+//go:generate mockery --name=Service --output=mocks --outpkg=mocks --filename=mock_service.go
+type Service interface {
+	CreatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error)
+	GetPromoCode(ctx context.Context, id int) (*models.PromoCode, error)
+	ListPromoCodes(ctx context.Context, activeOnly bool) ([]*models.PromoCode, error)
+}
+
+// Handler реализует gRPC-сервис Promotions. Права администратора проверяет gateway.
+type Handler struct {
+	pb.UnimplementedPromotionsServer
+	svc Service
+	log *slog.Logger
+}
+
+func NewHandler(svc Service, log *slog.Logger) *Handler {
+	return &Handler{svc: svc, log: log}
+}
+
+func (h *Handler) CreatePromoCode(ctx context.Context, req *pb.CreatePromoCodeRequest) (*pb.CreatePromoCodeResponse, error) {
+	if req.GetPromoCode() == nil {
+		return nil, status.Error(codes.InvalidArgument, "promo_code is required")
+	}
+
+	promo, err := h.svc.CreatePromoCode(ctx, promoFromProto(req.GetPromoCode()))
+	if err != nil {
+		return nil, h.mapError("create promo code failed", err)
+	}
+
+	return &pb.CreatePromoCodeResponse{PromoCode: promoToProto(promo)}, nil
+}
+
+func (h *Handler) UpdatePromoCode(ctx context.Context, req *pb.UpdatePromoCodeRequest) (*pb.UpdatePromoCodeResponse, error) {
+	if req.GetPromoCode().GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid promo_code.id")
+	}
+
+	promo, err := h.svc.UpdatePromoCode(ctx, promoFromProto(req.GetPromoCode()))
+	if err != nil {
+		return nil, h.mapError("update promo code failed", err)
+	}
+
+	return &pb.UpdatePromoCodeResponse{PromoCode: promoToProto(promo)}, nil
+}
+
+func (h *Handler) GetPromoCode(ctx context.Context, req *pb.GetPromoCodeRequest) (*pb.GetPromoCodeResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	promo, err := h.svc.GetPromoCode(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, h.mapError("get promo code failed", err)
+	}
+
+	return &pb.GetPromoCodeResponse{PromoCode: promoToProto(promo)}, nil
+}
+
+func (h *Handler) ListPromoCodes(ctx context.Context, req *pb.ListPromoCodesRequest) (*pb.ListPromoCodesResponse, error) {
+	promos, err := h.svc.ListPromoCodes(ctx, req.GetActiveOnly())
+	if err != nil {
+		return nil, h.mapError("list promo codes failed", err)
+	}
+
+	out := make([]*pb.PromoCode, len(promos))
+	for i, p := range promos {
+		out[i] = promoToProto(p)
+	}
+
+	return &pb.ListPromoCodesResponse{PromoCodes: out}, nil
+}
+
+func (h *Handler) mapError(msg string, err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidPromoCode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrPromoCodeExists):
+		return status.Error(codes.AlreadyExists, "promo code already exists")
+	case errors.Is(err, models.ErrPromoCodeNotFound):
+		return status.Error(codes.NotFound, "promo code not found")
+	}
+	h.log.Error(msg, slog.String("error", err.Error()))
+	return status.Error(codes.Internal, msg)
+}
+
+func promoFromProto(p *pb.PromoCode) *models.PromoCode {
+	promo := &models.PromoCode{
+		ID:             int(p.GetId()),
+		Code:           p.GetCode(),
+		Kind:           p.GetKind(),
+		Value:          int(p.GetValue()),
+		MinOrderAmount: int(p.GetMinOrderAmountKopecks()),
+		MaxUsesPerUser: int(p.GetMaxUsesPerUser()),
+		Brands:         p.GetBrands(),
+		Active:         p.GetActive(),
+	}
+	if from := p.GetValidFrom(); from != 0 {
+		promo.ValidFrom = time.Unix(from, 0)
+	}
+	if to := p.GetValidTo(); to != 0 {
+		promo.ValidTo = time.Unix(to, 0)
+	}
+	for _, id := range p.GetProductIds() {
+		promo.ProductIDs = append(promo.ProductIDs, int(id))
+	}
+	return promo
+}
+
+func promoToProto(p *models.PromoCode) *pb.PromoCode {
+	out := &pb.PromoCode{
+		Id:                    int64(p.ID),
+		Code:                  p.Code,
+		Kind:                  p.Kind,
+		Value:                 int64(p.Value),
+		MinOrderAmountKopecks: int64(p.MinOrderAmount),
+		MaxUsesPerUser:        int32(p.MaxUsesPerUser),
+		Brands:                p.Brands,
+		Active:                p.Active,
+		CreatedAt:             p.CreatedAt.Unix(),
+		UpdatedAt:             p.UpdatedAt.Unix(),
+	}
+	if !p.ValidFrom.IsZero() {
+		out.ValidFrom = p.ValidFrom.Unix()
+	}
+	if !p.ValidTo.IsZero() {
+		out.ValidTo = p.ValidTo.Unix()
+	}
+	for _, id := range p.ProductIDs {
+		out.ProductIds = append(out.ProductIds, int64(id))
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package promo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/stpnv0/protos/gen/go/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	handler "order_service/internal/grpc/promo"
+	handlerMocks "order_service/internal/grpc/promo/mocks"
+	"order_service/internal/models"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestCreatePromoCode_Success(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	validTo := time.Unix(1_800_000_000, 0)
+	svc.On("CreatePromoCode", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool {
+		return p.Code == "sale" && p.Kind == models.PromoKindPercent && p.Value == 15 &&
+			p.ValidFrom.IsZero() && p.ValidTo.Equal(validTo) &&
+			assert.ObjectsAreEqual([]int{10, 11}, p.ProductIDs)
+	})).Return(&models.PromoCode{
+		ID: 1, Code: "SALE", Kind: models.PromoKindPercent, Value: 15,
+		ValidTo: validTo, ProductIDs: []int{10, 11}, Active: true,
+	}, nil)
+
+	resp, err := h.CreatePromoCode(context.Background(), &pb.CreatePromoCodeRequest{
+		PromoCode: &pb.PromoCode{
+			Code: "sale", Kind: models.PromoKindPercent, Value: 15,
+			ValidTo: validTo.Unix(), ProductIds: []int64{10, 11}, Active: true,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.GetPromoCode().GetId())
+	assert.Equal(t, "SALE", resp.GetPromoCode().GetCode())
+	assert.Equal(t, int64(0), resp.GetPromoCode().GetValidFrom())
+	assert.Equal(t, validTo.Unix(), resp.GetPromoCode().GetValidTo())
+	svc.AssertExpectations(t)
+}
+
+func TestCreatePromoCode_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "invalid", err: models.ErrInvalidPromoCode, code: codes.InvalidArgument},
+		{name: "duplicate", err: models.ErrPromoCodeExists, code: codes.AlreadyExists},
+		{name: "internal", err: errors.New("db down"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(handlerMocks.MockService)
+			h := handler.NewHandler(svc, newTestLogger())
+
+			svc.On("CreatePromoCode", mock.Anything, mock.Anything).
+				Return(nil, fmt.Errorf("create promo code: %w", tt.err))
+
+			_, err := h.CreatePromoCode(context.Background(), &pb.CreatePromoCodeRequest{
+				PromoCode: &pb.PromoCode{Code: "SALE", Kind: models.PromoKindFixed, Value: 100},
+			})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestCreatePromoCode_MissingBody(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.CreatePromoCode(context.Background(), &pb.CreatePromoCodeRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	svc.AssertNotCalled(t, "CreatePromoCode", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePromoCode_NotFound(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("UpdatePromoCode", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool { return p.ID == 9 })).
+		Return(nil, models.ErrPromoCodeNotFound)
+
+	_, err := h.UpdatePromoCode(context.Background(), &pb.UpdatePromoCodeRequest{
+		PromoCode: &pb.PromoCode{Id: 9, Kind: models.PromoKindFixed, Value: 100},
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUpdatePromoCode_InvalidID(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	_, err := h.UpdatePromoCode(context.Background(), &pb.UpdatePromoCodeRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	svc.AssertNotCalled(t, "UpdatePromoCode", mock.Anything, mock.Anything)
+}
+
+func TestListPromoCodes_ActiveOnly(t *testing.T) {
+	svc := new(handlerMocks.MockService)
+	h := handler.NewHandler(svc, newTestLogger())
+
+	svc.On("ListPromoCodes", mock.Anything, true).Return([]*models.PromoCode{
+		{ID: 2, Code: "B", Active: true},
+		{ID: 1, Code: "A", Active: true},
+	}, nil)
+
+	resp, err := h.ListPromoCodes(context.Background(), &pb.ListPromoCodesRequest{ActiveOnly: true})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetPromoCodes(), 2)
+	assert.Equal(t, "B", resp.GetPromoCodes()[0].GetCode())
+	svc.AssertExpectations(t)
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"github.com/stpnv0/protos/events"
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
 
 	"order_service/internal/models"
 )
@@ -21,7 +24,7 @@ func NewProducer(brokers []string, topic string, log *slog.Logger) *Producer {
 	writer := &kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{},
 		BatchSize:    1,
 		BatchTimeout: 10 * time.Millisecond,
 	}
@@ -32,29 +35,78 @@ func NewProducer(brokers []string, topic string, log *slog.Logger) *Producer {
 	}
 }
 
-func (p *Producer) PublishOrderEvent(ctx context.Context, event models.OrderEvent) error {
-	const op = "kafka.Producer.PublishOrderEvent"
+// PublishOutboxMessage отправляет сообщение outbox в Kafka. Ключ — id заказа,
+// поэтому события одного заказа попадают в одну партицию и читаются по порядку.
+// Заголовок event_id позволяет потребителям отбрасывать повторы при at-least-once доставке.
+func (p *Producer) PublishOutboxMessage(ctx context.Context, msg models.OutboxMessage) error {
+	const op = "kafka.Producer.PublishOutboxMessage"
 
-	data, err := json.Marshal(event)
+	message, err := buildMessage(msg)
 	if err != nil {
-		return fmt.Errorf("%s: marshal event: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := p.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(fmt.Sprintf("order-%d", event.OrderID)),
-		Value: data,
-	}); err != nil {
+	if err := p.writer.WriteMessages(ctx, message); err != nil {
 		return fmt.Errorf("%s: write message: %w", op, err)
 	}
 
 	p.log.Info("published order event",
 		slog.String("op", op),
-		slog.String("event_type", event.EventType),
-		slog.Int("order_id", event.OrderID),
+		slog.String("event_type", msg.EventType),
+		slog.Int("order_id", msg.AggregateID),
+		slog.Int64("event_id", msg.ID),
 	)
 	return nil
 }
 
+// buildMessage упаковывает событие outbox в конверт CloudEvents текущей версии схемы.
+// В outbox события лежат в JSON, так что сообщения, записанные до перехода на конверты,
+// публикуются в новом формате. Трассировка в сервисе не ведётся — traceparent пустой.
+func buildMessage(msg models.OutboxMessage) (kafka.Message, error) {
+	var event models.OrderEvent
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return kafka.Message{}, fmt.Errorf("decode payload: %w", err)
+	}
+
+	eventID := strconv.FormatInt(msg.ID, 10)
+	subject := fmt.Sprintf("order-%d", msg.AggregateID)
+
+	value, err := events.Marshal(events.Meta{
+		ID:      eventID,
+		Source:  events.SourceOrderService,
+		Type:    msg.EventType,
+		Subject: subject,
+		Time:    msg.CreatedAt,
+	}, &eventsv1.OrderEvent{
+		OrderId:        int64(event.OrderID),
+		UserId:         int64(event.UserID),
+		Status:         event.Status,
+		TotalAmount:    int64(event.TotalAmount),
+		CustomerEmail:  event.CustomerEmail,
+		Reason:         event.Reason,
+		RefundedAmount: int64(event.RefundedAmount),
+		Carrier:        event.Carrier,
+		TrackingNumber: event.TrackingNumber,
+		PromoCode:      event.PromoCode,
+		DiscountAmount: int64(event.DiscountAmount),
+		PaymentUrl:     event.PaymentURL,
+	})
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	return kafka.Message{
+		Key:   []byte(subject),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: events.HeaderEventID, Value: []byte(eventID)},
+			{Key: events.HeaderEventType, Value: []byte(msg.EventType)},
+			{Key: events.HeaderSchemaVersion, Value: []byte(events.SchemaVersion)},
+			{Key: events.HeaderContentType, Value: []byte(events.ContentType)},
+		},
+	}, nil
+}
+
 func (p *Producer) Close() error {
 	return p.writer.Close()
 }
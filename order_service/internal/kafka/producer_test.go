@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stpnv0/protos/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+func TestBuildMessage(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := models.OutboxMessage{
+		ID:          42,
+		AggregateID: 7,
+		EventType:   models.EventOrderShipped,
+		Payload: []byte(`{"event_type":"OrderShipped","order_id":7,"user_id":3,"status":"SHIPPED",` +
+			`"total_amount":25000,"customer_email":"user@example.com","carrier":"cdek","tracking_number":"TR-1",` +
+			`"timestamp":"2025-03-01T12:00:00Z"}`),
+		CreatedAt: createdAt,
+	}
+
+	message, err := buildMessage(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "order-7", string(message.Key))
+	headers := make(map[string]string, len(message.Headers))
+	for _, h := range message.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	assert.Equal(t, map[string]string{
+		"event_id":       "42",
+		"event_type":     "OrderShipped",
+		"schema_version": "1",
+		"content-type":   "application/cloudevents+protobuf",
+	}, headers)
+
+	env, event, err := events.DecodeOrderEvent(headers["schema_version"], message.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "42", env.GetId())
+	assert.Equal(t, "order_service", env.GetSource())
+	assert.Equal(t, "OrderShipped", env.GetType())
+	assert.Equal(t, "order-7", env.GetSubject())
+	assert.True(t, createdAt.Equal(env.GetTime().AsTime()))
+
+	assert.Equal(t, int64(7), event.GetOrderId())
+	assert.Equal(t, int64(3), event.GetUserId())
+	assert.Equal(t, "SHIPPED", event.GetStatus())
+	assert.Equal(t, int64(25000), event.GetTotalAmount())
+	assert.Equal(t, "user@example.com", event.GetCustomerEmail())
+	assert.Equal(t, "cdek", event.GetCarrier())
+	assert.Equal(t, "TR-1", event.GetTrackingNumber())
+}
+
+func TestBuildMessage_PaymentURL(t *testing.T) {
+	message, err := buildMessage(models.OutboxMessage{
+		ID:          43,
+		AggregateID: 7,
+		EventType:   models.EventOrderPaymentCreated,
+		Payload: []byte(`{"event_type":"OrderPaymentCreated","order_id":7,"user_id":3,"status":"PENDING_PAYMENT",` +
+			`"total_amount":25000,"payment_url":"https://pay.example/7","timestamp":"2025-03-01T12:00:00Z"}`),
+	})
+	require.NoError(t, err)
+
+	_, event, err := events.DecodeOrderEvent(events.SchemaVersion, message.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "https://pay.example/7", event.GetPaymentUrl())
+}
+
+func TestBuildMessage_InvalidPayload(t *testing.T) {
+	_, err := buildMessage(models.OutboxMessage{ID: 1, AggregateID: 7, EventType: models.EventOrderCreated,
+		Payload: []byte("{")})
+	require.Error(t, err)
+}
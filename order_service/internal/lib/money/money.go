@@ -0,0 +1,31 @@
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format переводит копейки в строку рублей ("123.45").
+func Format(kopecks int) string {
+	return fmt.Sprintf("%.2f", float64(kopecks)/100.0)
+}
+
+// Parse переводит сумму ЮKassa ("123.45", "123.4", "123") в копейки без потери точности.
+func Parse(value string) (int, error) {
+	rubles, kopecks, _ := strings.Cut(value, ".")
+	if len(kopecks) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	kopecks += strings.Repeat("0", 2-len(kopecks))
+
+	r, err := strconv.Atoi(rubles)
+	if err != nil || r < 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	k, err := strconv.Atoi(kopecks)
+	if err != nil || k < 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return r*100 + k, nil
+}
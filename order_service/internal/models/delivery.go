@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Способы доставки.
+const (
+	DeliveryMethodPickup  = "pickup"  // самовывоз, адрес не нужен
+	DeliveryMethodCourier = "courier" // курьер до двери
+	DeliveryMethodPost    = "post"    // Почта России
+)
+
+var (
+	// ErrInvalidDelivery — неизвестный способ доставки, нет адреса для доставки
+	// или переданы одновременно address_id и адрес.
+	ErrInvalidDelivery = errors.New("invalid delivery")
+	// ErrAddressNotFound — адреса с таким id нет в адресной книге пользователя.
+	ErrAddressNotFound = errors.New("address not found")
+)
+
+// NeedsAddress сообщает, нужен ли способу доставки адрес получателя.
+func NeedsAddress(method string) bool {
+	return method == DeliveryMethodCourier || method == DeliveryMethodPost
+}
+
+// ShippingAddress — адрес получателя, скопированный в заказ при оформлении.
+// Изменения в адресной книге на оформленный заказ не влияют.
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	City          string `json:"city"`
+	AddressLine   string `json:"address_line"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// Validate проверяет, что по адресу можно доставить заказ.
+func (a *ShippingAddress) Validate() error {
+	switch {
+	case strings.TrimSpace(a.RecipientName) == "":
+		return fmt.Errorf("%w: recipient_name is required", ErrInvalidDelivery)
+	case strings.TrimSpace(a.Phone) == "":
+		return fmt.Errorf("%w: phone is required", ErrInvalidDelivery)
+	case strings.TrimSpace(a.City) == "":
+		return fmt.Errorf("%w: city is required", ErrInvalidDelivery)
+	case strings.TrimSpace(a.AddressLine) == "":
+		return fmt.Errorf("%w: address_line is required", ErrInvalidDelivery)
+	}
+	return nil
+}
+
+// DeliveryRequest — выбор доставки при оформлении заказа.
+// Для courier и post задаётся ровно одно из AddressID и Address.
+type DeliveryRequest struct {
+	Method    string // пусто — самовывоз
+	AddressID int
+	Address   *ShippingAddress
+}
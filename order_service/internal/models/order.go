@@ -1,22 +1,35 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
 
 // Order statuses.
 const (
 	OrderStatusPendingPayment = "PENDING_PAYMENT"
 	OrderStatusPaid           = "PAID"
 	OrderStatusCancelled      = "CANCELLED"
-	OrderStatusShipped        = "SHIPPED"
+	OrderStatusProcessing     = "PROCESSING" // оплачен, собирается на складе
+	OrderStatusShipped        = "SHIPPED"    // передан перевозчику
+	OrderStatusDelivered      = "DELIVERED"
+	OrderStatusReturned       = "RETURNED" // отправление вернулось на склад
 	OrderStatusPaymentFailed  = "PAYMENT_FAILED"
+	OrderStatusRefunded       = "REFUNDED" // оплата возвращена полностью
 )
 
 var validOrderStatuses = map[string]struct{}{
 	OrderStatusPendingPayment: {},
 	OrderStatusPaid:           {},
 	OrderStatusCancelled:      {},
+	OrderStatusProcessing:     {},
 	OrderStatusShipped:        {},
+	OrderStatusDelivered:      {},
+	OrderStatusReturned:       {},
 	OrderStatusPaymentFailed:  {},
+	OrderStatusRefunded:       {},
 }
 
 func IsValidStatus(status string) bool {
@@ -24,12 +37,37 @@ func IsValidStatus(status string) bool {
 	return ok
 }
 
+// Оплаченный заказ не отменяется сменой статуса: деньги возвращает RefundOrder,
+// и после полного возврата заказ переходит в REFUNDED.
 var validTransitions = map[string][]string{
 	OrderStatusPendingPayment: {OrderStatusPaid, OrderStatusPaymentFailed, OrderStatusCancelled},
-	OrderStatusPaid:           {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusPaid:           {OrderStatusProcessing, OrderStatusShipped, OrderStatusRefunded},
 	OrderStatusPaymentFailed:  {OrderStatusPendingPayment, OrderStatusCancelled},
-	OrderStatusShipped:        {},
+	OrderStatusProcessing:     {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:        {OrderStatusDelivered, OrderStatusReturned, OrderStatusRefunded},
+	OrderStatusDelivered:      {OrderStatusReturned, OrderStatusRefunded},
+	OrderStatusReturned:       {OrderStatusRefunded},
 	OrderStatusCancelled:      {},
+	OrderStatusRefunded:       {},
+}
+
+// RefundableStatuses — статусы оплаченного заказа, по которому ещё можно вернуть деньги.
+var RefundableStatuses = []string{
+	OrderStatusPaid,
+	OrderStatusProcessing,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusReturned,
+}
+
+// IsCancellable сообщает, можно ли отменить заказ без возврата денег.
+func IsCancellable(status string) bool {
+	return status == OrderStatusPendingPayment || status == OrderStatusPaymentFailed
+}
+
+// IsRefundable сообщает, можно ли вернуть деньги по заказу.
+func IsRefundable(status string) bool {
+	return slices.Contains(RefundableStatuses, status)
 }
 
 func ValidTransition(from, to string) bool {
@@ -46,27 +84,143 @@ func ValidTransition(from, to string) bool {
 }
 
 type Order struct {
-	ID          int       `db:"id"`
-	UserID      int       `db:"user_id"`
-	Status      string    `db:"status"`
-	TotalAmount int       `db:"total_amount"`
-	PaymentURL  string    `db:"payment_url"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int    `db:"id"`
+	UserID      int    `db:"user_id"`
+	Status      string `db:"status"`
+	TotalAmount int    `db:"total_amount"`
+	// RefundedAmount — сумма успешных возвратов в копейках.
+	RefundedAmount int       `db:"refunded_amount"`
+	PaymentURL     string    `db:"payment_url"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+	// IdempotencyKey — ключ клиента из Idempotency-Key, уникален в пределах пользователя.
+	IdempotencyKey string `db:"idempotency_key"`
+	// RequestHash — отпечаток запроса, создавшего заказ: повтор с тем же ключом
+	// должен прийти с тем же запросом. Пусто у заказов, созданных до его появления.
+	RequestHash    string `db:"request_hash"`
+	DeliveryMethod string `db:"delivery_method"`
+	// DeliveryCost — стоимость доставки в копейках, уже включена в TotalAmount.
+	DeliveryCost    int              `db:"delivery_cost"`
+	ShippingAddress *ShippingAddress `db:"shipping_address"` // nil — самовывоз
+	// Carrier и TrackingNumber заполняются при отправке (SHIPPED).
+	Carrier        string `db:"carrier"`
+	TrackingNumber string `db:"tracking_number"`
+	// PromoCodeID и PromoCode — применённый промокод; 0 и пусто — без промокода.
+	PromoCodeID int    `db:"promo_code_id"`
+	PromoCode   string `db:"promo_code"`
+	// DiscountAmount — скидка по промокоду в копейках, уже вычтена из TotalAmount.
+	DiscountAmount int `db:"discount_amount"`
+	// Discounts — скидка по позициям; заполняется при создании и в GetByID.
+	Discounts []OrderDiscount `db:"-"`
+	// CustomerEmail — email покупателя из токена SSO, на него уходит чек.
+	CustomerEmail string `db:"customer_email"`
 }
 
 type OrderItem struct {
-	ID              int       `db:"id"`
-	OrderID         int       `db:"order_id"`
-	SneakerID       int       `db:"sneaker_id"`
-	Quantity        int       `db:"quantity"`
+	ID        int `db:"id"`
+	OrderID   int `db:"order_id"`
+	SneakerID int `db:"sneaker_id"`
+	VariantID int `db:"variant_id"` // 0 — товар без вариантов
+	Quantity  int `db:"quantity"`
+	// PriceAtPurchase заполняет сам order_service по текущей цене из product_service.
 	PriceAtPurchase int       `db:"price_at_purchase"`
 	CreatedAt       time.Time `db:"created_at"`
+	// Title — название позиции в каталоге на момент покупки (с размером варианта), для чека.
+	Title string `db:"title"`
+	// Brand приходит из каталога вместе с ценой для промокодов по брендам; не хранится.
+	Brand string `db:"-"`
 }
 
 type OrderWithItems struct {
 	Order
 	Items []OrderItem
+	// History — смены статуса от старых к новым; заполняется только в GetOrder.
+	History []StatusHistoryEntry
+}
+
+// Источники смены статуса в истории заказа.
+const (
+	StatusSourceUser      = "user"      // действие покупателя
+	StatusSourceAdmin     = "admin"     // администратор или запрос с X-Admin-API-Key
+	StatusSourceWebhook   = "webhook"   // уведомление платёжного провайдера
+	StatusSourceScheduler = "scheduler" // фоновые воркеры: автоотмена, сверка платежей
+	StatusSourceSystem    = "system"    // внутренние вызовы и автоматические откаты
+)
+
+// StatusHistoryEntry — запись истории статусов заказа.
+type StatusHistoryEntry struct {
+	FromStatus string // пусто — заказ создан
+	ToStatus   string
+	Source     string
+	Reason     string
+	ActorID    int // администратор, сменивший статус; 0 — не администратор
+	CreatedAt  time.Time
+}
+
+var (
+	// ErrInvalidPageToken — токен страницы повреждён или не разбирается.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrInvalidOrder — в заказе нет позиций, есть дубли или неположительное количество.
+	ErrInvalidOrder = errors.New("invalid order")
+	// ErrProductUnavailable — товара или варианта нет в каталоге либо он снят с продажи.
+	ErrProductUnavailable = errors.New("product unavailable")
+	// ErrDuplicateIdempotencyKey — у пользователя уже есть заказ с таким ключом идемпотентности.
+	ErrDuplicateIdempotencyKey = errors.New("duplicate idempotency key")
+	// ErrIdempotencyKeyReused — ключ идемпотентности повторён с другим запросом.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrPaymentUnavailable — заказ создан, но платёж у провайдера создать не удалось;
+	// повтор с тем же ключом идемпотентности создаст платёж.
+	ErrPaymentUnavailable = errors.New("payment provider unavailable")
+	// ErrOrderNotCancellable — заказ уже оплачен, отправлен или закрыт; оплаченный заказ возвращают через возврат.
+	ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+	// ErrOrderNotRefundable — заказ не оплачен или уже возвращён.
+	ErrOrderNotRefundable = errors.New("order cannot be refunded")
+	// ErrRefundAmountExceeded — сумма возврата больше невозвращённого остатка платежа.
+	ErrRefundAmountExceeded = errors.New("refund amount exceeds refundable amount")
+	// ErrRefundNotFound — вебхук пришёл по возврату, которого ещё нет в базе.
+	ErrRefundNotFound = errors.New("refund not found")
+	// ErrPaymentNotRetryable — повторить оплату можно только у заказа в PAYMENT_FAILED.
+	ErrPaymentNotRetryable = errors.New("payment cannot be retried")
+	// ErrInvalidStatus — неизвестный статус или статус, который нельзя выставить вручную.
+	ErrInvalidStatus = errors.New("invalid order status")
+	// ErrInvalidTransition — из текущего статуса заказа в запрошенный перейти нельзя.
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	// ErrInvalidOrderFilter — противоречивые или некорректные условия выборки заказов.
+	ErrInvalidOrderFilter = errors.New("invalid order filter")
+)
+
+// OrderPage — страница списка заказов, от новых к старым.
+type OrderPage struct {
+	Orders        []*OrderWithItems
+	NextPageToken string // пусто — страниц больше нет
+}
+
+// OrderFilter — условия выборки заказов в админском списке.
+// Нулевое значение поля условие не накладывает.
+type OrderFilter struct {
+	Status      string
+	UserID      int
+	CreatedFrom time.Time // created_at >= CreatedFrom
+	CreatedTo   time.Time // created_at < CreatedTo
+	MinAmount   int       // total_amount >= MinAmount, в копейках
+	MaxAmount   int       // total_amount <= MaxAmount, в копейках
+}
+
+// Validate проверяет, что условия не противоречат друг другу.
+func (f OrderFilter) Validate() error {
+	switch {
+	case f.Status != "" && !IsValidStatus(f.Status):
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidOrderFilter, f.Status)
+	case f.UserID < 0:
+		return fmt.Errorf("%w: user_id must be positive", ErrInvalidOrderFilter)
+	case f.MinAmount < 0 || f.MaxAmount < 0:
+		return fmt.Errorf("%w: amount must not be negative", ErrInvalidOrderFilter)
+	case f.MaxAmount > 0 && f.MinAmount > f.MaxAmount:
+		return fmt.Errorf("%w: min amount is greater than max amount", ErrInvalidOrderFilter)
+	case !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedFrom.Before(f.CreatedTo):
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidOrderFilter)
+	}
+	return nil
 }
 
 type OrderEvent struct {
@@ -75,6 +229,26 @@ type OrderEvent struct {
 	UserID      int    `json:"user_id"`
 	Status      string `json:"status"`
 	TotalAmount int    `json:"total_amount"`
-	PaymentURL  string `json:"payment_url,omitempty"`
-	Timestamp   string `json:"timestamp"`
+	// CustomerEmail — email покупателя на момент оформления; пустой у заказов, оформленных без него.
+	CustomerEmail string `json:"customer_email,omitempty"`
+	// RefundedAmount — сумма всех успешных возвратов, только в OrderRefunded.
+	RefundedAmount int    `json:"refunded_amount,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	// Carrier и TrackingNumber — для событий после отправки заказа.
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	// PromoCode и DiscountAmount — применённый промокод и скидка, только в OrderCreated.
+	PromoCode      string `json:"promo_code,omitempty"`
+	DiscountAmount int    `json:"discount_amount,omitempty"`
+	// PaymentURL — ссылка на страницу оплаты, только в OrderPaymentCreated.
+	PaymentURL string `json:"payment_url,omitempty"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// StatusChange описывает смену статуса для события в outbox и истории заказа.
+type StatusChange struct {
+	EventType string
+	Source    string
+	Reason    string
+	ActorID   int // id администратора для истории; 0 — смена не от администратора
 }
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Типы событий заказа.
+const (
+	EventOrderCreated        = "OrderCreated"
+	EventOrderPaymentUpdated = "OrderPaymentUpdated"
+	EventOrderPaymentCreated = "OrderPaymentCreated"
+	EventOrderStatusChanged  = "OrderStatusChanged"
+	EventOrderCancelled      = "OrderCancelled"
+	EventOrderRefunded       = "OrderRefunded"
+	EventOrderExpired        = "OrderExpired"
+	EventOrderPaymentRetried = "OrderPaymentRetried"
+	EventOrderProcessing     = "OrderProcessing"
+	EventOrderShipped        = "OrderShipped"
+	EventOrderDelivered      = "OrderDelivered"
+	EventOrderReturned       = "OrderReturned"
+)
+
+// OutboxMessage — событие, записанное в outbox и ожидающее публикации в Kafka.
+type OutboxMessage struct {
+	ID          int64
+	AggregateID int // id заказа
+	EventType   string
+	Payload     []byte
+	Attempts    int
+	CreatedAt   time.Time
+}
+
+// OutboxResult — итог попытки публикации сообщения: Err == nil — опубликовано.
+type OutboxResult struct {
+	ID  int64
+	Err error
+	// RetryAt — не раньше какого момента повторить неудачную публикацию.
+	RetryAt time.Time
+	// Dead — попытки исчерпаны: сообщение больше не публикуется.
+	Dead bool
+}
+
+// OutboxStats — состояние очереди outbox для метрик.
+type OutboxStats struct {
+	Pending          int
+	OldestPendingAge time.Duration
+	Dead             int // исчерпали попытки и ждут ручного разбора
+}
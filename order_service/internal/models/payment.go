@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 const (
 	PaymentStatusPending   = "pending"
@@ -37,10 +41,61 @@ type Payment struct {
 	ConfirmationURL   string    `db:"confirmation_url"`
 	CreatedAt         time.Time `db:"created_at"`
 	UpdatedAt         time.Time `db:"updated_at"`
+	// Receipt — чек, отправленный провайдеру с платежом; nil — без чека.
+	Receipt *Receipt `db:"receipt"`
 }
 
+var (
+	// ErrPaymentNotFound — провайдер не знает платёж с таким id.
+	ErrPaymentNotFound = errors.New("payment not found at provider")
+	// ErrUnknownPayment — платежа с таким id провайдера нет в базе.
+	ErrUnknownPayment = errors.New("unknown payment")
+	// ErrProviderRefundNotFound — провайдер не знает возврат с таким id.
+	ErrProviderRefundNotFound = errors.New("refund not found at provider")
+	// ErrRefundRejected — провайдер окончательно отклонил запрос на возврат и не провёл его.
+	// Прочие ошибки RefundPayment (таймаут, 5xx) не говорят, проведён ли возврат.
+	ErrRefundRejected = errors.New("refund rejected by provider")
+)
+
 type PaymentProviderResponse struct {
 	ID              string
 	Status          string
 	ConfirmationURL string
+	Amount          int // в копейках
+	Currency        string
+}
+
+// PaymentNotification — уведомление провайдера о смене статуса платежа.
+type PaymentNotification struct {
+	PaymentID string // id платежа у провайдера
+	Status    string
+	Amount    int // в копейках
+	Currency  string
+}
+
+// RefundNotification — уведомление провайдера о завершении возврата.
+type RefundNotification struct {
+	RefundID string // id возврата у провайдера
+	Status   string
+	Amount   int // в копейках
+	Currency string
+}
+
+// Причины, по которым уведомление о платеже или возврате отклоняется.
+const (
+	WebhookRejectUnknownPayment = "unknown_payment"
+	WebhookRejectUnknownRefund  = "unknown_refund"
+	WebhookRejectAmountMismatch = "amount_mismatch"
+	WebhookRejectStatusMismatch = "status_mismatch"
+)
+
+// WebhookRejectedError — уведомление не прошло проверку подлинности
+// и обрабатываться не должно.
+type WebhookRejectedError struct {
+	Reason string
+	Detail string
+}
+
+func (e *WebhookRejectedError) Error() string {
+	return fmt.Sprintf("webhook rejected: %s: %s", e.Reason, e.Detail)
 }
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Виды промокодов.
+const (
+	PromoKindPercent = "percent" // Value — процент от суммы подходящих позиций, 1–99
+	PromoKindFixed   = "fixed"   // Value — скидка в копейках, не больше суммы подходящих позиций
+)
+
+// MaxPromoCodeLen — длина колонки promo_codes.code.
+const MaxPromoCodeLen = 32
+
+// MinPaymentAmount — минимальная сумма платежа у провайдера в копейках (1 ₽):
+// скидка не может опустить сумму заказа ниже неё.
+const MinPaymentAmount = 100
+
+var (
+	// ErrInvalidPromoCode — параметры промокода некорректны или противоречат друг другу.
+	ErrInvalidPromoCode = errors.New("invalid promo code")
+	// ErrPromoCodeNotFound — промокода нет или он выключен.
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	// ErrPromoCodeExists — промокод с таким кодом уже заведён.
+	ErrPromoCodeExists = errors.New("promo code already exists")
+	// ErrPromoNotApplicable — промокод не действует сейчас, сумма заказа меньше минимальной
+	// или в заказе нет подходящих товаров.
+	ErrPromoNotApplicable = errors.New("promo code is not applicable")
+	// ErrPromoUsageExceeded — пользователь уже использовал промокод максимальное число раз.
+	ErrPromoUsageExceeded = errors.New("promo code usage limit exceeded")
+)
+
+// NormalizePromoCode приводит код к виду, в котором он хранится: без пробелов по краям, в верхнем регистре.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// PromoCode — промокод на скидку. Если заданы ProductIDs или Brands, скидка считается
+// только с позиций этих товаров или брендов; иначе — со всех позиций. Доставка не дисконтируется.
+type PromoCode struct {
+	ID    int
+	Code  string
+	Kind  string
+	Value int
+	// MinOrderAmount — минимальная сумма позиций заказа до скидки в копейках; 0 — без ограничения.
+	MinOrderAmount int
+	// MaxUsesPerUser — сколько заказов пользователь может оформить с кодом; 0 — без ограничения.
+	// Отменённые заказы не считаются.
+	MaxUsesPerUser int
+	ValidFrom      time.Time // нулевое — действует сразу
+	ValidTo        time.Time // нулевое — бессрочно; момент ValidTo уже не входит
+	ProductIDs     []int
+	Brands         []string
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Validate проверяет параметры промокода перед сохранением.
+func (p *PromoCode) Validate() error {
+	switch {
+	case p.Code == "" || len(p.Code) > MaxPromoCodeLen:
+		return fmt.Errorf("%w: code must be 1-%d characters", ErrInvalidPromoCode, MaxPromoCodeLen)
+	case strings.ContainsAny(p.Code, " \t\n"):
+		return fmt.Errorf("%w: code must not contain spaces", ErrInvalidPromoCode)
+	case p.Kind == PromoKindPercent && (p.Value < 1 || p.Value > 99):
+		return fmt.Errorf("%w: percent must be between 1 and 99", ErrInvalidPromoCode)
+	case p.Kind == PromoKindFixed && p.Value <= 0:
+		return fmt.Errorf("%w: fixed discount must be positive", ErrInvalidPromoCode)
+	case p.Kind != PromoKindPercent && p.Kind != PromoKindFixed:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidPromoCode, p.Kind)
+	case p.MinOrderAmount < 0 || p.MaxUsesPerUser < 0:
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidPromoCode)
+	case !p.ValidFrom.IsZero() && !p.ValidTo.IsZero() && !p.ValidFrom.Before(p.ValidTo):
+		return fmt.Errorf("%w: valid_from must be before valid_to", ErrInvalidPromoCode)
+	}
+	for _, id := range p.ProductIDs {
+		if id <= 0 {
+			return fmt.Errorf("%w: invalid product id %d", ErrInvalidPromoCode, id)
+		}
+	}
+	for _, brand := range p.Brands {
+		if strings.TrimSpace(brand) == "" {
+			return fmt.Errorf("%w: empty brand", ErrInvalidPromoCode)
+		}
+	}
+	return nil
+}
+
+// ActiveAt сообщает, действует ли промокод в момент now.
+func (p *PromoCode) ActiveAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if !p.ValidFrom.IsZero() && now.Before(p.ValidFrom) {
+		return false
+	}
+	return p.ValidTo.IsZero() || now.Before(p.ValidTo)
+}
+
+// Covers сообщает, распространяется ли промокод на позицию.
+func (p *PromoCode) Covers(item OrderItem) bool {
+	if len(p.ProductIDs) == 0 && len(p.Brands) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == item.SneakerID {
+			return true
+		}
+	}
+	for _, brand := range p.Brands {
+		if strings.EqualFold(brand, item.Brand) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderDiscount — скидка промокода, приходящаяся на позицию заказа.
+type OrderDiscount struct {
+	ID        int
+	OrderID   int
+	SneakerID int
+	VariantID int
+	Amount    int // в копейках, за все единицы позиции
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Признак предмета расчёта (payment_subject) по 54-ФЗ.
+const (
+	PaymentSubjectCommodity = "commodity" // товар
+	PaymentSubjectService   = "service"   // услуга — доставка
+)
+
+// Признак способа расчёта (payment_mode) по 54-ФЗ.
+const (
+	PaymentModeFullPrepayment = "full_prepayment" // полная предоплата до передачи товара
+	PaymentModeFullPayment    = "full_payment"    // полный расчёт при передаче товара
+)
+
+// MaxReceiptItemDescriptionLen — длина названия позиции чека у ЮKassa в символах.
+const MaxReceiptItemDescriptionLen = 128
+
+// ErrReceiptContactRequired — в чек некуда отправить: у покупателя нет ни email, ни телефона.
+var ErrReceiptContactRequired = errors.New("receipt requires customer email or phone")
+
+// ReceiptSettings — параметры фискализации из конфигурации. Enabled = false — платежи без чеков.
+type ReceiptSettings struct {
+	Enabled bool
+	// VATCode — код ставки НДС по справочнику ЮKassa (1 — без НДС).
+	VATCode       int
+	PaymentMode   string
+	TaxSystemCode int // 0 — не передаётся, у магазина одна система налогообложения
+	DeliveryTitle string
+}
+
+// Receipt — чек по 54-ФЗ, отправляемый вместе с платежом или возвратом.
+// Сохраняется у платежа и возврата как есть, в JSON, для аудита.
+type Receipt struct {
+	Email         string        `json:"email,omitempty"`
+	Phone         string        `json:"phone,omitempty"`
+	TaxSystemCode int           `json:"tax_system_code,omitempty"`
+	Items         []ReceiptItem `json:"items"`
+}
+
+// ReceiptItem — позиция чека. Сумма позиции — Price * Quantity.
+type ReceiptItem struct {
+	Description    string `json:"description"`
+	Quantity       int    `json:"quantity"`
+	Price          int    `json:"price"` // за единицу в копейках, с учётом скидки
+	VATCode        int    `json:"vat_code"`
+	PaymentSubject string `json:"payment_subject"`
+	PaymentMode    string `json:"payment_mode"`
+}
+
+// Total — сумма чека в копейках.
+func (r *Receipt) Total() int {
+	total := 0
+	for _, item := range r.Items {
+		total += item.Price * item.Quantity
+	}
+	return total
+}
+
+// BuildReceipt собирает чек на всю сумму заказа: позиции по ценам покупки за вычетом
+// скидки промокода и доставку отдельной услугой. Если настройки выключены, возвращает nil.
+// Чек уходит на email покупателя, а без него — на телефон получателя.
+func BuildReceipt(order *OrderWithItems, settings ReceiptSettings) (*Receipt, error) {
+	if !settings.Enabled {
+		return nil, nil
+	}
+
+	receipt := &Receipt{
+		Email:         order.CustomerEmail,
+		TaxSystemCode: settings.TaxSystemCode,
+	}
+	if receipt.Email == "" && order.ShippingAddress != nil {
+		receipt.Phone = normalizePhone(order.ShippingAddress.Phone)
+	}
+	if receipt.Email == "" && receipt.Phone == "" {
+		return nil, ErrReceiptContactRequired
+	}
+
+	discounts := make(map[[2]int]int, len(order.Discounts))
+	for _, d := range order.Discounts {
+		discounts[[2]int{d.SneakerID, d.VariantID}] += d.Amount
+	}
+
+	for _, item := range order.Items {
+		amount := item.PriceAtPurchase*item.Quantity - discounts[[2]int{item.SneakerID, item.VariantID}]
+		receipt.Items = append(receipt.Items, splitReceiptLine(ReceiptItem{
+			Description:    receiptDescription(item),
+			VATCode:        settings.VATCode,
+			PaymentSubject: PaymentSubjectCommodity,
+			PaymentMode:    settings.PaymentMode,
+		}, item.Quantity, amount)...)
+	}
+
+	if order.DeliveryCost > 0 {
+		receipt.Items = append(receipt.Items, ReceiptItem{
+			Description:    truncateDescription(settings.DeliveryTitle),
+			Quantity:       1,
+			Price:          order.DeliveryCost,
+			VATCode:        settings.VATCode,
+			PaymentSubject: PaymentSubjectService,
+			PaymentMode:    settings.PaymentMode,
+		})
+	}
+
+	return receipt, nil
+}
+
+// Part возвращает чек на amount копеек из этого чека — для частичного возврата.
+// Сумма раскладывается по позициям пропорционально их стоимости; на весь чек
+// возвращается его копия.
+func (r *Receipt) Part(amount int) *Receipt {
+	total := r.Total()
+	part := &Receipt{Email: r.Email, Phone: r.Phone, TaxSystemCode: r.TaxSystemCode}
+	if amount >= total {
+		part.Items = append(part.Items, r.Items...)
+		return part
+	}
+
+	shares := make([]int, len(r.Items))
+	distributed := 0
+	for i, item := range r.Items {
+		shares[i] = amount * item.Price * item.Quantity / total
+		distributed += shares[i]
+	}
+	// Остаток от округления вниз меньше числа позиций: раздаём по копейке.
+	for i := 0; distributed < amount; i = (i + 1) % len(shares) {
+		if shares[i] < r.Items[i].Price*r.Items[i].Quantity {
+			shares[i]++
+			distributed++
+		}
+	}
+
+	for i, item := range r.Items {
+		if shares[i] == 0 {
+			continue
+		}
+		part.Items = append(part.Items, splitReceiptLine(item, item.Quantity, shares[i])...)
+	}
+	return part
+}
+
+// splitReceiptLine раскладывает amount копеек на quantity единиц позиции. Если amount
+// не делится нацело, последняя единица выносится отдельной строкой с остатком,
+// чтобы сумма чека совпала с суммой платежа. Меньше копейки на единицу —
+// одна строка на всю сумму.
+func splitReceiptLine(item ReceiptItem, quantity, amount int) []ReceiptItem {
+	if amount > 0 && amount < quantity {
+		quantity = 1
+	}
+	unit, rem := amount/quantity, amount%quantity
+	if rem == 0 {
+		item.Quantity, item.Price = quantity, unit
+		return []ReceiptItem{item}
+	}
+
+	last := item
+	last.Quantity, last.Price = 1, unit+rem
+	if quantity == 1 {
+		return []ReceiptItem{last}
+	}
+	item.Quantity, item.Price = quantity-1, unit
+	return []ReceiptItem{item, last}
+}
+
+func receiptDescription(item OrderItem) string {
+	title := item.Title
+	if title == "" {
+		title = fmt.Sprintf("Товар #%d", item.SneakerID)
+	}
+	return truncateDescription(title)
+}
+
+func truncateDescription(s string) string {
+	if utf8.RuneCountInString(s) <= MaxReceiptItemDescriptionLen {
+		return s
+	}
+	return string([]rune(s)[:MaxReceiptItemDescriptionLen])
+}
+
+// normalizePhone оставляет в телефоне только цифры, как требует ЮKassa (79991234567).
+func normalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
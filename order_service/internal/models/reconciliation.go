@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Виды расхождений между платежом в базе и у провайдера.
+const (
+	// MismatchStatus — провайдер уже завершил платёж, а локально он pending
+	// (вебхук потерялся) или в статусе, который сервис не ожидает.
+	MismatchStatus = "status"
+	// MismatchAmount — сумма или валюта у провайдера не совпадает с локальной.
+	MismatchAmount = "amount"
+	// MismatchMissing — провайдер не знает платёж.
+	MismatchMissing = "missing"
+	// MismatchProviderError — статус у провайдера получить не удалось.
+	MismatchProviderError = "provider_error"
+)
+
+// ErrInvalidMismatchFilter — неизвестный вид расхождения в фильтре.
+var ErrInvalidMismatchFilter = errors.New("invalid reconciliation mismatch filter")
+
+// ReconciliationMismatch — расхождение по одному платежу.
+type ReconciliationMismatch struct {
+	ID             int    `json:"id"` // заполняется при сохранении
+	Kind           string `json:"kind"`
+	PaymentID      string `json:"payment_id"` // id платежа у провайдера
+	OrderID        int    `json:"order_id"`
+	LocalStatus    string `json:"local_status"`
+	ProviderStatus string `json:"provider_status,omitempty"`
+	LocalAmount    int    `json:"local_amount"`
+	ProviderAmount int    `json:"provider_amount,omitempty"`
+	// Resolved — расхождение устранено: статус провайдера применён как вебхук.
+	Resolved  bool      `json:"resolved"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReconciliationReport — итог сверки пачки ожидающих платежей с провайдером.
+type ReconciliationReport struct {
+	StartedAt  time.Time                `json:"started_at"`
+	FinishedAt time.Time                `json:"finished_at"`
+	Checked    int                      `json:"checked"`
+	Mismatches []ReconciliationMismatch `json:"mismatches"`
+}
+
+// MismatchFilter — условия выборки расхождений в админском списке.
+type MismatchFilter struct {
+	Kind           string // пусто — все виды
+	UnresolvedOnly bool
+}
+
+// Validate проверяет, что вид расхождения известен.
+func (f MismatchFilter) Validate() error {
+	switch f.Kind {
+	case "", MismatchStatus, MismatchAmount, MismatchMissing, MismatchProviderError:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown kind %q", ErrInvalidMismatchFilter, f.Kind)
+}
+
+// MismatchPage — страница списка расхождений, от новых к старым.
+type MismatchPage struct {
+	Mismatches    []ReconciliationMismatch
+	NextPageToken string // пусто — страниц больше нет
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusCanceled  = "canceled"
+)
+
+// Коды причин отмены заказа и возврата.
+const (
+	ReasonCustomerRequest = "customer_request"
+	ReasonOutOfStock      = "out_of_stock"
+	ReasonPaymentIssue    = "payment_issue"
+	ReasonFraudSuspected  = "fraud_suspected"
+	ReasonDamagedGoods    = "damaged_goods"
+	ReasonDeliveryFailed  = "delivery_failed"
+	ReasonOther           = "other"
+)
+
+// ReasonPaymentTimeout — заказ не оплачен вовремя и отменён автоматически.
+// Выставляется только сервисом, в RefundOrder и CancelOrder не принимается.
+const ReasonPaymentTimeout = "payment_timeout"
+
+var validReasons = map[string]struct{}{
+	ReasonCustomerRequest: {},
+	ReasonOutOfStock:      {},
+	ReasonPaymentIssue:    {},
+	ReasonFraudSuspected:  {},
+	ReasonDamagedGoods:    {},
+	ReasonDeliveryFailed:  {},
+	ReasonOther:           {},
+}
+
+func IsValidReason(reason string) bool {
+	_, ok := validReasons[reason]
+	return ok
+}
+
+// Refund — возврат (полный или частичный) по успешному платежу.
+type Refund struct {
+	ID               int       `db:"id"`
+	OrderID          int       `db:"order_id"`
+	PaymentID        int       `db:"payment_id"`
+	ProviderRefundID string    `db:"provider_refund_id"`
+	Amount           int       `db:"amount"`
+	Currency         string    `db:"currency"`
+	Status           string    `db:"status"`
+	Reason           string    `db:"reason"`
+	Comment          string    `db:"comment"`
+	ActorID          int       `db:"actor_id"` // администратор, запросивший возврат; 0 — автоматический
+	IdempotencyKey   string    `db:"idempotency_key"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+	// Receipt — чек возврата, отправленный провайдеру; nil — без чека.
+	Receipt *Receipt `db:"receipt"`
+}
+
+type RefundProviderResponse struct {
+	ID     string
+	Status string
+	// Amount и Currency заполняет только GetRefund.
+	Amount   int // в копейках
+	Currency string
+}
+
+// RefundIdempotenceKey выводит Idempotence-Key запроса к провайдеру из id возврата:
+// повторная отправка того же возврата не проведёт его у провайдера второй раз.
+func RefundIdempotenceKey(refundID int) string {
+	name := fmt.Sprintf("refund:%d", refundID)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
@@ -0,0 +1,43 @@
+package models
+
+import "errors"
+
+// Состояния отправления у перевозчика.
+const (
+	TrackingInTransit = "in_transit"
+	TrackingDelivered = "delivered"
+	TrackingReturned  = "returned" // не вручено и возвращено отправителю
+)
+
+// TrackingInfo — состояние отправления по данным перевозчика.
+type TrackingInfo struct {
+	Status string
+	// Description — последнее событие отслеживания в свободной форме.
+	Description string
+}
+
+// fulfilmentEvents сопоставляет статусы выполнения заказа с событиями outbox.
+var fulfilmentEvents = map[string]string{
+	OrderStatusProcessing: EventOrderProcessing,
+	OrderStatusShipped:    EventOrderShipped,
+	OrderStatusDelivered:  EventOrderDelivered,
+	OrderStatusReturned:   EventOrderReturned,
+}
+
+// FulfilmentEvent возвращает событие для статуса выполнения заказа;
+// ok == false — статус не относится к выполнению.
+func FulfilmentEvent(status string) (event string, ok bool) {
+	event, ok = fulfilmentEvents[status]
+	return event, ok
+}
+
+var (
+	// ErrInvalidShipment — статус не из цепочки выполнения, неизвестный перевозчик
+	// или для отправки не указаны перевозчик и трек-номер.
+	ErrInvalidShipment = errors.New("invalid shipment")
+	// ErrOrderNotShippable — заказ нельзя перевести в этот статус выполнения
+	// (не оплачен, уже доставлен, возвращены деньги).
+	ErrOrderNotShippable = errors.New("order cannot move to this fulfilment status")
+	// ErrUnknownCarrier — перевозчик не подключён.
+	ErrUnknownCarrier = errors.New("unknown carrier")
+)
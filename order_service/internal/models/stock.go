@@ -0,0 +1,34 @@
+package models
+
+import "errors"
+
+// ErrInsufficientStock возвращается, когда товара на складе не хватает для заказа.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// StockAction — действие с резервом остатков при переходе заказа в новый статус.
+type StockAction int
+
+const (
+	StockActionNone StockAction = iota
+	// StockActionReserve — заказ снова ждёт оплаты, остатки нужно зарезервировать.
+	StockActionReserve
+	// StockActionCommit — заказ оплачен, резерв списывается со склада.
+	StockActionCommit
+	// StockActionRelease — заказ не будет оплачен, резерв возвращается в продажу.
+	StockActionRelease
+)
+
+// StockActionFor возвращает действие с резервом для статуса, в который переходит заказ.
+// Вызывается только для переходов, разрешённых ValidTransition.
+func StockActionFor(newStatus string) StockAction {
+	switch newStatus {
+	case OrderStatusPendingPayment:
+		return StockActionReserve
+	case OrderStatusPaid:
+		return StockActionCommit
+	case OrderStatusCancelled, OrderStatusPaymentFailed:
+		return StockActionRelease
+	default:
+		return StockActionNone
+	}
+}
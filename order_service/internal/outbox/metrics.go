@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pendingMessages = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "order_outbox_pending_messages",
+		Help: "Number of outbox messages not yet published to Kafka.",
+	})
+	oldestPendingAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "order_outbox_oldest_pending_age_seconds",
+		Help: "Age of the oldest unpublished outbox message (relay lag).",
+	})
+	deadMessages = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "order_outbox_dead_messages",
+		Help: "Number of outbox messages that exhausted their publish attempts.",
+	})
+	deadLetteredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_outbox_dead_lettered_total",
+		Help: "Outbox messages moved to dead letter after exhausting publish attempts.",
+	})
+	publishedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_outbox_published_total",
+		Help: "Outbox messages published to Kafka.",
+	})
+	publishErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_outbox_publish_errors_total",
+		Help: "Failed attempts to publish outbox messages.",
+	})
+	publishLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "order_outbox_publish_lag_seconds",
+		Help:    "Time between writing an event to the outbox and publishing it.",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 300, 900},
+	})
+)
@@ -0,0 +1,222 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"order_service/internal/models"
+)
+
+// Store — хранилище outbox.
+type Store interface {
+	ProcessPending(
+		ctx context.Context,
+		limit int,
+		handle func(ctx context.Context, msgs []models.OutboxMessage) []models.OutboxResult,
+	) (int, error)
+	Stats(ctx context.Context) (models.OutboxStats, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Publisher отправляет сообщение outbox в брокер.
+type Publisher interface {
+	PublishOutboxMessage(ctx context.Context, msg models.OutboxMessage) error
+}
+
+// Config — настройки relay.
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Retention — сколько хранить уже опубликованные сообщения.
+	Retention time.Duration
+	// MaxAttempts — после стольких неудачных попыток сообщение уходит в dead letter;
+	// 0 — без ограничения.
+	MaxAttempts int
+	// RetryBackoff — пауза перед первым повтором; дальше она удваивается до MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// cleanupInterval — как часто удалять опубликованные сообщения старше Retention.
+const cleanupInterval = time.Hour
+
+// Relay переносит события из outbox в Kafka.
+//
+// Доставка at-least-once: сообщение помечается опубликованным только после
+// успешной записи в Kafka, поэтому при сбое между ними оно уйдёт повторно.
+// Порядок событий одного заказа сохраняется: после ошибки публикации
+// остальные события этого заказа ждут, пока сообщение не опубликуется
+// или не уйдёт в dead letter, исчерпав MaxAttempts попыток.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	cfg       Config
+	log       *slog.Logger
+}
+
+func NewRelay(store Store, publisher Publisher, cfg Config, log *slog.Logger) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// Run публикует outbox раз в PollInterval, пока не отменён ctx.
+func (r *Relay) Run(ctx context.Context) {
+	const op = "outbox.Relay.Run"
+
+	r.log.Info("outbox relay started",
+		slog.String("op", op),
+		slog.Duration("poll_interval", r.cfg.PollInterval),
+		slog.Int("batch_size", r.cfg.BatchSize),
+	)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("outbox relay stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		r.drain(ctx)
+		r.updateStats(ctx)
+
+		if r.cfg.Retention > 0 && time.Since(lastCleanup) >= cleanupInterval {
+			r.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+// drain публикует пачки, пока они приходят полными и без ошибок.
+func (r *Relay) drain(ctx context.Context) {
+	const op = "outbox.Relay.drain"
+
+	for ctx.Err() == nil {
+		var attempted, failed int
+		_, err := r.store.ProcessPending(ctx, r.cfg.BatchSize,
+			func(ctx context.Context, msgs []models.OutboxMessage) []models.OutboxResult {
+				results := r.publishBatch(ctx, msgs)
+				attempted = len(msgs)
+				for _, res := range results {
+					if res.Err != nil {
+						failed++
+					}
+				}
+				return results
+			},
+		)
+		if err != nil {
+			r.log.Error("failed to process outbox",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+		if attempted < r.cfg.BatchSize || failed > 0 {
+			return
+		}
+	}
+}
+
+// publishBatch публикует сообщения по порядку. Сообщения заказа, у которого
+// уже была ошибка в этой пачке, не отправляются и в результат не попадают.
+func (r *Relay) publishBatch(ctx context.Context, msgs []models.OutboxMessage) []models.OutboxResult {
+	const op = "outbox.Relay.publishBatch"
+
+	results := make([]models.OutboxResult, 0, len(msgs))
+	blocked := make(map[int]struct{})
+
+	for _, msg := range msgs {
+		if _, ok := blocked[msg.AggregateID]; ok {
+			continue
+		}
+
+		err := r.publisher.PublishOutboxMessage(ctx, msg)
+		if err != nil {
+			blocked[msg.AggregateID] = struct{}{}
+			publishErrorsTotal.Inc()
+			results = append(results, r.failure(msg, err))
+			continue
+		}
+		results = append(results, models.OutboxResult{ID: msg.ID})
+
+		publishedTotal.Inc()
+		publishLag.Observe(time.Since(msg.CreatedAt).Seconds())
+	}
+
+	return results
+}
+
+// failure решает, когда повторить неудачную публикацию msg, или отправляет
+// сообщение в dead letter, если попытки исчерпаны.
+func (r *Relay) failure(msg models.OutboxMessage, err error) models.OutboxResult {
+	const op = "outbox.Relay.publishBatch"
+
+	attempts := msg.Attempts + 1
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("event_id", msg.ID),
+		slog.Int("order_id", msg.AggregateID),
+		slog.String("event_type", msg.EventType),
+		slog.Int("attempts", attempts),
+		slog.String("error", err.Error()),
+	)
+
+	if r.cfg.MaxAttempts > 0 && attempts >= r.cfg.MaxAttempts {
+		deadLetteredTotal.Inc()
+		log.Error("outbox message moved to dead letter")
+		return models.OutboxResult{ID: msg.ID, Err: err, Dead: true}
+	}
+
+	log.Warn("failed to publish outbox message")
+	return models.OutboxResult{ID: msg.ID, Err: err, RetryAt: time.Now().Add(r.backoff(attempts))}
+}
+
+// backoff — пауза после attempts неудачных попыток: RetryBackoff, удваиваемая
+// с каждой попыткой, но не больше MaxRetryBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < r.cfg.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, max(r.cfg.MaxRetryBackoff, r.cfg.RetryBackoff))
+}
+
+func (r *Relay) updateStats(ctx context.Context) {
+	stats, err := r.store.Stats(ctx)
+	if err != nil {
+		r.log.Warn("failed to collect outbox stats",
+			slog.String("op", "outbox.Relay.updateStats"),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	pendingMessages.Set(float64(stats.Pending))
+	oldestPendingAge.Set(stats.OldestPendingAge.Seconds())
+	deadMessages.Set(float64(stats.Dead))
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	const op = "outbox.Relay.cleanup"
+
+	deleted, err := r.store.DeletePublishedBefore(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		r.log.Warn("failed to clean up outbox",
+			slog.String("op", op),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if deleted > 0 {
+		r.log.Info("outbox cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+	}
+}
@@ -0,0 +1,195 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+type fakePublisher struct {
+	failFor map[int64]error
+	sent    []int64
+}
+
+func (p *fakePublisher) PublishOutboxMessage(_ context.Context, msg models.OutboxMessage) error {
+	if err := p.failFor[msg.ID]; err != nil {
+		return err
+	}
+	p.sent = append(p.sent, msg.ID)
+	return nil
+}
+
+// fakeStore отдаёт неопубликованные сообщения пачками, как OutboxRepository:
+// без dead letter и без заказов, у которых есть сообщение в ожидании повтора.
+type fakeStore struct {
+	msgs      []models.OutboxMessage
+	published map[int64]bool
+	attempts  map[int64]int
+	retryAt   map[int64]time.Time
+	dead      map[int64]bool
+}
+
+func newFakeStore(msgs ...models.OutboxMessage) *fakeStore {
+	return &fakeStore{
+		msgs: msgs, published: map[int64]bool{}, attempts: map[int64]int{},
+		retryAt: map[int64]time.Time{}, dead: map[int64]bool{},
+	}
+}
+
+func (s *fakeStore) pending(m models.OutboxMessage) bool {
+	return !s.published[m.ID] && !s.dead[m.ID]
+}
+
+func (s *fakeStore) ProcessPending(
+	ctx context.Context,
+	limit int,
+	handle func(ctx context.Context, msgs []models.OutboxMessage) []models.OutboxResult,
+) (int, error) {
+	waiting := map[int]bool{}
+	for _, m := range s.msgs {
+		if s.pending(m) && s.retryAt[m.ID].After(time.Now()) {
+			waiting[m.AggregateID] = true
+		}
+	}
+
+	var batch []models.OutboxMessage
+	for _, m := range s.msgs {
+		if s.pending(m) && !waiting[m.AggregateID] && len(batch) < limit {
+			m.Attempts = s.attempts[m.ID]
+			batch = append(batch, m)
+		}
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	n := 0
+	for _, res := range handle(ctx, batch) {
+		s.attempts[res.ID]++
+		if res.Err == nil {
+			s.published[res.ID] = true
+			n++
+			continue
+		}
+		s.retryAt[res.ID] = res.RetryAt
+		s.dead[res.ID] = res.Dead
+	}
+	return n, nil
+}
+
+func (s *fakeStore) Stats(context.Context) (models.OutboxStats, error) {
+	return models.OutboxStats{}, nil
+}
+
+func (s *fakeStore) DeletePublishedBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newTestRelay(store Store, pub Publisher, batchSize int) *Relay {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewRelay(store, pub, Config{PollInterval: time.Second, BatchSize: batchSize}, log)
+}
+
+func msg(id int64, orderID int) models.OutboxMessage {
+	return models.OutboxMessage{ID: id, AggregateID: orderID, EventType: models.EventOrderCreated, CreatedAt: time.Now()}
+}
+
+func TestPublishBatch_FailureBlocksSameOrderOnly(t *testing.T) {
+	pub := &fakePublisher{failFor: map[int64]error{2: errors.New("kafka down")}}
+	relay := newTestRelay(newFakeStore(), pub, 10)
+
+	results := relay.publishBatch(context.Background(), []models.OutboxMessage{
+		msg(1, 100), msg(2, 200), msg(3, 100), msg(4, 200), msg(5, 300),
+	})
+
+	assert.Equal(t, []int64{1, 3, 5}, pub.sent)
+	require.Len(t, results, 4, "event 4 is held back behind failed event 2")
+	for _, res := range results {
+		if res.ID == 2 {
+			assert.Error(t, res.Err)
+		} else {
+			assert.NoError(t, res.Err)
+		}
+	}
+}
+
+func TestDrain_DrainsFullBatches(t *testing.T) {
+	store := newFakeStore(msg(1, 1), msg(2, 2), msg(3, 3), msg(4, 4), msg(5, 5))
+	pub := &fakePublisher{}
+	relay := newTestRelay(store, pub, 2)
+
+	relay.drain(context.Background())
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, pub.sent)
+}
+
+func TestDrain_RetriesFailedMessagesInOrder(t *testing.T) {
+	store := newFakeStore(msg(1, 7), msg(2, 7), msg(3, 8))
+	pub := &fakePublisher{failFor: map[int64]error{1: errors.New("timeout")}}
+	relay := newTestRelay(store, pub, 10)
+
+	relay.drain(context.Background())
+	assert.Equal(t, []int64{3}, pub.sent)
+	assert.Equal(t, 1, store.attempts[1])
+	assert.False(t, store.published[2])
+
+	// Kafka снова доступна — следующий проход публикует события заказа 7 по порядку.
+	pub.failFor = nil
+	relay.drain(context.Background())
+	assert.Equal(t, []int64{3, 1, 2}, pub.sent)
+	assert.True(t, store.published[1])
+	assert.True(t, store.published[2])
+}
+
+func TestDrain_BackoffHoldsOrderUntilRetryTime(t *testing.T) {
+	store := newFakeStore(msg(1, 7), msg(2, 7), msg(3, 8))
+	pub := &fakePublisher{failFor: map[int64]error{1: errors.New("timeout")}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	relay := NewRelay(store, pub, Config{BatchSize: 10, RetryBackoff: time.Hour, MaxRetryBackoff: time.Hour}, log)
+
+	relay.drain(context.Background())
+	pub.failFor = nil
+	relay.drain(context.Background())
+
+	// Сообщение 1 ждёт повтора, и событие 2 того же заказа его не обгоняет.
+	assert.Equal(t, []int64{3}, pub.sent)
+	assert.Equal(t, 1, store.attempts[1])
+	assert.False(t, store.published[2])
+}
+
+func TestDrain_MovesExhaustedMessageToDeadLetter(t *testing.T) {
+	store := newFakeStore(msg(1, 7), msg(2, 7))
+	pub := &fakePublisher{failFor: map[int64]error{1: errors.New("message too large")}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	relay := NewRelay(store, pub, Config{BatchSize: 10, MaxAttempts: 3}, log)
+
+	for range 3 {
+		relay.drain(context.Background())
+	}
+	assert.Equal(t, 3, store.attempts[1])
+	assert.True(t, store.dead[1])
+
+	// Следующие события заказа больше не ждут сообщение из dead letter.
+	relay.drain(context.Background())
+	assert.Equal(t, []int64{2}, pub.sent)
+	assert.Equal(t, 3, store.attempts[1], "dead message is not retried")
+}
+
+func TestBackoff_DoublesUpToMax(t *testing.T) {
+	relay := NewRelay(newFakeStore(), &fakePublisher{}, Config{
+		RetryBackoff: time.Second, MaxRetryBackoff: 10 * time.Second,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(50))
+}
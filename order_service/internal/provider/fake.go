@@ -0,0 +1,438 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"order_service/internal/lib/money"
+	"order_service/internal/lib/webhooksign"
+	"order_service/internal/models"
+)
+
+// fakeWebhookAttempts — сколько раз fake-провайдер пытается доставить вебхук.
+const fakeWebhookAttempts = 3
+
+// FakeConfig — настройки fake-провайдера.
+type FakeConfig struct {
+	// PublicURL — адрес HTTP-сервера order_service, доступный браузеру;
+	// на нём открывается страница подтверждения оплаты.
+	PublicURL string
+	// ReturnURL — куда вернуть пользователя после оплаты или отмены.
+	ReturnURL string
+	// WebhookURL — куда отправлять вебхуки о смене статуса платежа.
+	WebhookURL string
+	// WebhookSecret — ключ HMAC-подписи вебхуков (заголовок webhooksign.Header).
+	WebhookSecret string
+	HTTPTimeout   time.Duration
+}
+
+// FakeProvider — платёжный провайдер для локального запуска и CI.
+// Платежи хранятся в памяти, страница оплаты отдаётся самим order_service,
+// а решение пользователя приходит обратно подписанным вебхуком
+// в формате уведомлений ЮKassa.
+type FakeProvider struct {
+	cfg    FakeConfig
+	client *http.Client
+	log    *slog.Logger
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+	byKey    map[string]string // idempotenceKey -> id платежа
+	refunds  map[string]string // idempotenceKey -> id возврата
+	byRefund map[string]*fakeRefund
+}
+
+type fakeRefund struct {
+	ID       string
+	Amount   int
+	Currency string
+}
+
+type fakePayment struct {
+	ID          string
+	Amount      int
+	Currency    string
+	Description string
+	Status      string
+	Refunded    int
+}
+
+func NewFakeProvider(cfg FakeConfig, log *slog.Logger) *FakeProvider {
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	return &FakeProvider{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: cfg.HTTPTimeout},
+		log:      log,
+		payments: make(map[string]*fakePayment),
+		byKey:    make(map[string]string),
+		refunds:  make(map[string]string),
+		byRefund: make(map[string]*fakeRefund),
+	}
+}
+
+// CreatePayment создаёт платёж в памяти. Чек, как и ЮKassa, проверяется
+// на совпадение суммы с суммой платежа.
+func (p *FakeProvider) CreatePayment(_ context.Context, amountVal int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.PaymentProviderResponse, error) {
+	const op = "provider.FakeProvider.CreatePayment"
+
+	if err := checkReceipt(receipt, amountVal); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.byKey[idempotenceKey]; ok {
+		return p.response(p.payments[id]), nil
+	}
+
+	payment := &fakePayment{
+		ID:          "fake-" + uuid.NewString(),
+		Amount:      amountVal,
+		Currency:    currency,
+		Description: description,
+		Status:      "pending",
+	}
+	p.payments[payment.ID] = payment
+	if idempotenceKey != "" {
+		p.byKey[idempotenceKey] = payment.ID
+	}
+
+	p.log.Info("fake payment created",
+		slog.String("op", op),
+		slog.String("payment_id", payment.ID),
+		slog.Int("amount", amountVal),
+	)
+	return p.response(payment), nil
+}
+
+// RefundPayment сразу проводит возврат по оплаченному платежу и, как ЮKassa,
+// дополнительно присылает вебхук refund.succeeded.
+func (p *FakeProvider) RefundPayment(_ context.Context, paymentID string, amountVal int, currency, _, idempotenceKey string, receipt *models.Receipt) (*models.RefundProviderResponse, error) {
+	const op = "provider.FakeProvider.RefundPayment"
+
+	if err := checkReceipt(receipt, amountVal); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, models.ErrRefundRejected, err)
+	}
+
+	p.mu.Lock()
+	if id, ok := p.refunds[idempotenceKey]; ok {
+		p.mu.Unlock()
+		return &models.RefundProviderResponse{ID: id, Status: "succeeded"}, nil
+	}
+
+	payment, ok := p.payments[paymentID]
+	switch {
+	case !ok:
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w: payment %s not found", op, models.ErrRefundRejected, paymentID)
+	case payment.Status != "succeeded":
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w: payment %s is %s", op, models.ErrRefundRejected, paymentID, payment.Status)
+	case amountVal <= 0 || payment.Refunded+amountVal > payment.Amount:
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w: refund amount %d exceeds refundable amount", op, models.ErrRefundRejected, amountVal)
+	}
+
+	payment.Refunded += amountVal
+	refundID := "fake-refund-" + uuid.NewString()
+	p.byRefund[refundID] = &fakeRefund{ID: refundID, Amount: amountVal, Currency: currency}
+	if idempotenceKey != "" {
+		p.refunds[idempotenceKey] = refundID
+	}
+	p.mu.Unlock()
+
+	p.log.Info("fake refund created",
+		slog.String("op", op),
+		slog.String("payment_id", paymentID),
+		slog.String("refund_id", refundID),
+		slog.Int("amount", amountVal),
+	)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := p.sendNotification(ctx, "refund.succeeded", fakeNotificationObject{
+			ID:        refundID,
+			Status:    "succeeded",
+			PaymentID: paymentID,
+			Amount:    amount{Value: money.Format(amountVal), Currency: currency},
+		})
+		if err != nil {
+			p.log.Warn("failed to deliver fake refund webhook",
+				slog.String("op", op),
+				slog.String("refund_id", refundID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	return &models.RefundProviderResponse{ID: refundID, Status: "succeeded"}, nil
+}
+
+func (p *FakeProvider) GetPayment(_ context.Context, paymentID string) (*models.PaymentProviderResponse, error) {
+	const op = "provider.FakeProvider.GetPayment"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s: %w", op, paymentID, models.ErrPaymentNotFound)
+	}
+	resp := p.response(payment)
+	resp.Amount = payment.Amount
+	resp.Currency = payment.Currency
+	return resp, nil
+}
+
+// GetRefund возвращает проведённый возврат: fake-провайдер проводит их сразу.
+func (p *FakeProvider) GetRefund(_ context.Context, refundID string) (*models.RefundProviderResponse, error) {
+	const op = "provider.FakeProvider.GetRefund"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	refund, ok := p.byRefund[refundID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s: %w", op, refundID, models.ErrProviderRefundNotFound)
+	}
+	return &models.RefundProviderResponse{
+		ID:       refund.ID,
+		Status:   "succeeded",
+		Amount:   refund.Amount,
+		Currency: refund.Currency,
+	}, nil
+}
+
+// CancelPayment отменяет ожидающий платёж и, как ЮKassa, присылает вебхук payment.canceled.
+func (p *FakeProvider) CancelPayment(_ context.Context, paymentID, _ string) error {
+	const op = "provider.FakeProvider.CancelPayment"
+
+	p.mu.Lock()
+	payment, ok := p.payments[paymentID]
+	switch {
+	case !ok:
+		p.mu.Unlock()
+		return fmt.Errorf("%s: payment %s not found", op, paymentID)
+	case payment.Status == "canceled":
+		p.mu.Unlock()
+		return nil
+	case payment.Status != "pending":
+		p.mu.Unlock()
+		return fmt.Errorf("%s: payment %s is %s", op, paymentID, payment.Status)
+	}
+	payment.Status = "canceled"
+	snapshot := *payment
+	p.mu.Unlock()
+
+	p.log.Info("fake payment canceled", slog.String("op", op), slog.String("payment_id", paymentID))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := p.sendNotification(ctx, "payment.canceled", fakeNotificationObject{
+			ID:     snapshot.ID,
+			Status: snapshot.Status,
+			Amount: amount{Value: money.Format(snapshot.Amount), Currency: snapshot.Currency},
+		})
+		if err != nil {
+			p.log.Warn("failed to deliver fake cancel webhook",
+				slog.String("op", op),
+				slog.String("payment_id", paymentID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	return nil
+}
+
+// checkReceipt повторяет проверку ЮKassa: сумма позиций чека равна сумме операции.
+func checkReceipt(receipt *models.Receipt, amountVal int) error {
+	if receipt == nil {
+		return nil
+	}
+	if total := receipt.Total(); total != amountVal {
+		return fmt.Errorf("receipt total %d does not match amount %d", total, amountVal)
+	}
+	return nil
+}
+
+func (p *FakeProvider) response(payment *fakePayment) *models.PaymentProviderResponse {
+	return &models.PaymentProviderResponse{
+		ID:              payment.ID,
+		Status:          payment.Status,
+		ConfirmationURL: p.cfg.PublicURL + "/fake-pay/" + payment.ID,
+	}
+}
+
+// RegisterRoutes подключает страницу подтверждения оплаты.
+func (p *FakeProvider) RegisterRoutes(router gin.IRouter) {
+	router.GET("/fake-pay/:id", p.confirmationPage)
+	router.POST("/fake-pay/:id", p.resolve)
+}
+
+var fakePageTmpl = template.Must(template.New("fake-pay").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><title>Тестовая оплата</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto;">
+  <h1>Тестовая оплата</h1>
+  <p>{{.Description}}</p>
+  <p><b>{{.Amount}} {{.Currency}}</b></p>
+  <p>Платёж: <code>{{.ID}}</code>, статус: <code>{{.Status}}</code></p>
+  {{if eq .Status "pending"}}
+  <form method="post">
+    <button name="action" value="succeed">Оплатить</button>
+    <button name="action" value="cancel">Отменить</button>
+  </form>
+  {{end}}
+</body>
+</html>`))
+
+func (p *FakeProvider) confirmationPage(c *gin.Context) {
+	p.mu.Lock()
+	payment, ok := p.payments[c.Param("id")]
+	var view fakePayment
+	if ok {
+		view = *payment
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		c.String(http.StatusNotFound, "payment not found")
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	_ = fakePageTmpl.Execute(c.Writer, struct {
+		fakePayment
+		Amount string
+	}{fakePayment: view, Amount: money.Format(view.Amount)})
+}
+
+// resolve завершает платёж по кнопке со страницы (action=succeed|cancel),
+// отправляет вебхук и возвращает пользователя на ReturnURL.
+func (p *FakeProvider) resolve(c *gin.Context) {
+	const op = "provider.FakeProvider.resolve"
+
+	var status string
+	switch c.PostForm("action") {
+	case "succeed":
+		status = "succeeded"
+	case "cancel":
+		status = "canceled"
+	default:
+		c.String(http.StatusBadRequest, "action must be succeed or cancel")
+		return
+	}
+
+	id := c.Param("id")
+	p.mu.Lock()
+	payment, ok := p.payments[id]
+	if !ok {
+		p.mu.Unlock()
+		c.String(http.StatusNotFound, "payment not found")
+		return
+	}
+	if payment.Status != "pending" {
+		p.mu.Unlock()
+		c.String(http.StatusConflict, "payment already %s", payment.Status)
+		return
+	}
+	payment.Status = status
+	snapshot := *payment
+	p.mu.Unlock()
+
+	err := p.sendNotification(c.Request.Context(), "payment."+snapshot.Status, fakeNotificationObject{
+		ID:     snapshot.ID,
+		Status: snapshot.Status,
+		Amount: amount{Value: money.Format(snapshot.Amount), Currency: snapshot.Currency},
+	})
+	if err != nil {
+		// Заказ о решении не узнал — оставляем платёж ожидающим, чтобы можно было повторить.
+		p.mu.Lock()
+		payment.Status = "pending"
+		p.mu.Unlock()
+
+		p.log.Error("failed to deliver fake webhook",
+			slog.String("op", op),
+			slog.String("payment_id", id),
+			slog.String("error", err.Error()),
+		)
+		c.String(http.StatusBadGateway, "failed to deliver webhook")
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, p.cfg.ReturnURL)
+}
+
+type fakeNotification struct {
+	Type   string                 `json:"type"`
+	Event  string                 `json:"event"`
+	Object fakeNotificationObject `json:"object"`
+}
+
+type fakeNotificationObject struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	PaymentID string `json:"payment_id,omitempty"` // только у возвратов
+	Amount    amount `json:"amount"`
+}
+
+func (p *FakeProvider) sendNotification(ctx context.Context, event string, object fakeNotificationObject) error {
+	body, err := json.Marshal(fakeNotification{
+		Type:   "notification",
+		Event:  event,
+		Object: object,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+	signature := webhooksign.Sign(p.cfg.WebhookSecret, body)
+
+	var lastErr error
+	for attempt := 1; attempt <= fakeWebhookAttempts; attempt++ {
+		if lastErr = p.postWebhook(ctx, body, signature); lastErr == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 200 * time.Millisecond):
+		}
+	}
+	return lastErr
+}
+
+func (p *FakeProvider) postWebhook(ctx context.Context, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooksign.Header, signature)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/lib/webhooksign"
+	"order_service/internal/models"
+	"order_service/internal/provider"
+)
+
+const testSecret = "test-secret"
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+type receivedWebhook struct {
+	body      []byte
+	signature string
+}
+
+// newFakeEnv поднимает fake-провайдер и приёмник вебхуков, отвечающий webhookStatus.
+func newFakeEnv(t *testing.T, webhookStatus int) (*provider.FakeProvider, *gin.Engine, chan receivedWebhook) {
+	t.Helper()
+
+	received := make(chan receivedWebhook, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{body: body, signature: r.Header.Get(webhooksign.Header)}
+		w.WriteHeader(webhookStatus)
+	}))
+	t.Cleanup(receiver.Close)
+
+	p := provider.NewFakeProvider(provider.FakeConfig{
+		PublicURL:     "http://localhost:8084/",
+		ReturnURL:     "http://localhost/",
+		WebhookURL:    receiver.URL,
+		WebhookSecret: testSecret,
+		HTTPTimeout:   time.Second,
+	}, newTestLogger())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	p.RegisterRoutes(router)
+	return p, router, received
+}
+
+func resolve(router *gin.Engine, paymentURL, action string) *httptest.ResponseRecorder {
+	u, _ := url.Parse(paymentURL)
+	req := httptest.NewRequest(http.MethodPost, u.Path, strings.NewReader(url.Values{"action": {action}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestFakeProvider_CreatePaymentIsIdempotent(t *testing.T) {
+	p, _, _ := newFakeEnv(t, http.StatusOK)
+
+	first, err := p.CreatePayment(context.Background(), 12345, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+	again, err := p.CreatePayment(context.Background(), 12345, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+	other, err := p.CreatePayment(context.Background(), 12345, "RUB", "Order #2", "key-2", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, again.ID)
+	assert.NotEqual(t, first.ID, other.ID)
+	assert.Equal(t, "pending", first.Status)
+	assert.Equal(t, "http://localhost:8084/fake-pay/"+first.ID, first.ConfirmationURL)
+}
+
+func TestFakeProvider_ConfirmationPage(t *testing.T) {
+	p, router, _ := newFakeEnv(t, http.StatusOK)
+
+	payment, err := p.CreatePayment(context.Background(), 12345, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fake-pay/"+payment.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "123.45 RUB")
+	assert.Contains(t, w.Body.String(), `value="succeed"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fake-pay/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFakeProvider_SucceedSendsSignedWebhook(t *testing.T) {
+	p, router, received := newFakeEnv(t, http.StatusOK)
+
+	payment, err := p.CreatePayment(context.Background(), 12345, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+
+	w := resolve(router, payment.ConfirmationURL, "succeed")
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "http://localhost/", w.Header().Get("Location"))
+
+	hook := <-received
+	assert.True(t, webhooksign.Verify(testSecret, hook.body, hook.signature))
+
+	var body struct {
+		Event  string `json:"event"`
+		Object struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			Amount struct {
+				Value string `json:"value"`
+			} `json:"amount"`
+		} `json:"object"`
+	}
+	require.NoError(t, json.Unmarshal(hook.body, &body))
+	assert.Equal(t, "payment.succeeded", body.Event)
+	assert.Equal(t, payment.ID, body.Object.ID)
+	assert.Equal(t, "succeeded", body.Object.Status)
+	assert.Equal(t, "123.45", body.Object.Amount.Value)
+
+	// Повторное решение по тому же платежу отклоняется.
+	w = resolve(router, payment.ConfirmationURL, "cancel")
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestFakeProvider_FailedWebhookKeepsPaymentPending(t *testing.T) {
+	p, router, received := newFakeEnv(t, http.StatusInternalServerError)
+
+	payment, err := p.CreatePayment(context.Background(), 100, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+
+	w := resolve(router, payment.ConfirmationURL, "cancel")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Len(t, received, 3, "webhook is retried")
+
+	again, err := p.CreatePayment(context.Background(), 100, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", again.Status)
+}
+
+func TestFakeProvider_UnknownAction(t *testing.T) {
+	p, router, _ := newFakeEnv(t, http.StatusOK)
+
+	payment, err := p.CreatePayment(context.Background(), 100, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+
+	w := resolve(router, payment.ConfirmationURL, "refund")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFakeProvider_CancelPayment(t *testing.T) {
+	p, router, received := newFakeEnv(t, http.StatusOK)
+
+	payment, err := p.CreatePayment(context.Background(), 100, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, p.CancelPayment(context.Background(), payment.ID, "cancel-1"))
+	require.NoError(t, p.CancelPayment(context.Background(), payment.ID, "cancel-1"), "repeated cancel is a no-op")
+
+	hook := <-received
+	assert.Contains(t, string(hook.body), `"event":"payment.canceled"`)
+
+	// Отменённый платёж оплатить уже нельзя.
+	w := resolve(router, payment.ConfirmationURL, "succeed")
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestFakeProvider_RejectsReceiptMismatch(t *testing.T) {
+	p, _, _ := newFakeEnv(t, http.StatusOK)
+
+	receipt := &models.Receipt{Email: "user@example.com", Items: []models.ReceiptItem{
+		{Description: "Кроссовки", Quantity: 2, Price: 5000},
+	}}
+
+	_, err := p.CreatePayment(context.Background(), 9999, "RUB", "Order #1", "key-1", receipt)
+	require.Error(t, err)
+
+	_, err = p.CreatePayment(context.Background(), 10000, "RUB", "Order #1", "key-1", receipt)
+	require.NoError(t, err)
+}
+
+func TestFakeProvider_GetRefund(t *testing.T) {
+	p, router, received := newFakeEnv(t, http.StatusOK)
+
+	payment, err := p.CreatePayment(context.Background(), 10000, "RUB", "Order #1", "key-1", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSeeOther, resolve(router, payment.ConfirmationURL, "succeed").Code)
+	<-received
+
+	refund, err := p.RefundPayment(context.Background(), payment.ID, 3000, "RUB", "Refund", "refund-1", nil)
+	require.NoError(t, err)
+
+	got, err := p.GetRefund(context.Background(), refund.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", got.Status)
+	assert.Equal(t, 3000, got.Amount)
+	assert.Equal(t, "RUB", got.Currency)
+
+	_, err = p.GetRefund(context.Background(), "fake-refund-unknown")
+	assert.ErrorIs(t, err, models.ErrProviderRefundNotFound)
+}
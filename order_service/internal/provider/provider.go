@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"order_service/internal/models"
+)
+
+// Имена провайдеров в конфигурации (payment.provider).
+const (
+	NameYooKassa = "yookassa"
+	NameFake     = "fake"
+)
+
+// Provider — платёжный провайдер.
+type Provider interface {
+	CreatePayment(ctx context.Context, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.PaymentProviderResponse, error)
+	RefundPayment(ctx context.Context, paymentID string, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.RefundProviderResponse, error)
+	CancelPayment(ctx context.Context, paymentID, idempotenceKey string) error
+	GetPayment(ctx context.Context, paymentID string) (*models.PaymentProviderResponse, error)
+	GetRefund(ctx context.Context, refundID string) (*models.RefundProviderResponse, error)
+}
+
+// Starter — провайдер, которому нужна подготовка при запуске сервиса,
+// например регистрация URL вебхуков.
+type Starter interface {
+	Start(ctx context.Context)
+}
+
+// RouteRegistrar — провайдер, который сам обслуживает HTTP-страницы.
+type RouteRegistrar interface {
+	RegisterRoutes(router gin.IRouter)
+}
+
+// Factory создаёт провайдер.
+type Factory func() (Provider, error)
+
+// Registry хранит фабрики провайдеров по имени; нужный выбирается конфигом.
+type Registry struct {
+	factories map[string]Factory
+}
+
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+func (r *Registry) Register(name string, factory Factory) {
+	r.factories[name] = factory
+}
+
+// Build создаёт провайдер name. Неизвестное имя — ошибка со списком доступных.
+func (r *Registry) Build(name string) (Provider, error) {
+	const op = "provider.Registry.Build"
+
+	factory, ok := r.factories[name]
+	if !ok {
+		names := make([]string, 0, len(r.factories))
+		for n := range r.factories {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%s: unknown payment provider %q (available: %s)", op, name, strings.Join(names, ", "))
+	}
+
+	p, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, name, err)
+	}
+	return p, nil
+}
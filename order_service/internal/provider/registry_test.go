@@ -0,0 +1,51 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/provider"
+)
+
+type stubProvider struct{}
+
+func (stubProvider) CreatePayment(context.Context, int, string, string, string, *models.Receipt) (*models.PaymentProviderResponse, error) {
+	return &models.PaymentProviderResponse{ID: "stub"}, nil
+}
+
+func (stubProvider) RefundPayment(context.Context, string, int, string, string, string, *models.Receipt) (*models.RefundProviderResponse, error) {
+	return &models.RefundProviderResponse{ID: "stub-refund"}, nil
+}
+
+func (stubProvider) CancelPayment(context.Context, string, string) error {
+	return nil
+}
+
+func (stubProvider) GetPayment(context.Context, string) (*models.PaymentProviderResponse, error) {
+	return &models.PaymentProviderResponse{ID: "stub"}, nil
+}
+
+func (stubProvider) GetRefund(context.Context, string) (*models.RefundProviderResponse, error) {
+	return &models.RefundProviderResponse{ID: "stub-refund"}, nil
+}
+
+func TestRegistry_Build(t *testing.T) {
+	registry := provider.NewRegistry()
+	registry.Register("stub", func() (provider.Provider, error) { return stubProvider{}, nil })
+	registry.Register("broken", func() (provider.Provider, error) { return nil, errors.New("no credentials") })
+
+	p, err := registry.Build("stub")
+	require.NoError(t, err)
+	assert.IsType(t, stubProvider{}, p)
+
+	_, err = registry.Build("broken")
+	require.ErrorContains(t, err, "no credentials")
+
+	_, err = registry.Build("paypal")
+	require.ErrorContains(t, err, `unknown payment provider "paypal" (available: broken, stub)`)
+}
@@ -9,16 +9,20 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
-
+	"order_service/internal/lib/money"
 	"order_service/internal/models"
 )
 
-const baseURL = "https://api.yookassa.ru/v3"
+// DefaultYooKassaBaseURL — адрес боевого API ЮKassa.
+const DefaultYooKassaBaseURL = "https://api.yookassa.ru/v3"
 
 type YooKassaProvider struct {
+	baseURL         string
 	shopID          string
 	secretKey       string
 	returnURL       string
@@ -27,8 +31,14 @@ type YooKassaProvider struct {
 	log             *slog.Logger
 }
 
-func NewYooKassaProvider(shopID, secretKey, returnURL, notificationURL string, httpTimeout time.Duration, log *slog.Logger) *YooKassaProvider {
+// NewYooKassaProvider создаёт клиент API ЮKassa. baseURL можно направить
+// на локальную заглушку; пустой — DefaultYooKassaBaseURL.
+func NewYooKassaProvider(baseURL, shopID, secretKey, returnURL, notificationURL string, httpTimeout time.Duration, log *slog.Logger) *YooKassaProvider {
+	if baseURL == "" {
+		baseURL = DefaultYooKassaBaseURL
+	}
 	return &YooKassaProvider{
+		baseURL:         strings.TrimRight(baseURL, "/"),
 		shopID:          shopID,
 		secretKey:       secretKey,
 		returnURL:       returnURL,
@@ -48,6 +58,50 @@ type paymentRequest struct {
 	Confirmation requestConfirmation `json:"confirmation"`
 	Description  string              `json:"description"`
 	Metadata     map[string]string   `json:"metadata,omitempty"`
+	Receipt      *receipt            `json:"receipt,omitempty"`
+}
+
+// receipt — чек по 54-ФЗ в формате API ЮKassa.
+type receipt struct {
+	Customer      receiptCustomer `json:"customer"`
+	Items         []receiptItem   `json:"items"`
+	TaxSystemCode int             `json:"tax_system_code,omitempty"`
+}
+
+type receiptCustomer struct {
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+type receiptItem struct {
+	Description    string `json:"description"`
+	Quantity       string `json:"quantity"`
+	Amount         amount `json:"amount"` // цена за единицу
+	VATCode        int    `json:"vat_code"`
+	PaymentSubject string `json:"payment_subject"`
+	PaymentMode    string `json:"payment_mode"`
+}
+
+func receiptToRequest(r *models.Receipt, currency string) *receipt {
+	if r == nil {
+		return nil
+	}
+	out := &receipt{
+		Customer:      receiptCustomer{Email: r.Email, Phone: r.Phone},
+		Items:         make([]receiptItem, len(r.Items)),
+		TaxSystemCode: r.TaxSystemCode,
+	}
+	for i, item := range r.Items {
+		out.Items[i] = receiptItem{
+			Description:    item.Description,
+			Quantity:       strconv.Itoa(item.Quantity),
+			Amount:         amount{Value: money.Format(item.Price), Currency: currency},
+			VATCode:        item.VATCode,
+			PaymentSubject: item.PaymentSubject,
+			PaymentMode:    item.PaymentMode,
+		}
+	}
+	return out
 }
 
 type amount struct {
@@ -73,12 +127,14 @@ type paymentResponse struct {
 	Paid         bool                 `json:"paid"`
 }
 
-func (p *YooKassaProvider) CreatePayment(ctx context.Context, amountVal int, currency, description string) (*models.PaymentProviderResponse, error) {
+// CreatePayment создаёт платёж с немедленным списанием. Если передан receipt,
+// ЮKassa формирует по нему чек прихода.
+func (p *YooKassaProvider) CreatePayment(ctx context.Context, amountVal int, currency, description, idempotenceKey string, rcpt *models.Receipt) (*models.PaymentProviderResponse, error) {
 	const op = "provider.YooKassaProvider.CreatePayment"
 
 	reqBody := paymentRequest{
 		Amount: amount{
-			Value:    fmt.Sprintf("%.2f", float64(amountVal)/100.0),
+			Value:    money.Format(amountVal),
 			Currency: currency,
 		},
 		Capture: true,
@@ -87,6 +143,7 @@ func (p *YooKassaProvider) CreatePayment(ctx context.Context, amountVal int, cur
 			ReturnURL: p.returnURL,
 		},
 		Description: description,
+		Receipt:     receiptToRequest(rcpt, currency),
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -94,13 +151,13 @@ func (p *YooKassaProvider) CreatePayment(ctx context.Context, amountVal int, cur
 		return nil, fmt.Errorf("%s: marshal request: %w", op, err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/payments", bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/payments", bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("%s: create request: %w", op, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Idempotence-Key", uuid.New().String())
+	req.Header.Set("Idempotence-Key", idempotenceKey)
 	req.Header.Set("Authorization", p.authHeader())
 
 	resp, err := p.client.Do(req)
@@ -139,3 +196,204 @@ func (p *YooKassaProvider) CreatePayment(ctx context.Context, amountVal int, cur
 		ConfirmationURL: pr.Confirmation.ConfirmationURL,
 	}, nil
 }
+
+// GetPayment запрашивает платёж paymentID; 404 — models.ErrPaymentNotFound.
+func (p *YooKassaProvider) GetPayment(ctx context.Context, paymentID string) (*models.PaymentProviderResponse, error) {
+	const op = "provider.YooKassaProvider.GetPayment"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/payments/"+url.PathEscape(paymentID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: create request: %w", op, err)
+	}
+	req.Header.Set("Authorization", p.authHeader())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: send request: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: read response body: %w", op, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %s: %w", op, paymentID, models.ErrPaymentNotFound)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s: yookassa api error: status %d", op, resp.StatusCode)
+	}
+
+	var pr paymentResponse
+	if err := json.Unmarshal(bodyBytes, &pr); err != nil {
+		return nil, fmt.Errorf("%s: decode response: %w", op, err)
+	}
+	kopecks, err := money.Parse(pr.Amount.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.PaymentProviderResponse{
+		ID:              pr.ID,
+		Status:          pr.Status,
+		ConfirmationURL: pr.Confirmation.ConfirmationURL,
+		Amount:          kopecks,
+		Currency:        pr.Amount.Currency,
+	}, nil
+}
+
+// CancelPayment отменяет платёж paymentID. ЮKassa отменяет только платежи
+// в статусе waiting_for_capture; неоплаченный pending-платёж истекает у неё сам,
+// и на такой запрос API отвечает ошибкой.
+func (p *YooKassaProvider) CancelPayment(ctx context.Context, paymentID, idempotenceKey string) error {
+	const op = "provider.YooKassaProvider.CancelPayment"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.baseURL+"/payments/"+url.PathEscape(paymentID)+"/cancel", strings.NewReader("{}"))
+	if err != nil {
+		return fmt.Errorf("%s: create request: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotence-Key", idempotenceKey)
+	req.Header.Set("Authorization", p.authHeader())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: send request: %w", op, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s: yookassa api error: status %d", op, resp.StatusCode)
+	}
+
+	p.log.Info("yookassa payment canceled",
+		slog.String("op", op),
+		slog.String("payment_id", paymentID),
+	)
+	return nil
+}
+
+type refundRequest struct {
+	PaymentID   string   `json:"payment_id"`
+	Amount      amount   `json:"amount"`
+	Description string   `json:"description,omitempty"`
+	Receipt     *receipt `json:"receipt,omitempty"`
+}
+
+type refundResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Amount amount `json:"amount"`
+}
+
+// RefundPayment возвращает amount копеек по платежу paymentID (полностью или частично).
+// Если передан receipt, ЮKassa формирует по нему чек возврата прихода.
+func (p *YooKassaProvider) RefundPayment(ctx context.Context, paymentID string, amountVal int, currency, description, idempotenceKey string, rcpt *models.Receipt) (*models.RefundProviderResponse, error) {
+	const op = "provider.YooKassaProvider.RefundPayment"
+
+	body, err := json.Marshal(refundRequest{
+		PaymentID:   paymentID,
+		Amount:      amount{Value: money.Format(amountVal), Currency: currency},
+		Description: description,
+		Receipt:     receiptToRequest(rcpt, currency),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: marshal request: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/refunds", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: create request: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotence-Key", idempotenceKey)
+	req.Header.Set("Authorization", p.authHeader())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: send request: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: read response body: %w", op, err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		p.log.Error("yookassa api error",
+			slog.String("op", op),
+			slog.Int("status_code", resp.StatusCode),
+		)
+		// 4xx (кроме 429) — запрос отклонён и возврат не проведён; после 5xx
+		// возврат мог пройти, его повторяют с тем же ключом.
+		if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%s: status %d: %w", op, resp.StatusCode, models.ErrRefundRejected)
+		}
+		return nil, fmt.Errorf("%s: yookassa api error: status %d", op, resp.StatusCode)
+	}
+
+	var rr refundResponse
+	if err := json.Unmarshal(bodyBytes, &rr); err != nil {
+		return nil, fmt.Errorf("%s: decode response: %w", op, err)
+	}
+
+	p.log.Info("yookassa refund created",
+		slog.String("op", op),
+		slog.String("payment_id", paymentID),
+		slog.String("refund_id", rr.ID),
+		slog.String("status", rr.Status),
+	)
+
+	return &models.RefundProviderResponse{ID: rr.ID, Status: rr.Status}, nil
+}
+
+// GetRefund запрашивает возврат refundID; 404 — models.ErrProviderRefundNotFound.
+func (p *YooKassaProvider) GetRefund(ctx context.Context, refundID string) (*models.RefundProviderResponse, error) {
+	const op = "provider.YooKassaProvider.GetRefund"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/refunds/"+url.PathEscape(refundID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: create request: %w", op, err)
+	}
+	req.Header.Set("Authorization", p.authHeader())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: send request: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: read response body: %w", op, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %s: %w", op, refundID, models.ErrProviderRefundNotFound)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s: yookassa api error: status %d", op, resp.StatusCode)
+	}
+
+	var rr refundResponse
+	if err := json.Unmarshal(bodyBytes, &rr); err != nil {
+		return nil, fmt.Errorf("%s: decode response: %w", op, err)
+	}
+	kopecks, err := money.Parse(rr.Amount.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.RefundProviderResponse{
+		ID:       rr.ID,
+		Status:   rr.Status,
+		Amount:   kopecks,
+		Currency: rr.Amount.Currency,
+	}, nil
+}
@@ -0,0 +1,184 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/provider"
+)
+
+func TestYooKassaProvider_UsesConfiguredBaseURL(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/payments", r.URL.Path)
+		assert.Equal(t, "order-key", r.Header.Get("Idempotence-Key"))
+
+		var req struct {
+			Amount struct {
+				Value string `json:"value"`
+			} `json:"amount"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "150.00", req.Amount.Value)
+
+		_, _ = w.Write([]byte(`{"id":"stub-1","status":"pending","confirmation":{"confirmation_url":"http://stub/pay"}}`))
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL+"/v3/", "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	resp, err := p.CreatePayment(context.Background(), 15000, "RUB", "Order #1", "order-key", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "stub-1", resp.ID)
+	assert.Equal(t, "http://stub/pay", resp.ConfirmationURL)
+}
+
+func TestYooKassaProvider_CancelPayment(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/payments/pay-1/cancel", r.URL.Path)
+		assert.Equal(t, "cancel-key", r.Header.Get("Idempotence-Key"))
+		_, _ = w.Write([]byte(`{"id":"pay-1","status":"canceled"}`))
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL+"/v3", "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	require.NoError(t, p.CancelPayment(context.Background(), "pay-1", "cancel-key"))
+}
+
+func TestYooKassaProvider_GetPayment(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/v3/payments/pay-1":
+			_, _ = w.Write([]byte(`{"id":"pay-1","status":"succeeded","amount":{"value":"1234.5","currency":"RUB"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL+"/v3", "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	resp, err := p.GetPayment(context.Background(), "pay-1")
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", resp.Status)
+	assert.Equal(t, 123450, resp.Amount)
+	assert.Equal(t, "RUB", resp.Currency)
+
+	_, err = p.GetPayment(context.Background(), "unknown")
+	assert.ErrorIs(t, err, models.ErrPaymentNotFound)
+}
+
+func TestYooKassaProvider_GetRefund(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/v3/refunds/rf-1":
+			_, _ = w.Write([]byte(`{"id":"rf-1","status":"succeeded","amount":{"value":"300.00","currency":"RUB"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL+"/v3", "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	resp, err := p.GetRefund(context.Background(), "rf-1")
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", resp.Status)
+	assert.Equal(t, 30000, resp.Amount)
+	assert.Equal(t, "RUB", resp.Currency)
+
+	_, err = p.GetRefund(context.Background(), "unknown")
+	assert.ErrorIs(t, err, models.ErrProviderRefundNotFound)
+}
+
+func TestYooKassaProvider_SendsReceipt(t *testing.T) {
+	var got map[string]any
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"id":"refund-1","status":"succeeded"}`))
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL, "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	_, err := p.RefundPayment(context.Background(), "pay-1", 15050, "RUB", "Refund", "refund-key", &models.Receipt{
+		Email: "user@example.com",
+		Items: []models.ReceiptItem{
+			{Description: "Кроссовки, размер 42", Quantity: 3, Price: 5000, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+			{Description: "Доставка", Quantity: 1, Price: 50, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectService, PaymentMode: models.PaymentModeFullPrepayment},
+		},
+	})
+	require.NoError(t, err)
+
+	receipt, ok := got["receipt"].(map[string]any)
+	require.True(t, ok, "receipt is sent")
+	assert.Equal(t, map[string]any{"email": "user@example.com"}, receipt["customer"])
+	assert.NotContains(t, receipt, "tax_system_code")
+
+	items := receipt["items"].([]any)
+	require.Len(t, items, 2)
+	assert.Equal(t, map[string]any{
+		"description":     "Кроссовки, размер 42",
+		"quantity":        "3",
+		"amount":          map[string]any{"value": "50.00", "currency": "RUB"},
+		"vat_code":        float64(1),
+		"payment_subject": "commodity",
+		"payment_mode":    "full_prepayment",
+	}, items[0])
+	assert.Equal(t, "service", items[1].(map[string]any)["payment_subject"])
+}
+
+func TestYooKassaProvider_NoReceipt(t *testing.T) {
+	var got map[string]any
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"id":"stub-1","status":"pending"}`))
+	}))
+	defer stub.Close()
+
+	p := provider.NewYooKassaProvider(stub.URL, "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+	_, err := p.CreatePayment(context.Background(), 15000, "RUB", "Order #1", "order-key", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "receipt")
+}
+
+func TestYooKassaProvider_RefundRejection(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		rejected bool
+	}{
+		{"bad request", http.StatusBadRequest, true},
+		{"too many requests", http.StatusTooManyRequests, false},
+		{"server error", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer stub.Close()
+
+			p := provider.NewYooKassaProvider(stub.URL, "shop", "secret", "http://localhost/", "", time.Second, newTestLogger())
+
+			_, err := p.RefundPayment(context.Background(), "pay-1", 100, "RUB", "Refund", "refund-key", nil)
+			require.Error(t, err)
+			assert.Equal(t, tt.rejected, errors.Is(err, models.ErrRefundRejected))
+		})
+	}
+}
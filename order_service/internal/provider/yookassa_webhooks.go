@@ -28,6 +28,11 @@ type webhookListResponse struct {
 	Items []webhookItem `json:"items"`
 }
 
+// Start регистрирует вебхуки при запуске сервиса.
+func (p *YooKassaProvider) Start(ctx context.Context) {
+	p.RegisterWebhooks(ctx)
+}
+
 // RegisterWebhooks регистрирует URL вебхуков в ЮKassa, Удаляет устаревшие вебхуки,
 func (p *YooKassaProvider) RegisterWebhooks(ctx context.Context) {
 	const op = "provider.YooKassaProvider.RegisterWebhooks"
@@ -50,6 +55,7 @@ func (p *YooKassaProvider) RegisterWebhooks(ctx context.Context) {
 	desiredEvents := map[string]bool{
 		"payment.succeeded": false,
 		"payment.canceled":  false,
+		"refund.succeeded":  false,
 	}
 
 	for _, wh := range existing {
@@ -81,7 +87,7 @@ func (p *YooKassaProvider) RegisterWebhooks(ctx context.Context) {
 }
 
 func (p *YooKassaProvider) listWebhooks(ctx context.Context) ([]webhookItem, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/webhooks", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/webhooks", nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -110,7 +116,7 @@ func (p *YooKassaProvider) createWebhook(ctx context.Context, event, url string)
 		return fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/webhooks", bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/webhooks", bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -132,7 +138,7 @@ func (p *YooKassaProvider) createWebhook(ctx context.Context, event, url string)
 }
 
 func (p *YooKassaProvider) deleteWebhook(ctx context.Context, id string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, baseURL+"/webhooks/"+id, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, p.baseURL+"/webhooks/"+id, nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -0,0 +1,21 @@
+package reconciliation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	checkedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "order_reconciliation_checked_total",
+		Help: "Pending payments checked against the payment provider.",
+	})
+	mismatchesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "order_reconciliation_mismatches_total",
+		Help: "Mismatches between local and provider payment state, by kind and whether they were resolved.",
+	}, []string{"kind", "resolved"})
+	lastRunTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "order_reconciliation_last_run_timestamp_seconds",
+		Help: "Unix time of the last finished reconciliation run.",
+	})
+)
@@ -0,0 +1,114 @@
+package reconciliation
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"order_service/internal/models"
+)
+
+// Reconciler сверяет ожидающие платежи с провайдером.
+type Reconciler interface {
+	ReconcilePayments(ctx context.Context, pendingBefore time.Time, limit int) (*models.ReconciliationReport, error)
+}
+
+// Config — настройки сверки.
+type Config struct {
+	Interval time.Duration
+	// PendingThreshold — сколько платёж может ждать вебхука, прежде чем
+	// его статус запросят у провайдера.
+	PendingThreshold time.Duration
+	BatchSize        int
+}
+
+// Worker раз в Interval сверяет платежи, ожидающие дольше PendingThreshold.
+// Расхождения сохраняет Reconciler; воркер пишет их в лог и метрики.
+type Worker struct {
+	reconciler Reconciler
+	cfg        Config
+	log        *slog.Logger
+}
+
+func NewWorker(reconciler Reconciler, cfg Config, log *slog.Logger) *Worker {
+	return &Worker{
+		reconciler: reconciler,
+		cfg:        cfg,
+		log:        log,
+	}
+}
+
+// Run сверяет платежи раз в Interval, пока не отменён ctx.
+func (w *Worker) Run(ctx context.Context) {
+	const op = "reconciliation.Worker.Run"
+
+	w.log.Info("payment reconciliation started",
+		slog.String("op", op),
+		slog.Duration("interval", w.cfg.Interval),
+		slog.Duration("pending_threshold", w.cfg.PendingThreshold),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("payment reconciliation stopped", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+
+		w.runOnce(ctx)
+	}
+}
+
+// runOnce сверяет пачки, пока они приходят полными, и сводит их в один отчёт.
+func (w *Worker) runOnce(ctx context.Context) *models.ReconciliationReport {
+	const op = "reconciliation.Worker.runOnce"
+
+	total := &models.ReconciliationReport{StartedAt: time.Now()}
+	for ctx.Err() == nil {
+		report, err := w.reconciler.ReconcilePayments(ctx, time.Now().Add(-w.cfg.PendingThreshold), w.cfg.BatchSize)
+		if err != nil {
+			w.log.Error("failed to reconcile payments",
+				slog.String("op", op),
+				slog.String("error", err.Error()),
+			)
+			break
+		}
+		total.Checked += report.Checked
+		total.Mismatches = append(total.Mismatches, report.Mismatches...)
+		if report.Checked < w.cfg.BatchSize {
+			break
+		}
+	}
+	total.FinishedAt = time.Now()
+
+	checkedTotal.Add(float64(total.Checked))
+	lastRunTimestamp.Set(float64(total.FinishedAt.Unix()))
+	for _, m := range total.Mismatches {
+		mismatchesTotal.WithLabelValues(m.Kind, strconv.FormatBool(m.Resolved)).Inc()
+		w.log.Warn("payment reconciliation mismatch",
+			slog.String("op", op),
+			slog.String("kind", m.Kind),
+			slog.Int("order_id", m.OrderID),
+			slog.String("payment_id", m.PaymentID),
+			slog.String("local_status", m.LocalStatus),
+			slog.String("provider_status", m.ProviderStatus),
+			slog.Int("local_amount", m.LocalAmount),
+			slog.Int("provider_amount", m.ProviderAmount),
+			slog.Bool("resolved", m.Resolved),
+			slog.String("error", m.Error),
+		)
+	}
+	if total.Checked > 0 {
+		w.log.Info("payment reconciliation finished",
+			slog.String("op", op),
+			slog.Int("checked", total.Checked),
+			slog.Int("mismatches", len(total.Mismatches)),
+		)
+	}
+	return total
+}
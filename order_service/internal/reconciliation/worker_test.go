@@ -0,0 +1,66 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+// fakeReconciler отдаёт заранее заданные отчёты по одному на вызов.
+type fakeReconciler struct {
+	reports []*models.ReconciliationReport
+	err     error
+	calls   int
+}
+
+func (r *fakeReconciler) ReconcilePayments(context.Context, time.Time, int) (*models.ReconciliationReport, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.reports) == 0 {
+		return &models.ReconciliationReport{}, nil
+	}
+	report := r.reports[0]
+	r.reports = r.reports[1:]
+	return report, nil
+}
+
+func newTestWorker(r Reconciler) *Worker {
+	return NewWorker(r, Config{Interval: time.Minute, PendingThreshold: 10 * time.Minute, BatchSize: 2},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWorker_MergesBatchesIntoOneReport(t *testing.T) {
+	r := &fakeReconciler{reports: []*models.ReconciliationReport{
+		{Checked: 2, Mismatches: []models.ReconciliationMismatch{{Kind: models.MismatchStatus, OrderID: 1, Resolved: true}}},
+		{Checked: 1, Mismatches: []models.ReconciliationMismatch{{Kind: models.MismatchAmount, OrderID: 3}}},
+	}}
+	w := newTestWorker(r)
+
+	report := w.runOnce(context.Background())
+	require.NotNil(t, report)
+	assert.Equal(t, 2, r.calls, "stops after a partial batch")
+	assert.Equal(t, 3, report.Checked)
+	assert.Len(t, report.Mismatches, 2)
+	assert.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestWorker_ErrorKeepsPartialReport(t *testing.T) {
+	r := &fakeReconciler{err: errors.New("db down")}
+	w := newTestWorker(r)
+
+	report := w.runOnce(context.Background())
+
+	require.NotNil(t, report)
+	assert.Equal(t, 0, report.Checked)
+	assert.Equal(t, 1, r.calls)
+}
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"order_service/internal/models"
@@ -18,6 +23,9 @@ func NewOrderRepository(pool *pgxpool.Pool) *OrderRepository {
 	return &OrderRepository{pool: pool}
 }
 
+// Create сохраняет заказ с позициями и скидками. Если применён промокод, лимит его
+// использований пользователем проверяется под блокировкой промокода; превышен —
+// models.ErrPromoUsageExceeded.
 func (r *OrderRepository) Create(ctx context.Context, order *models.Order, items []models.OrderItem) (*models.OrderWithItems, error) {
 	const op = "repository.OrderRepository.Create"
 
@@ -27,29 +35,75 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order, items
 	}
 	defer tx.Rollback(ctx)
 
+	if order.PromoCodeID != 0 {
+		if err := checkPromoUsage(ctx, tx, order.PromoCodeID, order.UserID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	now := time.Now()
 
 	var orderID int
 	err = tx.QueryRow(ctx,
-		`INSERT INTO orders (user_id, status, total_amount, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
-		order.UserID, order.Status, order.TotalAmount, now, now,
+		`INSERT INTO orders (user_id, status, total_amount, idempotency_key, request_hash,
+		                     delivery_method, delivery_cost, shipping_address,
+		                     promo_code_id, promo_code, discount_amount, customer_email, created_at, updated_at)
+		 VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, NULLIF($9, 0), NULLIF($10, ''), $11, $12, $13, $14)
+		 RETURNING id`,
+		order.UserID, order.Status, order.TotalAmount, order.IdempotencyKey, order.RequestHash,
+		order.DeliveryMethod, order.DeliveryCost, order.ShippingAddress,
+		order.PromoCodeID, order.PromoCode, order.DiscountAmount, order.CustomerEmail, now, now,
 	).Scan(&orderID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrDuplicateIdempotencyKey)
+		}
 		return nil, fmt.Errorf("%s: insert order: %w", op, err)
 	}
 
 	for i, item := range items {
 		_, err = tx.Exec(ctx,
-			`INSERT INTO order_items (order_id, sneaker_id, quantity, price_at_purchase, created_at)
-			 VALUES ($1, $2, $3, $4, $5)`,
-			orderID, item.SneakerID, item.Quantity, item.PriceAtPurchase, now,
+			`INSERT INTO order_items (order_id, sneaker_id, variant_id, quantity, price_at_purchase, title, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			orderID, item.SneakerID, item.VariantID, item.Quantity, item.PriceAtPurchase, item.Title, now,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: insert item[%d]: %w", op, i, err)
 		}
 	}
 
+	for i, d := range order.Discounts {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO order_discounts (order_id, sneaker_id, variant_id, amount)
+			 VALUES ($1, $2, $3, $4)`,
+			orderID, d.SneakerID, d.VariantID, d.Amount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: insert discount[%d]: %w", op, i, err)
+		}
+	}
+
+	if err := insertOutboxEvent(ctx, tx, models.OrderEvent{
+		EventType:      models.EventOrderCreated,
+		OrderID:        orderID,
+		UserID:         order.UserID,
+		Status:         order.Status,
+		TotalAmount:    order.TotalAmount,
+		CustomerEmail:  order.CustomerEmail,
+		PromoCode:      order.PromoCode,
+		DiscountAmount: order.DiscountAmount,
+		Timestamp:      now.Format(time.RFC3339),
+	}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Заказ всегда создаёт покупатель.
+	if err := insertStatusHistory(ctx, tx, orderID, "", order.Status,
+		models.StatusChange{Source: models.StatusSourceUser}, now); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("%s: commit tx: %w", op, err)
 	}
@@ -57,16 +111,53 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order, items
 	return r.GetByID(ctx, orderID)
 }
 
+// checkPromoUsage блокирует промокод до конца транзакции и проверяет, что пользователь
+// не исчерпал лимит: параллельные заказы с тем же кодом ждут друг друга. Использованием
+// считается только оплаченный заказ, деньги по которому не возвращены целиком
+// (models.RefundableStatuses): неоплаченные, отменённые и возвращённые код не занимают.
+func checkPromoUsage(ctx context.Context, tx pgx.Tx, promoCodeID, userID int) error {
+	var maxUses int
+	if err := tx.QueryRow(ctx,
+		`SELECT max_uses_per_user FROM promo_codes WHERE id = $1 FOR UPDATE`, promoCodeID,
+	).Scan(&maxUses); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrPromoCodeNotFound
+		}
+		return fmt.Errorf("lock promo code: %w", err)
+	}
+	if maxUses == 0 {
+		return nil
+	}
+
+	var used int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM orders
+		 WHERE promo_code_id = $1 AND user_id = $2 AND status = ANY($3)`,
+		promoCodeID, userID, models.RefundableStatuses,
+	).Scan(&used); err != nil {
+		return fmt.Errorf("count promo usage: %w", err)
+	}
+	if used >= maxUses {
+		return models.ErrPromoUsageExceeded
+	}
+	return nil
+}
+
 func (r *OrderRepository) GetByID(ctx context.Context, orderID int) (*models.OrderWithItems, error) {
 	const op = "repository.OrderRepository.GetByID"
 
 	var o models.Order
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, user_id, status, total_amount,
+		`SELECT id, user_id, status, total_amount, refunded_amount,
 		        COALESCE(payment_url, '') AS payment_url,
+		        delivery_method, delivery_cost, shipping_address, carrier, tracking_number,
+		        COALESCE(promo_code_id, 0), COALESCE(promo_code, ''), discount_amount, customer_email,
 		        created_at, updated_at
 		 FROM orders WHERE id = $1`, orderID,
-	).Scan(&o.ID, &o.UserID, &o.Status, &o.TotalAmount, &o.PaymentURL, &o.CreatedAt, &o.UpdatedAt)
+	).Scan(&o.ID, &o.UserID, &o.Status, &o.TotalAmount, &o.RefundedAmount, &o.PaymentURL,
+		&o.DeliveryMethod, &o.DeliveryCost, &o.ShippingAddress, &o.Carrier, &o.TrackingNumber,
+		&o.PromoCodeID, &o.PromoCode, &o.DiscountAmount, &o.CustomerEmail,
+		&o.CreatedAt, &o.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("%s: query order: %w", op, err)
 	}
@@ -76,24 +167,142 @@ func (r *OrderRepository) GetByID(ctx context.Context, orderID int) (*models.Ord
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if o.PromoCodeID != 0 {
+		o.Discounts, err = r.getDiscountsByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	return &models.OrderWithItems{Order: o, Items: items}, nil
 }
 
-func (r *OrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error) {
+// GetByIdempotencyKey ищет заказ пользователя по ключу идемпотентности.
+// Если заказа нет, возвращает nil, nil.
+func (r *OrderRepository) GetByIdempotencyKey(ctx context.Context, userID int, key string) (*models.OrderWithItems, error) {
+	const op = "repository.OrderRepository.GetByIdempotencyKey"
+
+	var (
+		orderID     int
+		requestHash string
+	)
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, COALESCE(request_hash, '') FROM orders WHERE user_id = $1 AND idempotency_key = $2`, userID, key,
+	).Scan(&orderID, &requestHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := r.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	order.IdempotencyKey = key
+	order.RequestHash = requestHash
+	return order, nil
+}
+
+// ClearIdempotencyKey освобождает ключ заказа, чтобы его можно было использовать снова.
+func (r *OrderRepository) ClearIdempotencyKey(ctx context.Context, orderID int) error {
+	const op = "repository.OrderRepository.ClearIdempotencyKey"
+
+	if _, err := r.pool.Exec(ctx,
+		`UPDATE orders SET idempotency_key = NULL WHERE id = $1`, orderID,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetUserOrders возвращает до limit заказов пользователя с id меньше beforeID
+// (0 — с самого нового) вместе с позициями, от новых к старым.
+func (r *OrderRepository) GetUserOrders(ctx context.Context, userID, beforeID, limit int) ([]*models.OrderWithItems, error) {
 	const op = "repository.OrderRepository.GetUserOrders"
 
+	orders, err := r.listOrders(ctx, models.OrderFilter{UserID: userID}, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return orders, nil
+}
+
+// ListOrders возвращает до limit заказов, подходящих под filter, с id меньше
+// beforeID (0 — с самого нового) вместе с позициями, от новых к старым.
+func (r *OrderRepository) ListOrders(ctx context.Context, filter models.OrderFilter, beforeID, limit int) ([]*models.OrderWithItems, error) {
+	const op = "repository.OrderRepository.ListOrders"
+
+	orders, err := r.listOrders(ctx, filter, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return orders, nil
+}
+
+// orderFilterWhere собирает условие WHERE для filter и keyset-курсора beforeID.
+func orderFilterWhere(filter models.OrderFilter, beforeID int) (string, []any) {
+	conds := []string{"TRUE"}
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.UserID != 0 {
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		add("created_at >= $%d", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		add("created_at < $%d", filter.CreatedTo)
+	}
+	if filter.MinAmount != 0 {
+		add("total_amount >= $%d", filter.MinAmount)
+	}
+	if filter.MaxAmount != 0 {
+		add("total_amount <= $%d", filter.MaxAmount)
+	}
+	if beforeID != 0 {
+		add("id < $%d", beforeID)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+func (r *OrderRepository) listOrders(ctx context.Context, filter models.OrderFilter, beforeID, limit int) ([]*models.OrderWithItems, error) {
+	where, args := orderFilterWhere(filter, beforeID)
+	args = append(args, limit)
+
+	// Сначала выбираем страницу заказов по индексу,
+	// затем подтягиваем их позиции: LIMIT по JOIN отрезал бы позиции.
 	rows, err := r.pool.Query(ctx,
-		`SELECT o.id, o.user_id, o.status, o.total_amount,
+		`WITH page AS (
+		     SELECT id, user_id, status, total_amount, refunded_amount, payment_url,
+		            delivery_method, delivery_cost, shipping_address, carrier, tracking_number,
+		            promo_code_id, promo_code, discount_amount,
+		            created_at, updated_at
+		     FROM orders
+		     WHERE `+where+`
+		     ORDER BY id DESC
+		     LIMIT $`+strconv.Itoa(len(args))+`
+		 )
+		 SELECT o.id, o.user_id, o.status, o.total_amount, o.refunded_amount,
 		        COALESCE(o.payment_url, '') AS payment_url,
+		        o.delivery_method, o.delivery_cost, o.shipping_address, o.carrier, o.tracking_number,
+		        COALESCE(o.promo_code_id, 0), COALESCE(o.promo_code, ''), o.discount_amount,
 		        o.created_at, o.updated_at,
-		        oi.id, oi.order_id, oi.sneaker_id, oi.quantity, oi.price_at_purchase, oi.created_at
-		 FROM orders o
+		        oi.id, oi.order_id, oi.sneaker_id, oi.variant_id, oi.quantity, oi.price_at_purchase, oi.created_at
+		 FROM page o
 		 LEFT JOIN order_items oi ON o.id = oi.order_id
-		 WHERE o.user_id = $1
-		 ORDER BY o.created_at DESC, oi.id`, userID,
+		 ORDER BY o.id DESC, oi.id`, args...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%s: query orders: %w", op, err)
+		return nil, fmt.Errorf("query orders: %w", err)
 	}
 	defer rows.Close()
 
@@ -102,14 +311,17 @@ func (r *OrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*mod
 
 	for rows.Next() {
 		var o models.Order
-		var itemID, itemOrderID, itemSneakerID, itemQuantity, itemPrice *int
+		var itemID, itemOrderID, itemSneakerID, itemVariantID, itemQuantity, itemPrice *int
 		var itemCreatedAt *time.Time
 
 		if err := rows.Scan(
-			&o.ID, &o.UserID, &o.Status, &o.TotalAmount, &o.PaymentURL, &o.CreatedAt, &o.UpdatedAt,
-			&itemID, &itemOrderID, &itemSneakerID, &itemQuantity, &itemPrice, &itemCreatedAt,
+			&o.ID, &o.UserID, &o.Status, &o.TotalAmount, &o.RefundedAmount, &o.PaymentURL,
+			&o.DeliveryMethod, &o.DeliveryCost, &o.ShippingAddress, &o.Carrier, &o.TrackingNumber,
+			&o.PromoCodeID, &o.PromoCode, &o.DiscountAmount,
+			&o.CreatedAt, &o.UpdatedAt,
+			&itemID, &itemOrderID, &itemSneakerID, &itemVariantID, &itemQuantity, &itemPrice, &itemCreatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+			return nil, fmt.Errorf("scan row: %w", err)
 		}
 
 		owi, exists := ordersMap[o.ID]
@@ -124,6 +336,7 @@ func (r *OrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*mod
 				ID:              *itemID,
 				OrderID:         *itemOrderID,
 				SneakerID:       *itemSneakerID,
+				VariantID:       *itemVariantID,
 				Quantity:        *itemQuantity,
 				PriceAtPurchase: *itemPrice,
 				CreatedAt:       *itemCreatedAt,
@@ -131,7 +344,7 @@ func (r *OrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*mod
 		}
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: rows iteration: %w", op, err)
+		return nil, fmt.Errorf("rows iteration: %w", err)
 	}
 
 	result := make([]*models.OrderWithItems, 0, len(orderIDs))
@@ -142,34 +355,248 @@ func (r *OrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*mod
 	return result, nil
 }
 
-func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string) error {
+// UpdateStatus меняет статус заказа, если он всё ещё равен expectedCurrentStatus,
+// и в той же транзакции пишет в outbox событие change.EventType.
+func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string, change models.StatusChange) error {
 	const op = "repository.OrderRepository.UpdateStatus"
 
-	ct, err := r.pool.Exec(ctx,
-		`UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
-		newStatus, time.Now(), orderID, expectedCurrentStatus,
+	if err := r.updateStatus(ctx, orderID, newStatus, expectedCurrentStatus, "", "", change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Ship переводит заказ в SHIPPED и сохраняет перевозчика и трек-номер
+// в одной транзакции со сменой статуса и событием в outbox.
+func (r *OrderRepository) Ship(ctx context.Context, orderID int, expectedCurrentStatus, carrier, trackingNumber string, change models.StatusChange) error {
+	const op = "repository.OrderRepository.Ship"
+
+	if err := r.updateStatus(ctx, orderID, models.OrderStatusShipped, expectedCurrentStatus, carrier, trackingNumber, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// updateStatus меняет статус, пишет событие в outbox и запись в историю.
+// Непустые carrier и trackingNumber перезаписывают данные отправления.
+func (r *OrderRepository) updateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus, carrier, trackingNumber string, change models.StatusChange) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	var (
+		userID, totalAmount int
+		customerEmail       string
 	)
+	err = tx.QueryRow(ctx,
+		`UPDATE orders
+		 SET status = $1, updated_at = $2,
+		     carrier = COALESCE(NULLIF($5, ''), carrier),
+		     tracking_number = COALESCE(NULLIF($6, ''), tracking_number)
+		 WHERE id = $3 AND status = $4
+		 RETURNING user_id, total_amount, customer_email, carrier, tracking_number`,
+		newStatus, now, orderID, expectedCurrentStatus, carrier, trackingNumber,
+	).Scan(&userID, &totalAmount, &customerEmail, &carrier, &trackingNumber)
 	if err != nil {
-		return fmt.Errorf("%s: exec: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("order %d not found or status already changed from %q", orderID, expectedCurrentStatus)
+		}
+		return fmt.Errorf("exec: %w", err)
 	}
-	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("%s: order %d not found or status already changed from %q", op, orderID, expectedCurrentStatus)
+
+	if err := insertOutboxEvent(ctx, tx, models.OrderEvent{
+		EventType:      change.EventType,
+		OrderID:        orderID,
+		UserID:         userID,
+		Status:         newStatus,
+		TotalAmount:    totalAmount,
+		CustomerEmail:  customerEmail,
+		Reason:         change.Reason,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Timestamp:      now.Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+
+	if err := insertStatusHistory(ctx, tx, orderID, expectedCurrentStatus, newStatus, change, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
 	}
 	return nil
 }
 
+// GetStatusHistory возвращает историю статусов заказа от старых записей к новым.
+func (r *OrderRepository) GetStatusHistory(ctx context.Context, orderID int) ([]models.StatusHistoryEntry, error) {
+	const op = "repository.OrderRepository.GetStatusHistory"
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT COALESCE(from_status, ''), to_status, source, reason, COALESCE(actor_id, 0), created_at
+		 FROM order_status_history WHERE order_id = $1 ORDER BY id`, orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var history []models.StatusHistoryEntry
+	for rows.Next() {
+		var e models.StatusHistoryEntry
+		if err := rows.Scan(&e.FromStatus, &e.ToStatus, &e.Source, &e.Reason, &e.ActorID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		history = append(history, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return history, nil
+}
+
+// insertStatusHistory записывает смену статуса в историю заказа в транзакции tx.
+// Пустой from — заказ только что создан, нулевой change.ActorID пишется как NULL.
+func insertStatusHistory(ctx context.Context, tx pgx.Tx, orderID int, from, to string, change models.StatusChange, at time.Time) error {
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO order_status_history (order_id, from_status, to_status, source, reason, actor_id, created_at)
+		 VALUES ($1, NULLIF($2, ''), $3, $4, $5, NULLIF($6, 0), $7)`,
+		orderID, from, to, change.Source, change.Reason, change.ActorID, at,
+	); err != nil {
+		return fmt.Errorf("insert status history: %w", err)
+	}
+	return nil
+}
+
+// expiryClaimTTL — через сколько заказ, взятый в обработку, но так и не отменённый
+// (реплика упала, провайдер не ответил), снова попадёт в выборку ClaimExpired.
+const expiryClaimTTL = 5 * time.Minute
+
+// ClaimExpired выбирает до limit заказов в PENDING_PAYMENT, не менявшихся с pendingBefore,
+// и помечает их expiry_claimed_at. Строки блокируются FOR UPDATE SKIP LOCKED, поэтому
+// реплики, запущенные одновременно, разбирают разные заказы; отметка не даёт взять
+// заказ повторно, пока его отменяет другая реплика.
+func (r *OrderRepository) ClaimExpired(ctx context.Context, pendingBefore time.Time, limit int) ([]int, error) {
+	const op = "repository.OrderRepository.ClaimExpired"
+
+	now := time.Now()
+	rows, err := r.pool.Query(ctx,
+		`UPDATE orders SET expiry_claimed_at = $1
+		 WHERE id IN (
+		     SELECT id FROM orders
+		     WHERE status = $2 AND updated_at < $3
+		       AND (expiry_claimed_at IS NULL OR expiry_claimed_at < $4)
+		     ORDER BY updated_at
+		     LIMIT $5
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id`,
+		now, models.OrderStatusPendingPayment, pendingBefore, now.Add(-expiryClaimTTL), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return ids, nil
+}
+
+// ClaimShipped выбирает до limit заказов, отправленных перевозчиками carriers, статус
+// которых не запрашивался с polledBefore, и отмечает время запроса. Давно не
+// проверявшиеся идут первыми; FOR UPDATE SKIP LOCKED не даёт репликам взять один заказ.
+func (r *OrderRepository) ClaimShipped(ctx context.Context, carriers []string, polledBefore time.Time, limit int) ([]*models.Order, error) {
+	const op = "repository.OrderRepository.ClaimShipped"
+
+	rows, err := r.pool.Query(ctx,
+		`UPDATE orders SET tracking_polled_at = $1
+		 WHERE id IN (
+		     SELECT id FROM orders
+		     WHERE status = $2 AND carrier = ANY($5)
+		       AND (tracking_polled_at IS NULL OR tracking_polled_at < $3)
+		     ORDER BY tracking_polled_at NULLS FIRST, id
+		     LIMIT $4
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, user_id, status, carrier, tracking_number`,
+		time.Now(), models.OrderStatusShipped, polledBefore, limit, carriers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var orders []*models.Order
+	for rows.Next() {
+		var o models.Order
+		if err := rows.Scan(&o.ID, &o.UserID, &o.Status, &o.Carrier, &o.TrackingNumber); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		orders = append(orders, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return orders, nil
+}
+
+// UpdatePaymentURL сохраняет ссылку на оплату и в той же транзакции пишет в outbox
+// OrderPaymentCreated: OrderCreated записывается раньше, чем появляется платёж.
 func (r *OrderRepository) UpdatePaymentURL(ctx context.Context, orderID int, paymentURL string) error {
 	const op = "repository.OrderRepository.UpdatePaymentURL"
 
-	ct, err := r.pool.Exec(ctx,
-		`UPDATE orders SET payment_url = $1, updated_at = $2 WHERE id = $3`,
-		paymentURL, time.Now(), orderID,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	var (
+		userID, totalAmount   int
+		status, customerEmail string
 	)
+	err = tx.QueryRow(ctx,
+		`UPDATE orders SET payment_url = $1, updated_at = $2 WHERE id = $3
+		 RETURNING user_id, status, total_amount, customer_email`,
+		paymentURL, now, orderID,
+	).Scan(&userID, &status, &totalAmount, &customerEmail)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: order %d not found", op, orderID)
+		}
 		return fmt.Errorf("%s: exec: %w", op, err)
 	}
-	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("%s: order %d not found", op, orderID)
+
+	if err := insertOutboxEvent(ctx, tx, models.OrderEvent{
+		EventType:     models.EventOrderPaymentCreated,
+		OrderID:       orderID,
+		UserID:        userID,
+		Status:        status,
+		TotalAmount:   totalAmount,
+		CustomerEmail: customerEmail,
+		PaymentURL:    paymentURL,
+		Timestamp:     now.Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: commit tx: %w", op, err)
 	}
 	return nil
 }
@@ -178,8 +605,8 @@ func (r *OrderRepository) getItemsByOrderID(ctx context.Context, orderID int) ([
 	const op = "repository.OrderRepository.getItemsByOrderID"
 
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, order_id, sneaker_id, quantity, price_at_purchase, created_at
-		 FROM order_items WHERE order_id = $1`, orderID,
+		`SELECT id, order_id, sneaker_id, variant_id, quantity, price_at_purchase, title, created_at
+		 FROM order_items WHERE order_id = $1 ORDER BY id`, orderID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s: query: %w", op, err)
@@ -189,7 +616,7 @@ func (r *OrderRepository) getItemsByOrderID(ctx context.Context, orderID int) ([
 	var items []models.OrderItem
 	for rows.Next() {
 		var item models.OrderItem
-		if err := rows.Scan(&item.ID, &item.OrderID, &item.SneakerID, &item.Quantity, &item.PriceAtPurchase, &item.CreatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.SneakerID, &item.VariantID, &item.Quantity, &item.PriceAtPurchase, &item.Title, &item.CreatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		items = append(items, item)
@@ -200,3 +627,30 @@ func (r *OrderRepository) getItemsByOrderID(ctx context.Context, orderID int) ([
 
 	return items, nil
 }
+
+func (r *OrderRepository) getDiscountsByOrderID(ctx context.Context, orderID int) ([]models.OrderDiscount, error) {
+	const op = "repository.OrderRepository.getDiscountsByOrderID"
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, order_id, sneaker_id, variant_id, amount
+		 FROM order_discounts WHERE order_id = $1 ORDER BY id`, orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var discounts []models.OrderDiscount
+	for rows.Next() {
+		var d models.OrderDiscount
+		if err := rows.Scan(&d.ID, &d.OrderID, &d.SneakerID, &d.VariantID, &d.Amount); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		discounts = append(discounts, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows iteration: %w", op, err)
+	}
+
+	return discounts, nil
+}
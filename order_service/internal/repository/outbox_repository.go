@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"order_service/internal/models"
+)
+
+// outboxRelayLockKey — ключ advisory-лока relay: публикует только одна реплика,
+// иначе события одного заказа могли бы уйти в Kafka не по порядку.
+const outboxRelayLockKey int64 = 0x6f7574626f78 // "outbox"
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+// insertOutboxEvent пишет событие в outbox в рамках транзакции изменения заказа.
+func insertOutboxEvent(ctx context.Context, tx pgx.Tx, event models.OrderEvent) error {
+	if event.Timestamp == "" {
+		event.Timestamp = time.Now().Format(time.RFC3339)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal outbox event: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO outbox (aggregate_id, event_type, payload) VALUES ($1, $2, $3)`,
+		event.OrderID, event.EventType, payload,
+	); err != nil {
+		return fmt.Errorf("insert outbox event: %w", err)
+	}
+	return nil
+}
+
+// ProcessPending выбирает до limit неопубликованных сообщений в порядке записи
+// и передаёт их в handle. Сообщения заказа, у которого есть сообщение в ожидании
+// повтора (next_attempt_at в будущем), не выбираются, чтобы не нарушить порядок;
+// сообщения в dead letter не выбираются совсем. Результаты фиксируются в той же
+// транзакции: успешные помечаются опубликованными, у остальных растёт счётчик
+// попыток и выставляется время повтора либо dead_at.
+// Если пачку уже обрабатывает другая реплика, возвращает 0 без ошибки.
+func (r *OutboxRepository) ProcessPending(
+	ctx context.Context,
+	limit int,
+	handle func(ctx context.Context, msgs []models.OutboxMessage) []models.OutboxResult,
+) (int, error) {
+	const op = "repository.OutboxRepository.ProcessPending"
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("%s: acquire lock: %w", op, err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT id, aggregate_id, event_type, payload, attempts, created_at
+		 FROM outbox
+		 WHERE published_at IS NULL AND dead_at IS NULL
+		   AND aggregate_id NOT IN (
+		       SELECT aggregate_id FROM outbox
+		       WHERE published_at IS NULL AND dead_at IS NULL AND next_attempt_at > NOW()
+		   )
+		 ORDER BY id
+		 LIMIT $1`, limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: query pending: %w", op, err)
+	}
+	msgs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxMessage, error) {
+		var m models.OutboxMessage
+		err := row.Scan(&m.ID, &m.AggregateID, &m.EventType, &m.Payload, &m.Attempts, &m.CreatedAt)
+		return m, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: scan pending: %w", op, err)
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	var published []int64
+	for _, res := range handle(ctx, msgs) {
+		if res.Err == nil {
+			published = append(published, res.ID)
+			continue
+		}
+		var retryAt *time.Time
+		if !res.RetryAt.IsZero() {
+			retryAt = &res.RetryAt
+		}
+		if _, err := tx.Exec(ctx,
+			`UPDATE outbox
+			 SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3,
+			     dead_at = CASE WHEN $4 THEN NOW() END
+			 WHERE id = $1`,
+			res.ID, res.Err.Error(), retryAt, res.Dead,
+		); err != nil {
+			return 0, fmt.Errorf("%s: record failure: %w", op, err)
+		}
+	}
+
+	if len(published) > 0 {
+		if _, err := tx.Exec(ctx,
+			`UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+			 WHERE id = ANY($1)`, published,
+		); err != nil {
+			return 0, fmt.Errorf("%s: mark published: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: commit tx: %w", op, err)
+	}
+	return len(published), nil
+}
+
+// Stats возвращает размер очереди, возраст самого старого неопубликованного сообщения
+// и число сообщений в dead letter.
+func (r *OutboxRepository) Stats(ctx context.Context) (models.OutboxStats, error) {
+	const op = "repository.OutboxRepository.Stats"
+
+	var (
+		stats  models.OutboxStats
+		oldest *time.Time
+	)
+	if err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FILTER (WHERE dead_at IS NULL),
+		        MIN(created_at) FILTER (WHERE dead_at IS NULL),
+		        COUNT(*) FILTER (WHERE dead_at IS NOT NULL)
+		 FROM outbox WHERE published_at IS NULL`,
+	).Scan(&stats.Pending, &oldest, &stats.Dead); err != nil {
+		return models.OutboxStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if oldest != nil {
+		stats.OldestPendingAge = time.Since(*oldest)
+	}
+	return stats, nil
+}
+
+// DeletePublishedBefore удаляет опубликованные сообщения старше before.
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "repository.OutboxRepository.DeletePublishedBefore"
+
+	ct, err := r.pool.Exec(ctx, `DELETE FROM outbox WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return ct.RowsAffected(), nil
+}
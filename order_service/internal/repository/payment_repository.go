@@ -26,11 +26,11 @@ func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment)
 	now := time.Now()
 	err := r.pool.QueryRow(ctx,
 		`INSERT INTO payments
-		   (order_id, yookassa_payment_id, amount, currency, status, confirmation_url, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		   (order_id, yookassa_payment_id, amount, currency, status, confirmation_url, receipt, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		 RETURNING id`,
 		payment.OrderID, payment.YooKassaPaymentID, payment.Amount,
-		payment.Currency, payment.Status, payment.ConfirmationURL, now, now,
+		payment.Currency, payment.Status, payment.ConfirmationURL, payment.Receipt, now, now,
 	).Scan(&payment.ID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -46,11 +46,11 @@ func (r *PaymentRepository) UpdateStatusAndGet(ctx context.Context, yookassaID,
 		`UPDATE payments SET status = $1, updated_at = $2
 		 WHERE yookassa_payment_id = $3 AND status != $1
 		 RETURNING id, order_id, yookassa_payment_id, status, amount, currency,
-		           confirmation_url, created_at, updated_at`,
+		           confirmation_url, receipt, created_at, updated_at`,
 		newStatus, time.Now(), yookassaID,
 	).Scan(
 		&p.ID, &p.OrderID, &p.YooKassaPaymentID, &p.Status, &p.Amount,
-		&p.Currency, &p.ConfirmationURL, &p.CreatedAt, &p.UpdatedAt,
+		&p.Currency, &p.ConfirmationURL, &p.Receipt, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -67,14 +67,141 @@ func (r *PaymentRepository) GetByYooKassaID(ctx context.Context, yookassaID stri
 	var p models.Payment
 	err := r.pool.QueryRow(ctx,
 		`SELECT id, order_id, yookassa_payment_id, amount, currency, status,
-		        confirmation_url, created_at, updated_at
+		        confirmation_url, receipt, created_at, updated_at
 		 FROM payments WHERE yookassa_payment_id = $1`, yookassaID,
 	).Scan(
 		&p.ID, &p.OrderID, &p.YooKassaPaymentID, &p.Amount,
-		&p.Currency, &p.Status, &p.ConfirmationURL, &p.CreatedAt, &p.UpdatedAt,
+		&p.Currency, &p.Status, &p.ConfirmationURL, &p.Receipt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrUnknownPayment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &p, nil
+}
+
+// GetByOrderID возвращает последнюю попытку оплаты заказа.
+func (r *PaymentRepository) GetByOrderID(ctx context.Context, orderID int) (*models.Payment, error) {
+	const op = "repository.PaymentRepository.GetByOrderID"
+
+	var p models.Payment
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, order_id, yookassa_payment_id, amount, currency, status,
+		        confirmation_url, receipt, created_at, updated_at
+		 FROM payments WHERE order_id = $1
+		 ORDER BY id DESC LIMIT 1`, orderID,
+	).Scan(
+		&p.ID, &p.OrderID, &p.YooKassaPaymentID, &p.Amount,
+		&p.Currency, &p.Status, &p.ConfirmationURL, &p.Receipt, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return &p, nil
 }
+
+// ClaimForReconciliation выбирает до limit платежей, которые ждут оплаты с момента раньше
+// pendingBefore и с тех пор не сверялись, и отмечает время сверки. Давно не сверявшиеся
+// идут первыми; FOR UPDATE SKIP LOCKED не даёт репликам взять один платёж.
+func (r *PaymentRepository) ClaimForReconciliation(ctx context.Context, pendingBefore time.Time, limit int) ([]*models.Payment, error) {
+	const op = "repository.PaymentRepository.ClaimForReconciliation"
+
+	rows, err := r.pool.Query(ctx,
+		`UPDATE payments SET reconciled_at = $1
+		 WHERE id IN (
+		     SELECT id FROM payments
+		     WHERE status = $2 AND created_at < $3
+		       AND (reconciled_at IS NULL OR reconciled_at < $3)
+		     ORDER BY reconciled_at NULLS FIRST, id
+		     LIMIT $4
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, order_id, yookassa_payment_id, amount, currency, status,
+		           confirmation_url, receipt, created_at, updated_at`,
+		time.Now(), models.PaymentStatusPending, pendingBefore, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var payments []*models.Payment
+	for rows.Next() {
+		var p models.Payment
+		if err := rows.Scan(
+			&p.ID, &p.OrderID, &p.YooKassaPaymentID, &p.Amount,
+			&p.Currency, &p.Status, &p.ConfirmationURL, &p.Receipt, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		payments = append(payments, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return payments, nil
+}
+
+// SaveMismatches сохраняет расхождения, найденные сверкой, и заполняет их ID и CreatedAt.
+func (r *PaymentRepository) SaveMismatches(ctx context.Context, mismatches []models.ReconciliationMismatch) error {
+	const op = "repository.PaymentRepository.SaveMismatches"
+
+	now := time.Now()
+	for i := range mismatches {
+		m := &mismatches[i]
+		err := r.pool.QueryRow(ctx,
+			`INSERT INTO reconciliation_mismatches
+			   (kind, payment_id, order_id, local_status, provider_status,
+			    local_amount, provider_amount, resolved, error, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			 RETURNING id`,
+			m.Kind, m.PaymentID, m.OrderID, m.LocalStatus, m.ProviderStatus,
+			m.LocalAmount, m.ProviderAmount, m.Resolved, m.Error, now,
+		).Scan(&m.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		m.CreatedAt = now
+	}
+	return nil
+}
+
+// ListMismatches возвращает до limit расхождений, подходящих под filter, с id меньше
+// beforeID (0 — с самого нового), от новых к старым.
+func (r *PaymentRepository) ListMismatches(ctx context.Context, filter models.MismatchFilter, beforeID, limit int) ([]models.ReconciliationMismatch, error) {
+	const op = "repository.PaymentRepository.ListMismatches"
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, kind, payment_id, order_id, local_status, provider_status,
+		        local_amount, provider_amount, resolved, error, created_at
+		 FROM reconciliation_mismatches
+		 WHERE ($1 = 0 OR id < $1)
+		   AND ($2 = '' OR kind = $2)
+		   AND (NOT $3 OR NOT resolved)
+		 ORDER BY id DESC
+		 LIMIT $4`,
+		beforeID, filter.Kind, filter.UnresolvedOnly, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var mismatches []models.ReconciliationMismatch
+	for rows.Next() {
+		var m models.ReconciliationMismatch
+		if err := rows.Scan(
+			&m.ID, &m.Kind, &m.PaymentID, &m.OrderID, &m.LocalStatus, &m.ProviderStatus,
+			&m.LocalAmount, &m.ProviderAmount, &m.Resolved, &m.Error, &m.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		mismatches = append(mismatches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+	return mismatches, nil
+}
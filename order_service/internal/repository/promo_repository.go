@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"order_service/internal/models"
+)
+
+const promoColumns = `id, code, kind, value, min_order_amount, max_uses_per_user,
+	valid_from, valid_to, product_ids, brands, active, created_at, updated_at`
+
+type PromoRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPromoRepository(pool *pgxpool.Pool) *PromoRepository {
+	return &PromoRepository{pool: pool}
+}
+
+func scanPromoCode(row pgx.Row) (*models.PromoCode, error) {
+	var p models.PromoCode
+	var validFrom, validTo *time.Time
+	err := row.Scan(
+		&p.ID, &p.Code, &p.Kind, &p.Value, &p.MinOrderAmount, &p.MaxUsesPerUser,
+		&validFrom, &validTo, &p.ProductIDs, &p.Brands, &p.Active, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if validFrom != nil {
+		p.ValidFrom = *validFrom
+	}
+	if validTo != nil {
+		p.ValidTo = *validTo
+	}
+	return &p, nil
+}
+
+// nullTime превращает нулевое время в NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Create сохраняет промокод и заполняет ID и время создания;
+// код уже занят — models.ErrPromoCodeExists.
+func (r *PromoRepository) Create(ctx context.Context, promo *models.PromoCode) error {
+	const op = "repository.PromoRepository.Create"
+
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO promo_codes (code, kind, value, min_order_amount, max_uses_per_user,
+		                          valid_from, valid_to, product_ids, brands, active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id, created_at, updated_at`,
+		promo.Code, promo.Kind, promo.Value, promo.MinOrderAmount, promo.MaxUsesPerUser,
+		nullTime(promo.ValidFrom), nullTime(promo.ValidTo), nonNilInts(promo.ProductIDs),
+		nonNilStrings(promo.Brands), promo.Active,
+	).Scan(&promo.ID, &promo.CreatedAt, &promo.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, models.ErrPromoCodeExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Update перезаписывает параметры промокода promo.ID, кроме самого кода;
+// промокода нет — models.ErrPromoCodeNotFound.
+func (r *PromoRepository) Update(ctx context.Context, promo *models.PromoCode) error {
+	const op = "repository.PromoRepository.Update"
+
+	err := r.pool.QueryRow(ctx,
+		`UPDATE promo_codes
+		 SET kind = $2, value = $3, min_order_amount = $4, max_uses_per_user = $5,
+		     valid_from = $6, valid_to = $7, product_ids = $8, brands = $9, active = $10,
+		     updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING code, created_at, updated_at`,
+		promo.ID, promo.Kind, promo.Value, promo.MinOrderAmount, promo.MaxUsesPerUser,
+		nullTime(promo.ValidFrom), nullTime(promo.ValidTo), nonNilInts(promo.ProductIDs),
+		nonNilStrings(promo.Brands), promo.Active,
+	).Scan(&promo.Code, &promo.CreatedAt, &promo.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, models.ErrPromoCodeNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetByID возвращает промокод; нет — models.ErrPromoCodeNotFound.
+func (r *PromoRepository) GetByID(ctx context.Context, id int) (*models.PromoCode, error) {
+	const op = "repository.PromoRepository.GetByID"
+
+	promo, err := scanPromoCode(r.pool.QueryRow(ctx,
+		`SELECT `+promoColumns+` FROM promo_codes WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrPromoCodeNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return promo, nil
+}
+
+// GetByCode ищет промокод по нормализованному коду; нет — models.ErrPromoCodeNotFound.
+func (r *PromoRepository) GetByCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	const op = "repository.PromoRepository.GetByCode"
+
+	promo, err := scanPromoCode(r.pool.QueryRow(ctx,
+		`SELECT `+promoColumns+` FROM promo_codes WHERE code = $1`, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrPromoCodeNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return promo, nil
+}
+
+// List возвращает промокоды от новых к старым; activeOnly — только включённые.
+func (r *PromoRepository) List(ctx context.Context, activeOnly bool) ([]*models.PromoCode, error) {
+	const op = "repository.PromoRepository.List"
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+promoColumns+` FROM promo_codes
+		 WHERE NOT $1 OR active
+		 ORDER BY id DESC`, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var promos []*models.PromoCode
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		promos = append(promos, promo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows iteration: %w", op, err)
+	}
+	return promos, nil
+}
+
+// nonNilInts и nonNilStrings пишут пустой массив вместо NULL в NOT NULL колонки.
+func nonNilInts(s []int) []int {
+	if s == nil {
+		return []int{}
+	}
+	return s
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
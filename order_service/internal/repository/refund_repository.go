@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"order_service/internal/models"
+)
+
+const refundColumns = `id, order_id, payment_id, COALESCE(provider_refund_id, ''), amount, currency,
+	status, reason, comment, COALESCE(actor_id, 0), idempotency_key::text, created_at, updated_at`
+
+type RefundRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRefundRepository(pool *pgxpool.Pool) *RefundRepository {
+	return &RefundRepository{pool: pool}
+}
+
+func scanRefund(row pgx.Row) (*models.Refund, error) {
+	var rf models.Refund
+	err := row.Scan(
+		&rf.ID, &rf.OrderID, &rf.PaymentID, &rf.ProviderRefundID, &rf.Amount, &rf.Currency,
+		&rf.Status, &rf.Reason, &rf.Comment, &rf.ActorID, &rf.IdempotencyKey, &rf.CreatedAt, &rf.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// Create сохраняет возврат в статусе pending. Сумма проверяется под блокировкой
+// платежа: вместе с уже созданными (pending и succeeded) возвратами она не может
+// превысить сумму платежа. Нулевая refund.Amount — вернуть весь остаток.
+// Ключ идемпотентности выводится из id возврата (models.RefundIdempotenceKey).
+func (r *RefundRepository) Create(ctx context.Context, refund *models.Refund) error {
+	const op = "repository.RefundRepository.Create"
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var paymentAmount int
+	if err := tx.QueryRow(ctx,
+		`SELECT amount FROM payments WHERE id = $1 FOR UPDATE`, refund.PaymentID,
+	).Scan(&paymentAmount); err != nil {
+		return fmt.Errorf("%s: lock payment: %w", op, err)
+	}
+
+	var reserved int
+	if err := tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(amount), 0) FROM refunds
+		 WHERE payment_id = $1 AND status IN ($2, $3)`,
+		refund.PaymentID, models.RefundStatusPending, models.RefundStatusSucceeded,
+	).Scan(&reserved); err != nil {
+		return fmt.Errorf("%s: sum refunds: %w", op, err)
+	}
+
+	remaining := paymentAmount - reserved
+	if refund.Amount == 0 {
+		refund.Amount = remaining
+	}
+	if refund.Amount <= 0 || refund.Amount > remaining {
+		return fmt.Errorf("%s: %w", op, models.ErrRefundAmountExceeded)
+	}
+
+	if err := tx.QueryRow(ctx,
+		`SELECT nextval(pg_get_serial_sequence('refunds', 'id'))`,
+	).Scan(&refund.ID); err != nil {
+		return fmt.Errorf("%s: reserve refund id: %w", op, err)
+	}
+	refund.IdempotencyKey = models.RefundIdempotenceKey(refund.ID)
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO refunds
+		   (id, order_id, payment_id, amount, currency, status, reason, comment, actor_id, idempotency_key, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0), $10, $11, $11)`,
+		refund.ID, refund.OrderID, refund.PaymentID, refund.Amount, refund.Currency, models.RefundStatusPending,
+		refund.Reason, refund.Comment, refund.ActorID, refund.IdempotencyKey, now,
+	); err != nil {
+		return fmt.Errorf("%s: insert refund: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: commit tx: %w", op, err)
+	}
+
+	refund.Status = models.RefundStatusPending
+	refund.CreatedAt = now
+	refund.UpdatedAt = now
+	return nil
+}
+
+// AttachProviderID запоминает id возврата у провайдера, чтобы сопоставлять вебхуки.
+func (r *RefundRepository) AttachProviderID(ctx context.Context, refundID int, providerRefundID string, receipt *models.Receipt) error {
+	const op = "repository.RefundRepository.AttachProviderID"
+
+	if _, err := r.pool.Exec(ctx,
+		`UPDATE refunds SET provider_refund_id = $1, receipt = $2, updated_at = $3 WHERE id = $4`,
+		providerRefundID, receipt, time.Now(), refundID,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// MarkSucceeded переводит ожидающий возврат в succeeded и в той же транзакции
+// увеличивает refunded_amount заказа. Если вернули всё, оплаченный или отправленный
+// заказ переходит в REFUNDED, переход пишется в историю с источником source
+// и администратором, запросившим возврат.
+// Событие OrderRefunded пишется в outbox.
+// Если возврат уже обработан, возвращает nil, nil; если его нет — models.ErrRefundNotFound
+// (вебхук обогнал сохранение id возврата, провайдер повторит уведомление).
+func (r *RefundRepository) MarkSucceeded(ctx context.Context, providerRefundID, source string) (*models.Refund, error) {
+	const op = "repository.RefundRepository.MarkSucceeded"
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	refund, err := scanRefund(tx.QueryRow(ctx,
+		`UPDATE refunds SET status = $1, updated_at = $2
+		 WHERE provider_refund_id = $3 AND status = $4
+		 RETURNING `+refundColumns,
+		models.RefundStatusSucceeded, now, providerRefundID, models.RefundStatusPending,
+	))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: update refund: %w", op, err)
+		}
+		var exists bool
+		if err := tx.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM refunds WHERE provider_refund_id = $1)`, providerRefundID,
+		).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("%s: check refund: %w", op, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%s: %w", op, models.ErrRefundNotFound)
+		}
+		return nil, nil // idempotent: already processed
+	}
+
+	var (
+		event      models.OrderEvent
+		prevStatus string
+	)
+	if err := tx.QueryRow(ctx,
+		`UPDATE orders o
+		 SET refunded_amount = o.refunded_amount + $1,
+		     status = CASE WHEN o.refunded_amount + $1 >= o.total_amount AND o.status = ANY($2)
+		                   THEN $3 ELSE o.status END,
+		     updated_at = $4
+		 FROM (SELECT id, status FROM orders WHERE id = $5 FOR UPDATE) prev
+		 WHERE o.id = prev.id
+		 RETURNING o.user_id, o.status, o.total_amount, o.refunded_amount, o.customer_email, prev.status`,
+		refund.Amount, models.RefundableStatuses, models.OrderStatusRefunded,
+		now, refund.OrderID,
+	).Scan(&event.UserID, &event.Status, &event.TotalAmount, &event.RefundedAmount, &event.CustomerEmail, &prevStatus); err != nil {
+		return nil, fmt.Errorf("%s: update order: %w", op, err)
+	}
+
+	if event.Status != prevStatus {
+		change := models.StatusChange{
+			EventType: models.EventOrderRefunded,
+			Source:    source,
+			Reason:    refund.Reason,
+			ActorID:   refund.ActorID,
+		}
+		if err := insertStatusHistory(ctx, tx, refund.OrderID, prevStatus, event.Status, change, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	event.EventType = models.EventOrderRefunded
+	event.OrderID = refund.OrderID
+	event.Reason = refund.Reason
+	event.Timestamp = now.Format(time.RFC3339)
+	if err := insertOutboxEvent(ctx, tx, event); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit tx: %w", op, err)
+	}
+	return refund, nil
+}
+
+// GetByProviderID возвращает возврат по его id у провайдера.
+func (r *RefundRepository) GetByProviderID(ctx context.Context, providerRefundID string) (*models.Refund, error) {
+	const op = "repository.RefundRepository.GetByProviderID"
+
+	refund, err := scanRefund(r.pool.QueryRow(ctx,
+		`SELECT `+refundColumns+` FROM refunds WHERE provider_refund_id = $1`, providerRefundID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrRefundNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return refund, nil
+}
+
+// GetUnsent возвращает ожидающий возврат по платежу, который провайдер ещё не принял
+// (нет provider_refund_id): прошлая отправка оборвалась на таймауте или ошибке 5xx.
+// Такого нет — models.ErrRefundNotFound.
+func (r *RefundRepository) GetUnsent(ctx context.Context, paymentID int) (*models.Refund, error) {
+	const op = "repository.RefundRepository.GetUnsent"
+
+	refund, err := scanRefund(r.pool.QueryRow(ctx,
+		`SELECT `+refundColumns+` FROM refunds
+		 WHERE payment_id = $1 AND status = $2 AND provider_refund_id IS NULL
+		 ORDER BY id
+		 LIMIT 1`,
+		paymentID, models.RefundStatusPending,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrRefundNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return refund, nil
+}
+
+// MarkCanceled отменяет ожидающий возврат; его сумма снова доступна для возврата.
+func (r *RefundRepository) MarkCanceled(ctx context.Context, refundID int) error {
+	const op = "repository.RefundRepository.MarkCanceled"
+
+	if _, err := r.pool.Exec(ctx,
+		`UPDATE refunds SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
+		models.RefundStatusCanceled, time.Now(), refundID, models.RefundStatusPending,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
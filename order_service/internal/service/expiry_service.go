@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order_service/internal/models"
+)
+
+// ExpireUnpaidOrders отменяет до limit заказов, ожидающих оплаты с момента раньше
+// pendingBefore: отменяет платёж у провайдера, переводит заказ в CANCELLED
+// (резерв снимается как при обычной отмене) и пишет событие OrderExpired.
+// Возвращает, сколько заказов взято в обработку и сколько из них отменено;
+// ошибки по отдельным заказам логируются, такой заказ будет взят повторно.
+func (s *OrderServiceImpl) ExpireUnpaidOrders(ctx context.Context, pendingBefore time.Time, limit int) (claimed, expired int, err error) {
+	const op = "service.OrderService.ExpireUnpaidOrders"
+
+	orderIDs, err := s.repo.ClaimExpired(ctx, pendingBefore, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, orderID := range orderIDs {
+		if err := s.expireOrder(ctx, orderID); err != nil {
+			s.log.Warn("failed to expire order",
+				slog.String("op", op),
+				slog.Int("order_id", orderID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		expired++
+		s.log.Info("unpaid order expired", slog.String("op", op), slog.Int("order_id", orderID))
+	}
+
+	return len(orderIDs), expired, nil
+}
+
+func (s *OrderServiceImpl) expireOrder(ctx context.Context, orderID int) error {
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		// Платёж мог не создаться (провайдер не ответил) — отменять у провайдера нечего.
+		s.log.Warn("no payment for expiring order", slog.Int("order_id", orderID), slog.String("error", err.Error()))
+	} else if payment.Status == models.PaymentStatusPending {
+		// Ошибку только логируем: если платёж всё же пройдёт, вебхук об оплате
+		// отменённого заказа вернёт деньги (см. settleCancelledOrder).
+		if err := s.provider.CancelPayment(ctx, payment.YooKassaPaymentID, cancelIdempotenceKey(payment.ID)); err != nil {
+			s.log.Warn("failed to cancel payment at provider",
+				slog.Int("order_id", orderID),
+				slog.String("payment_id", payment.YooKassaPaymentID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	change := models.StatusChange{
+		EventType: models.EventOrderExpired,
+		Source:    models.StatusSourceScheduler,
+		Reason:    models.ReasonPaymentTimeout,
+	}
+	return s.updateOrderStatus(ctx, orderID, models.OrderStatusCancelled, change)
+}
+
+// cancelIdempotenceKey выводит Idempotence-Key отмены платежа из его id,
+// чтобы повторная попытка после сбоя не считалась новым запросом.
+func cancelIdempotenceKey(paymentID int) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("payment-cancel:%d", paymentID))).String()
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+func TestExpireUnpaidOrders_CancelsPaymentAndOrder(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	cutoff := time.Now().Add(-30 * time.Minute)
+	repo.On("ClaimExpired", mock.Anything, cutoff, 10).Return([]int{1}, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(&models.Payment{
+		ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-1", Status: models.PaymentStatusPending,
+	}, nil)
+	provider.On("CancelPayment", mock.Anything, "yoo-1", mock.Anything).Return(nil)
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusCancelled, models.OrderStatusPendingPayment,
+		models.StatusChange{
+			EventType: models.EventOrderExpired, Source: models.StatusSourceScheduler, Reason: models.ReasonPaymentTimeout,
+		}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
+
+	claimed, expired, err := svc.ExpireUnpaidOrders(context.Background(), cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Equal(t, 1, expired)
+	repo.AssertExpectations(t)
+	provider.AssertExpectations(t)
+	inventory.AssertExpectations(t)
+}
+
+func TestExpireUnpaidOrders_ProviderErrorStillExpires(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	repo.On("ClaimExpired", mock.Anything, mock.Anything, 10).Return([]int{1}, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(&models.Payment{
+		ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-1", Status: models.PaymentStatusPending,
+	}, nil)
+	provider.On("CancelPayment", mock.Anything, "yoo-1", mock.Anything).Return(errors.New("cannot cancel pending payment"))
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusCancelled, models.OrderStatusPendingPayment, mock.Anything).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
+
+	_, expired, err := svc.ExpireUnpaidOrders(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+}
+
+func TestExpireUnpaidOrders_PaidMeanwhileIsSkipped(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	repo.On("ClaimExpired", mock.Anything, mock.Anything, 10).Return([]int{1, 2}, nil)
+
+	// Заказ 1 оплачен между выборкой и отменой: платёж уже не pending.
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(&models.Payment{
+		ID: 7, OrderID: 1, Status: models.PaymentStatusSucceeded,
+	}, nil)
+	repo.On("GetByID", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid}}, nil)
+
+	// У заказа 2 платёж так и не создался.
+	paymentRepo.On("GetByOrderID", mock.Anything, 2).Return(nil, errors.New("no rows"))
+	repo.On("GetByID", mock.Anything, 2).Return(&models.OrderWithItems{Order: models.Order{ID: 2, Status: models.OrderStatusPendingPayment}}, nil)
+	repo.On("UpdateStatus", mock.Anything, 2, models.OrderStatusCancelled, models.OrderStatusPendingPayment, mock.Anything).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 2).Return(nil)
+
+	claimed, expired, err := svc.ExpireUnpaidOrders(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+	assert.Equal(t, 1, expired)
+	provider.AssertNotCalled(t, "CancelPayment", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, 1, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessWebhook_CanceledPaymentForExpiredOrder(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, _, inventory := newRefundTestService()
+
+	existing := &models.Payment{ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-1", Status: models.PaymentStatusPending}
+	updated := &models.Payment{ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-1", Status: models.PaymentStatusCanceled}
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(existing, nil)
+	paymentRepo.On("UpdateStatusAndGet", mock.Anything, "yoo-1", models.PaymentStatusCanceled).Return(updated, nil)
+	repo.On("GetByID", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusCancelled}}, nil)
+
+	require.NoError(t, svc.ProcessWebhook(context.Background(), "yoo-1", "canceled"))
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	refundRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	inventory.AssertNotCalled(t, "ReleaseStock", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"order_service/internal/models"
+)
+
+// maxTrackingNumberLen — длина колонки orders.tracking_number.
+const maxTrackingNumberLen = 64
+
+// trackingTransitions — в какой статус переводится отправленный заказ
+// по состоянию отправления у перевозчика.
+var trackingTransitions = map[string]string{
+	models.TrackingDelivered: models.OrderStatusDelivered,
+	models.TrackingReturned:  models.OrderStatusReturned,
+}
+
+// MarkShipment переводит оплаченный заказ по цепочке выполнения
+// PROCESSING → SHIPPED → DELIVERED (или RETURNED). Для SHIPPED обязательны
+// подключённый перевозчик и трек-номер, для остальных статусов они не передаются.
+// Операция администратора actorID; событие статуса уходит в outbox.
+func (s *OrderServiceImpl) MarkShipment(ctx context.Context, orderID int, status, carrier, trackingNumber string, actorID int) (*models.OrderWithItems, error) {
+	const op = "service.OrderService.MarkShipment"
+
+	event, ok := models.FulfilmentEvent(status)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: unknown fulfilment status %q", op, models.ErrInvalidShipment, status)
+	}
+
+	carrier = strings.TrimSpace(carrier)
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if status == models.OrderStatusShipped {
+		if carrier == "" || trackingNumber == "" {
+			return nil, fmt.Errorf("%s: %w: carrier and tracking_number are required", op, models.ErrInvalidShipment)
+		}
+		if len(trackingNumber) > maxTrackingNumberLen {
+			return nil, fmt.Errorf("%s: %w: tracking_number is longer than %d", op, models.ErrInvalidShipment, maxTrackingNumberLen)
+		}
+		if !s.carriers.Supports(carrier) {
+			return nil, fmt.Errorf("%s: %w: %w %q", op, models.ErrInvalidShipment, models.ErrUnknownCarrier, carrier)
+		}
+	} else if carrier != "" || trackingNumber != "" {
+		return nil, fmt.Errorf("%s: %w: carrier and tracking_number are set only when shipping", op, models.ErrInvalidShipment)
+	}
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get order: %w", op, err)
+	}
+	if !models.ValidTransition(order.Status, status) {
+		return nil, fmt.Errorf("%s: %w: %s -> %s", op, models.ErrOrderNotShippable, order.Status, status)
+	}
+
+	change := models.StatusChange{EventType: event, Source: models.StatusSourceAdmin, ActorID: actorID}
+	if status == models.OrderStatusShipped {
+		err = s.repo.Ship(ctx, orderID, order.Status, carrier, trackingNumber, change)
+	} else {
+		err = s.repo.UpdateStatus(ctx, orderID, status, order.Status, change)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("order fulfilment status changed",
+		slog.String("op", op),
+		slog.Int("order_id", orderID),
+		slog.String("from", order.Status),
+		slog.String("to", status),
+		slog.String("carrier", carrier),
+	)
+
+	return s.GetOrder(ctx, orderID)
+}
+
+// PollShipments запрашивает у перевозчиков состояние до limit отправленных заказов,
+// не проверявшихся с polledBefore: вручённые переводятся в DELIVERED, вернувшиеся —
+// в RETURNED, с событием в outbox. Возвращает, сколько заказов проверено и сколько
+// сменили статус; ошибки по отдельным заказам логируются, заказ проверится снова.
+// Отправления перевозчиков без отслеживания не опрашиваются.
+func (s *OrderServiceImpl) PollShipments(ctx context.Context, polledBefore time.Time, limit int) (polled, advanced int, err error) {
+	const op = "service.OrderService.PollShipments"
+
+	tracked := s.carriers.Tracked()
+	if len(tracked) == 0 {
+		return 0, 0, nil
+	}
+
+	orders, err := s.repo.ClaimShipped(ctx, tracked, polledBefore, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, order := range orders {
+		changed, err := s.pollShipment(ctx, order)
+		if err != nil {
+			s.log.Warn("failed to poll shipment",
+				slog.String("op", op),
+				slog.Int("order_id", order.ID),
+				slog.String("carrier", order.Carrier),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if changed {
+			advanced++
+		}
+	}
+
+	return len(orders), advanced, nil
+}
+
+// pollShipment сверяет один заказ с перевозчиком; true — статус заказа сменился.
+func (s *OrderServiceImpl) pollShipment(ctx context.Context, order *models.Order) (bool, error) {
+	info, err := s.carriers.Track(ctx, order.Carrier, order.TrackingNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if info.Status == models.TrackingInTransit {
+		return false, nil
+	}
+	newStatus, ok := trackingTransitions[info.Status]
+	if !ok {
+		return false, fmt.Errorf("unknown tracking status %q", info.Status)
+	}
+
+	event, _ := models.FulfilmentEvent(newStatus)
+	change := models.StatusChange{EventType: event, Source: models.StatusSourceScheduler}
+	if err := s.repo.UpdateStatus(ctx, order.ID, newStatus, order.Status, change); err != nil {
+		return false, err
+	}
+
+	s.log.Info("shipment status advanced",
+		slog.Int("order_id", order.ID),
+		slog.String("carrier", order.Carrier),
+		slog.String("tracking_status", info.Status),
+		slog.String("status", newStatus),
+	)
+	return true, nil
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/service"
+	"order_service/internal/service/mocks"
+)
+
+func newFulfilmentTestService() (*service.OrderServiceImpl, *mocks.MockOrderRepository, *mocks.MockCarrierTracker) {
+	repo := new(mocks.MockOrderRepository)
+	carriers := new(mocks.MockCarrierTracker)
+	svc := service.NewOrderService(repo, new(mocks.MockPaymentRepository), new(mocks.MockRefundRepository),
+		new(mocks.MockPromoRepository), new(mocks.MockPaymentProvider), new(mocks.MockInventoryClient), new(mocks.MockCatalogClient),
+		new(mocks.MockAddressBook), carriers, testDeliveryCosts, models.ReceiptSettings{}, 24*time.Hour, newTestLogger())
+	return svc, repo, carriers
+}
+
+// ---------------------------------------------------------------------------
+// MarkShipment
+// ---------------------------------------------------------------------------
+
+func TestMarkShipment_Processing(t *testing.T) {
+	svc, repo, _ := newFulfilmentTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusProcessing, models.OrderStatusPaid,
+		models.StatusChange{EventType: models.EventOrderProcessing, Source: models.StatusSourceAdmin, ActorID: 7}).Return(nil)
+	repo.On("GetStatusHistory", mock.Anything, 1).Return([]models.StatusHistoryEntry(nil), nil)
+
+	_, err := svc.MarkShipment(context.Background(), 1, models.OrderStatusProcessing, "", "", 7)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestMarkShipment_Shipped(t *testing.T) {
+	svc, repo, carriers := newFulfilmentTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusProcessing}}
+	carriers.On("Supports", "fake").Return(true)
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	repo.On("Ship", mock.Anything, 1, models.OrderStatusProcessing, "fake", "TRK-1",
+		models.StatusChange{EventType: models.EventOrderShipped, Source: models.StatusSourceAdmin, ActorID: 7}).Return(nil)
+	repo.On("GetStatusHistory", mock.Anything, 1).Return([]models.StatusHistoryEntry(nil), nil)
+
+	_, err := svc.MarkShipment(context.Background(), 1, models.OrderStatusShipped, " fake ", "TRK-1", 7)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestMarkShipment_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           string
+		carrier, number  string
+		carrierSupported bool
+	}{
+		{name: "not a fulfilment status", status: models.OrderStatusPaid},
+		{name: "shipped without tracking number", status: models.OrderStatusShipped, carrier: "fake"},
+		{name: "unknown carrier", status: models.OrderStatusShipped, carrier: "dhl", number: "TRK-1"},
+		{name: "tracking on delivered", status: models.OrderStatusDelivered, carrier: "fake", number: "TRK-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, carriers := newFulfilmentTestService()
+			carriers.On("Supports", mock.Anything).Return(tt.carrierSupported)
+
+			_, err := svc.MarkShipment(context.Background(), 1, tt.status, tt.carrier, tt.number, 7)
+			require.ErrorIs(t, err, models.ErrInvalidShipment)
+			repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMarkShipment_NotShippable(t *testing.T) {
+	svc, repo, _ := newFulfilmentTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+
+	_, err := svc.MarkShipment(context.Background(), 1, models.OrderStatusProcessing, "", "", 7)
+	require.ErrorIs(t, err, models.ErrOrderNotShippable)
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// PollShipments
+// ---------------------------------------------------------------------------
+
+func TestPollShipments_AdvancesFinishedShipments(t *testing.T) {
+	svc, repo, carriers := newFulfilmentTestService()
+
+	cutoff := time.Now()
+	orders := []*models.Order{
+		{ID: 1, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "TRK-1"},
+		{ID: 2, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "TRK-2"},
+		{ID: 3, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "RET-3"},
+	}
+	carriers.On("Tracked").Return([]string{"fake"})
+	repo.On("ClaimShipped", mock.Anything, []string{"fake"}, cutoff, 10).Return(orders, nil)
+	carriers.On("Track", mock.Anything, "fake", "TRK-1").
+		Return(&models.TrackingInfo{Status: models.TrackingDelivered}, nil)
+	carriers.On("Track", mock.Anything, "fake", "TRK-2").
+		Return(&models.TrackingInfo{Status: models.TrackingInTransit}, nil)
+	carriers.On("Track", mock.Anything, "fake", "RET-3").
+		Return(&models.TrackingInfo{Status: models.TrackingReturned}, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusDelivered, models.OrderStatusShipped,
+		models.StatusChange{EventType: models.EventOrderDelivered, Source: models.StatusSourceScheduler}).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 3, models.OrderStatusReturned, models.OrderStatusShipped,
+		models.StatusChange{EventType: models.EventOrderReturned, Source: models.StatusSourceScheduler}).Return(nil)
+
+	polled, advanced, err := svc.PollShipments(context.Background(), cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, polled)
+	assert.Equal(t, 2, advanced)
+	repo.AssertExpectations(t)
+}
+
+func TestPollShipments_CarrierErrorSkipsOrder(t *testing.T) {
+	svc, repo, carriers := newFulfilmentTestService()
+
+	cutoff := time.Now()
+	orders := []*models.Order{
+		{ID: 1, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "TRK-1"},
+		{ID: 2, Status: models.OrderStatusShipped, Carrier: "fake", TrackingNumber: "TRK-2"},
+	}
+	carriers.On("Tracked").Return([]string{"fake"})
+	repo.On("ClaimShipped", mock.Anything, []string{"fake"}, cutoff, 10).Return(orders, nil)
+	carriers.On("Track", mock.Anything, "fake", "TRK-1").Return(nil, errors.New("carrier unavailable"))
+	carriers.On("Track", mock.Anything, "fake", "TRK-2").
+		Return(&models.TrackingInfo{Status: models.TrackingDelivered}, nil)
+	repo.On("UpdateStatus", mock.Anything, 2, models.OrderStatusDelivered, models.OrderStatusShipped, mock.Anything).Return(nil)
+
+	polled, advanced, err := svc.PollShipments(context.Background(), cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, polled)
+	assert.Equal(t, 1, advanced)
+}
+
+func TestPollShipments_NoTrackedCarriers(t *testing.T) {
+	svc, repo, carriers := newFulfilmentTestService()
+
+	carriers.On("Tracked").Return([]string{})
+
+	polled, advanced, err := svc.PollShipments(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Zero(t, polled)
+	assert.Zero(t, advanced)
+	repo.AssertNotCalled(t, "ClaimShipped", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
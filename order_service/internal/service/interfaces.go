@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"order_service/internal/models"
 )
@@ -10,9 +11,23 @@ import (
 type OrderRepository interface {
 	Create(ctx context.Context, order *models.Order, items []models.OrderItem) (*models.OrderWithItems, error)
 	GetByID(ctx context.Context, orderID int) (*models.OrderWithItems, error)
-	GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error)
-	UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string) error
+	GetByIdempotencyKey(ctx context.Context, userID int, key string) (*models.OrderWithItems, error)
+	ClearIdempotencyKey(ctx context.Context, orderID int) error
+	GetUserOrders(ctx context.Context, userID, beforeID, limit int) ([]*models.OrderWithItems, error)
+	ListOrders(ctx context.Context, filter models.OrderFilter, beforeID, limit int) ([]*models.OrderWithItems, error)
+	// UpdateStatus атомарно меняет статус и пишет событие change в outbox.
+	UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string, change models.StatusChange) error
 	UpdatePaymentURL(ctx context.Context, orderID int, paymentURL string) error
+	// GetStatusHistory возвращает смены статуса заказа от старых к новым.
+	GetStatusHistory(ctx context.Context, orderID int) ([]models.StatusHistoryEntry, error)
+	// ClaimExpired помечает до limit заказов, ожидающих оплаты с момента раньше
+	// pendingBefore, как взятые в обработку, и возвращает их id.
+	ClaimExpired(ctx context.Context, pendingBefore time.Time, limit int) ([]int, error)
+	// Ship переводит заказ в SHIPPED вместе с перевозчиком и трек-номером.
+	Ship(ctx context.Context, orderID int, expectedCurrentStatus, carrier, trackingNumber string, change models.StatusChange) error
+	// ClaimShipped отбирает отправленные перевозчиками carriers заказы, статус которых
+	// давно не запрашивался.
+	ClaimShipped(ctx context.Context, carriers []string, polledBefore time.Time, limit int) ([]*models.Order, error)
 }
 
 //go:generate mockery --name=PaymentRepository --output=mocks --outpkg=mocks --filename=mock_payment_repository.go
@@ -20,15 +35,100 @@ type PaymentRepository interface {
 	Create(ctx context.Context, payment *models.Payment) error
 	UpdateStatusAndGet(ctx context.Context, yookassaID, newStatus string) (*models.Payment, error)
 	GetByYooKassaID(ctx context.Context, yookassaID string) (*models.Payment, error)
+	// GetByOrderID возвращает последнюю попытку оплаты заказа.
+	GetByOrderID(ctx context.Context, orderID int) (*models.Payment, error)
+	// ClaimForReconciliation отбирает давно ожидающие платежи для сверки с провайдером.
+	ClaimForReconciliation(ctx context.Context, pendingBefore time.Time, limit int) ([]*models.Payment, error)
+	// SaveMismatches сохраняет расхождения, найденные сверкой, и заполняет их ID.
+	SaveMismatches(ctx context.Context, mismatches []models.ReconciliationMismatch) error
+	// ListMismatches возвращает расхождения с id меньше beforeID (0 — с самого нового), от новых к старым.
+	ListMismatches(ctx context.Context, filter models.MismatchFilter, beforeID, limit int) ([]models.ReconciliationMismatch, error)
+}
+
+//go:generate mockery --name=RefundRepository --output=mocks --outpkg=mocks --filename=mock_refund_repository.go
+type RefundRepository interface {
+	// Create сохраняет возврат в статусе pending; нулевая сумма — весь невозвращённый остаток.
+	// Если сумма больше остатка — models.ErrRefundAmountExceeded.
+	Create(ctx context.Context, refund *models.Refund) error
+	AttachProviderID(ctx context.Context, refundID int, providerRefundID string, receipt *models.Receipt) error
+	// MarkSucceeded завершает возврат и обновляет заказ; source попадает в историю статусов,
+	// если заказ перешёл в REFUNDED. nil, nil — уже обработан.
+	MarkSucceeded(ctx context.Context, providerRefundID, source string) (*models.Refund, error)
+	MarkCanceled(ctx context.Context, refundID int) error
+	// GetByProviderID возвращает возврат по id у провайдера; нет — models.ErrRefundNotFound.
+	GetByProviderID(ctx context.Context, providerRefundID string) (*models.Refund, error)
+	// GetUnsent возвращает ожидающий возврат по платежу, ещё не принятый провайдером;
+	// нет — models.ErrRefundNotFound.
+	GetUnsent(ctx context.Context, paymentID int) (*models.Refund, error)
+}
+
+//go:generate mockery --name=PromoRepository --output=mocks --outpkg=mocks --filename=mock_promo_repository.go
+type PromoRepository interface {
+	// Create сохраняет промокод; код занят — models.ErrPromoCodeExists.
+	Create(ctx context.Context, promo *models.PromoCode) error
+	// Update перезаписывает параметры промокода, кроме кода.
+	Update(ctx context.Context, promo *models.PromoCode) error
+	// GetByID и GetByCode: промокода нет — models.ErrPromoCodeNotFound.
+	GetByID(ctx context.Context, id int) (*models.PromoCode, error)
+	GetByCode(ctx context.Context, code string) (*models.PromoCode, error)
+	List(ctx context.Context, activeOnly bool) ([]*models.PromoCode, error)
 }
 
 //go:generate mockery --name=PaymentProvider --output=mocks --outpkg=mocks --filename=mock_payment_provider.go
 type PaymentProvider interface {
-	CreatePayment(ctx context.Context, amount int, currency, description string) (*models.PaymentProviderResponse, error)
+	// CreatePayment создаёт платёж; повторный вызов с тем же idempotenceKey
+	// возвращает уже созданный платёж. receipt — чек по 54-ФЗ на amount; nil — без чека.
+	CreatePayment(ctx context.Context, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.PaymentProviderResponse, error)
+	// RefundPayment возвращает amount копеек по платежу провайдера paymentID.
+	// Повторный вызов с тем же idempotenceKey не создаёт второй возврат. receipt — чек возврата.
+	// models.ErrRefundRejected — провайдер отклонил возврат и не провёл его.
+	RefundPayment(ctx context.Context, paymentID string, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.RefundProviderResponse, error)
+	// CancelPayment отменяет ещё не завершённый платёж провайдера paymentID.
+	CancelPayment(ctx context.Context, paymentID, idempotenceKey string) error
+	// GetPayment возвращает текущее состояние платежа у провайдера;
+	// models.ErrPaymentNotFound — провайдер такого платежа не знает.
+	GetPayment(ctx context.Context, paymentID string) (*models.PaymentProviderResponse, error)
+	// GetRefund возвращает текущее состояние возврата у провайдера;
+	// models.ErrProviderRefundNotFound — провайдер такого возврата не знает.
+	GetRefund(ctx context.Context, refundID string) (*models.RefundProviderResponse, error)
+}
+
+// InventoryClient резервирует остатки товаров в product_service.
+//
+//go:generate mockery --name=InventoryClient --output=mocks --outpkg=mocks --filename=mock_inventory_client.go
+type InventoryClient interface {
+	ReserveStock(ctx context.Context, orderID int, items []models.OrderItem) error
+	ReleaseStock(ctx context.Context, orderID int) error
+	CommitStock(ctx context.Context, orderID int) error
+}
+
+// CatalogClient получает актуальные цены товаров из product_service.
+//
+//go:generate mockery --name=CatalogClient --output=mocks --outpkg=mocks --filename=mock_catalog_client.go
+type CatalogClient interface {
+	// PriceItems возвращает копию позиций с заполненным PriceAtPurchase.
+	// Если товара или варианта нет либо он в архиве — models.ErrProductUnavailable.
+	PriceItems(ctx context.Context, items []models.OrderItem) ([]models.OrderItem, error)
+}
+
+// AddressBook читает адресную книгу пользователя в sso_service.
+//
+//go:generate mockery --name=AddressBook --output=mocks --outpkg=mocks --filename=mock_address_book.go
+type AddressBook interface {
+	// GetAddress возвращает адрес пользователя; models.ErrAddressNotFound —
+	// адреса нет или он принадлежит другому пользователю.
+	GetAddress(ctx context.Context, userID, addressID int) (*models.ShippingAddress, error)
 }
 
-//go:generate mockery --name=EventPublisher --output=mocks --outpkg=mocks --filename=mock_event_publisher.go
-type EventPublisher interface {
-	PublishOrderEvent(ctx context.Context, event models.OrderEvent) error
-	Close() error
+// CarrierTracker узнаёт состояние отправлений у служб доставки.
+//
+//go:generate mockery --name=CarrierTracker --output=mocks --outpkg=mocks --filename=mock_carrier_tracker.go
+type CarrierTracker interface {
+	// Supports сообщает, подключён ли перевозчик.
+	Supports(carrier string) bool
+	// Tracked возвращает перевозчиков, у которых можно узнать состояние отправления;
+	// отправления остальных завершает только администратор.
+	Tracked() []string
+	// Track возвращает состояние отправления; неподключённый перевозчик — models.ErrUnknownCarrier.
+	Track(ctx context.Context, carrier, trackingNumber string) (*models.TrackingInfo, error)
 }
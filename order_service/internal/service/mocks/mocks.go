@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"order_service/internal/models"
 
@@ -26,19 +27,63 @@ func (m *MockOrderRepository) GetByID(ctx context.Context, orderID int) (*models
 	}
 	return args.Get(0).(*models.OrderWithItems), args.Error(1)
 }
-func (m *MockOrderRepository) GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error) {
-	args := m.Called(ctx, userID)
+func (m *MockOrderRepository) GetByIdempotencyKey(ctx context.Context, userID int, key string) (*models.OrderWithItems, error) {
+	args := m.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrderWithItems), args.Error(1)
+}
+func (m *MockOrderRepository) ClearIdempotencyKey(ctx context.Context, orderID int) error {
+	return m.Called(ctx, orderID).Error(0)
+}
+func (m *MockOrderRepository) GetUserOrders(ctx context.Context, userID, beforeID, limit int) ([]*models.OrderWithItems, error) {
+	args := m.Called(ctx, userID, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderWithItems), args.Error(1)
+}
+func (m *MockOrderRepository) ListOrders(ctx context.Context, filter models.OrderFilter, beforeID, limit int) ([]*models.OrderWithItems, error) {
+	args := m.Called(ctx, filter, beforeID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.OrderWithItems), args.Error(1)
 }
-func (m *MockOrderRepository) UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string) error {
-	return m.Called(ctx, orderID, newStatus, expectedCurrentStatus).Error(0)
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, orderID int, newStatus, expectedCurrentStatus string, change models.StatusChange) error {
+	return m.Called(ctx, orderID, newStatus, expectedCurrentStatus, change).Error(0)
 }
 func (m *MockOrderRepository) UpdatePaymentURL(ctx context.Context, orderID int, paymentURL string) error {
 	return m.Called(ctx, orderID, paymentURL).Error(0)
 }
+func (m *MockOrderRepository) GetStatusHistory(ctx context.Context, orderID int) ([]models.StatusHistoryEntry, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StatusHistoryEntry), args.Error(1)
+}
+func (m *MockOrderRepository) ClaimExpired(ctx context.Context, pendingBefore time.Time, limit int) ([]int, error) {
+	args := m.Called(ctx, pendingBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockOrderRepository) Ship(ctx context.Context, orderID int, expectedCurrentStatus, carrier, trackingNumber string, change models.StatusChange) error {
+	args := m.Called(ctx, orderID, expectedCurrentStatus, carrier, trackingNumber, change)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ClaimShipped(ctx context.Context, carriers []string, polledBefore time.Time, limit int) ([]*models.Order, error) {
+	args := m.Called(ctx, carriers, polledBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Order), args.Error(1)
+}
 
 // --- MockPaymentRepository ---
 
@@ -62,25 +107,198 @@ func (m *MockPaymentRepository) GetByYooKassaID(ctx context.Context, yookassaID
 	return args.Get(0).(*models.Payment), args.Error(1)
 }
 
+func (m *MockPaymentRepository) GetByOrderID(ctx context.Context, orderID int) (*models.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) ClaimForReconciliation(ctx context.Context, pendingBefore time.Time, limit int) ([]*models.Payment, error) {
+	args := m.Called(ctx, pendingBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) SaveMismatches(ctx context.Context, mismatches []models.ReconciliationMismatch) error {
+	args := m.Called(ctx, mismatches)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) ListMismatches(ctx context.Context, filter models.MismatchFilter, beforeID, limit int) ([]models.ReconciliationMismatch, error) {
+	args := m.Called(ctx, filter, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReconciliationMismatch), args.Error(1)
+}
+
+// --- MockRefundRepository ---
+
+type MockRefundRepository struct{ mock.Mock }
+
+func (m *MockRefundRepository) Create(ctx context.Context, refund *models.Refund) error {
+	return m.Called(ctx, refund).Error(0)
+}
+func (m *MockRefundRepository) AttachProviderID(ctx context.Context, refundID int, providerRefundID string, receipt *models.Receipt) error {
+	return m.Called(ctx, refundID, providerRefundID, receipt).Error(0)
+}
+func (m *MockRefundRepository) MarkSucceeded(ctx context.Context, providerRefundID, source string) (*models.Refund, error) {
+	args := m.Called(ctx, providerRefundID, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Refund), args.Error(1)
+}
+func (m *MockRefundRepository) MarkCanceled(ctx context.Context, refundID int) error {
+	return m.Called(ctx, refundID).Error(0)
+}
+func (m *MockRefundRepository) GetByProviderID(ctx context.Context, providerRefundID string) (*models.Refund, error) {
+	args := m.Called(ctx, providerRefundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Refund), args.Error(1)
+}
+
+func (m *MockRefundRepository) GetUnsent(ctx context.Context, paymentID int) (*models.Refund, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Refund), args.Error(1)
+}
+
+// --- MockPromoRepository ---
+
+type MockPromoRepository struct{ mock.Mock }
+
+func (m *MockPromoRepository) Create(ctx context.Context, promo *models.PromoCode) error {
+	return m.Called(ctx, promo).Error(0)
+}
+func (m *MockPromoRepository) Update(ctx context.Context, promo *models.PromoCode) error {
+	return m.Called(ctx, promo).Error(0)
+}
+func (m *MockPromoRepository) GetByID(ctx context.Context, id int) (*models.PromoCode, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PromoCode), args.Error(1)
+}
+func (m *MockPromoRepository) GetByCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PromoCode), args.Error(1)
+}
+func (m *MockPromoRepository) List(ctx context.Context, activeOnly bool) ([]*models.PromoCode, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PromoCode), args.Error(1)
+}
+
 // --- MockPaymentProvider ---
 
 type MockPaymentProvider struct{ mock.Mock }
 
-func (m *MockPaymentProvider) CreatePayment(ctx context.Context, amount int, currency, description string) (*models.PaymentProviderResponse, error) {
-	args := m.Called(ctx, amount, currency, description)
+func (m *MockPaymentProvider) CreatePayment(ctx context.Context, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.PaymentProviderResponse, error) {
+	args := m.Called(ctx, amount, currency, description, idempotenceKey, receipt)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.PaymentProviderResponse), args.Error(1)
 }
+func (m *MockPaymentProvider) RefundPayment(ctx context.Context, paymentID string, amount int, currency, description, idempotenceKey string, receipt *models.Receipt) (*models.RefundProviderResponse, error) {
+	args := m.Called(ctx, paymentID, amount, currency, description, idempotenceKey, receipt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefundProviderResponse), args.Error(1)
+}
+func (m *MockPaymentProvider) CancelPayment(ctx context.Context, paymentID, idempotenceKey string) error {
+	return m.Called(ctx, paymentID, idempotenceKey).Error(0)
+}
+func (m *MockPaymentProvider) GetPayment(ctx context.Context, paymentID string) (*models.PaymentProviderResponse, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PaymentProviderResponse), args.Error(1)
+}
+func (m *MockPaymentProvider) GetRefund(ctx context.Context, refundID string) (*models.RefundProviderResponse, error) {
+	args := m.Called(ctx, refundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefundProviderResponse), args.Error(1)
+}
+
+// --- MockInventoryClient ---
+
+type MockInventoryClient struct{ mock.Mock }
+
+func (m *MockInventoryClient) ReserveStock(ctx context.Context, orderID int, items []models.OrderItem) error {
+	return m.Called(ctx, orderID, items).Error(0)
+}
+func (m *MockInventoryClient) ReleaseStock(ctx context.Context, orderID int) error {
+	return m.Called(ctx, orderID).Error(0)
+}
+func (m *MockInventoryClient) CommitStock(ctx context.Context, orderID int) error {
+	return m.Called(ctx, orderID).Error(0)
+}
 
-// --- MockEventPublisher ---
+// --- MockCatalogClient ---
 
-type MockEventPublisher struct{ mock.Mock }
+type MockCatalogClient struct{ mock.Mock }
 
-func (m *MockEventPublisher) PublishOrderEvent(ctx context.Context, event models.OrderEvent) error {
-	return m.Called(ctx, event).Error(0)
+func (m *MockCatalogClient) PriceItems(ctx context.Context, items []models.OrderItem) ([]models.OrderItem, error) {
+	args := m.Called(ctx, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderItem), args.Error(1)
 }
-func (m *MockEventPublisher) Close() error {
-	return m.Called().Error(0)
+
+// --- MockAddressBook ---
+
+type MockAddressBook struct{ mock.Mock }
+
+func (m *MockAddressBook) GetAddress(ctx context.Context, userID, addressID int) (*models.ShippingAddress, error) {
+	args := m.Called(ctx, userID, addressID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShippingAddress), args.Error(1)
+}
+
+// --- MockCarrierTracker ---
+
+type MockCarrierTracker struct{ mock.Mock }
+
+func (m *MockCarrierTracker) Supports(carrier string) bool {
+	args := m.Called(carrier)
+	return args.Bool(0)
+}
+
+func (m *MockCarrierTracker) Tracked() []string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]string)
+}
+
+func (m *MockCarrierTracker) Track(ctx context.Context, carrier, trackingNumber string) (*models.TrackingInfo, error) {
+	args := m.Called(ctx, carrier, trackingNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TrackingInfo), args.Error(1)
 }
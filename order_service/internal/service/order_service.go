@@ -1,116 +1,419 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
+	"order_service/internal/lib/pagetoken"
 	"order_service/internal/models"
 )
 
+// Размеры страницы списка заказов.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// orderCursor — позиция в списке заказов или расхождений сверки: последний отданный id.
+type orderCursor struct {
+	ID int `json:"id"`
+}
+
 type OrderServiceImpl struct {
 	repo        OrderRepository
 	paymentRepo PaymentRepository
+	refundRepo  RefundRepository
+	promoRepo   PromoRepository
 	provider    PaymentProvider
-	publisher   EventPublisher
-	log         *slog.Logger
+	inventory   InventoryClient
+	catalog     CatalogClient
+	addressBook AddressBook
+	carriers    CarrierTracker
+	// deliveryCosts — стоимость доставки в копейках по способам; способа нет в карте — он недоступен.
+	deliveryCosts map[string]int
+	receipts      models.ReceiptSettings
+	// idempotencyTTL — окно, в котором повтор с тем же ключом возвращает исходный заказ.
+	idempotencyTTL time.Duration
+	log            *slog.Logger
 }
 
 func NewOrderService(
 	repo OrderRepository,
 	paymentRepo PaymentRepository,
+	refundRepo RefundRepository,
+	promoRepo PromoRepository,
 	provider PaymentProvider,
-	publisher EventPublisher,
+	inventory InventoryClient,
+	catalog CatalogClient,
+	addressBook AddressBook,
+	carriers CarrierTracker,
+	deliveryCosts map[string]int,
+	receipts models.ReceiptSettings,
+	idempotencyTTL time.Duration,
 	log *slog.Logger,
 ) *OrderServiceImpl {
 	return &OrderServiceImpl{
-		repo:        repo,
-		paymentRepo: paymentRepo,
-		provider:    provider,
-		publisher:   publisher,
-		log:         log,
+		repo:           repo,
+		paymentRepo:    paymentRepo,
+		refundRepo:     refundRepo,
+		promoRepo:      promoRepo,
+		provider:       provider,
+		inventory:      inventory,
+		catalog:        catalog,
+		addressBook:    addressBook,
+		carriers:       carriers,
+		deliveryCosts:  deliveryCosts,
+		receipts:       receipts,
+		idempotencyTTL: idempotencyTTL,
+		log:            log,
 	}
 }
 
-func (s *OrderServiceImpl) CreateOrder(ctx context.Context, userID int, items []models.OrderItem) (*models.OrderWithItems, error) {
+// CreateOrder создаёт заказ. Цены позиций, пришедшие от вызывающего, игнорируются:
+// они и итоговая сумма считаются по текущему каталогу product_service.
+// Стоимость доставки прибавляется к сумме заказа, адрес копируется в заказ.
+// Если передан promoCode, скидка по нему вычитается из суммы позиций и сохраняется
+// в заказе построчно; платёж создаётся на сумму со скидкой.
+// К платежу прикладывается чек по 54-ФЗ, он уходит на customerEmail (email из токена),
+// а без него — на телефон получателя.
+// Если передан idempotencyKey и заказ с ним уже создан не раньше idempotencyTTL назад,
+// возвращается этот заказ, а новый не создаётся; повтор с тем же ключом, но другим
+// запросом — models.ErrIdempotencyKeyReused. Если платёж у провайдера создать не
+// удалось — models.ErrPaymentUnavailable: повтор с тем же ключом создаст платёж
+// для уже созданного заказа.
+func (s *OrderServiceImpl) CreateOrder(ctx context.Context, userID int, customerEmail string, items []models.OrderItem, delivery models.DeliveryRequest, promoCode, idempotencyKey string) (*models.OrderWithItems, error) {
 	const op = "service.OrderService.CreateOrder"
 
-	var totalAmount int
+	requestHash := orderRequestHash(items, delivery, promoCode)
+
+	if idempotencyKey != "" {
+		existing, err := s.findByIdempotencyKey(ctx, userID, idempotencyKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if existing != nil {
+			if err := s.replayOrder(ctx, existing, requestHash); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			s.log.Info("order replayed by idempotency key",
+				slog.String("op", op),
+				slog.Int("order_id", existing.ID),
+				slog.Int("user_id", userID),
+			)
+			return existing, nil
+		}
+	}
+
+	if err := validateItems(items); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := s.resolveDelivery(ctx, userID, delivery)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, err = s.catalog.PriceItems(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("%s: price items: %w", op, err)
+	}
+
+	if promoCode != "" {
+		if err := s.applyPromo(ctx, order, items, promoCode); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	totalAmount := order.DeliveryCost - order.DiscountAmount
 	for _, item := range items {
 		totalAmount += item.PriceAtPurchase * item.Quantity
 	}
+	if order.DiscountAmount > 0 && totalAmount < models.MinPaymentAmount {
+		return nil, fmt.Errorf("%s: %w: order total after discount is below the minimum payment",
+			op, models.ErrPromoNotApplicable)
+	}
 
-	order := &models.Order{
-		UserID:      userID,
-		Status:      models.OrderStatusPendingPayment,
-		TotalAmount: totalAmount,
+	order.UserID = userID
+	order.Status = models.OrderStatusPendingPayment
+	order.TotalAmount = totalAmount
+	order.IdempotencyKey = idempotencyKey
+	order.RequestHash = requestHash
+	order.CustomerEmail = customerEmail
+
+	// Чек собираем до сохранения заказа: без контакта покупателя оплатить заказ нельзя.
+	receipt, err := models.BuildReceipt(&models.OrderWithItems{Order: *order, Items: items}, s.receipts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	created, err := s.repo.Create(ctx, order, items)
 	if err != nil {
+		if errors.Is(err, models.ErrDuplicateIdempotencyKey) {
+			// Параллельный запрос с тем же ключом успел создать заказ первым.
+			existing, findErr := s.repo.GetByIdempotencyKey(ctx, userID, idempotencyKey)
+			if findErr == nil && existing != nil {
+				if !sameRequest(existing, requestHash) {
+					return nil, fmt.Errorf("%s: %w", op, models.ErrIdempotencyKeyReused)
+				}
+				return existing, nil
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Резервируем остатки до создания платежа: без товара платить не за что.
+	if err := s.inventory.ReserveStock(ctx, created.ID, items); err != nil {
+		if cancelErr := s.repo.UpdateStatus(ctx, created.ID, models.OrderStatusCancelled, created.Status,
+			models.StatusChange{
+				EventType: models.EventOrderCancelled,
+				Source:    models.StatusSourceSystem,
+				Reason:    models.ReasonOutOfStock,
+			}); cancelErr != nil {
+			s.log.Error("failed to cancel order after reservation failure",
+				slog.String("op", op),
+				slog.Int("order_id", created.ID),
+				slog.String("error", cancelErr.Error()),
+			)
+		}
+		// Повтор с тем же ключом не должен возвращать отменённый заказ.
+		if idempotencyKey != "" {
+			if clearErr := s.repo.ClearIdempotencyKey(ctx, created.ID); clearErr != nil {
+				s.log.Error("failed to release idempotency key",
+					slog.String("op", op),
+					slog.Int("order_id", created.ID),
+					slog.String("error", clearErr.Error()),
+				)
+			}
+		}
+		return nil, fmt.Errorf("%s: reserve stock: %w", op, err)
+	}
+
+	// Синхронно создаём платёж в YooKassa. Если не вышло, заказ остаётся
+	// в PENDING_PAYMENT без платежа: повтор с тем же ключом создаст платёж,
+	// а без ключа заказ отменит истечение срока оплаты.
+	if err := s.createPayment(ctx, created, totalAmount, idempotencyKey, receipt); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Синхронно создаём платёж в YooKassa.
-	description := fmt.Sprintf("Order #%d", created.ID)
-	providerResp, err := s.provider.CreatePayment(ctx, totalAmount, "RUB", description)
+	s.log.Info("order created",
+		slog.String("op", op),
+		slog.Int("order_id", created.ID),
+		slog.Int("user_id", userID),
+		slog.Int("total_amount", totalAmount),
+	)
+
+	return created, nil
+}
+
+// createPayment создаёт платёж на amount копеек по заказу у провайдера, сохраняет его
+// и ссылку на оплату в заказе. Ключ идемпотентности провайдера выводится из заказа
+// и idempotencyKey, поэтому повторный вызов не создаёт второй платёж.
+// Ошибка провайдера — models.ErrPaymentUnavailable.
+func (s *OrderServiceImpl) createPayment(ctx context.Context, order *models.OrderWithItems, amount int, idempotencyKey string, receipt *models.Receipt) error {
+	const op = "service.OrderService.createPayment"
+
+	description := fmt.Sprintf("Order #%d", order.ID)
+	providerResp, err := s.provider.CreatePayment(ctx, amount, "RUB", description,
+		paymentIdempotenceKey(order.ID, idempotencyKey), receipt)
 	if err != nil {
 		s.log.Error("failed to create payment in provider",
 			slog.String("op", op),
-			slog.Int("order_id", created.ID),
+			slog.Int("order_id", order.ID),
 			slog.String("error", err.Error()),
 		)
-		return created, nil
+		return fmt.Errorf("%w: %w", models.ErrPaymentUnavailable, err)
 	}
 
 	// Сохраняем запись о платеже
 	payment := &models.Payment{
-		OrderID:           created.ID,
+		OrderID:           order.ID,
 		YooKassaPaymentID: providerResp.ID,
-		Amount:            totalAmount,
+		Amount:            amount,
 		Currency:          "RUB",
 		Status:            models.PaymentStatusPending,
 		ConfirmationURL:   providerResp.ConfirmationURL,
+		Receipt:           receipt,
 	}
 	if err := s.paymentRepo.Create(ctx, payment); err != nil {
 		s.log.Error("failed to save payment record",
 			slog.String("op", op),
-			slog.Int("order_id", created.ID),
+			slog.Int("order_id", order.ID),
 			slog.String("error", err.Error()),
 		)
 	}
 
 	// Обновляем payment_url в заказе
-	created.PaymentURL = providerResp.ConfirmationURL
-	if err := s.repo.UpdatePaymentURL(ctx, created.ID, providerResp.ConfirmationURL); err != nil {
+	order.PaymentURL = providerResp.ConfirmationURL
+	if err := s.repo.UpdatePaymentURL(ctx, order.ID, providerResp.ConfirmationURL); err != nil {
 		s.log.Error("failed to update payment url",
 			slog.String("op", op),
-			slog.Int("order_id", created.ID),
+			slog.Int("order_id", order.ID),
 			slog.String("error", err.Error()),
 		)
 	}
+	return nil
+}
+
+// replayOrder проверяет, что повтор пришёл с тем же запросом, и создаёт платёж
+// для заказа, которому в прошлый раз не досталось ссылки на оплату.
+func (s *OrderServiceImpl) replayOrder(ctx context.Context, existing *models.OrderWithItems, requestHash string) error {
+	if !sameRequest(existing, requestHash) {
+		return models.ErrIdempotencyKeyReused
+	}
+	if existing.Status != models.OrderStatusPendingPayment || existing.PaymentURL != "" {
+		return nil
+	}
+
+	receipt, err := models.BuildReceipt(existing, s.receipts)
+	if err != nil {
+		return err
+	}
+	return s.createPayment(ctx, existing, existing.TotalAmount, existing.IdempotencyKey, receipt)
+}
+
+// sameRequest сообщает, создан ли заказ тем же запросом. У заказов, созданных
+// до появления отпечатка запроса, сравнивать не с чем.
+func sameRequest(order *models.OrderWithItems, requestHash string) bool {
+	return order.RequestHash == "" || order.RequestHash == requestHash
+}
 
-	s.publishEvent(ctx, op, models.OrderEvent{
-		EventType:   "OrderCreated",
-		OrderID:     created.ID,
-		UserID:      userID,
-		Status:      created.Status,
-		TotalAmount: totalAmount,
-		PaymentURL:  providerResp.ConfirmationURL,
-		Timestamp:   time.Now().Format(time.RFC3339),
+// orderRequestHash — отпечаток запроса на создание заказа: позиции без учёта
+// порядка, доставка и промокод. Цены не входят — их считает сам сервис.
+func orderRequestHash(items []models.OrderItem, delivery models.DeliveryRequest, promoCode string) string {
+	type line struct {
+		SneakerID, VariantID, Quantity int
+	}
+	lines := make([]line, len(items))
+	for i, item := range items {
+		lines[i] = line{SneakerID: item.SneakerID, VariantID: item.VariantID, Quantity: item.Quantity}
+	}
+	slices.SortFunc(lines, func(a, b line) int {
+		return cmp.Or(cmp.Compare(a.SneakerID, b.SneakerID), cmp.Compare(a.VariantID, b.VariantID))
 	})
 
-	s.log.Info("order created",
-		slog.String("op", op),
-		slog.Int("order_id", created.ID),
-		slog.Int("user_id", userID),
-		slog.Int("total_amount", totalAmount),
-	)
+	method := delivery.Method
+	if method == "" {
+		method = models.DeliveryMethodPickup
+	}
 
-	return created, nil
+	// Ошибки быть не может: все поля — числа и строки.
+	payload, _ := json.Marshal(struct {
+		Items     []line
+		Method    string
+		AddressID int
+		Address   *models.ShippingAddress
+		PromoCode string
+	}{lines, method, delivery.AddressID, delivery.Address, strings.TrimSpace(promoCode)})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// findByIdempotencyKey возвращает заказ, созданный с этим ключом в пределах TTL.
+// Ключ просроченного заказа освобождается, чтобы по нему можно было создать новый.
+func (s *OrderServiceImpl) findByIdempotencyKey(ctx context.Context, userID int, key string) (*models.OrderWithItems, error) {
+	existing, err := s.repo.GetByIdempotencyKey(ctx, userID, key)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if time.Since(existing.CreatedAt) < s.idempotencyTTL {
+		return existing, nil
+	}
+	if err := s.repo.ClearIdempotencyKey(ctx, existing.ID); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// resolveDelivery проверяет выбор доставки и возвращает заказ с заполненными
+// способом, стоимостью и копией адреса.
+func (s *OrderServiceImpl) resolveDelivery(ctx context.Context, userID int, req models.DeliveryRequest) (*models.Order, error) {
+	method := req.Method
+	if method == "" {
+		method = models.DeliveryMethodPickup
+	}
+
+	cost, ok := s.deliveryCosts[method]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown delivery method %q", models.ErrInvalidDelivery, method)
+	}
+
+	order := &models.Order{DeliveryMethod: method, DeliveryCost: cost}
+
+	hasID, hasInline := req.AddressID != 0, req.Address != nil
+	if !models.NeedsAddress(method) {
+		if hasID || hasInline {
+			return nil, fmt.Errorf("%w: %s does not take an address", models.ErrInvalidDelivery, method)
+		}
+		return order, nil
+	}
+
+	switch {
+	case hasID && hasInline:
+		return nil, fmt.Errorf("%w: pass either address_id or address, not both", models.ErrInvalidDelivery)
+	case hasID:
+		addr, err := s.addressBook.GetAddress(ctx, userID, req.AddressID)
+		if err != nil {
+			return nil, fmt.Errorf("get address: %w", err)
+		}
+		order.ShippingAddress = addr
+	case hasInline:
+		if err := req.Address.Validate(); err != nil {
+			return nil, err
+		}
+		addr := *req.Address
+		order.ShippingAddress = &addr
+	default:
+		return nil, fmt.Errorf("%w: %s requires an address", models.ErrInvalidDelivery, method)
+	}
+
+	return order, nil
+}
+
+// paymentIdempotenceKey выводит ключ Idempotence-Key для YooKassa из заказа
+// и клиентского ключа, чтобы повторная отправка не создала второй платёж.
+func paymentIdempotenceKey(orderID int, idempotencyKey string) string {
+	name := fmt.Sprintf("order:%d:%s", orderID, idempotencyKey)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+// validateItems проверяет позиции заказа: каждая пара товар/вариант
+// встречается один раз, количество положительное.
+func validateItems(items []models.OrderItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: no items", models.ErrInvalidOrder)
+	}
+
+	type lineKey struct{ sneakerID, variantID int }
+	seen := make(map[lineKey]struct{}, len(items))
+	for _, item := range items {
+		if item.SneakerID <= 0 || item.VariantID < 0 {
+			return fmt.Errorf("%w: invalid sneaker %d variant %d", models.ErrInvalidOrder, item.SneakerID, item.VariantID)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be positive for sneaker %d", models.ErrInvalidOrder, item.SneakerID)
+		}
+		key := lineKey{item.SneakerID, item.VariantID}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("%w: duplicate line for sneaker %d variant %d", models.ErrInvalidOrder, item.SneakerID, item.VariantID)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
 }
 
+// GetOrder возвращает заказ вместе с историей смены статусов.
 func (s *OrderServiceImpl) GetOrder(ctx context.Context, orderID int) (*models.OrderWithItems, error) {
 	const op = "service.OrderService.GetOrder"
 
@@ -118,24 +421,109 @@ func (s *OrderServiceImpl) GetOrder(ctx context.Context, orderID int) (*models.O
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	order.History, err = s.repo.GetStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	return order, nil
 }
 
-func (s *OrderServiceImpl) GetUserOrders(ctx context.Context, userID int) ([]*models.OrderWithItems, error) {
+func (s *OrderServiceImpl) GetUserOrders(ctx context.Context, userID, pageSize int, pageToken string) (*models.OrderPage, error) {
 	const op = "service.OrderService.GetUserOrders"
 
-	orders, err := s.repo.GetUserOrders(ctx, userID)
+	page, err := paginateOrders(pageSize, pageToken, func(beforeID, limit int) ([]*models.OrderWithItems, error) {
+		return s.repo.GetUserOrders(ctx, userID, beforeID, limit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return page, nil
+}
+
+// ListOrders — админский список всех заказов, подходящих под filter, от новых к старым.
+func (s *OrderServiceImpl) ListOrders(ctx context.Context, filter models.OrderFilter, pageSize int, pageToken string) (*models.OrderPage, error) {
+	const op = "service.OrderService.ListOrders"
+
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page, err := paginateOrders(pageSize, pageToken, func(beforeID, limit int) ([]*models.OrderWithItems, error) {
+		return s.repo.ListOrders(ctx, filter, beforeID, limit)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	return orders, nil
+	return page, nil
+}
+
+// paginateOrders разбирает pageToken, запрашивает через fetch страницу заказов
+// с id меньше курсора и выставляет токен следующей страницы.
+func paginateOrders(
+	pageSize int, pageToken string, fetch func(beforeID, limit int) ([]*models.OrderWithItems, error),
+) (*models.OrderPage, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var cursor orderCursor
+	if err := pagetoken.Decode(pageToken, &cursor); err != nil || cursor.ID < 0 {
+		return nil, models.ErrInvalidPageToken
+	}
+
+	// Запрашиваем на один заказ больше, чтобы понять, есть ли следующая страница.
+	orders, err := fetch(cursor.ID, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.OrderPage{Orders: orders}
+	if len(orders) > pageSize {
+		page.Orders = orders[:pageSize]
+		next, err := pagetoken.Encode(orderCursor{ID: page.Orders[pageSize-1].ID})
+		if err != nil {
+			return nil, fmt.Errorf("encode page token: %w", err)
+		}
+		page.NextPageToken = next
+	}
+	return page, nil
+}
+
+// UpdateOrderStatus — ручная смена статуса. Ненулевой actorID — администратор, запрос пришёл
+// через gateway: в историю пишутся источник admin и его id; нулевой — внутренний вызов
+// с источником system. В REFUNDED заказ переводит только возврат,
+// в статусы выполнения — только MarkShipment, чтобы не потерять перевозчика и событие.
+func (s *OrderServiceImpl) UpdateOrderStatus(ctx context.Context, orderID int, newStatus string, actorID int) error {
+	const op = "service.OrderService.UpdateOrderStatus"
+
+	if newStatus == models.OrderStatusRefunded {
+		return fmt.Errorf("%s: %w: use RefundOrder to refund order %d", op, models.ErrInvalidStatus, orderID)
+	}
+	if _, ok := models.FulfilmentEvent(newStatus); ok {
+		return fmt.Errorf("%s: %w: use MarkShipment to move order %d to %s", op, models.ErrInvalidStatus, orderID, newStatus)
+	}
+	source := models.StatusSourceSystem
+	if actorID != 0 {
+		source = models.StatusSourceAdmin
+	}
+	return s.updateOrderStatus(ctx, orderID, newStatus, models.StatusChange{
+		EventType: models.EventOrderStatusChanged,
+		Source:    source,
+		ActorID:   actorID,
+	})
 }
 
-func (s *OrderServiceImpl) UpdateOrderStatus(ctx context.Context, orderID int, newStatus string) error {
+// updateOrderStatus меняет статус заказа и двигает резерв остатков.
+// change — событие, которое уйдёт в outbox вместе со сменой статуса.
+func (s *OrderServiceImpl) updateOrderStatus(ctx context.Context, orderID int, newStatus string, change models.StatusChange) error {
 	const op = "service.OrderService.UpdateOrderStatus"
 
 	if !models.IsValidStatus(newStatus) {
-		return fmt.Errorf("%s: invalid order status %q", op, newStatus)
+		return fmt.Errorf("%s: %w %q", op, models.ErrInvalidStatus, newStatus)
 	}
 
 	order, err := s.repo.GetByID(ctx, orderID)
@@ -144,17 +532,99 @@ func (s *OrderServiceImpl) UpdateOrderStatus(ctx context.Context, orderID int, n
 	}
 
 	if !models.ValidTransition(order.Status, newStatus) {
-		return fmt.Errorf("%s: invalid transition from %q to %q", op, order.Status, newStatus)
+		return fmt.Errorf("%s: %w from %q to %q", op, models.ErrInvalidTransition, order.Status, newStatus)
+	}
+
+	action := models.StockActionFor(newStatus)
+
+	// Повторная попытка оплаты: без резерва переводить заказ нельзя.
+	if action == models.StockActionReserve {
+		if err := s.inventory.ReserveStock(ctx, orderID, order.Items); err != nil {
+			return fmt.Errorf("%s: reserve stock: %w", op, err)
+		}
 	}
 
-	if err := s.repo.UpdateStatus(ctx, orderID, newStatus, order.Status); err != nil {
+	if err := s.repo.UpdateStatus(ctx, orderID, newStatus, order.Status, change); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+
+	// Статус уже сменён, поэтому ошибки резерва только логируем:
+	// неснятый резерв истечёт сам, несписанный — требует ручного разбора.
+	switch action {
+	case models.StockActionCommit:
+		if err := s.inventory.CommitStock(ctx, orderID); err != nil {
+			s.log.Error("failed to commit stock for paid order",
+				slog.String("op", op),
+				slog.Int("order_id", orderID),
+				slog.String("error", err.Error()),
+			)
+		}
+	case models.StockActionRelease:
+		if err := s.inventory.ReleaseStock(ctx, orderID); err != nil {
+			s.log.Warn("failed to release stock",
+				slog.String("op", op),
+				slog.Int("order_id", orderID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// VerifyPaymentWebhook проверяет, что уведомление о платеже подлинное: платёж известен,
+// сумма и валюта в уведомлении совпадают с сохранёнными, а провайдер при повторном
+// запросе сообщает тот же статус и ту же сумму. Непрошедшее проверку уведомление —
+// *models.WebhookRejectedError; прочие ошибки (провайдер недоступен) временные.
+func (s *OrderServiceImpl) VerifyPaymentWebhook(ctx context.Context, n models.PaymentNotification) error {
+	const op = "service.OrderService.VerifyPaymentWebhook"
+
+	payment, err := s.paymentRepo.GetByYooKassaID(ctx, n.PaymentID)
+	if errors.Is(err, models.ErrUnknownPayment) {
+		return &models.WebhookRejectedError{Reason: models.WebhookRejectUnknownPayment, Detail: "unknown payment"}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: get payment: %w", op, err)
+	}
+
+	if n.Amount != payment.Amount || n.Currency != payment.Currency {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectAmountMismatch,
+			Detail: fmt.Sprintf("notification %d %s, payment %d %s", n.Amount, n.Currency, payment.Amount, payment.Currency),
+		}
+	}
+
+	remote, err := s.provider.GetPayment(ctx, n.PaymentID)
+	if err != nil {
+		if errors.Is(err, models.ErrPaymentNotFound) {
+			return &models.WebhookRejectedError{Reason: models.WebhookRejectUnknownPayment, Detail: "unknown to provider"}
+		}
+		return fmt.Errorf("%s: get payment: %w", op, err)
+	}
+	if remote.Status != n.Status {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectStatusMismatch,
+			Detail: fmt.Sprintf("notification %s, provider %s", n.Status, remote.Status),
+		}
+	}
+	if remote.Amount != payment.Amount || remote.Currency != payment.Currency {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectAmountMismatch,
+			Detail: fmt.Sprintf("provider %d %s, payment %d %s", remote.Amount, remote.Currency, payment.Amount, payment.Currency),
+		}
+	}
 	return nil
 }
 
+// ProcessWebhook применяет статус платежа из уведомления провайдера.
 func (s *OrderServiceImpl) ProcessWebhook(ctx context.Context, yookassaID, status string) error {
-	const op = "service.OrderService.ProcessWebhook"
+	return s.ApplyPaymentStatus(ctx, yookassaID, status, models.StatusSourceWebhook)
+}
+
+// ApplyPaymentStatus переводит платёж и заказ в статус, сообщённый провайдером;
+// source — кто его сообщил, для истории статусов заказа.
+func (s *OrderServiceImpl) ApplyPaymentStatus(ctx context.Context, yookassaID, status, source string) error {
+	const op = "service.OrderService.ApplyPaymentStatus"
 
 	s.log.Info("processing webhook",
 		slog.String("op", op),
@@ -205,7 +675,16 @@ func (s *OrderServiceImpl) ProcessWebhook(ctx context.Context, yookassaID, statu
 		orderStatus = models.OrderStatusPaymentFailed
 	}
 
-	if err := s.UpdateOrderStatus(ctx, payment.OrderID, orderStatus); err != nil {
+	settled, err := s.settleCancelledOrder(ctx, payment, source)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if settled {
+		return nil
+	}
+
+	change := models.StatusChange{EventType: models.EventOrderPaymentUpdated, Source: source}
+	if err := s.updateOrderStatus(ctx, payment.OrderID, orderStatus, change); err != nil {
 		s.log.Error("failed to update order status after payment",
 			slog.String("op", op),
 			slog.Int("order_id", payment.OrderID),
@@ -214,13 +693,6 @@ func (s *OrderServiceImpl) ProcessWebhook(ctx context.Context, yookassaID, statu
 		return fmt.Errorf("%s: update order status: %w", op, err)
 	}
 
-	s.publishEvent(ctx, op, models.OrderEvent{
-		EventType: "OrderPaymentUpdated",
-		OrderID:   payment.OrderID,
-		Status:    orderStatus,
-		Timestamp: time.Now().Format(time.RFC3339),
-	})
-
 	s.log.Info("order status updated after payment",
 		slog.String("op", op),
 		slog.Int("order_id", payment.OrderID),
@@ -229,13 +701,3 @@ func (s *OrderServiceImpl) ProcessWebhook(ctx context.Context, yookassaID, statu
 
 	return nil
 }
-
-func (s *OrderServiceImpl) publishEvent(ctx context.Context, op string, event models.OrderEvent) {
-	if err := s.publisher.PublishOrderEvent(ctx, event); err != nil {
-		s.log.Error("failed to publish event",
-			slog.String("op", op),
-			slog.String("event_type", event.EventType),
-			slog.String("error", err.Error()),
-		)
-	}
-}
@@ -21,19 +21,28 @@ func newTestLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 }
 
+var testDeliveryCosts = map[string]int{
+	models.DeliveryMethodPickup:  0,
+	models.DeliveryMethodCourier: 500,
+	models.DeliveryMethodPost:    300,
+}
+
 func newTestService() (
 	*service.OrderServiceImpl,
 	*mocks.MockOrderRepository,
 	*mocks.MockPaymentRepository,
 	*mocks.MockPaymentProvider,
-	*mocks.MockEventPublisher,
+	*mocks.MockInventoryClient,
+	*mocks.MockCatalogClient,
 ) {
 	repo := new(mocks.MockOrderRepository)
 	paymentRepo := new(mocks.MockPaymentRepository)
 	provider := new(mocks.MockPaymentProvider)
-	pub := new(mocks.MockEventPublisher)
-	svc := service.NewOrderService(repo, paymentRepo, provider, pub, newTestLogger())
-	return svc, repo, paymentRepo, provider, pub
+	inventory := new(mocks.MockInventoryClient)
+	catalog := new(mocks.MockCatalogClient)
+	svc := service.NewOrderService(repo, paymentRepo, new(mocks.MockRefundRepository), new(mocks.MockPromoRepository),
+		provider, inventory, catalog, new(mocks.MockAddressBook), new(mocks.MockCarrierTracker), testDeliveryCosts, models.ReceiptSettings{}, 24*time.Hour, newTestLogger())
+	return svc, repo, paymentRepo, provider, inventory, catalog
 }
 
 // ---------------------------------------------------------------------------
@@ -41,8 +50,13 @@ func newTestService() (
 // ---------------------------------------------------------------------------
 
 func TestCreateOrder_Success(t *testing.T) {
-	svc, repo, paymentRepo, provider, pub := newTestService()
+	svc, repo, paymentRepo, provider, inventory, catalog := newTestService()
 
+	// Цена от клиента не учитывается — её подставляет каталог.
+	requested := []models.OrderItem{
+		{SneakerID: 1, Quantity: 2, PriceAtPurchase: 1},
+		{SneakerID: 2, Quantity: 1},
+	}
 	items := []models.OrderItem{
 		{SneakerID: 1, Quantity: 2, PriceAtPurchase: 100},
 		{SneakerID: 2, Quantity: 1, PriceAtPurchase: 200},
@@ -56,17 +70,19 @@ func TestCreateOrder_Success(t *testing.T) {
 		Items: items,
 	}
 
-	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
-		Return(expectedOrder, nil)
-	provider.On("CreatePayment", mock.Anything, 400, "RUB", "Order #1").
+	catalog.On("PriceItems", mock.Anything, requested).Return(items, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(o *models.Order) bool {
+		return o.TotalAmount == 400
+	}), items).Return(expectedOrder, nil)
+	inventory.On("ReserveStock", mock.Anything, 1, items).Return(nil)
+	provider.On("CreatePayment", mock.Anything, 400, "RUB", "Order #1", mock.AnythingOfType("string"), mock.Anything).
 		Return(&models.PaymentProviderResponse{
 			ID: "yoo-123", Status: "pending", ConfirmationURL: "https://pay.example.com/123",
 		}, nil)
 	paymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Payment")).Return(nil)
 	repo.On("UpdatePaymentURL", mock.Anything, 1, "https://pay.example.com/123").Return(nil)
-	pub.On("PublishOrderEvent", mock.Anything, mock.AnythingOfType("models.OrderEvent")).Return(nil)
 
-	result, err := svc.CreateOrder(context.Background(), 42, items)
+	result, err := svc.CreateOrder(context.Background(), 42, "", requested, models.DeliveryRequest{}, "", "")
 
 	require.NoError(t, err)
 	assert.Equal(t, 1, result.ID)
@@ -77,24 +93,51 @@ func TestCreateOrder_Success(t *testing.T) {
 }
 
 func TestCreateOrder_RepositoryError(t *testing.T) {
-	svc, repo, _, _, _ := newTestService()
+	svc, repo, _, _, _, catalog := newTestService()
 
 	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
 
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
 		Return(nil, errors.New("db connection lost"))
 
-	result, err := svc.CreateOrder(context.Background(), 42, items)
+	result, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
 
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "db connection lost")
 }
 
-func TestCreateOrder_PaymentProviderError_StillSucceeds(t *testing.T) {
-	svc, repo, _, provider, _ := newTestService()
+func TestCreateOrder_InsufficientStock_CancelsOrder(t *testing.T) {
+	svc, repo, _, provider, inventory, catalog := newTestService()
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 3, PriceAtPurchase: 100}}
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+	created := &models.OrderWithItems{
+		Order: models.Order{ID: 6, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 300},
+		Items: items,
+	}
+
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).Return(created, nil)
+	inventory.On("ReserveStock", mock.Anything, 6, items).Return(models.ErrInsufficientStock)
+	repo.On("UpdateStatus", mock.Anything, 6, models.OrderStatusCancelled, models.OrderStatusPendingPayment,
+		models.StatusChange{
+			EventType: models.EventOrderCancelled, Source: models.StatusSourceSystem, Reason: models.ReasonOutOfStock,
+		}).Return(nil)
+
+	result, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
+
+	require.ErrorIs(t, err, models.ErrInsufficientStock)
+	assert.Nil(t, result)
+	repo.AssertExpectations(t)
+	provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_PaymentProviderError_RetryCreatesPayment(t *testing.T) {
+	svc, repo, paymentRepo, provider, inventory, catalog := newTestService()
 
 	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
 
 	created := &models.OrderWithItems{
 		Order: models.Order{
@@ -104,16 +147,339 @@ func TestCreateOrder_PaymentProviderError_StillSucceeds(t *testing.T) {
 		Items: items,
 	}
 
+	var saved *models.Order
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(nil, nil).Once()
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*models.Order) }).
 		Return(created, nil)
-	provider.On("CreatePayment", mock.Anything, 100, "RUB", "Order #5").
-		Return(nil, errors.New("yookassa unavailable"))
+	inventory.On("ReserveStock", mock.Anything, 5, items).Return(nil)
+	var keys []string
+	provider.On("CreatePayment", mock.Anything, 100, "RUB", "Order #5", mock.AnythingOfType("string"), mock.Anything).
+		Run(func(args mock.Arguments) { keys = append(keys, args.String(4)) }).
+		Return(nil, errors.New("yookassa unavailable")).Once()
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "key-1")
+	require.ErrorIs(t, err, models.ErrPaymentUnavailable)
+	require.NotEmpty(t, saved.RequestHash)
+
+	// Повтор с тем же ключом создаёт платёж для уже созданного заказа.
+	replayed := &models.OrderWithItems{Order: created.Order, Items: items}
+	replayed.IdempotencyKey = "key-1"
+	replayed.RequestHash = saved.RequestHash
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(replayed, nil).Once()
+	provider.On("CreatePayment", mock.Anything, 100, "RUB", "Order #5", mock.AnythingOfType("string"), mock.Anything).
+		Run(func(args mock.Arguments) { keys = append(keys, args.String(4)) }).
+		Return(&models.PaymentProviderResponse{ID: "yoo-5", ConfirmationURL: "https://pay.example.com/5"}, nil).Once()
+	paymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("UpdatePaymentURL", mock.Anything, 5, "https://pay.example.com/5").Return(nil)
+
+	result, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://pay.example.com/5", result.PaymentURL)
+	require.Len(t, keys, 2)
+	assert.Equal(t, keys[0], keys[1], "the retry must reuse the YooKassa key")
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestCreateOrder_IdempotencyKeyReusedWithDifferentRequest(t *testing.T) {
+	svc, repo, _, provider, _, catalog := newTestService()
+
+	existing := &models.OrderWithItems{
+		Order: models.Order{
+			ID: 9, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 100,
+			RequestHash: "hash-of-another-request", CreatedAt: time.Now().Add(-time.Minute),
+		},
+	}
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(existing, nil)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", []models.OrderItem{{SneakerID: 1, Quantity: 2}}, models.DeliveryRequest{}, "", "key-1")
+
+	require.ErrorIs(t, err, models.ErrIdempotencyKeyReused)
+	catalog.AssertNotCalled(t, "PriceItems", mock.Anything, mock.Anything)
+	provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_RequestHashIgnoresItemOrder(t *testing.T) {
+	a := models.OrderItem{SneakerID: 1, VariantID: 3, Quantity: 1}
+	b := models.OrderItem{SneakerID: 2, Quantity: 2}
+
+	hashes := make([]string, 0, 2)
+	for _, items := range [][]models.OrderItem{{a, b}, {b, a}} {
+		svc, repo, _, _, _, catalog := newTestService()
+
+		catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), mock.Anything).
+			Run(func(args mock.Arguments) { hashes = append(hashes, args.Get(1).(*models.Order).RequestHash) }).
+			Return(nil, errors.New("stop"))
+
+		_, _ = svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
+	}
+
+	require.Len(t, hashes, 2)
+	assert.Equal(t, hashes[0], hashes[1])
+}
+
+func TestCreateOrder_ReplaysByIdempotencyKey(t *testing.T) {
+	svc, repo, _, provider, _, catalog := newTestService()
+
+	existing := &models.OrderWithItems{
+		Order: models.Order{
+			ID: 9, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 100,
+			PaymentURL: "https://pay.example.com/9", CreatedAt: time.Now().Add(-time.Minute),
+		},
+		Items: []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}},
+	}
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(existing, nil)
+
+	result, err := svc.CreateOrder(context.Background(), 42, "", []models.OrderItem{{SneakerID: 1, Quantity: 1}}, models.DeliveryRequest{}, "", "key-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, existing, result)
+	catalog.AssertNotCalled(t, "PriceItems", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_ExpiredIdempotencyKeyCreatesNewOrder(t *testing.T) {
+	svc, repo, _, _, _, catalog := newTestService()
+
+	stale := &models.OrderWithItems{
+		Order: models.Order{ID: 9, UserID: 42, CreatedAt: time.Now().Add(-48 * time.Hour)},
+	}
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
+
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(stale, nil)
+	repo.On("ClearIdempotencyKey", mock.Anything, 9).Return(nil)
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(o *models.Order) bool {
+		return o.IdempotencyKey == "key-1"
+	}), items).Return(nil, errors.New("db connection lost"))
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "key-1")
+
+	require.Error(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateOrder_ConcurrentDuplicateReturnsWinner(t *testing.T) {
+	svc, repo, _, provider, _, catalog := newTestService()
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
+	winner := &models.OrderWithItems{
+		Order: models.Order{ID: 11, UserID: 42, Status: models.OrderStatusPendingPayment, CreatedAt: time.Now()},
+		Items: items,
+	}
+
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(nil, nil).Once()
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
+		Return(nil, models.ErrDuplicateIdempotencyKey)
+	repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(winner, nil).Once()
+
+	result, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "key-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 11, result.ID)
+	provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_PaymentIdempotenceKeyIsDerived(t *testing.T) {
+	keys := make([]string, 0, 2)
+	for range 2 {
+		svc, repo, paymentRepo, provider, inventory, catalog := newTestService()
+
+		items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
+		created := &models.OrderWithItems{
+			Order: models.Order{ID: 3, UserID: 42, Status: models.OrderStatusPendingPayment, TotalAmount: 100},
+			Items: items,
+		}
+
+		repo.On("GetByIdempotencyKey", mock.Anything, 42, "key-1").Return(nil, nil)
+		catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).Return(created, nil)
+		inventory.On("ReserveStock", mock.Anything, 3, items).Return(nil)
+		provider.On("CreatePayment", mock.Anything, 100, "RUB", "Order #3", mock.AnythingOfType("string"), mock.Anything).
+			Run(func(args mock.Arguments) { keys = append(keys, args.String(4)) }).
+			Return(&models.PaymentProviderResponse{ID: "yoo-3", ConfirmationURL: "https://pay.example.com/3"}, nil)
+		paymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		repo.On("UpdatePaymentURL", mock.Anything, 3, mock.Anything).Return(nil)
+
+		_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "key-1")
+		require.NoError(t, err)
+	}
+
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1], "retries of the same order must reuse the YooKassa key")
+}
+
+func TestCreateOrder_InvalidItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []models.OrderItem
+	}{
+		{name: "no items", items: nil},
+		{name: "zero quantity", items: []models.OrderItem{{SneakerID: 1, Quantity: 0}}},
+		{name: "negative quantity", items: []models.OrderItem{{SneakerID: 1, Quantity: -2}}},
+		{name: "duplicate line", items: []models.OrderItem{
+			{SneakerID: 1, VariantID: 3, Quantity: 1},
+			{SneakerID: 1, VariantID: 3, Quantity: 2},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _, _, _, catalog := newTestService()
+
+			result, err := svc.CreateOrder(context.Background(), 42, "", tt.items, models.DeliveryRequest{}, "", "")
+
+			require.ErrorIs(t, err, models.ErrInvalidOrder)
+			assert.Nil(t, result)
+			catalog.AssertNotCalled(t, "PriceItems", mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateOrder_SameSneakerDifferentVariants(t *testing.T) {
+	svc, repo, _, _, _, catalog := newTestService()
+
+	items := []models.OrderItem{
+		{SneakerID: 1, VariantID: 3, Quantity: 1},
+		{SneakerID: 1, VariantID: 4, Quantity: 1},
+	}
+	catalog.On("PriceItems", mock.Anything, items).Return(items, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
+		Return(nil, errors.New("db connection lost"))
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
+
+	assert.NotErrorIs(t, err, models.ErrInvalidOrder)
+	catalog.AssertExpectations(t)
+}
+
+func TestCreateOrder_ProductUnavailable(t *testing.T) {
+	svc, repo, _, _, _, catalog := newTestService()
+
+	items := []models.OrderItem{{SneakerID: 7, Quantity: 1}}
+	catalog.On("PriceItems", mock.Anything, items).Return(nil, models.ErrProductUnavailable)
+
+	result, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
+
+	require.ErrorIs(t, err, models.ErrProductUnavailable)
+	assert.Nil(t, result)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// CreateOrder: доставка
+// ---------------------------------------------------------------------------
+
+// newDeliveryTestService собирает сервис, в котором Create падает сразу после
+// проверки переданного заказа: для тестов доставки дальнейшие шаги не нужны.
+func newDeliveryTestService(check func(o *models.Order)) (*service.OrderServiceImpl, *mocks.MockOrderRepository, *mocks.MockAddressBook) {
+	repo := new(mocks.MockOrderRepository)
+	catalog := new(mocks.MockCatalogClient)
+	addressBook := new(mocks.MockAddressBook)
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 2, PriceAtPurchase: 100}}
+	catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil).Maybe()
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), items).
+		Run(func(args mock.Arguments) { check(args.Get(1).(*models.Order)) }).
+		Return(nil, errors.New("stop")).Maybe()
+
+	svc := service.NewOrderService(repo, new(mocks.MockPaymentRepository), new(mocks.MockRefundRepository),
+		new(mocks.MockPromoRepository), new(mocks.MockPaymentProvider), new(mocks.MockInventoryClient), catalog,
+		addressBook, new(mocks.MockCarrierTracker), testDeliveryCosts, models.ReceiptSettings{}, 24*time.Hour, newTestLogger())
+	return svc, repo, addressBook
+}
+
+var deliveryTestItems = []models.OrderItem{{SneakerID: 1, Quantity: 2}}
+
+func TestCreateOrder_DefaultsToPickup(t *testing.T) {
+	var got *models.Order
+	svc, repo, _ := newDeliveryTestService(func(o *models.Order) { got = o })
+
+	_, _ = svc.CreateOrder(context.Background(), 42, "", deliveryTestItems, models.DeliveryRequest{}, "", "")
+
+	repo.AssertCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, models.DeliveryMethodPickup, got.DeliveryMethod)
+	assert.Zero(t, got.DeliveryCost)
+	assert.Nil(t, got.ShippingAddress)
+	assert.Equal(t, 200, got.TotalAmount)
+}
+
+func TestCreateOrder_CourierFromAddressBook(t *testing.T) {
+	var got *models.Order
+	svc, _, addressBook := newDeliveryTestService(func(o *models.Order) { got = o })
+
+	addr := &models.ShippingAddress{RecipientName: "Иван", Phone: "+79991234567", City: "Москва", AddressLine: "Ленина, 1"}
+	addressBook.On("GetAddress", mock.Anything, 42, 7).Return(addr, nil)
+
+	_, _ = svc.CreateOrder(context.Background(), 42, "", deliveryTestItems,
+		models.DeliveryRequest{Method: models.DeliveryMethodCourier, AddressID: 7}, "", "")
+
+	require.NotNil(t, got)
+	assert.Equal(t, models.DeliveryMethodCourier, got.DeliveryMethod)
+	assert.Equal(t, 500, got.DeliveryCost)
+	assert.Equal(t, 700, got.TotalAmount, "стоимость доставки входит в сумму заказа")
+	assert.Equal(t, addr, got.ShippingAddress)
+}
+
+func TestCreateOrder_PostWithInlineAddressIsCopied(t *testing.T) {
+	var got *models.Order
+	svc, _, addressBook := newDeliveryTestService(func(o *models.Order) { got = o })
+
+	addr := &models.ShippingAddress{RecipientName: "Иван", Phone: "+79991234567", City: "Казань", AddressLine: "Баумана, 5"}
 
-	result, err := svc.CreateOrder(context.Background(), 42, items)
+	_, _ = svc.CreateOrder(context.Background(), 42, "", deliveryTestItems,
+		models.DeliveryRequest{Method: models.DeliveryMethodPost, Address: addr}, "", "")
 
-	require.NoError(t, err, "order should succeed even when payment provider fails")
-	assert.Equal(t, 5, result.ID)
-	assert.Empty(t, result.PaymentURL)
+	require.NotNil(t, got)
+	assert.Equal(t, 500, got.TotalAmount)
+	assert.Equal(t, *addr, *got.ShippingAddress)
+	assert.NotSame(t, addr, got.ShippingAddress)
+	addressBook.AssertNotCalled(t, "GetAddress", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_InvalidDelivery(t *testing.T) {
+	addr := &models.ShippingAddress{RecipientName: "Иван", Phone: "+79991234567", City: "Москва", AddressLine: "Ленина, 1"}
+
+	tests := []struct {
+		name     string
+		delivery models.DeliveryRequest
+	}{
+		{"unknown method", models.DeliveryRequest{Method: "drone"}},
+		{"courier without address", models.DeliveryRequest{Method: models.DeliveryMethodCourier}},
+		{"both address id and address", models.DeliveryRequest{Method: models.DeliveryMethodPost, AddressID: 7, Address: addr}},
+		{"pickup with address", models.DeliveryRequest{Method: models.DeliveryMethodPickup, Address: addr}},
+		{"incomplete inline address", models.DeliveryRequest{
+			Method: models.DeliveryMethodCourier, Address: &models.ShippingAddress{RecipientName: "Иван"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newDeliveryTestService(func(*models.Order) {})
+
+			_, err := svc.CreateOrder(context.Background(), 42, "", deliveryTestItems, tt.delivery, "", "")
+
+			require.ErrorIs(t, err, models.ErrInvalidDelivery)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateOrder_AddressNotFound(t *testing.T) {
+	svc, repo, addressBook := newDeliveryTestService(func(*models.Order) {})
+
+	addressBook.On("GetAddress", mock.Anything, 42, 7).Return(nil, models.ErrAddressNotFound)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", deliveryTestItems,
+		models.DeliveryRequest{Method: models.DeliveryMethodCourier, AddressID: 7}, "", "")
+
+	require.ErrorIs(t, err, models.ErrAddressNotFound)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // ---------------------------------------------------------------------------
@@ -121,20 +487,26 @@ func TestCreateOrder_PaymentProviderError_StillSucceeds(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestGetOrder_Success(t *testing.T) {
-	svc, repo, _, _, _ := newTestService()
+	svc, repo, _, _, _, _ := newTestService()
 
 	expected := &models.OrderWithItems{
 		Order: models.Order{ID: 10, UserID: 1, Status: models.OrderStatusPaid},
 	}
+	history := []models.StatusHistoryEntry{
+		{ToStatus: models.OrderStatusPendingPayment, Source: models.StatusSourceUser},
+		{FromStatus: models.OrderStatusPendingPayment, ToStatus: models.OrderStatusPaid, Source: models.StatusSourceWebhook},
+	}
 	repo.On("GetByID", mock.Anything, 10).Return(expected, nil)
+	repo.On("GetStatusHistory", mock.Anything, 10).Return(history, nil)
 
 	result, err := svc.GetOrder(context.Background(), 10)
 	require.NoError(t, err)
 	assert.Equal(t, 10, result.ID)
+	assert.Equal(t, history, result.History)
 }
 
 func TestGetOrder_NotFound(t *testing.T) {
-	svc, repo, _, _, _ := newTestService()
+	svc, repo, _, _, _, _ := newTestService()
 
 	repo.On("GetByID", mock.Anything, 999).Return(nil, errors.New("not found"))
 
@@ -144,17 +516,102 @@ func TestGetOrder_NotFound(t *testing.T) {
 }
 
 func TestGetUserOrders_Success(t *testing.T) {
-	svc, repo, _, _, _ := newTestService()
+	svc, repo, _, _, _, _ := newTestService()
 
 	orders := []*models.OrderWithItems{
 		{Order: models.Order{ID: 1, UserID: 42}},
 		{Order: models.Order{ID: 2, UserID: 42}},
 	}
-	repo.On("GetUserOrders", mock.Anything, 42).Return(orders, nil)
+	repo.On("GetUserOrders", mock.Anything, 42, 0, service.DefaultPageSize+1).Return(orders, nil)
+
+	page, err := svc.GetUserOrders(context.Background(), 42, 0, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Orders, 2)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestGetUserOrders_Paginates(t *testing.T) {
+	svc, repo, _, _, _, _ := newTestService()
+
+	repo.On("GetUserOrders", mock.Anything, 42, 0, 3).Return([]*models.OrderWithItems{
+		{Order: models.Order{ID: 9}}, {Order: models.Order{ID: 7}}, {Order: models.Order{ID: 4}},
+	}, nil)
+	repo.On("GetUserOrders", mock.Anything, 42, 7, 3).Return([]*models.OrderWithItems{
+		{Order: models.Order{ID: 4}},
+	}, nil)
+
+	page, err := svc.GetUserOrders(context.Background(), 42, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = svc.GetUserOrders(context.Background(), 42, 2, page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+	assert.Equal(t, 4, page.Orders[0].ID)
+	assert.Empty(t, page.NextPageToken)
+	repo.AssertExpectations(t)
+}
+
+func TestGetUserOrders_InvalidPageToken(t *testing.T) {
+	svc, repo, _, _, _, _ := newTestService()
+
+	_, err := svc.GetUserOrders(context.Background(), 42, 0, "%%%")
+	assert.ErrorIs(t, err, models.ErrInvalidPageToken)
+	repo.AssertNotCalled(t, "GetUserOrders", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// ListOrders
+// ---------------------------------------------------------------------------
+
+func TestListOrders_PassesFilterAndPaginates(t *testing.T) {
+	svc, repo, _, _, _, _ := newTestService()
+
+	filter := models.OrderFilter{
+		Status:      models.OrderStatusPaid,
+		CreatedFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		MinAmount:   1000,
+	}
+	repo.On("ListOrders", mock.Anything, filter, 0, 3).Return([]*models.OrderWithItems{
+		{Order: models.Order{ID: 9}}, {Order: models.Order{ID: 7}}, {Order: models.Order{ID: 5}},
+	}, nil)
+	repo.On("ListOrders", mock.Anything, filter, 7, 3).Return([]*models.OrderWithItems{
+		{Order: models.Order{ID: 5}},
+	}, nil)
+
+	page, err := svc.ListOrders(context.Background(), filter, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	require.NotEmpty(t, page.NextPageToken)
 
-	result, err := svc.GetUserOrders(context.Background(), 42)
+	page, err = svc.ListOrders(context.Background(), filter, 2, page.NextPageToken)
 	require.NoError(t, err)
-	assert.Len(t, result, 2)
+	require.Len(t, page.Orders, 1)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestListOrders_InvalidFilter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		filter models.OrderFilter
+	}{
+		{"unknown status", models.OrderFilter{Status: "BOGUS"}},
+		{"negative amount", models.OrderFilter{MinAmount: -1}},
+		{"min above max", models.OrderFilter{MinAmount: 500, MaxAmount: 100}},
+		{"empty date range", models.OrderFilter{CreatedFrom: now, CreatedTo: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _, _, _, _ := newTestService()
+
+			_, err := svc.ListOrders(context.Background(), tt.filter, 0, "")
+			require.ErrorIs(t, err, models.ErrInvalidOrderFilter)
+			repo.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -162,23 +619,99 @@ func TestGetUserOrders_Success(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestUpdateOrderStatus_Success(t *testing.T) {
-	svc, repo, _, _, _ := newTestService()
+	svc, repo, _, _, inventory, _ := newTestService()
+
+	existing := &models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment},
+	}
+	repo.On("GetByID", mock.Anything, 1).Return(existing, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPaid, models.OrderStatusPendingPayment, models.StatusChange{EventType: models.EventOrderStatusChanged, Source: models.StatusSourceSystem}).Return(nil)
+	inventory.On("CommitStock", mock.Anything, 1).Return(nil)
+
+	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusPaid, 0)
+	require.NoError(t, err)
+	inventory.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_CancelReleasesStock(t *testing.T) {
+	svc, repo, _, _, inventory, _ := newTestService()
 
 	existing := &models.OrderWithItems{
 		Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment},
 	}
 	repo.On("GetByID", mock.Anything, 1).Return(existing, nil)
-	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPaid, models.OrderStatusPendingPayment).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusCancelled, models.OrderStatusPendingPayment, models.StatusChange{EventType: models.EventOrderStatusChanged, Source: models.StatusSourceSystem}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
 
-	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusPaid)
+	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusCancelled, 0)
 	require.NoError(t, err)
+	inventory.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_RetryPaymentReservesAgain(t *testing.T) {
+	svc, repo, _, _, inventory, _ := newTestService()
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 100}}
+	existing := &models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.OrderStatusPaymentFailed},
+		Items: items,
+	}
+	repo.On("GetByID", mock.Anything, 1).Return(existing, nil)
+	inventory.On("ReserveStock", mock.Anything, 1, items).Return(models.ErrInsufficientStock)
+
+	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusPendingPayment, 0)
+	require.ErrorIs(t, err, models.ErrInsufficientStock)
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_InvalidTransitionSkipsStock(t *testing.T) {
+	svc, repo, _, _, inventory, _ := newTestService()
+
+	existing := &models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.OrderStatusShipped},
+	}
+	repo.On("GetByID", mock.Anything, 1).Return(existing, nil)
+
+	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusCancelled, 0)
+	require.ErrorIs(t, err, models.ErrInvalidTransition)
+	inventory.AssertNotCalled(t, "ReleaseStock", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_RecordsAdminSource(t *testing.T) {
+	svc, repo, _, _, inventory, _ := newTestService()
+
+	existing := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaymentFailed}}
+	repo.On("GetByID", mock.Anything, 1).Return(existing, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusCancelled, models.OrderStatusPaymentFailed,
+		models.StatusChange{EventType: models.EventOrderStatusChanged, Source: models.StatusSourceAdmin, ActorID: 7}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
+
+	err := svc.UpdateOrderStatus(context.Background(), 1, models.OrderStatusCancelled, 7)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_FulfilmentStatusesRequireMarkShipment(t *testing.T) {
+	for _, status := range []string{
+		models.OrderStatusProcessing, models.OrderStatusShipped, models.OrderStatusDelivered, models.OrderStatusReturned,
+	} {
+		t.Run(status, func(t *testing.T) {
+			svc, repo, _, _, _, _ := newTestService()
+
+			err := svc.UpdateOrderStatus(context.Background(), 1, status, 7)
+			require.ErrorIs(t, err, models.ErrInvalidStatus)
+			assert.Contains(t, err.Error(), "MarkShipment")
+			repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
 }
 
 func TestUpdateOrderStatus_InvalidStatus(t *testing.T) {
-	svc, _, _, _, _ := newTestService()
+	svc, _, _, _, _, _ := newTestService()
 
-	err := svc.UpdateOrderStatus(context.Background(), 1, "BOGUS")
-	require.Error(t, err)
+	err := svc.UpdateOrderStatus(context.Background(), 1, "BOGUS", 0)
+	require.ErrorIs(t, err, models.ErrInvalidStatus)
 	assert.Contains(t, err.Error(), "invalid order status")
 }
 
@@ -187,7 +720,7 @@ func TestUpdateOrderStatus_InvalidStatus(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestProcessWebhook_Succeeded(t *testing.T) {
-	svc, repo, paymentRepo, _, pub := newTestService()
+	svc, repo, paymentRepo, _, inventory, _ := newTestService()
 
 	existing := &models.Payment{
 		ID: 1, OrderID: 10, YooKassaPaymentID: "yoo-abc", Status: models.PaymentStatusPending,
@@ -203,8 +736,8 @@ func TestProcessWebhook_Succeeded(t *testing.T) {
 		Order: models.Order{ID: 10, Status: models.OrderStatusPendingPayment},
 	}
 	repo.On("GetByID", mock.Anything, 10).Return(orderWithItems, nil)
-	repo.On("UpdateStatus", mock.Anything, 10, models.OrderStatusPaid, models.OrderStatusPendingPayment).Return(nil)
-	pub.On("PublishOrderEvent", mock.Anything, mock.AnythingOfType("models.OrderEvent")).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 10, models.OrderStatusPaid, models.OrderStatusPendingPayment, models.StatusChange{EventType: models.EventOrderPaymentUpdated, Source: models.StatusSourceWebhook}).Return(nil)
+	inventory.On("CommitStock", mock.Anything, 10).Return(nil)
 
 	err := svc.ProcessWebhook(context.Background(), "yoo-abc", "succeeded")
 	require.NoError(t, err)
@@ -213,7 +746,7 @@ func TestProcessWebhook_Succeeded(t *testing.T) {
 }
 
 func TestProcessWebhook_Canceled(t *testing.T) {
-	svc, repo, paymentRepo, _, pub := newTestService()
+	svc, repo, paymentRepo, _, inventory, _ := newTestService()
 
 	existing := &models.Payment{
 		ID: 2, OrderID: 20, Status: models.PaymentStatusPending,
@@ -229,15 +762,15 @@ func TestProcessWebhook_Canceled(t *testing.T) {
 		Order: models.Order{ID: 20, Status: models.OrderStatusPendingPayment},
 	}
 	repo.On("GetByID", mock.Anything, 20).Return(orderWithItems, nil)
-	repo.On("UpdateStatus", mock.Anything, 20, models.OrderStatusPaymentFailed, models.OrderStatusPendingPayment).Return(nil)
-	pub.On("PublishOrderEvent", mock.Anything, mock.AnythingOfType("models.OrderEvent")).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 20, models.OrderStatusPaymentFailed, models.OrderStatusPendingPayment, models.StatusChange{EventType: models.EventOrderPaymentUpdated, Source: models.StatusSourceWebhook}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 20).Return(nil)
 
 	err := svc.ProcessWebhook(context.Background(), "yoo-xyz", "canceled")
 	require.NoError(t, err)
 }
 
 func TestProcessWebhook_AlreadyProcessed(t *testing.T) {
-	svc, _, paymentRepo, _, _ := newTestService()
+	svc, _, paymentRepo, _, _, _ := newTestService()
 
 	existing := &models.Payment{
 		ID: 3, OrderID: 30, Status: models.PaymentStatusPending,
@@ -251,7 +784,7 @@ func TestProcessWebhook_AlreadyProcessed(t *testing.T) {
 }
 
 func TestProcessWebhook_InvalidTransition(t *testing.T) {
-	svc, _, paymentRepo, _, _ := newTestService()
+	svc, _, paymentRepo, _, _, _ := newTestService()
 
 	existing := &models.Payment{
 		ID: 4, OrderID: 40, Status: models.PaymentStatusSucceeded,
@@ -263,3 +796,102 @@ func TestProcessWebhook_InvalidTransition(t *testing.T) {
 	require.NoError(t, err)
 	paymentRepo.AssertNotCalled(t, "UpdateStatusAndGet")
 }
+
+// ---------------------------------------------------------------------------
+// VerifyPaymentWebhook
+// ---------------------------------------------------------------------------
+
+func succeededNotification(paymentID string) models.PaymentNotification {
+	return models.PaymentNotification{
+		PaymentID: paymentID, Status: models.PaymentStatusSucceeded, Amount: 1000, Currency: "RUB",
+	}
+}
+
+func requireRejected(t *testing.T, err error, reason string) {
+	t.Helper()
+	var rejected *models.WebhookRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, reason, rejected.Reason)
+}
+
+func TestVerifyPaymentWebhook_OK(t *testing.T) {
+	svc, _, paymentRepo, provider, _, _ := newTestService()
+
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(pendingPayment(1, 10, "yoo-1"), nil)
+	provider.On("GetPayment", mock.Anything, "yoo-1").Return(&models.PaymentProviderResponse{
+		ID: "yoo-1", Status: models.PaymentStatusSucceeded, Amount: 1000, Currency: "RUB",
+	}, nil)
+
+	require.NoError(t, svc.VerifyPaymentWebhook(context.Background(), succeededNotification("yoo-1")))
+}
+
+func TestVerifyPaymentWebhook_UnknownPayment(t *testing.T) {
+	svc, _, paymentRepo, provider, _, _ := newTestService()
+
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-forged").Return(nil, models.ErrUnknownPayment)
+
+	err := svc.VerifyPaymentWebhook(context.Background(), succeededNotification("yoo-forged"))
+	requireRejected(t, err, models.WebhookRejectUnknownPayment)
+	provider.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+}
+
+func TestVerifyPaymentWebhook_NotificationAmountMismatch(t *testing.T) {
+	svc, _, paymentRepo, provider, _, _ := newTestService()
+
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(pendingPayment(1, 10, "yoo-1"), nil)
+
+	n := succeededNotification("yoo-1")
+	n.Amount = 1
+	err := svc.VerifyPaymentWebhook(context.Background(), n)
+	requireRejected(t, err, models.WebhookRejectAmountMismatch)
+	provider.AssertNotCalled(t, "GetPayment", mock.Anything, mock.Anything)
+}
+
+func TestVerifyPaymentWebhook_ProviderDisagrees(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote *models.PaymentProviderResponse
+		err    error
+		reason string
+	}{
+		{
+			name:   "status",
+			remote: &models.PaymentProviderResponse{Status: models.PaymentStatusPending, Amount: 1000, Currency: "RUB"},
+			reason: models.WebhookRejectStatusMismatch,
+		},
+		{
+			name:   "amount",
+			remote: &models.PaymentProviderResponse{Status: models.PaymentStatusSucceeded, Amount: 500, Currency: "RUB"},
+			reason: models.WebhookRejectAmountMismatch,
+		},
+		{
+			name:   "not found",
+			err:    models.ErrPaymentNotFound,
+			reason: models.WebhookRejectUnknownPayment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, paymentRepo, provider, _, _ := newTestService()
+
+			paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(pendingPayment(1, 10, "yoo-1"), nil)
+			provider.On("GetPayment", mock.Anything, "yoo-1").Return(tt.remote, tt.err)
+
+			err := svc.VerifyPaymentWebhook(context.Background(), succeededNotification("yoo-1"))
+			requireRejected(t, err, tt.reason)
+		})
+	}
+}
+
+func TestVerifyPaymentWebhook_ProviderUnavailableIsNotRejection(t *testing.T) {
+	svc, _, paymentRepo, provider, _, _ := newTestService()
+
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(pendingPayment(1, 10, "yoo-1"), nil)
+	provider.On("GetPayment", mock.Anything, "yoo-1").Return(nil, errors.New("connection refused"))
+
+	err := svc.VerifyPaymentWebhook(context.Background(), succeededNotification("yoo-1"))
+	require.Error(t, err)
+	var rejected *models.WebhookRejectedError
+	assert.False(t, errors.As(err, &rejected))
+}
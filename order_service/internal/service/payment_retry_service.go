@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"order_service/internal/models"
+)
+
+// RetryPayment создаёт новую попытку оплаты заказа в PAYMENT_FAILED: заказ снова
+// переходит в PENDING_PAYMENT (остатки резервируются заново), у провайдера создаётся
+// новый платёж, а payment_url заказа указывает на него. Прежние платежи остаются
+// в истории. Если провайдер платёж не создал, заказ возвращается в PAYMENT_FAILED.
+func (s *OrderServiceImpl) RetryPayment(ctx context.Context, orderID int) (*models.OrderWithItems, error) {
+	const op = "service.OrderService.RetryPayment"
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get order: %w", op, err)
+	}
+	if order.Status != models.OrderStatusPaymentFailed {
+		return nil, fmt.Errorf("%s: order %d is %s: %w", op, orderID, order.Status, models.ErrPaymentNotRetryable)
+	}
+
+	receipt, err := models.BuildReceipt(order, s.receipts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	previous, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get last payment: %w", op, err)
+	}
+
+	change := models.StatusChange{EventType: models.EventOrderPaymentRetried, Source: models.StatusSourceUser}
+	if err := s.updateOrderStatus(ctx, orderID, models.OrderStatusPendingPayment, change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	description := fmt.Sprintf("Order #%d", orderID)
+	providerResp, err := s.provider.CreatePayment(ctx, order.TotalAmount, "RUB", description,
+		retryIdempotenceKey(orderID, previous.ID), receipt)
+	if err != nil {
+		if revertErr := s.updateOrderStatus(ctx, orderID, models.OrderStatusPaymentFailed,
+			models.StatusChange{
+				EventType: models.EventOrderPaymentUpdated,
+				Source:    models.StatusSourceSystem,
+				Reason:    models.ReasonPaymentIssue,
+			}); revertErr != nil {
+			s.log.Error("failed to revert order after payment retry failure",
+				slog.String("op", op),
+				slog.Int("order_id", orderID),
+				slog.String("error", revertErr.Error()),
+			)
+		}
+		return nil, fmt.Errorf("%s: create payment: %w", op, err)
+	}
+
+	payment := &models.Payment{
+		OrderID:           orderID,
+		YooKassaPaymentID: providerResp.ID,
+		Amount:            order.TotalAmount,
+		Currency:          "RUB",
+		Status:            models.PaymentStatusPending,
+		ConfirmationURL:   providerResp.ConfirmationURL,
+		Receipt:           receipt,
+	}
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		// Без записи вебхук по платежу будет отклонён; заказ отменится по таймауту.
+		return nil, fmt.Errorf("%s: save payment: %w", op, err)
+	}
+
+	if err := s.repo.UpdatePaymentURL(ctx, orderID, providerResp.ConfirmationURL); err != nil {
+		s.log.Error("failed to update payment url",
+			slog.String("op", op),
+			slog.Int("order_id", orderID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	s.log.Info("payment retried",
+		slog.String("op", op),
+		slog.Int("order_id", orderID),
+		slog.String("payment_id", providerResp.ID),
+	)
+
+	order.Status = models.OrderStatusPendingPayment
+	order.PaymentURL = providerResp.ConfirmationURL
+	return order, nil
+}
+
+// retryIdempotenceKey — ключ провайдера для попытки, следующей за платежом previousPaymentID:
+// повтор запроса после сбоя не создаст у провайдера второй платёж.
+func retryIdempotenceKey(orderID, previousPaymentID int) string {
+	name := fmt.Sprintf("order:%d:retry-after:%d", orderID, previousPaymentID)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+func failedOrder(orderID int) *models.OrderWithItems {
+	return &models.OrderWithItems{
+		Order: models.Order{ID: orderID, UserID: 42, Status: models.OrderStatusPaymentFailed, TotalAmount: 1000},
+		Items: []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 1000}},
+	}
+}
+
+func canceledPayment(orderID int) *models.Payment {
+	return &models.Payment{
+		ID: 7, OrderID: orderID, YooKassaPaymentID: "yoo-old", Amount: 1000,
+		Currency: "RUB", Status: models.PaymentStatusCanceled,
+	}
+}
+
+func TestRetryPayment_CreatesNewAttempt(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	order := failedOrder(1)
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(canceledPayment(1), nil)
+	inventory.On("ReserveStock", mock.Anything, 1, order.Items).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPendingPayment, models.OrderStatusPaymentFailed,
+		models.StatusChange{EventType: models.EventOrderPaymentRetried, Source: models.StatusSourceUser}).Return(nil)
+	provider.On("CreatePayment", mock.Anything, 1000, "RUB", "Order #1", mock.AnythingOfType("string"), mock.Anything).
+		Return(&models.PaymentProviderResponse{ID: "yoo-new", Status: "pending", ConfirmationURL: "https://pay/new"}, nil)
+	paymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *models.Payment) bool {
+		return p.OrderID == 1 && p.YooKassaPaymentID == "yoo-new" && p.Status == models.PaymentStatusPending
+	})).Return(nil)
+	repo.On("UpdatePaymentURL", mock.Anything, 1, "https://pay/new").Return(nil)
+
+	retried, err := svc.RetryPayment(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPendingPayment, retried.Status)
+	assert.Equal(t, "https://pay/new", retried.PaymentURL)
+	repo.AssertExpectations(t)
+	paymentRepo.AssertExpectations(t)
+}
+
+func TestRetryPayment_OnlyFromPaymentFailed(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, _ := newRefundTestService()
+
+	order := failedOrder(1)
+	order.Status = models.OrderStatusPendingPayment
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+
+	_, err := svc.RetryPayment(context.Background(), 1)
+	require.ErrorIs(t, err, models.ErrPaymentNotRetryable)
+	paymentRepo.AssertNotCalled(t, "GetByOrderID", mock.Anything, mock.Anything)
+	provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetryPayment_ProviderFailureRevertsOrder(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	order := failedOrder(1)
+	pending := failedOrder(1)
+	pending.Status = models.OrderStatusPendingPayment
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil).Twice()
+	repo.On("GetByID", mock.Anything, 1).Return(pending, nil).Once()
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(canceledPayment(1), nil)
+	inventory.On("ReserveStock", mock.Anything, 1, order.Items).Return(nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPendingPayment, models.OrderStatusPaymentFailed,
+		models.StatusChange{EventType: models.EventOrderPaymentRetried, Source: models.StatusSourceUser}).Return(nil)
+	provider.On("CreatePayment", mock.Anything, 1000, "RUB", "Order #1", mock.AnythingOfType("string"), mock.Anything).
+		Return(nil, errors.New("provider unavailable"))
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPaymentFailed, models.OrderStatusPendingPayment,
+		models.StatusChange{
+			EventType: models.EventOrderPaymentUpdated, Source: models.StatusSourceSystem, Reason: models.ReasonPaymentIssue,
+		}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
+
+	_, err := svc.RetryPayment(context.Background(), 1)
+	require.ErrorContains(t, err, "provider unavailable")
+	repo.AssertExpectations(t)
+	inventory.AssertExpectations(t)
+	paymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"order_service/internal/models"
+)
+
+// CreatePromoCode заводит промокод (операция администратора). Код хранится
+// в верхнем регистре, поэтому покупатель может вводить его в любом.
+func (s *OrderServiceImpl) CreatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error) {
+	const op = "service.OrderService.CreatePromoCode"
+
+	promo.Code = models.NormalizePromoCode(promo.Code)
+	normalizeBrands(promo)
+	if err := promo.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.promoRepo.Create(ctx, promo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("promo code created",
+		slog.String("op", op),
+		slog.Int("promo_code_id", promo.ID),
+		slog.String("code", promo.Code),
+	)
+	return promo, nil
+}
+
+// UpdatePromoCode меняет параметры промокода promo.ID; сам код не меняется.
+// Выключенный промокод (Active = false) не применяется к новым заказам.
+func (s *OrderServiceImpl) UpdatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error) {
+	const op = "service.OrderService.UpdatePromoCode"
+
+	existing, err := s.promoRepo.GetByID(ctx, promo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	promo.Code = existing.Code
+	normalizeBrands(promo)
+	if err := promo.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.promoRepo.Update(ctx, promo); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("promo code updated",
+		slog.String("op", op),
+		slog.Int("promo_code_id", promo.ID),
+		slog.Bool("active", promo.Active),
+	)
+	return promo, nil
+}
+
+// GetPromoCode возвращает промокод по id.
+func (s *OrderServiceImpl) GetPromoCode(ctx context.Context, id int) (*models.PromoCode, error) {
+	const op = "service.OrderService.GetPromoCode"
+
+	promo, err := s.promoRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return promo, nil
+}
+
+// ListPromoCodes возвращает промокоды от новых к старым; activeOnly — только включённые.
+func (s *OrderServiceImpl) ListPromoCodes(ctx context.Context, activeOnly bool) ([]*models.PromoCode, error) {
+	const op = "service.OrderService.ListPromoCodes"
+
+	promos, err := s.promoRepo.List(ctx, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return promos, nil
+}
+
+// applyPromo проверяет промокод для позиций с ценами и записывает в order
+// промокод, скидку по позициям и её сумму. Лимит использований проверяет
+// OrderRepository.Create в транзакции создания заказа.
+func (s *OrderServiceImpl) applyPromo(ctx context.Context, order *models.Order, items []models.OrderItem, code string) error {
+	promo, err := s.promoRepo.GetByCode(ctx, models.NormalizePromoCode(code))
+	if err != nil {
+		return err
+	}
+	if !promo.Active {
+		return fmt.Errorf("%w: %s", models.ErrPromoCodeNotFound, promo.Code)
+	}
+	if !promo.ActiveAt(time.Now()) {
+		return fmt.Errorf("%w: %s is not valid at this time", models.ErrPromoNotApplicable, promo.Code)
+	}
+
+	subtotal := 0
+	for _, item := range items {
+		subtotal += item.PriceAtPurchase * item.Quantity
+	}
+	if subtotal < promo.MinOrderAmount {
+		return fmt.Errorf("%w: %s requires order amount of at least %d", models.ErrPromoNotApplicable, promo.Code, promo.MinOrderAmount)
+	}
+
+	discounts, total := promoDiscounts(promo, items)
+	if total == 0 {
+		return fmt.Errorf("%w: %s does not cover any item in the order", models.ErrPromoNotApplicable, promo.Code)
+	}
+
+	order.PromoCodeID = promo.ID
+	order.PromoCode = promo.Code
+	order.DiscountAmount = total
+	order.Discounts = discounts
+	return nil
+}
+
+// promoDiscounts считает скидку промокода и раскладывает её по подходящим позициям
+// пропорционально их стоимости; копейки от округления достаются первым позициям.
+func promoDiscounts(promo *models.PromoCode, items []models.OrderItem) ([]models.OrderDiscount, int) {
+	eligible := 0
+	for _, item := range items {
+		if promo.Covers(item) {
+			eligible += item.PriceAtPurchase * item.Quantity
+		}
+	}
+	if eligible == 0 {
+		return nil, 0
+	}
+
+	total := promo.Value
+	if promo.Kind == models.PromoKindPercent {
+		total = eligible * promo.Value / 100
+	}
+	total = min(total, eligible)
+	if total == 0 {
+		return nil, 0
+	}
+
+	var lines []models.OrderDiscount
+	var lineTotals []int
+	distributed := 0
+	for _, item := range items {
+		if !promo.Covers(item) {
+			continue
+		}
+		lineTotal := item.PriceAtPurchase * item.Quantity
+		amount := total * lineTotal / eligible
+		lines = append(lines, models.OrderDiscount{
+			SneakerID: item.SneakerID,
+			VariantID: item.VariantID,
+			Amount:    amount,
+		})
+		lineTotals = append(lineTotals, lineTotal)
+		distributed += amount
+	}
+
+	// Остаток от округления вниз меньше числа позиций: раздаём по копейке.
+	for i := 0; distributed < total; i = (i + 1) % len(lines) {
+		if lines[i].Amount < lineTotals[i] {
+			lines[i].Amount++
+			distributed++
+		}
+	}
+
+	nonZero := lines[:0]
+	for _, line := range lines {
+		if line.Amount > 0 {
+			nonZero = append(nonZero, line)
+		}
+	}
+	return nonZero, total
+}
+
+// normalizeBrands убирает пробелы по краям названий брендов.
+func normalizeBrands(promo *models.PromoCode) {
+	for i, brand := range promo.Brands {
+		promo.Brands[i] = strings.TrimSpace(brand)
+	}
+}
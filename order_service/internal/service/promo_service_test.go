@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/service"
+	"order_service/internal/service/mocks"
+)
+
+type promoTestDeps struct {
+	repo      *mocks.MockOrderRepository
+	promoRepo *mocks.MockPromoRepository
+	provider  *mocks.MockPaymentProvider
+	catalog   *mocks.MockCatalogClient
+}
+
+func newPromoTestService() (*service.OrderServiceImpl, promoTestDeps) {
+	d := promoTestDeps{
+		repo:      new(mocks.MockOrderRepository),
+		promoRepo: new(mocks.MockPromoRepository),
+		provider:  new(mocks.MockPaymentProvider),
+		catalog:   new(mocks.MockCatalogClient),
+	}
+	inventory := new(mocks.MockInventoryClient)
+	inventory.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	paymentRepo := new(mocks.MockPaymentRepository)
+	paymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	d.repo.On("UpdatePaymentURL", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	svc := service.NewOrderService(d.repo, paymentRepo, new(mocks.MockRefundRepository), d.promoRepo,
+		d.provider, inventory, d.catalog, new(mocks.MockAddressBook), new(mocks.MockCarrierTracker),
+		testDeliveryCosts, models.ReceiptSettings{}, 24*time.Hour, newTestLogger())
+	return svc, d
+}
+
+var promoTestItems = []models.OrderItem{
+	{SneakerID: 1, Quantity: 2, PriceAtPurchase: 10000, Brand: "Nike"},
+	{SneakerID: 2, Quantity: 1, PriceAtPurchase: 5000, Brand: "Adidas"},
+}
+
+// createWithPromo оформляет заказ promoTestItems с промокодом и возвращает заказ,
+// переданный в репозиторий.
+func createWithPromo(t *testing.T, promo *models.PromoCode) (*models.Order, error) {
+	t.Helper()
+	svc, d := newPromoTestService()
+
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(promoTestItems, nil)
+	d.promoRepo.On("GetByCode", mock.Anything, "SALE").Return(promo, nil)
+
+	var saved *models.Order
+	d.repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Order"), promoTestItems).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*models.Order) }).
+		Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil).Maybe()
+	d.provider.On("CreatePayment", mock.Anything, mock.Anything, "RUB", "Order #1", mock.Anything, mock.Anything).
+		Return(&models.PaymentProviderResponse{ID: "yoo-1", ConfirmationURL: "https://pay"}, nil).Maybe()
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", promoTestItems, models.DeliveryRequest{}, " sale ", "")
+	return saved, err
+}
+
+func TestCreateOrder_PromoPercent(t *testing.T) {
+	saved, err := createWithPromo(t, &models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, saved.PromoCodeID)
+	assert.Equal(t, "SALE", saved.PromoCode)
+	assert.Equal(t, 2500, saved.DiscountAmount)
+	assert.Equal(t, 22500, saved.TotalAmount)
+	assert.Equal(t, []models.OrderDiscount{
+		{SneakerID: 1, Amount: 2000},
+		{SneakerID: 2, Amount: 500},
+	}, saved.Discounts)
+}
+
+func TestCreateOrder_PromoChargesDiscountedAmount(t *testing.T) {
+	svc, d := newPromoTestService()
+
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(promoTestItems, nil)
+	d.promoRepo.On("GetByCode", mock.Anything, "SALE").Return(&models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindFixed, Value: 3000, Active: true,
+	}, nil)
+	d.repo.On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+	d.provider.On("CreatePayment", mock.Anything, 22000, "RUB", "Order #1", mock.Anything, mock.Anything).
+		Return(&models.PaymentProviderResponse{ID: "yoo-1", ConfirmationURL: "https://pay"}, nil)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", promoTestItems, models.DeliveryRequest{}, "sale", "")
+	require.NoError(t, err)
+	d.provider.AssertExpectations(t)
+}
+
+func TestCreateOrder_PromoScopedToBrand(t *testing.T) {
+	saved, err := createWithPromo(t, &models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindFixed, Value: 100000, Active: true,
+		Brands: []string{"adidas"},
+	})
+
+	require.NoError(t, err)
+	// Фиксированная скидка не больше суммы подходящих позиций.
+	assert.Equal(t, 5000, saved.DiscountAmount)
+	assert.Equal(t, []models.OrderDiscount{{SneakerID: 2, Amount: 5000}}, saved.Discounts)
+}
+
+func TestCreateOrder_PromoRemainderDistributed(t *testing.T) {
+	saved, err := createWithPromo(t, &models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindFixed, Value: 1001, Active: true,
+		ProductIDs: []int{1, 2},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1001, saved.DiscountAmount)
+	assert.Equal(t, []models.OrderDiscount{
+		{SneakerID: 1, Amount: 801},
+		{SneakerID: 2, Amount: 200},
+	}, saved.Discounts)
+}
+
+func TestCreateOrder_PromoRejected(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		promo   *models.PromoCode
+		wantErr error
+	}{
+		{
+			name:    "disabled",
+			promo:   &models.PromoCode{Code: "SALE", Kind: models.PromoKindPercent, Value: 10},
+			wantErr: models.ErrPromoCodeNotFound,
+		},
+		{
+			name: "not started",
+			promo: &models.PromoCode{Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true,
+				ValidFrom: now.Add(time.Hour)},
+			wantErr: models.ErrPromoNotApplicable,
+		},
+		{
+			name: "expired",
+			promo: &models.PromoCode{Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true,
+				ValidTo: now.Add(-time.Hour)},
+			wantErr: models.ErrPromoNotApplicable,
+		},
+		{
+			name: "below minimum amount",
+			promo: &models.PromoCode{Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true,
+				MinOrderAmount: 30000},
+			wantErr: models.ErrPromoNotApplicable,
+		},
+		{
+			name: "no covered items",
+			promo: &models.PromoCode{Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true,
+				ProductIDs: []int{99}},
+			wantErr: models.ErrPromoNotApplicable,
+		},
+		{
+			name:    "total below minimum payment",
+			promo:   &models.PromoCode{Code: "SALE", Kind: models.PromoKindFixed, Value: 24950, Active: true},
+			wantErr: models.ErrPromoNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved, err := createWithPromo(t, tt.promo)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, saved)
+		})
+	}
+}
+
+func TestCreateOrder_PromoUsageExceeded(t *testing.T) {
+	svc, d := newPromoTestService()
+
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(promoTestItems, nil)
+	d.promoRepo.On("GetByCode", mock.Anything, "SALE").Return(&models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindPercent, Value: 10, Active: true, MaxUsesPerUser: 1,
+	}, nil)
+	d.repo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, models.ErrPromoUsageExceeded)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", promoTestItems, models.DeliveryRequest{}, "SALE", "")
+	require.ErrorIs(t, err, models.ErrPromoUsageExceeded)
+	d.provider.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// Управление промокодами
+// ---------------------------------------------------------------------------
+
+func TestCreatePromoCode_NormalizesCode(t *testing.T) {
+	svc, d := newPromoTestService()
+
+	d.promoRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool {
+		return p.Code == "SPRING25" && p.Brands[0] == "Nike"
+	})).Return(nil)
+
+	promo, err := svc.CreatePromoCode(context.Background(), &models.PromoCode{
+		Code: " spring25 ", Kind: models.PromoKindPercent, Value: 25, Brands: []string{" Nike "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SPRING25", promo.Code)
+	d.promoRepo.AssertExpectations(t)
+}
+
+func TestCreatePromoCode_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		promo models.PromoCode
+	}{
+		{name: "empty code", promo: models.PromoCode{Kind: models.PromoKindPercent, Value: 10}},
+		{name: "percent over 99", promo: models.PromoCode{Code: "X", Kind: models.PromoKindPercent, Value: 100}},
+		{name: "unknown kind", promo: models.PromoCode{Code: "X", Kind: "bogo", Value: 1}},
+		{name: "empty window", promo: models.PromoCode{Code: "X", Kind: models.PromoKindFixed, Value: 100,
+			ValidFrom: time.Unix(200, 0), ValidTo: time.Unix(100, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, d := newPromoTestService()
+
+			_, err := svc.CreatePromoCode(context.Background(), &tt.promo)
+			require.ErrorIs(t, err, models.ErrInvalidPromoCode)
+			d.promoRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdatePromoCode_KeepsCode(t *testing.T) {
+	svc, d := newPromoTestService()
+
+	d.promoRepo.On("GetByID", mock.Anything, 7).Return(&models.PromoCode{ID: 7, Code: "SALE"}, nil)
+	d.promoRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool {
+		return p.Code == "SALE" && !p.Active
+	})).Return(nil)
+
+	_, err := svc.UpdatePromoCode(context.Background(), &models.PromoCode{
+		ID: 7, Code: "OTHER", Kind: models.PromoKindFixed, Value: 500,
+	})
+	require.NoError(t, err)
+	d.promoRepo.AssertExpectations(t)
+}
@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/service"
+	"order_service/internal/service/mocks"
+)
+
+var testReceiptSettings = models.ReceiptSettings{
+	Enabled:       true,
+	VATCode:       1,
+	PaymentMode:   models.PaymentModeFullPrepayment,
+	DeliveryTitle: "Доставка",
+}
+
+type receiptTestDeps struct {
+	repo        *mocks.MockOrderRepository
+	paymentRepo *mocks.MockPaymentRepository
+	refundRepo  *mocks.MockRefundRepository
+	promoRepo   *mocks.MockPromoRepository
+	provider    *mocks.MockPaymentProvider
+	catalog     *mocks.MockCatalogClient
+}
+
+func newReceiptTestService() (*service.OrderServiceImpl, receiptTestDeps) {
+	d := receiptTestDeps{
+		repo:        new(mocks.MockOrderRepository),
+		paymentRepo: new(mocks.MockPaymentRepository),
+		refundRepo:  new(mocks.MockRefundRepository),
+		promoRepo:   new(mocks.MockPromoRepository),
+		provider:    new(mocks.MockPaymentProvider),
+		catalog:     new(mocks.MockCatalogClient),
+	}
+	inventory := new(mocks.MockInventoryClient)
+	inventory.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	d.repo.On("UpdatePaymentURL", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	svc := service.NewOrderService(d.repo, d.paymentRepo, d.refundRepo, d.promoRepo, d.provider, inventory,
+		d.catalog, new(mocks.MockAddressBook), new(mocks.MockCarrierTracker),
+		testDeliveryCosts, testReceiptSettings, 24*time.Hour, newTestLogger())
+	return svc, d
+}
+
+func TestCreateOrder_SendsReceipt(t *testing.T) {
+	svc, d := newReceiptTestService()
+
+	items := []models.OrderItem{
+		{SneakerID: 1, VariantID: 3, Quantity: 3, PriceAtPurchase: 10000, Title: "Nike Air Max, размер 42"},
+		{SneakerID: 2, Quantity: 1, PriceAtPurchase: 5000},
+	}
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+	d.promoRepo.On("GetByCode", mock.Anything, "SALE").Return(&models.PromoCode{
+		ID: 7, Code: "SALE", Kind: models.PromoKindFixed, Value: 1000, Active: true, ProductIDs: []int{1},
+	}, nil)
+	d.repo.On("Create", mock.Anything, mock.MatchedBy(func(o *models.Order) bool {
+		return o.CustomerEmail == "user@example.com"
+	}), items).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+
+	// 3 × 10000 − 1000 = 29000 не делится на 3: последняя пара уходит отдельной строкой.
+	want := &models.Receipt{
+		Email: "user@example.com",
+		Items: []models.ReceiptItem{
+			{Description: "Nike Air Max, размер 42", Quantity: 2, Price: 9666, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+			{Description: "Nike Air Max, размер 42", Quantity: 1, Price: 9668, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+			{Description: "Товар #2", Quantity: 1, Price: 5000, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+		},
+	}
+	d.provider.On("CreatePayment", mock.Anything, 34000, "RUB", "Order #1", mock.Anything, want).
+		Return(&models.PaymentProviderResponse{ID: "yoo-1", ConfirmationURL: "https://pay"}, nil)
+	d.paymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *models.Payment) bool {
+		return assert.ObjectsAreEqual(want, p.Receipt)
+	})).Return(nil)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "user@example.com", items, models.DeliveryRequest{}, "SALE", "")
+	require.NoError(t, err)
+	assert.Equal(t, 34000, want.Total())
+	d.provider.AssertExpectations(t)
+	d.paymentRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_ReceiptFallsBackToPhone(t *testing.T) {
+	svc, d := newReceiptTestService()
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 10000, Title: "Nike"}}
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+	d.repo.On("Create", mock.Anything, mock.Anything, items).Return(&models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+	d.provider.On("CreatePayment", mock.Anything, 10500, "RUB", "Order #1", mock.Anything,
+		mock.MatchedBy(func(r *models.Receipt) bool {
+			return r.Email == "" && r.Phone == "79991234567" && len(r.Items) == 2 &&
+				r.Items[1].Description == "Доставка" && r.Items[1].PaymentSubject == models.PaymentSubjectService &&
+				r.Items[1].Price == 500
+		})).Return(&models.PaymentProviderResponse{ID: "yoo-1"}, nil)
+	d.paymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{
+		Method: models.DeliveryMethodCourier,
+		Address: &models.ShippingAddress{
+			RecipientName: "Иван", Phone: "+7 (999) 123-45-67", City: "Москва", AddressLine: "Тверская, 1",
+		},
+	}, "", "")
+	require.NoError(t, err)
+	d.provider.AssertExpectations(t)
+}
+
+func TestCreateOrder_ReceiptContactRequired(t *testing.T) {
+	svc, d := newReceiptTestService()
+
+	items := []models.OrderItem{{SneakerID: 1, Quantity: 1, PriceAtPurchase: 10000}}
+	d.catalog.On("PriceItems", mock.Anything, mock.Anything).Return(items, nil)
+
+	_, err := svc.CreateOrder(context.Background(), 42, "", items, models.DeliveryRequest{}, "", "")
+	require.ErrorIs(t, err, models.ErrReceiptContactRequired)
+	d.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_PartialRefundReceipt(t *testing.T) {
+	svc, d := newReceiptTestService()
+
+	payment := paidPayment(1)
+	payment.Receipt = &models.Receipt{
+		Email: "user@example.com",
+		Items: []models.ReceiptItem{
+			{Description: "Nike", Quantity: 1, Price: 700, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+			{Description: "Доставка", Quantity: 1, Price: 300, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectService, PaymentMode: models.PaymentModeFullPrepayment},
+		},
+	}
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	d.repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	d.paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(payment, nil)
+	d.refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	d.refundRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Refund).ID = 5
+	}).Return(nil)
+
+	// 501 из 1000 делится пропорционально: 350 + 150 и копейка округления первой позиции.
+	want := &models.Receipt{
+		Email: "user@example.com",
+		Items: []models.ReceiptItem{
+			{Description: "Nike", Quantity: 1, Price: 351, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectCommodity, PaymentMode: models.PaymentModeFullPrepayment},
+			{Description: "Доставка", Quantity: 1, Price: 150, VATCode: 1,
+				PaymentSubject: models.PaymentSubjectService, PaymentMode: models.PaymentModeFullPrepayment},
+		},
+	}
+	d.provider.On("RefundPayment", mock.Anything, "yoo-paid", 501, "RUB", mock.Anything, mock.Anything, want).
+		Return(&models.RefundProviderResponse{ID: "rf-1", Status: models.RefundStatusPending}, nil)
+	d.refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-1", want).Return(nil)
+
+	refund, err := svc.RefundOrder(context.Background(), 1, 501, models.ReasonOther, "", 7)
+	require.NoError(t, err)
+	assert.Equal(t, want, refund.Receipt)
+	d.provider.AssertExpectations(t)
+	d.refundRepo.AssertExpectations(t)
+}
+
+func TestRefundOrder_WithoutReceipt(t *testing.T) {
+	svc, d := newReceiptTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	d.repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	d.paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	d.refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	d.refundRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Refund).ID = 5
+	}).Return(nil)
+	// Платёж создан до включения чеков — возврат тоже идёт без чека.
+	d.provider.On("RefundPayment", mock.Anything, "yoo-paid", 300, "RUB", mock.Anything, mock.Anything, (*models.Receipt)(nil)).
+		Return(&models.RefundProviderResponse{ID: "rf-1", Status: models.RefundStatusPending}, nil)
+	d.refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-1", (*models.Receipt)(nil)).Return(nil)
+
+	_, err := svc.RefundOrder(context.Background(), 1, 300, models.ReasonOther, "", 7)
+	require.NoError(t, err)
+	d.provider.AssertExpectations(t)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"order_service/internal/lib/pagetoken"
+	"order_service/internal/models"
+)
+
+// ReconcilePayments сверяет с провайдером до limit платежей, ожидающих оплаты
+// с момента раньше pendingBefore. Если провайдер уже завершил платёж (вебхук
+// потерялся), его статус применяется через ApplyPaymentStatus. Расхождения, которые
+// нельзя устранить автоматически (сумма, неизвестный платёж), только попадают в отчёт.
+// Все расхождения сохраняются в базе и доступны через ListReconciliationMismatches.
+func (s *OrderServiceImpl) ReconcilePayments(ctx context.Context, pendingBefore time.Time, limit int) (*models.ReconciliationReport, error) {
+	const op = "service.OrderService.ReconcilePayments"
+
+	report := &models.ReconciliationReport{StartedAt: time.Now()}
+
+	payments, err := s.paymentRepo.ClaimForReconciliation(ctx, pendingBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, payment := range payments {
+		report.Checked++
+		if mismatch := s.reconcilePayment(ctx, payment); mismatch != nil {
+			report.Mismatches = append(report.Mismatches, *mismatch)
+		}
+	}
+
+	// Статусы уже применены, поэтому ошибка сохранения не отменяет проход:
+	// расхождения останутся в логе воркера.
+	if len(report.Mismatches) > 0 {
+		if err := s.paymentRepo.SaveMismatches(ctx, report.Mismatches); err != nil {
+			s.log.Error("failed to save reconciliation mismatches",
+				slog.String("op", op),
+				slog.Int("mismatches", len(report.Mismatches)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// ListReconciliationMismatches — админский список расхождений сверки, от новых к старым.
+func (s *OrderServiceImpl) ListReconciliationMismatches(ctx context.Context, filter models.MismatchFilter, pageSize int, pageToken string) (*models.MismatchPage, error) {
+	const op = "service.OrderService.ListReconciliationMismatches"
+
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var cursor orderCursor
+	if err := pagetoken.Decode(pageToken, &cursor); err != nil || cursor.ID < 0 {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrInvalidPageToken)
+	}
+
+	// На одну запись больше, чтобы понять, есть ли следующая страница.
+	mismatches, err := s.paymentRepo.ListMismatches(ctx, filter, cursor.ID, pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page := &models.MismatchPage{Mismatches: mismatches}
+	if len(mismatches) > pageSize {
+		page.Mismatches = mismatches[:pageSize]
+		next, err := pagetoken.Encode(orderCursor{ID: page.Mismatches[pageSize-1].ID})
+		if err != nil {
+			return nil, fmt.Errorf("%s: encode page token: %w", op, err)
+		}
+		page.NextPageToken = next
+	}
+	return page, nil
+}
+
+// reconcilePayment сверяет один платёж; nil — состояние совпадает.
+func (s *OrderServiceImpl) reconcilePayment(ctx context.Context, payment *models.Payment) *models.ReconciliationMismatch {
+	mismatch := &models.ReconciliationMismatch{
+		PaymentID:   payment.YooKassaPaymentID,
+		OrderID:     payment.OrderID,
+		LocalStatus: payment.Status,
+		LocalAmount: payment.Amount,
+	}
+
+	remote, err := s.provider.GetPayment(ctx, payment.YooKassaPaymentID)
+	if err != nil {
+		mismatch.Kind = models.MismatchProviderError
+		if errors.Is(err, models.ErrPaymentNotFound) {
+			mismatch.Kind = models.MismatchMissing
+		}
+		mismatch.Error = err.Error()
+		return mismatch
+	}
+	mismatch.ProviderStatus = remote.Status
+	mismatch.ProviderAmount = remote.Amount
+
+	// Статус с расходящейся суммой не применяем: такой платёж разбирают вручную.
+	if remote.Amount != payment.Amount || remote.Currency != payment.Currency {
+		mismatch.Kind = models.MismatchAmount
+		return mismatch
+	}
+
+	mismatch.Kind = models.MismatchStatus
+	switch remote.Status {
+	case models.PaymentStatusPending:
+		return nil
+	case models.PaymentStatusSucceeded, models.PaymentStatusCanceled:
+		if err := s.ApplyPaymentStatus(ctx, payment.YooKassaPaymentID, remote.Status, models.StatusSourceScheduler); err != nil {
+			mismatch.Error = err.Error()
+			return mismatch
+		}
+		mismatch.Resolved = true
+		s.log.Info("payment reconciled",
+			slog.Int("order_id", payment.OrderID),
+			slog.String("payment_id", payment.YooKassaPaymentID),
+			slog.String("status", remote.Status),
+		)
+	}
+	// Прочие статусы (например, waiting_for_capture) сервис не ожидает — только в отчёт.
+	return mismatch
+}
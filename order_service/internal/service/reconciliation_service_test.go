@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+)
+
+func pendingPayment(id, orderID int, providerID string) *models.Payment {
+	return &models.Payment{
+		ID: id, OrderID: orderID, YooKassaPaymentID: providerID,
+		Amount: 1000, Currency: "RUB", Status: models.PaymentStatusPending,
+	}
+}
+
+func TestReconcilePayments_AppliesLostWebhook(t *testing.T) {
+	svc, repo, paymentRepo, _, provider, inventory := newRefundTestService()
+
+	cutoff := time.Now().Add(-10 * time.Minute)
+	payment := pendingPayment(7, 1, "yoo-1")
+	paymentRepo.On("ClaimForReconciliation", mock.Anything, cutoff, 50).Return([]*models.Payment{payment}, nil)
+	provider.On("GetPayment", mock.Anything, "yoo-1").Return(&models.PaymentProviderResponse{
+		ID: "yoo-1", Status: models.PaymentStatusSucceeded, Amount: 1000, Currency: "RUB",
+	}, nil)
+
+	// Дальше — обычная обработка вебхука.
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-1").Return(payment, nil)
+	paid := *payment
+	paid.Status = models.PaymentStatusSucceeded
+	paymentRepo.On("UpdateStatusAndGet", mock.Anything, "yoo-1", models.PaymentStatusSucceeded).Return(&paid, nil)
+	repo.On("GetByID", mock.Anything, 1).Return(&models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusPaid, models.OrderStatusPendingPayment,
+		models.StatusChange{EventType: models.EventOrderPaymentUpdated, Source: models.StatusSourceScheduler}).Return(nil)
+	inventory.On("CommitStock", mock.Anything, 1).Return(nil)
+	paymentRepo.On("SaveMismatches", mock.Anything, mock.Anything).Return(nil)
+
+	report, err := svc.ReconcilePayments(context.Background(), cutoff, 50)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, models.ReconciliationMismatch{
+		Kind: models.MismatchStatus, PaymentID: "yoo-1", OrderID: 1,
+		LocalStatus: models.PaymentStatusPending, ProviderStatus: models.PaymentStatusSucceeded,
+		LocalAmount: 1000, ProviderAmount: 1000, Resolved: true,
+	}, report.Mismatches[0])
+	repo.AssertExpectations(t)
+}
+
+func TestReconcilePayments_StillPendingIsNotAMismatch(t *testing.T) {
+	svc, _, paymentRepo, _, provider, _ := newRefundTestService()
+
+	paymentRepo.On("ClaimForReconciliation", mock.Anything, mock.Anything, 50).
+		Return([]*models.Payment{pendingPayment(7, 1, "yoo-1")}, nil)
+	provider.On("GetPayment", mock.Anything, "yoo-1").Return(&models.PaymentProviderResponse{
+		ID: "yoo-1", Status: models.PaymentStatusPending, Amount: 1000, Currency: "RUB",
+	}, nil)
+
+	report, err := svc.ReconcilePayments(context.Background(), time.Now(), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Empty(t, report.Mismatches)
+	paymentRepo.AssertNotCalled(t, "UpdateStatusAndGet", mock.Anything, mock.Anything, mock.Anything)
+	paymentRepo.AssertNotCalled(t, "SaveMismatches", mock.Anything, mock.Anything)
+}
+
+func TestReconcilePayments_ReportsUnresolvableMismatches(t *testing.T) {
+	svc, _, paymentRepo, _, provider, _ := newRefundTestService()
+
+	paymentRepo.On("ClaimForReconciliation", mock.Anything, mock.Anything, 50).Return([]*models.Payment{
+		pendingPayment(1, 10, "yoo-amount"),
+		pendingPayment(2, 20, "yoo-missing"),
+		pendingPayment(3, 30, "yoo-down"),
+	}, nil)
+	provider.On("GetPayment", mock.Anything, "yoo-amount").Return(&models.PaymentProviderResponse{
+		ID: "yoo-amount", Status: models.PaymentStatusSucceeded, Amount: 900, Currency: "RUB",
+	}, nil)
+	provider.On("GetPayment", mock.Anything, "yoo-missing").Return(nil, fmt.Errorf("get: %w", models.ErrPaymentNotFound))
+	provider.On("GetPayment", mock.Anything, "yoo-down").Return(nil, errors.New("timeout"))
+	paymentRepo.On("SaveMismatches", mock.Anything, mock.MatchedBy(func(m []models.ReconciliationMismatch) bool {
+		return len(m) == 3
+	})).Return(nil).Once()
+
+	report, err := svc.ReconcilePayments(context.Background(), time.Now(), 50)
+	require.NoError(t, err)
+	require.Len(t, report.Mismatches, 3)
+
+	kinds := make([]string, len(report.Mismatches))
+	for i, m := range report.Mismatches {
+		kinds[i] = m.Kind
+		assert.False(t, m.Resolved)
+	}
+	assert.Equal(t, []string{models.MismatchAmount, models.MismatchMissing, models.MismatchProviderError}, kinds)
+	assert.Equal(t, 900, report.Mismatches[0].ProviderAmount)
+
+	// Платёж с расходящейся суммой не применяется.
+	paymentRepo.AssertNotCalled(t, "UpdateStatusAndGet", mock.Anything, mock.Anything, mock.Anything)
+	paymentRepo.AssertExpectations(t)
+}
+
+func TestReconcilePayments_SaveErrorKeepsReport(t *testing.T) {
+	svc, _, paymentRepo, _, provider, _ := newRefundTestService()
+
+	paymentRepo.On("ClaimForReconciliation", mock.Anything, mock.Anything, 50).
+		Return([]*models.Payment{pendingPayment(1, 10, "yoo-missing")}, nil)
+	provider.On("GetPayment", mock.Anything, "yoo-missing").Return(nil, models.ErrPaymentNotFound)
+	paymentRepo.On("SaveMismatches", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	report, err := svc.ReconcilePayments(context.Background(), time.Now(), 50)
+	require.NoError(t, err)
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, models.MismatchMissing, report.Mismatches[0].Kind)
+}
+
+func TestListReconciliationMismatches_Paginates(t *testing.T) {
+	svc, _, paymentRepo, _, _, _ := newRefundTestService()
+
+	filter := models.MismatchFilter{UnresolvedOnly: true}
+	paymentRepo.On("ListMismatches", mock.Anything, filter, 0, 3).Return([]models.ReconciliationMismatch{
+		{ID: 9}, {ID: 7}, {ID: 4},
+	}, nil)
+	paymentRepo.On("ListMismatches", mock.Anything, filter, 7, 3).Return([]models.ReconciliationMismatch{
+		{ID: 4},
+	}, nil)
+
+	page, err := svc.ListReconciliationMismatches(context.Background(), filter, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Mismatches, 2)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = svc.ListReconciliationMismatches(context.Background(), filter, 2, page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page.Mismatches, 1)
+	assert.Equal(t, 4, page.Mismatches[0].ID)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestListReconciliationMismatches_InvalidInput(t *testing.T) {
+	svc, _, _, _, _, _ := newRefundTestService()
+
+	_, err := svc.ListReconciliationMismatches(context.Background(), models.MismatchFilter{Kind: "bogus"}, 0, "")
+	require.ErrorIs(t, err, models.ErrInvalidMismatchFilter)
+
+	_, err = svc.ListReconciliationMismatches(context.Background(), models.MismatchFilter{}, 0, "not-a-token")
+	require.ErrorIs(t, err, models.ErrInvalidPageToken)
+}
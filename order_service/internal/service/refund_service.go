@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"order_service/internal/models"
+)
+
+// CancelOrder отменяет неоплаченный заказ и снимает резерв остатков.
+// Оплаченный заказ так отменить нельзя (models.ErrOrderNotCancellable):
+// деньги по нему возвращает RefundOrder.
+func (s *OrderServiceImpl) CancelOrder(ctx context.Context, orderID int, reason string) (*models.OrderWithItems, error) {
+	const op = "service.OrderService.CancelOrder"
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get order: %w", op, err)
+	}
+	if !models.IsCancellable(order.Status) {
+		return nil, fmt.Errorf("%s: order %d is %s: %w", op, orderID, order.Status, models.ErrOrderNotCancellable)
+	}
+
+	change := models.StatusChange{EventType: models.EventOrderCancelled, Source: models.StatusSourceUser, Reason: reason}
+	if err := s.updateOrderStatus(ctx, orderID, models.OrderStatusCancelled, change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("order cancelled",
+		slog.String("op", op),
+		slog.Int("order_id", orderID),
+		slog.String("reason", reason),
+	)
+
+	order.Status = models.OrderStatusCancelled
+	return order, nil
+}
+
+// RefundOrder возвращает деньги по оплаченному или отправленному заказу.
+// amount — сумма в копейках, 0 — весь невозвращённый остаток. Когда возвращено всё,
+// заказ переходит в REFUNDED. Если провайдер проводит возврат асинхронно,
+// возврат остаётся pending до вебхука refund.succeeded. actorID — администратор,
+// запросивший возврат; он попадёт в историю статусов.
+func (s *OrderServiceImpl) RefundOrder(ctx context.Context, orderID, amount int, reason, comment string, actorID int) (*models.Refund, error) {
+	const op = "service.OrderService.RefundOrder"
+
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get order: %w", op, err)
+	}
+	if !models.IsRefundable(order.Status) {
+		return nil, fmt.Errorf("%s: order %d is %s: %w", op, orderID, order.Status, models.ErrOrderNotRefundable)
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get payment: %w", op, err)
+	}
+	if payment.Status != models.PaymentStatusSucceeded {
+		return nil, fmt.Errorf("%s: payment of order %d is %s: %w", op, orderID, payment.Status, models.ErrOrderNotRefundable)
+	}
+
+	refund, err := s.refund(ctx, payment, amount, reason, comment, models.StatusSourceAdmin, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return refund, nil
+}
+
+// VerifyRefundWebhook проверяет уведомление о возврате так же, как VerifyPaymentWebhook
+// проверяет платёж: провайдер при повторном запросе должен сообщить тот же статус,
+// а сумма и валюта — совпасть с сохранённым возвратом. Возврат, которому ещё не
+// присвоен id провайдера, — временная ошибка: провайдер повторит уведомление.
+func (s *OrderServiceImpl) VerifyRefundWebhook(ctx context.Context, n models.RefundNotification) error {
+	const op = "service.OrderService.VerifyRefundWebhook"
+
+	refund, err := s.refundRepo.GetByProviderID(ctx, n.RefundID)
+	if err != nil && !errors.Is(err, models.ErrRefundNotFound) {
+		return fmt.Errorf("%s: get refund: %w", op, err)
+	}
+	if refund != nil && (n.Amount != refund.Amount || n.Currency != refund.Currency) {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectAmountMismatch,
+			Detail: fmt.Sprintf("notification %d %s, refund %d %s", n.Amount, n.Currency, refund.Amount, refund.Currency),
+		}
+	}
+
+	remote, err := s.provider.GetRefund(ctx, n.RefundID)
+	if err != nil {
+		if errors.Is(err, models.ErrProviderRefundNotFound) {
+			return &models.WebhookRejectedError{Reason: models.WebhookRejectUnknownRefund, Detail: "unknown to provider"}
+		}
+		return fmt.Errorf("%s: get refund: %w", op, err)
+	}
+	if remote.Status != n.Status {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectStatusMismatch,
+			Detail: fmt.Sprintf("notification %s, provider %s", n.Status, remote.Status),
+		}
+	}
+	if refund == nil {
+		return fmt.Errorf("%s: %s: %w", op, n.RefundID, models.ErrRefundNotFound)
+	}
+	if remote.Amount != refund.Amount || remote.Currency != refund.Currency {
+		return &models.WebhookRejectedError{
+			Reason: models.WebhookRejectAmountMismatch,
+			Detail: fmt.Sprintf("provider %d %s, refund %d %s", remote.Amount, remote.Currency, refund.Amount, refund.Currency),
+		}
+	}
+	return nil
+}
+
+// ProcessRefundWebhook завершает возврат по уведомлению провайдера.
+func (s *OrderServiceImpl) ProcessRefundWebhook(ctx context.Context, providerRefundID, status string) error {
+	const op = "service.OrderService.ProcessRefundWebhook"
+
+	if status != models.RefundStatusSucceeded {
+		s.log.Info("ignoring refund webhook",
+			slog.String("op", op),
+			slog.String("refund_id", providerRefundID),
+			slog.String("status", status),
+		)
+		return nil
+	}
+
+	refund, err := s.refundRepo.MarkSucceeded(ctx, providerRefundID, models.StatusSourceWebhook)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if refund == nil {
+		s.log.Info("refund webhook already processed", slog.String("op", op), slog.String("refund_id", providerRefundID))
+		return nil
+	}
+
+	s.log.Info("refund succeeded",
+		slog.String("op", op),
+		slog.Int("order_id", refund.OrderID),
+		slog.Int("amount", refund.Amount),
+	)
+	return nil
+}
+
+// settleCancelledOrder обрабатывает вебхук платежа по уже отменённому заказу
+// (заказ истёк или пользователь оплатил по старой ссылке): отмена платежа
+// просто подтверждается, успешная оплата возвращается. true — статус заказа
+// менять не нужно.
+func (s *OrderServiceImpl) settleCancelledOrder(ctx context.Context, payment *models.Payment, source string) (bool, error) {
+	order, err := s.repo.GetByID(ctx, payment.OrderID)
+	if err != nil {
+		return false, fmt.Errorf("get order: %w", err)
+	}
+	if order.Status != models.OrderStatusCancelled {
+		return false, nil
+	}
+	if payment.Status != models.PaymentStatusSucceeded {
+		return true, nil
+	}
+
+	s.log.Warn("payment received for cancelled order, refunding",
+		slog.Int("order_id", order.ID),
+		slog.String("payment_id", payment.YooKassaPaymentID),
+	)
+	if _, err := s.refund(ctx, payment, 0, models.ReasonPaymentIssue, "payment received for cancelled order", source, 0); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// refund создаёт возврат и проводит его у провайдера; source и actorID — инициатор
+// возврата для истории статусов заказа (actorID 0 — не администратор). Если у платежа был чек, к возврату прикладывается
+// чек на возвращаемую часть.
+//
+// Если прошлая отправка возврата по платежу оборвалась (таймаут, 5xx), провайдер
+// мог его уже провести: новый возврат не создаётся, а тот же отправляется повторно
+// с прежним ключом идемпотентности.
+func (s *OrderServiceImpl) refund(ctx context.Context, payment *models.Payment, amount int, reason, comment, source string, actorID int) (*models.Refund, error) {
+	refund, err := s.refundRepo.GetUnsent(ctx, payment.ID)
+	switch {
+	case err == nil:
+		s.log.Warn("resending unsent refund",
+			slog.Int("refund_id", refund.ID),
+			slog.Int("order_id", refund.OrderID),
+			slog.Int("amount", refund.Amount),
+		)
+	case errors.Is(err, models.ErrRefundNotFound):
+		refund = &models.Refund{
+			OrderID:   payment.OrderID,
+			PaymentID: payment.ID,
+			Amount:    amount,
+			Currency:  payment.Currency,
+			Reason:    reason,
+			Comment:   comment,
+			ActorID:   actorID,
+		}
+		if err := s.refundRepo.Create(ctx, refund); err != nil {
+			return nil, fmt.Errorf("create refund: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("get unsent refund: %w", err)
+	}
+
+	if payment.Receipt != nil {
+		refund.Receipt = payment.Receipt.Part(refund.Amount)
+	}
+
+	description := fmt.Sprintf("Refund for order #%d", payment.OrderID)
+	resp, err := s.provider.RefundPayment(ctx, payment.YooKassaPaymentID, refund.Amount, refund.Currency, description,
+		refund.IdempotencyKey, refund.Receipt)
+	if err != nil {
+		// Сумму освобождаем, только если провайдер точно не провёл возврат;
+		// иначе возврат остаётся pending и при повторе уйдёт с тем же ключом.
+		if errors.Is(err, models.ErrRefundRejected) {
+			if cancelErr := s.refundRepo.MarkCanceled(ctx, refund.ID); cancelErr != nil {
+				s.log.Error("failed to cancel rejected refund",
+					slog.Int("refund_id", refund.ID),
+					slog.String("error", cancelErr.Error()),
+				)
+			}
+		}
+		return nil, fmt.Errorf("provider refund: %w", err)
+	}
+
+	if err := s.refundRepo.AttachProviderID(ctx, refund.ID, resp.ID, refund.Receipt); err != nil {
+		return nil, fmt.Errorf("attach provider refund id: %w", err)
+	}
+	refund.ProviderRefundID = resp.ID
+
+	switch resp.Status {
+	case models.RefundStatusSucceeded:
+		if _, err := s.refundRepo.MarkSucceeded(ctx, resp.ID, source); err != nil {
+			return nil, fmt.Errorf("mark refund succeeded: %w", err)
+		}
+		refund.Status = models.RefundStatusSucceeded
+	case models.RefundStatusCanceled:
+		if err := s.refundRepo.MarkCanceled(ctx, refund.ID); err != nil {
+			return nil, fmt.Errorf("mark refund canceled: %w", err)
+		}
+		refund.Status = models.RefundStatusCanceled
+	}
+	// pending — возврат завершит вебхук refund.succeeded.
+
+	return refund, nil
+}
@@ -0,0 +1,391 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"order_service/internal/models"
+	"order_service/internal/service"
+	"order_service/internal/service/mocks"
+)
+
+func newRefundTestService() (
+	*service.OrderServiceImpl,
+	*mocks.MockOrderRepository,
+	*mocks.MockPaymentRepository,
+	*mocks.MockRefundRepository,
+	*mocks.MockPaymentProvider,
+	*mocks.MockInventoryClient,
+) {
+	repo := new(mocks.MockOrderRepository)
+	paymentRepo := new(mocks.MockPaymentRepository)
+	refundRepo := new(mocks.MockRefundRepository)
+	provider := new(mocks.MockPaymentProvider)
+	inventory := new(mocks.MockInventoryClient)
+	svc := service.NewOrderService(repo, paymentRepo, refundRepo, new(mocks.MockPromoRepository), provider, inventory,
+		new(mocks.MockCatalogClient), new(mocks.MockAddressBook), new(mocks.MockCarrierTracker), testDeliveryCosts, models.ReceiptSettings{}, 24*time.Hour, newTestLogger())
+	return svc, repo, paymentRepo, refundRepo, provider, inventory
+}
+
+func paidPayment(orderID int) *models.Payment {
+	return &models.Payment{
+		ID: 7, OrderID: orderID, YooKassaPaymentID: "yoo-paid", Amount: 1000,
+		Currency: "RUB", Status: models.PaymentStatusSucceeded,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CancelOrder
+// ---------------------------------------------------------------------------
+
+func TestCancelOrder_Success(t *testing.T) {
+	svc, repo, _, _, _, inventory := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	repo.On("UpdateStatus", mock.Anything, 1, models.OrderStatusCancelled, models.OrderStatusPendingPayment,
+		models.StatusChange{
+			EventType: models.EventOrderCancelled, Source: models.StatusSourceUser, Reason: models.ReasonCustomerRequest,
+		}).Return(nil)
+	inventory.On("ReleaseStock", mock.Anything, 1).Return(nil)
+
+	result, err := svc.CancelOrder(context.Background(), 1, models.ReasonCustomerRequest)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, result.Status)
+	repo.AssertExpectations(t)
+	inventory.AssertExpectations(t)
+}
+
+func TestCancelOrder_PaidOrder(t *testing.T) {
+	svc, repo, _, _, _, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+
+	_, err := svc.CancelOrder(context.Background(), 1, models.ReasonCustomerRequest)
+	require.ErrorIs(t, err, models.ErrOrderNotCancellable)
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---------------------------------------------------------------------------
+// RefundOrder
+// ---------------------------------------------------------------------------
+
+func TestRefundOrder_FullRefundSucceeded(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+
+	// Нулевая сумма — весь остаток; его подставляет репозиторий.
+	refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.Refund) bool {
+		return r.OrderID == 1 && r.PaymentID == 7 && r.Amount == 0 && r.Reason == models.ReasonDamagedGoods && r.ActorID == 7
+	})).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*models.Refund)
+		r.ID = 5
+		r.Amount = 1000
+	}).Return(nil)
+	provider.On("RefundPayment", mock.Anything, "yoo-paid", 1000, "RUB", mock.Anything, mock.Anything, mock.Anything).
+		Return(&models.RefundProviderResponse{ID: "rf-1", Status: models.RefundStatusSucceeded}, nil)
+	refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-1", mock.Anything).Return(nil)
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-1", models.StatusSourceAdmin).Return(&models.Refund{ID: 5}, nil)
+
+	refund, err := svc.RefundOrder(context.Background(), 1, 0, models.ReasonDamagedGoods, "sole came off", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, refund.Amount)
+	assert.Equal(t, "rf-1", refund.ProviderRefundID)
+	assert.Equal(t, models.RefundStatusSucceeded, refund.Status)
+	refundRepo.AssertExpectations(t)
+	provider.AssertExpectations(t)
+}
+
+func TestRefundOrder_PendingAtProvider(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusShipped, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Refund).ID = 5
+	}).Return(nil)
+	provider.On("RefundPayment", mock.Anything, "yoo-paid", 300, "RUB", mock.Anything, mock.Anything, mock.Anything).
+		Return(&models.RefundProviderResponse{ID: "rf-2", Status: models.RefundStatusPending}, nil)
+	refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-2", mock.Anything).Return(nil)
+
+	refund, err := svc.RefundOrder(context.Background(), 1, 300, models.ReasonOther, "", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 300, refund.Amount)
+	refundRepo.AssertNotCalled(t, "MarkSucceeded", mock.Anything, mock.Anything, mock.Anything)
+	refundRepo.AssertNotCalled(t, "MarkCanceled", mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_NotRefundable(t *testing.T) {
+	svc, repo, _, refundRepo, _, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPendingPayment}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+
+	_, err := svc.RefundOrder(context.Background(), 1, 0, models.ReasonOther, "", 7)
+	require.ErrorIs(t, err, models.ErrOrderNotRefundable)
+	refundRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_AmountExceeded(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.Anything).Return(models.ErrRefundAmountExceeded)
+
+	_, err := svc.RefundOrder(context.Background(), 1, 5000, models.ReasonOther, "", 7)
+	require.ErrorIs(t, err, models.ErrRefundAmountExceeded)
+	provider.AssertNotCalled(t, "RefundPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_ProviderRejectionReleasesAmount(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*models.Refund)
+		r.ID = 5
+		r.Amount = 1000
+	}).Return(nil)
+	provider.On("RefundPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("status 400: %w", models.ErrRefundRejected))
+	refundRepo.On("MarkCanceled", mock.Anything, 5).Return(nil)
+
+	_, err := svc.RefundOrder(context.Background(), 1, 0, models.ReasonOther, "", 7)
+	require.ErrorIs(t, err, models.ErrRefundRejected)
+	refundRepo.AssertExpectations(t)
+	refundRepo.AssertNotCalled(t, "AttachProviderID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// После таймаута или 5xx провайдер мог провести возврат: сумма не освобождается.
+func TestRefundOrder_ProviderErrorKeepsRefundPending(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	refundRepo.On("GetUnsent", mock.Anything, 7).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*models.Refund)
+		r.ID = 5
+		r.Amount = 1000
+		r.IdempotencyKey = models.RefundIdempotenceKey(5)
+	}).Return(nil)
+	provider.On("RefundPayment", mock.Anything, "yoo-paid", 1000, "RUB", mock.Anything, models.RefundIdempotenceKey(5), mock.Anything).
+		Return(nil, errors.New("context deadline exceeded"))
+
+	_, err := svc.RefundOrder(context.Background(), 1, 0, models.ReasonOther, "", 7)
+	require.Error(t, err)
+	refundRepo.AssertNotCalled(t, "MarkCanceled", mock.Anything, mock.Anything)
+}
+
+func TestRefundOrder_ResendsUnsentRefundWithSameKey(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, _ := newRefundTestService()
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusPaid, TotalAmount: 1000}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+	paymentRepo.On("GetByOrderID", mock.Anything, 1).Return(paidPayment(1), nil)
+	unsent := &models.Refund{
+		ID: 5, OrderID: 1, PaymentID: 7, Amount: 1000, Currency: "RUB", Status: models.RefundStatusPending,
+		IdempotencyKey: models.RefundIdempotenceKey(5),
+	}
+	refundRepo.On("GetUnsent", mock.Anything, 7).Return(unsent, nil)
+	provider.On("RefundPayment", mock.Anything, "yoo-paid", 1000, "RUB", mock.Anything, models.RefundIdempotenceKey(5), mock.Anything).
+		Return(&models.RefundProviderResponse{ID: "rf-1", Status: models.RefundStatusSucceeded}, nil)
+	refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-1", mock.Anything).Return(nil)
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-1", models.StatusSourceAdmin).Return(&models.Refund{ID: 5}, nil)
+
+	refund, err := svc.RefundOrder(context.Background(), 1, 0, models.ReasonOther, "", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 5, refund.ID)
+	assert.Equal(t, models.RefundStatusSucceeded, refund.Status)
+	refundRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	provider.AssertExpectations(t)
+}
+
+// ---------------------------------------------------------------------------
+// VerifyRefundWebhook
+// ---------------------------------------------------------------------------
+
+func pendingRefund(providerID string) *models.Refund {
+	return &models.Refund{
+		ID: 5, OrderID: 1, PaymentID: 7, ProviderRefundID: providerID, Amount: 300,
+		Currency: "RUB", Status: models.RefundStatusPending,
+	}
+}
+
+func succeededRefundNotification(providerID string) models.RefundNotification {
+	return models.RefundNotification{RefundID: providerID, Status: models.RefundStatusSucceeded, Amount: 300, Currency: "RUB"}
+}
+
+func TestVerifyRefundWebhook_OK(t *testing.T) {
+	svc, _, _, refundRepo, provider, _ := newRefundTestService()
+
+	refundRepo.On("GetByProviderID", mock.Anything, "rf-1").Return(pendingRefund("rf-1"), nil)
+	provider.On("GetRefund", mock.Anything, "rf-1").Return(&models.RefundProviderResponse{
+		ID: "rf-1", Status: models.RefundStatusSucceeded, Amount: 300, Currency: "RUB",
+	}, nil)
+
+	require.NoError(t, svc.VerifyRefundWebhook(context.Background(), succeededRefundNotification("rf-1")))
+}
+
+func TestVerifyRefundWebhook_NotificationAmountMismatch(t *testing.T) {
+	svc, _, _, refundRepo, provider, _ := newRefundTestService()
+
+	refundRepo.On("GetByProviderID", mock.Anything, "rf-1").Return(pendingRefund("rf-1"), nil)
+
+	n := succeededRefundNotification("rf-1")
+	n.Amount = 100000
+	err := svc.VerifyRefundWebhook(context.Background(), n)
+	requireRejected(t, err, models.WebhookRejectAmountMismatch)
+	provider.AssertNotCalled(t, "GetRefund", mock.Anything, mock.Anything)
+}
+
+func TestVerifyRefundWebhook_ProviderDisagrees(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote *models.RefundProviderResponse
+		err    error
+		reason string
+	}{
+		{
+			name:   "status",
+			remote: &models.RefundProviderResponse{Status: models.RefundStatusPending, Amount: 300, Currency: "RUB"},
+			reason: models.WebhookRejectStatusMismatch,
+		},
+		{
+			name:   "amount",
+			remote: &models.RefundProviderResponse{Status: models.RefundStatusSucceeded, Amount: 100, Currency: "RUB"},
+			reason: models.WebhookRejectAmountMismatch,
+		},
+		{
+			name:   "not found",
+			err:    models.ErrProviderRefundNotFound,
+			reason: models.WebhookRejectUnknownRefund,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, refundRepo, provider, _ := newRefundTestService()
+
+			refundRepo.On("GetByProviderID", mock.Anything, "rf-1").Return(pendingRefund("rf-1"), nil)
+			provider.On("GetRefund", mock.Anything, "rf-1").Return(tt.remote, tt.err)
+
+			err := svc.VerifyRefundWebhook(context.Background(), succeededRefundNotification("rf-1"))
+			requireRejected(t, err, tt.reason)
+		})
+	}
+}
+
+func TestVerifyRefundWebhook_ForgedRefundIsRejected(t *testing.T) {
+	svc, _, _, refundRepo, provider, _ := newRefundTestService()
+
+	refundRepo.On("GetByProviderID", mock.Anything, "rf-forged").Return(nil, models.ErrRefundNotFound)
+	provider.On("GetRefund", mock.Anything, "rf-forged").Return(nil, models.ErrProviderRefundNotFound)
+
+	err := svc.VerifyRefundWebhook(context.Background(), succeededRefundNotification("rf-forged"))
+	requireRejected(t, err, models.WebhookRejectUnknownRefund)
+}
+
+// Уведомление может опередить AttachProviderID: провайдер знает возврат, а у нас
+// он ещё без id — просим повторить, а не отклоняем.
+func TestVerifyRefundWebhook_NotYetAttachedIsRetried(t *testing.T) {
+	svc, _, _, refundRepo, provider, _ := newRefundTestService()
+
+	refundRepo.On("GetByProviderID", mock.Anything, "rf-1").Return(nil, models.ErrRefundNotFound)
+	provider.On("GetRefund", mock.Anything, "rf-1").Return(&models.RefundProviderResponse{
+		ID: "rf-1", Status: models.RefundStatusSucceeded, Amount: 300, Currency: "RUB",
+	}, nil)
+
+	err := svc.VerifyRefundWebhook(context.Background(), succeededRefundNotification("rf-1"))
+	require.ErrorIs(t, err, models.ErrRefundNotFound)
+	var rejected *models.WebhookRejectedError
+	assert.False(t, errors.As(err, &rejected))
+}
+
+// ---------------------------------------------------------------------------
+// ProcessRefundWebhook
+// ---------------------------------------------------------------------------
+
+func TestProcessRefundWebhook_Succeeded(t *testing.T) {
+	svc, _, _, refundRepo, _, _ := newRefundTestService()
+
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-1", models.StatusSourceWebhook).Return(&models.Refund{ID: 5, OrderID: 1, Amount: 300}, nil)
+
+	require.NoError(t, svc.ProcessRefundWebhook(context.Background(), "rf-1", models.RefundStatusSucceeded))
+	refundRepo.AssertExpectations(t)
+}
+
+func TestProcessRefundWebhook_AlreadyProcessed(t *testing.T) {
+	svc, _, _, refundRepo, _, _ := newRefundTestService()
+
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-1", models.StatusSourceWebhook).Return(nil, nil)
+
+	require.NoError(t, svc.ProcessRefundWebhook(context.Background(), "rf-1", models.RefundStatusSucceeded))
+}
+
+func TestProcessRefundWebhook_UnknownRefundIsRetried(t *testing.T) {
+	svc, _, _, refundRepo, _, _ := newRefundTestService()
+
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-404", models.StatusSourceWebhook).Return(nil, models.ErrRefundNotFound)
+
+	err := svc.ProcessRefundWebhook(context.Background(), "rf-404", models.RefundStatusSucceeded)
+	require.ErrorIs(t, err, models.ErrRefundNotFound)
+}
+
+// ---------------------------------------------------------------------------
+// Оплата отменённого заказа
+// ---------------------------------------------------------------------------
+
+func TestProcessWebhook_PaymentForCancelledOrderIsRefunded(t *testing.T) {
+	svc, repo, paymentRepo, refundRepo, provider, inventory := newRefundTestService()
+
+	existing := &models.Payment{ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-late", Status: models.PaymentStatusPending}
+	updated := &models.Payment{
+		ID: 7, OrderID: 1, YooKassaPaymentID: "yoo-late", Amount: 1000, Currency: "RUB",
+		Status: models.PaymentStatusSucceeded,
+	}
+	paymentRepo.On("GetByYooKassaID", mock.Anything, "yoo-late").Return(existing, nil)
+	paymentRepo.On("UpdateStatusAndGet", mock.Anything, "yoo-late", models.PaymentStatusSucceeded).Return(updated, nil)
+
+	order := &models.OrderWithItems{Order: models.Order{ID: 1, Status: models.OrderStatusCancelled}}
+	repo.On("GetByID", mock.Anything, 1).Return(order, nil)
+
+	refundRepo.On("GetUnsent", mock.Anything, mock.Anything).Return(nil, models.ErrRefundNotFound)
+	refundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.Refund) bool {
+		return r.Amount == 0 && r.Reason == models.ReasonPaymentIssue
+	})).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*models.Refund)
+		r.ID = 5
+		r.Amount = 1000
+	}).Return(nil)
+	provider.On("RefundPayment", mock.Anything, "yoo-late", 1000, "RUB", mock.Anything, mock.Anything, mock.Anything).
+		Return(&models.RefundProviderResponse{ID: "rf-1", Status: models.RefundStatusSucceeded}, nil)
+	refundRepo.On("AttachProviderID", mock.Anything, 5, "rf-1", mock.Anything).Return(nil)
+	refundRepo.On("MarkSucceeded", mock.Anything, "rf-1", models.StatusSourceWebhook).Return(&models.Refund{ID: 5}, nil)
+
+	require.NoError(t, svc.ProcessWebhook(context.Background(), "yoo-late", "succeeded"))
+	refundRepo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	inventory.AssertNotCalled(t, "CommitStock", mock.Anything, mock.Anything)
+}
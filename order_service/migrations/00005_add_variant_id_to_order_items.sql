@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_id;
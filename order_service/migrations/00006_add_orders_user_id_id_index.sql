@@ -0,0 +1,8 @@
+-- +goose Up
+-- Keyset-пагинация заказов пользователя идёт по (user_id, id DESC).
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_id ON orders(user_id, id DESC);
+DROP INDEX IF EXISTS idx_orders_user_id;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders(user_id);
+DROP INDEX IF EXISTS idx_orders_user_id_id;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Ключ из заголовка Idempotency-Key: повторный CreateOrder с тем же ключом
+-- возвращает уже созданный заказ вместо нового.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(128);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_user_id_idempotency_key
+    ON orders(user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_user_id_idempotency_key;
+ALTER TABLE orders DROP COLUMN IF EXISTS idempotency_key;
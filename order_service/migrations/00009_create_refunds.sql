@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id),
+    payment_id INTEGER NOT NULL REFERENCES payments(id),
+    provider_refund_id VARCHAR(255) UNIQUE,     -- NULL, пока провайдер не принял возврат
+    amount INTEGER NOT NULL CHECK (amount > 0), -- сумма в копейках
+    currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
+    status VARCHAR(50) NOT NULL,                -- pending, succeeded, canceled
+    reason VARCHAR(32) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    idempotency_key UUID NOT NULL UNIQUE,       -- Idempotence-Key запроса к провайдеру
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS refunds;
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_amount;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expiry_claimed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_pending_updated_at
+    ON orders(updated_at) WHERE status = 'PENDING_PAYMENT';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_pending_updated_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS expiry_claimed_at;
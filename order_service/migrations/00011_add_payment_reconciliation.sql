@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS reconciled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_payments_pending_created_at
+    ON payments(created_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_payments_pending_created_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS reconciled_at;
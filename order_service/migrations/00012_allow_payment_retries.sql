@@ -0,0 +1,13 @@
+-- +goose Up
+-- У заказа может быть несколько попыток оплаты; ожидающая — не больше одной.
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_order_id_key;
+
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id, id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_pending
+    ON payments(order_id) WHERE status = 'pending';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_payments_order_id_pending;
+DROP INDEX IF EXISTS idx_payments_order_id;
+-- Не применится, пока есть заказы с несколькими попытками оплаты.
+ALTER TABLE payments ADD CONSTRAINT payments_order_id_key UNIQUE (order_id);
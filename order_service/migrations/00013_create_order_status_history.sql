@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),             -- NULL — заказ создан
+    to_status VARCHAR(50) NOT NULL,
+    source VARCHAR(16) NOT NULL,         -- user, admin, webhook, scheduler, system
+    reason VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id, id);
+
+-- Для существующих заказов известен только текущий статус.
+INSERT INTO order_status_history (order_id, to_status, source, created_at)
+SELECT id, status, 'system', updated_at FROM orders;
+
+-- +goose Down
+DROP TABLE IF EXISTS order_status_history;
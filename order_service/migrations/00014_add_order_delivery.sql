@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS delivery_method VARCHAR(16) NOT NULL DEFAULT 'pickup',
+    ADD COLUMN IF NOT EXISTS delivery_cost INTEGER NOT NULL DEFAULT 0,
+    -- Копия адреса на момент оформления; NULL — самовывоз.
+    ADD COLUMN IF NOT EXISTS shipping_address JSONB;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS shipping_address,
+    DROP COLUMN IF EXISTS delivery_cost,
+    DROP COLUMN IF EXISTS delivery_method;
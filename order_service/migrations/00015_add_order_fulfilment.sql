@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS carrier VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tracking_number VARCHAR(64) NOT NULL DEFAULT '',
+    -- Когда статус отправления последний раз запрашивался у перевозчика.
+    ADD COLUMN IF NOT EXISTS tracking_polled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_shipped_tracking_polled_at
+    ON orders(tracking_polled_at NULLS FIRST) WHERE status = 'SHIPPED';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_shipped_tracking_polled_at;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tracking_polled_at,
+    DROP COLUMN IF EXISTS tracking_number,
+    DROP COLUMN IF EXISTS carrier;
@@ -0,0 +1,44 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE,                -- в верхнем регистре
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value INTEGER NOT NULL CHECK (value > 0),        -- процент или копейки
+    min_order_amount INTEGER NOT NULL DEFAULT 0,
+    max_uses_per_user INTEGER NOT NULL DEFAULT 0,    -- 0 — без ограничения
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_to TIMESTAMP WITH TIME ZONE,
+    product_ids INTEGER[] NOT NULL DEFAULT '{}',     -- пусто вместе с brands — все товары
+    brands TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS promo_code_id INTEGER REFERENCES promo_codes(id),
+    ADD COLUMN IF NOT EXISTS promo_code VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0;
+
+-- Подсчёт использований промокода пользователем.
+CREATE INDEX IF NOT EXISTS idx_orders_promo_code_id_user_id
+    ON orders(promo_code_id, user_id) WHERE promo_code_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sneaker_id INTEGER NOT NULL,
+    variant_id INTEGER NOT NULL DEFAULT 0,
+    amount INTEGER NOT NULL CHECK (amount > 0)       -- в копейках, за всю позицию
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_discounts_order_id ON order_discounts(order_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_discounts;
+DROP INDEX IF EXISTS idx_orders_promo_code_id_user_id;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS promo_code,
+    DROP COLUMN IF EXISTS promo_code_id;
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS customer_email VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Название позиции на момент покупки: по нему формируется чек и при повторной оплате, и при возврате.
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS title VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Чеки 54-ФЗ, отправленные провайдеру, для аудита; NULL — платёж или возврат без чека.
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS receipt JSONB;
+
+ALTER TABLE refunds
+    ADD COLUMN IF NOT EXISTS receipt JSONB;
+
+-- +goose Down
+ALTER TABLE refunds DROP COLUMN IF EXISTS receipt;
+ALTER TABLE payments DROP COLUMN IF EXISTS receipt;
+ALTER TABLE order_items DROP COLUMN IF EXISTS title;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_email;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- variant_id ссылается на sneaker_variants.id в product_service, а это BIGSERIAL.
+ALTER TABLE order_items ALTER COLUMN variant_id TYPE BIGINT;
+ALTER TABLE order_discounts ALTER COLUMN variant_id TYPE BIGINT;
+
+-- +goose Down
+ALTER TABLE order_discounts ALTER COLUMN variant_id TYPE INTEGER;
+ALTER TABLE order_items ALTER COLUMN variant_id TYPE INTEGER;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Отпечаток запроса на создание заказа: повтор с тем же Idempotency-Key,
+-- но другим телом отклоняется. У старых заказов NULL — их не с чем сравнивать.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS request_hash VARCHAR(64);
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS request_hash;
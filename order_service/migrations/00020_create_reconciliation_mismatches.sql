@@ -0,0 +1,22 @@
+-- +goose Up
+-- Расхождения, найденные сверкой платежей: общие для всех реплик и переживают рестарт.
+CREATE TABLE IF NOT EXISTS reconciliation_mismatches (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL,               -- status, amount, missing, provider_error
+    payment_id VARCHAR(255) NOT NULL,        -- id платежа у провайдера
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    local_status VARCHAR(50) NOT NULL,
+    provider_status VARCHAR(50) NOT NULL DEFAULT '',
+    local_amount INTEGER NOT NULL,
+    provider_amount INTEGER NOT NULL DEFAULT 0,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Админский список неустранённых расхождений.
+CREATE INDEX IF NOT EXISTS idx_reconciliation_mismatches_unresolved
+    ON reconciliation_mismatches(id) WHERE NOT resolved;
+
+-- +goose Down
+DROP TABLE IF EXISTS reconciliation_mismatches;
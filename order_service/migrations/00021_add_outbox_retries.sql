@@ -0,0 +1,18 @@
+-- +goose Up
+-- Повторы публикации с backoff и dead letter: сообщение, исчерпавшее попытки,
+-- больше не публикуется и ждёт ручного разбора.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE, -- NULL — публиковать сразу
+    ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP WITH TIME ZONE;         -- NULL — ещё публикуется
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_dead ON outbox(id) WHERE dead_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outbox_dead;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(id) WHERE published_at IS NULL;
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS dead_at,
+    DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Кто из администраторов сменил статус; NULL — покупатель, вебхук или фоновый воркер.
+ALTER TABLE order_status_history ADD COLUMN IF NOT EXISTS actor_id INTEGER;
+-- Администратор, запросивший возврат: переход в REFUNDED может записать и вебхук.
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS actor_id INTEGER;
+
+-- +goose Down
+ALTER TABLE refunds DROP COLUMN IF EXISTS actor_id;
+ALTER TABLE order_status_history DROP COLUMN IF EXISTS actor_id;
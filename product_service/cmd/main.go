@@ -80,6 +80,9 @@ func run() error {
 	// Сервисный слой
 	productService := app.NewService(postgresRepo, redisRepo, fileStoreRepo, log, cfg.CacheTTL)
 
+	// Снятие просроченных резервов остатков
+	go productService.RunReservationSweeper(ctx, cfg.ReservationSweepInterval)
+
 	// gRPC-сервер
 	grpcServer := grpc.NewServer()
 	grpc_handler.Register(grpcServer, productService, log)
@@ -9,10 +9,23 @@ import (
 type ProductPostgres interface {
 	GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker, error)
 	AddSneaker(ctx context.Context, sneaker *model.Sneaker) (int64, error)
-	GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*model.Sneaker, error)
+	GetAllSneakers(ctx context.Context, limit uint64, afterID int64) ([]*model.Sneaker, *model.SneakerCursor, error)
 	GetSneakersByIDs(ctx context.Context, ids []int64) ([]*model.Sneaker, error)
-	DeleteSneaker(ctx context.Context, id int64) error
+	SearchSneakers(ctx context.Context, filter model.SneakerFilter) ([]*model.Sneaker, *model.SneakerCursor, error)
+	UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error)
+	ArchiveSneaker(ctx context.Context, id int64) error
+	RestoreSneaker(ctx context.Context, id int64) error
 	UpdateImageKey(ctx context.Context, id int64, imageKey string) error
+	CreateVariant(ctx context.Context, variant *model.SneakerVariant) (int64, error)
+	GetVariantsBySneakerID(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error)
+	GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error)
+	UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)
+	SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*model.StockLevel, error)
+	GetStock(ctx context.Context, sneakerID, variantID int64) (*model.StockLevel, error)
+	ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, expiresAt time.Time) error
+	ReleaseStock(ctx context.Context, orderID int64) (int64, error)
+	CommitStock(ctx context.Context, orderID int64) error
+	ReleaseExpiredReservations(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ProductCache interface {
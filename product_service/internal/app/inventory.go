@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"product_service/internal/model"
+)
+
+// DefaultReservationTTL — срок жизни резерва, если вызывающий не указал свой.
+const DefaultReservationTTL = 30 * time.Minute
+
+var ErrInvalidStock = errors.New("invalid stock request")
+
+func (s *Service) SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*model.StockLevel, error) {
+	const op = "app.Service.SetStock"
+	log := s.log.With(slog.String("op", op), slog.Int64("sneakerID", sneakerID), slog.Int64("variantID", variantID))
+
+	if quantity < 0 {
+		return nil, fmt.Errorf("%s: %w: quantity must not be negative", op, ErrInvalidStock)
+	}
+	if err := s.checkVariantOwner(ctx, sneakerID, variantID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	level, err := s.repo.SetStock(ctx, sneakerID, variantID, quantity)
+	if err != nil {
+		log.Error("failed to set stock in db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("stock updated", slog.Int64("quantity", quantity))
+
+	return level, nil
+}
+
+func (s *Service) GetStock(ctx context.Context, sneakerID, variantID int64) (*model.StockLevel, error) {
+	const op = "app.Service.GetStock"
+
+	level, err := s.repo.GetStock(ctx, sneakerID, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return level, nil
+}
+
+// ReserveStock резервирует остатки под заказ. ttl <= 0 — DefaultReservationTTL.
+func (s *Service) ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, ttl time.Duration) error {
+	const op = "app.Service.ReserveStock"
+	log := s.log.With(slog.String("op", op), slog.Int64("orderID", orderID))
+
+	if orderID <= 0 || len(items) == 0 {
+		return fmt.Errorf("%s: %w: order_id and items are required", op, ErrInvalidStock)
+	}
+	for _, it := range items {
+		if it.SneakerId <= 0 || it.VariantId < 0 || it.Quantity <= 0 {
+			return fmt.Errorf("%s: %w: invalid item for sneaker %d", op, ErrInvalidStock, it.SneakerId)
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+
+	if err := s.repo.ReserveStock(ctx, orderID, items, time.Now().Add(ttl)); err != nil {
+		log.Warn("failed to reserve stock", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("stock reserved", slog.Int("items", len(items)))
+
+	return nil
+}
+
+func (s *Service) ReleaseStock(ctx context.Context, orderID int64) error {
+	const op = "app.Service.ReleaseStock"
+	log := s.log.With(slog.String("op", op), slog.Int64("orderID", orderID))
+
+	released, err := s.repo.ReleaseStock(ctx, orderID)
+	if err != nil {
+		log.Error("failed to release stock", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("stock released", slog.Int64("reservations", released))
+
+	return nil
+}
+
+func (s *Service) CommitStock(ctx context.Context, orderID int64) error {
+	const op = "app.Service.CommitStock"
+	log := s.log.With(slog.String("op", op), slog.Int64("orderID", orderID))
+
+	if err := s.repo.CommitStock(ctx, orderID); err != nil {
+		log.Error("failed to commit stock", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("stock committed")
+
+	return nil
+}
+
+// ReleaseExpiredReservations снимает резервы заказов, которые так и не были оплачены.
+func (s *Service) ReleaseExpiredReservations(ctx context.Context) (int64, error) {
+	const op = "app.Service.ReleaseExpiredReservations"
+
+	released, err := s.repo.ReleaseExpiredReservations(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if released > 0 {
+		s.log.Info("expired reservations released", slog.String("op", op), slog.Int64("count", released))
+	}
+	return released, nil
+}
+
+// RunReservationSweeper периодически снимает просроченные резервы до отмены ctx.
+func (s *Service) RunReservationSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ReleaseExpiredReservations(ctx); err != nil {
+				s.log.Error("reservation sweep failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// checkVariantOwner проверяет, что вариант (если указан) принадлежит товару.
+func (s *Service) checkVariantOwner(ctx context.Context, sneakerID, variantID int64) error {
+	if variantID == 0 {
+		return nil
+	}
+	variants, err := s.repo.GetVariantsByIDs(ctx, []int64{variantID})
+	if err != nil {
+		return err
+	}
+	if len(variants) == 0 || variants[0].SneakerId != sneakerID {
+		return fmt.Errorf("%w: variant %d does not belong to sneaker %d", ErrInvalidStock, variantID, sneakerID)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"product_service/internal/app/mocks"
+	"product_service/internal/model"
+	"product_service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- SetStock ---
+
+func TestSetStock_Success(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	level := &model.StockLevel{SneakerId: 1, Quantity: 5}
+	repo.On("SetStock", mock.Anything, int64(1), int64(0), int64(5)).Return(level, nil)
+
+	result, err := svc.SetStock(context.Background(), 1, 0, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Available())
+}
+
+func TestSetStock_VariantOfAnotherSneaker(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	repo.On("GetVariantsByIDs", mock.Anything, []int64{7}).
+		Return([]*model.SneakerVariant{{Id: 7, SneakerId: 2}}, nil)
+
+	_, err := svc.SetStock(context.Background(), 1, 7, 5)
+	assert.ErrorIs(t, err, ErrInvalidStock)
+	repo.AssertNotCalled(t, "SetStock")
+}
+
+func TestSetStock_NegativeQuantity(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	_, err := svc.SetStock(context.Background(), 1, 0, -1)
+	assert.ErrorIs(t, err, ErrInvalidStock)
+}
+
+// --- ReserveStock ---
+
+func TestReserveStock_DefaultTTL(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	items := []model.StockItem{{SneakerId: 1, Quantity: 2}}
+	before := time.Now()
+	repo.On("ReserveStock", mock.Anything, int64(10), items, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return !expiresAt.Before(before.Add(DefaultReservationTTL))
+	})).Return(nil)
+
+	err := svc.ReserveStock(context.Background(), 10, items, 0)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestReserveStock_Insufficient(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	items := []model.StockItem{{SneakerId: 1, Quantity: 2}}
+	repo.On("ReserveStock", mock.Anything, int64(10), items, mock.Anything).Return(repository.ErrInsufficientStock)
+
+	err := svc.ReserveStock(context.Background(), 10, items, time.Minute)
+	assert.ErrorIs(t, err, repository.ErrInsufficientStock)
+}
+
+func TestReserveStock_InvalidItems(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	err := svc.ReserveStock(context.Background(), 10, []model.StockItem{{SneakerId: 1, Quantity: 0}}, 0)
+	assert.ErrorIs(t, err, ErrInvalidStock)
+
+	err = svc.ReserveStock(context.Background(), 10, nil, 0)
+	assert.ErrorIs(t, err, ErrInvalidStock)
+	repo.AssertNotCalled(t, "ReserveStock")
+}
+
+// --- Release / Commit ---
+
+func TestReleaseStock(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	repo.On("ReleaseStock", mock.Anything, int64(10)).Return(int64(2), nil)
+
+	require.NoError(t, svc.ReleaseStock(context.Background(), 10))
+	repo.AssertExpectations(t)
+}
+
+func TestCommitStock_Insufficient(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	repo.On("CommitStock", mock.Anything, int64(10)).Return(repository.ErrInsufficientStock)
+
+	err := svc.CommitStock(context.Background(), 10)
+	assert.ErrorIs(t, err, repository.ErrInsufficientStock)
+}
+
+func TestReleaseExpiredReservations(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+	repo.On("ReleaseExpiredReservations", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+
+	released, err := svc.ReleaseExpiredReservations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), released)
+}
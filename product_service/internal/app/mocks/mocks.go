@@ -105,12 +105,12 @@ func (_c *MockProductPostgres_AddSneaker_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
-// DeleteSneaker provides a mock function for the type MockProductPostgres
-func (_mock *MockProductPostgres) DeleteSneaker(ctx context.Context, id int64) error {
+// ArchiveSneaker provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) ArchiveSneaker(ctx context.Context, id int64) error {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSneaker")
+		panic("no return value specified for ArchiveSneaker")
 	}
 
 	var r0 error
@@ -122,19 +122,19 @@ func (_mock *MockProductPostgres) DeleteSneaker(ctx context.Context, id int64) e
 	return r0
 }
 
-// MockProductPostgres_DeleteSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSneaker'
-type MockProductPostgres_DeleteSneaker_Call struct {
+// MockProductPostgres_ArchiveSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveSneaker'
+type MockProductPostgres_ArchiveSneaker_Call struct {
 	*mock.Call
 }
 
-// DeleteSneaker is a helper method to define mock.On call
+// ArchiveSneaker is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockProductPostgres_Expecter) DeleteSneaker(ctx interface{}, id interface{}) *MockProductPostgres_DeleteSneaker_Call {
-	return &MockProductPostgres_DeleteSneaker_Call{Call: _e.mock.On("DeleteSneaker", ctx, id)}
+func (_e *MockProductPostgres_Expecter) ArchiveSneaker(ctx interface{}, id interface{}) *MockProductPostgres_ArchiveSneaker_Call {
+	return &MockProductPostgres_ArchiveSneaker_Call{Call: _e.mock.On("ArchiveSneaker", ctx, id)}
 }
 
-func (_c *MockProductPostgres_DeleteSneaker_Call) Run(run func(ctx context.Context, id int64)) *MockProductPostgres_DeleteSneaker_Call {
+func (_c *MockProductPostgres_ArchiveSneaker_Call) Run(run func(ctx context.Context, id int64)) *MockProductPostgres_ArchiveSneaker_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -152,42 +152,173 @@ func (_c *MockProductPostgres_DeleteSneaker_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockProductPostgres_DeleteSneaker_Call) Return(err error) *MockProductPostgres_DeleteSneaker_Call {
+func (_c *MockProductPostgres_ArchiveSneaker_Call) Return(err error) *MockProductPostgres_ArchiveSneaker_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockProductPostgres_DeleteSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockProductPostgres_DeleteSneaker_Call {
+func (_c *MockProductPostgres_ArchiveSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockProductPostgres_ArchiveSneaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommitStock provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) CommitStock(ctx context.Context, orderID int64) error {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductPostgres_CommitStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitStock'
+type MockProductPostgres_CommitStock_Call struct {
+	*mock.Call
+}
+
+// CommitStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockProductPostgres_Expecter) CommitStock(ctx interface{}, orderID interface{}) *MockProductPostgres_CommitStock_Call {
+	return &MockProductPostgres_CommitStock_Call{Call: _e.mock.On("CommitStock", ctx, orderID)}
+}
+
+func (_c *MockProductPostgres_CommitStock_Call) Run(run func(ctx context.Context, orderID int64)) *MockProductPostgres_CommitStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_CommitStock_Call) Return(err error) *MockProductPostgres_CommitStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductPostgres_CommitStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64) error) *MockProductPostgres_CommitStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVariant provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) CreateVariant(ctx context.Context, variant *model.SneakerVariant) (int64, error) {
+	ret := _mock.Called(ctx, variant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVariant")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) (int64, error)); ok {
+		return returnFunc(ctx, variant)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) int64); ok {
+		r0 = returnFunc(ctx, variant)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SneakerVariant) error); ok {
+		r1 = returnFunc(ctx, variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_CreateVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVariant'
+type MockProductPostgres_CreateVariant_Call struct {
+	*mock.Call
+}
+
+// CreateVariant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - variant *model.SneakerVariant
+func (_e *MockProductPostgres_Expecter) CreateVariant(ctx interface{}, variant interface{}) *MockProductPostgres_CreateVariant_Call {
+	return &MockProductPostgres_CreateVariant_Call{Call: _e.mock.On("CreateVariant", ctx, variant)}
+}
+
+func (_c *MockProductPostgres_CreateVariant_Call) Run(run func(ctx context.Context, variant *model.SneakerVariant)) *MockProductPostgres_CreateVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SneakerVariant
+		if args[1] != nil {
+			arg1 = args[1].(*model.SneakerVariant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_CreateVariant_Call) Return(n int64, err error) *MockProductPostgres_CreateVariant_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_CreateVariant_Call) RunAndReturn(run func(ctx context.Context, variant *model.SneakerVariant) (int64, error)) *MockProductPostgres_CreateVariant_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAllSneakers provides a mock function for the type MockProductPostgres
-func (_mock *MockProductPostgres) GetAllSneakers(ctx context.Context, limit uint64, offset uint64) ([]*model.Sneaker, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockProductPostgres) GetAllSneakers(ctx context.Context, limit uint64, afterID int64) ([]*model.Sneaker, *model.SneakerCursor, error) {
+	ret := _mock.Called(ctx, limit, afterID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllSneakers")
 	}
 
 	var r0 []*model.Sneaker
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]*model.Sneaker, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	var r1 *model.SneakerCursor
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, int64) ([]*model.Sneaker, *model.SneakerCursor, error)); ok {
+		return returnFunc(ctx, limit, afterID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) []*model.Sneaker); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, int64) []*model.Sneaker); ok {
+		r0 = returnFunc(ctx, limit, afterID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Sneaker)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, int64) *model.SneakerCursor); ok {
+		r1 = returnFunc(ctx, limit, afterID)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.SneakerCursor)
+		}
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uint64, int64) error); ok {
+		r2 = returnFunc(ctx, limit, afterID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockProductPostgres_GetAllSneakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllSneakers'
@@ -198,12 +329,12 @@ type MockProductPostgres_GetAllSneakers_Call struct {
 // GetAllSneakers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - limit uint64
-//   - offset uint64
-func (_e *MockProductPostgres_Expecter) GetAllSneakers(ctx interface{}, limit interface{}, offset interface{}) *MockProductPostgres_GetAllSneakers_Call {
-	return &MockProductPostgres_GetAllSneakers_Call{Call: _e.mock.On("GetAllSneakers", ctx, limit, offset)}
+//   - afterID int64
+func (_e *MockProductPostgres_Expecter) GetAllSneakers(ctx interface{}, limit interface{}, afterID interface{}) *MockProductPostgres_GetAllSneakers_Call {
+	return &MockProductPostgres_GetAllSneakers_Call{Call: _e.mock.On("GetAllSneakers", ctx, limit, afterID)}
 }
 
-func (_c *MockProductPostgres_GetAllSneakers_Call) Run(run func(ctx context.Context, limit uint64, offset uint64)) *MockProductPostgres_GetAllSneakers_Call {
+func (_c *MockProductPostgres_GetAllSneakers_Call) Run(run func(ctx context.Context, limit uint64, afterID int64)) *MockProductPostgres_GetAllSneakers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -213,9 +344,9 @@ func (_c *MockProductPostgres_GetAllSneakers_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(uint64)
 		}
-		var arg2 uint64
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(uint64)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -226,12 +357,12 @@ func (_c *MockProductPostgres_GetAllSneakers_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockProductPostgres_GetAllSneakers_Call) Return(sneakers []*model.Sneaker, err error) *MockProductPostgres_GetAllSneakers_Call {
-	_c.Call.Return(sneakers, err)
+func (_c *MockProductPostgres_GetAllSneakers_Call) Return(sneakers []*model.Sneaker, sneakerCursor *model.SneakerCursor, err error) *MockProductPostgres_GetAllSneakers_Call {
+	_c.Call.Return(sneakers, sneakerCursor, err)
 	return _c
 }
 
-func (_c *MockProductPostgres_GetAllSneakers_Call) RunAndReturn(run func(ctx context.Context, limit uint64, offset uint64) ([]*model.Sneaker, error)) *MockProductPostgres_GetAllSneakers_Call {
+func (_c *MockProductPostgres_GetAllSneakers_Call) RunAndReturn(run func(ctx context.Context, limit uint64, afterID int64) ([]*model.Sneaker, *model.SneakerCursor, error)) *MockProductPostgres_GetAllSneakers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -372,37 +503,48 @@ func (_c *MockProductPostgres_GetSneakersByIDs_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// UpdateImageKey provides a mock function for the type MockProductPostgres
-func (_mock *MockProductPostgres) UpdateImageKey(ctx context.Context, id int64, imageKey string) error {
-	ret := _mock.Called(ctx, id, imageKey)
+// GetStock provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) GetStock(ctx context.Context, sneakerID int64, variantID int64) (*model.StockLevel, error) {
+	ret := _mock.Called(ctx, sneakerID, variantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateImageKey")
+		panic("no return value specified for GetStock")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
-		r0 = returnFunc(ctx, id, imageKey)
+	var r0 *model.StockLevel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*model.StockLevel, error)); ok {
+		return returnFunc(ctx, sneakerID, variantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *model.StockLevel); ok {
+		r0 = returnFunc(ctx, sneakerID, variantID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StockLevel)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID, variantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockProductPostgres_UpdateImageKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImageKey'
-type MockProductPostgres_UpdateImageKey_Call struct {
+// MockProductPostgres_GetStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStock'
+type MockProductPostgres_GetStock_Call struct {
 	*mock.Call
 }
 
-// UpdateImageKey is a helper method to define mock.On call
+// GetStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-//   - imageKey string
-func (_e *MockProductPostgres_Expecter) UpdateImageKey(ctx interface{}, id interface{}, imageKey interface{}) *MockProductPostgres_UpdateImageKey_Call {
-	return &MockProductPostgres_UpdateImageKey_Call{Call: _e.mock.On("UpdateImageKey", ctx, id, imageKey)}
+//   - sneakerID int64
+//   - variantID int64
+func (_e *MockProductPostgres_Expecter) GetStock(ctx interface{}, sneakerID interface{}, variantID interface{}) *MockProductPostgres_GetStock_Call {
+	return &MockProductPostgres_GetStock_Call{Call: _e.mock.On("GetStock", ctx, sneakerID, variantID)}
 }
 
-func (_c *MockProductPostgres_UpdateImageKey_Call) Run(run func(ctx context.Context, id int64, imageKey string)) *MockProductPostgres_UpdateImageKey_Call {
+func (_c *MockProductPostgres_GetStock_Call) Run(run func(ctx context.Context, sneakerID int64, variantID int64)) *MockProductPostgres_GetStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -412,9 +554,9 @@ func (_c *MockProductPostgres_UpdateImageKey_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 string
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -425,12 +567,773 @@ func (_c *MockProductPostgres_UpdateImageKey_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockProductPostgres_UpdateImageKey_Call) Return(err error) *MockProductPostgres_UpdateImageKey_Call {
-	_c.Call.Return(err)
+func (_c *MockProductPostgres_GetStock_Call) Return(stockLevel *model.StockLevel, err error) *MockProductPostgres_GetStock_Call {
+	_c.Call.Return(stockLevel, err)
 	return _c
 }
 
-func (_c *MockProductPostgres_UpdateImageKey_Call) RunAndReturn(run func(ctx context.Context, id int64, imageKey string) error) *MockProductPostgres_UpdateImageKey_Call {
+func (_c *MockProductPostgres_GetStock_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64, variantID int64) (*model.StockLevel, error)) *MockProductPostgres_GetStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVariantsByIDs provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVariantsByIDs")
+	}
+
+	var r0 []*model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) ([]*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) []*model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_GetVariantsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVariantsByIDs'
+type MockProductPostgres_GetVariantsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetVariantsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *MockProductPostgres_Expecter) GetVariantsByIDs(ctx interface{}, ids interface{}) *MockProductPostgres_GetVariantsByIDs_Call {
+	return &MockProductPostgres_GetVariantsByIDs_Call{Call: _e.mock.On("GetVariantsByIDs", ctx, ids)}
+}
+
+func (_c *MockProductPostgres_GetVariantsByIDs_Call) Run(run func(ctx context.Context, ids []int64)) *MockProductPostgres_GetVariantsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int64
+		if args[1] != nil {
+			arg1 = args[1].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_GetVariantsByIDs_Call) Return(sneakerVariants []*model.SneakerVariant, err error) *MockProductPostgres_GetVariantsByIDs_Call {
+	_c.Call.Return(sneakerVariants, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_GetVariantsByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error)) *MockProductPostgres_GetVariantsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVariantsBySneakerID provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) GetVariantsBySneakerID(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, sneakerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVariantsBySneakerID")
+	}
+
+	var r0 []*model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, sneakerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []*model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, sneakerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_GetVariantsBySneakerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVariantsBySneakerID'
+type MockProductPostgres_GetVariantsBySneakerID_Call struct {
+	*mock.Call
+}
+
+// GetVariantsBySneakerID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sneakerID int64
+func (_e *MockProductPostgres_Expecter) GetVariantsBySneakerID(ctx interface{}, sneakerID interface{}) *MockProductPostgres_GetVariantsBySneakerID_Call {
+	return &MockProductPostgres_GetVariantsBySneakerID_Call{Call: _e.mock.On("GetVariantsBySneakerID", ctx, sneakerID)}
+}
+
+func (_c *MockProductPostgres_GetVariantsBySneakerID_Call) Run(run func(ctx context.Context, sneakerID int64)) *MockProductPostgres_GetVariantsBySneakerID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_GetVariantsBySneakerID_Call) Return(sneakerVariants []*model.SneakerVariant, err error) *MockProductPostgres_GetVariantsBySneakerID_Call {
+	_c.Call.Return(sneakerVariants, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_GetVariantsBySneakerID_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error)) *MockProductPostgres_GetVariantsBySneakerID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseExpiredReservations provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) ReleaseExpiredReservations(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_ReleaseExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseExpiredReservations'
+type MockProductPostgres_ReleaseExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseExpiredReservations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockProductPostgres_Expecter) ReleaseExpiredReservations(ctx interface{}, before interface{}) *MockProductPostgres_ReleaseExpiredReservations_Call {
+	return &MockProductPostgres_ReleaseExpiredReservations_Call{Call: _e.mock.On("ReleaseExpiredReservations", ctx, before)}
+}
+
+func (_c *MockProductPostgres_ReleaseExpiredReservations_Call) Run(run func(ctx context.Context, before time.Time)) *MockProductPostgres_ReleaseExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_ReleaseExpiredReservations_Call) Return(n int64, err error) *MockProductPostgres_ReleaseExpiredReservations_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_ReleaseExpiredReservations_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockProductPostgres_ReleaseExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStock provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) ReleaseStock(ctx context.Context, orderID int64) (int64, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStock")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_ReleaseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStock'
+type MockProductPostgres_ReleaseStock_Call struct {
+	*mock.Call
+}
+
+// ReleaseStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockProductPostgres_Expecter) ReleaseStock(ctx interface{}, orderID interface{}) *MockProductPostgres_ReleaseStock_Call {
+	return &MockProductPostgres_ReleaseStock_Call{Call: _e.mock.On("ReleaseStock", ctx, orderID)}
+}
+
+func (_c *MockProductPostgres_ReleaseStock_Call) Run(run func(ctx context.Context, orderID int64)) *MockProductPostgres_ReleaseStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_ReleaseStock_Call) Return(n int64, err error) *MockProductPostgres_ReleaseStock_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_ReleaseStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64) (int64, error)) *MockProductPostgres_ReleaseStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveStock provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, orderID, items, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []model.StockItem, time.Time) error); ok {
+		r0 = returnFunc(ctx, orderID, items, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductPostgres_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type MockProductPostgres_ReserveStock_Call struct {
+	*mock.Call
+}
+
+// ReserveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+//   - items []model.StockItem
+//   - expiresAt time.Time
+func (_e *MockProductPostgres_Expecter) ReserveStock(ctx interface{}, orderID interface{}, items interface{}, expiresAt interface{}) *MockProductPostgres_ReserveStock_Call {
+	return &MockProductPostgres_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, orderID, items, expiresAt)}
+}
+
+func (_c *MockProductPostgres_ReserveStock_Call) Run(run func(ctx context.Context, orderID int64, items []model.StockItem, expiresAt time.Time)) *MockProductPostgres_ReserveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []model.StockItem
+		if args[2] != nil {
+			arg2 = args[2].([]model.StockItem)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_ReserveStock_Call) Return(err error) *MockProductPostgres_ReserveStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductPostgres_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64, items []model.StockItem, expiresAt time.Time) error) *MockProductPostgres_ReserveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSneaker provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) RestoreSneaker(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSneaker")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductPostgres_RestoreSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSneaker'
+type MockProductPostgres_RestoreSneaker_Call struct {
+	*mock.Call
+}
+
+// RestoreSneaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockProductPostgres_Expecter) RestoreSneaker(ctx interface{}, id interface{}) *MockProductPostgres_RestoreSneaker_Call {
+	return &MockProductPostgres_RestoreSneaker_Call{Call: _e.mock.On("RestoreSneaker", ctx, id)}
+}
+
+func (_c *MockProductPostgres_RestoreSneaker_Call) Run(run func(ctx context.Context, id int64)) *MockProductPostgres_RestoreSneaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_RestoreSneaker_Call) Return(err error) *MockProductPostgres_RestoreSneaker_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductPostgres_RestoreSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockProductPostgres_RestoreSneaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSneakers provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) SearchSneakers(ctx context.Context, filter model.SneakerFilter) ([]*model.Sneaker, *model.SneakerCursor, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSneakers")
+	}
+
+	var r0 []*model.Sneaker
+	var r1 *model.SneakerCursor
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SneakerFilter) ([]*model.Sneaker, *model.SneakerCursor, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SneakerFilter) []*model.Sneaker); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Sneaker)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.SneakerFilter) *model.SneakerCursor); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.SneakerCursor)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, model.SneakerFilter) error); ok {
+		r2 = returnFunc(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockProductPostgres_SearchSneakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSneakers'
+type MockProductPostgres_SearchSneakers_Call struct {
+	*mock.Call
+}
+
+// SearchSneakers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.SneakerFilter
+func (_e *MockProductPostgres_Expecter) SearchSneakers(ctx interface{}, filter interface{}) *MockProductPostgres_SearchSneakers_Call {
+	return &MockProductPostgres_SearchSneakers_Call{Call: _e.mock.On("SearchSneakers", ctx, filter)}
+}
+
+func (_c *MockProductPostgres_SearchSneakers_Call) Run(run func(ctx context.Context, filter model.SneakerFilter)) *MockProductPostgres_SearchSneakers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.SneakerFilter
+		if args[1] != nil {
+			arg1 = args[1].(model.SneakerFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_SearchSneakers_Call) Return(sneakers []*model.Sneaker, sneakerCursor *model.SneakerCursor, err error) *MockProductPostgres_SearchSneakers_Call {
+	_c.Call.Return(sneakers, sneakerCursor, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_SearchSneakers_Call) RunAndReturn(run func(ctx context.Context, filter model.SneakerFilter) ([]*model.Sneaker, *model.SneakerCursor, error)) *MockProductPostgres_SearchSneakers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStock provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) SetStock(ctx context.Context, sneakerID int64, variantID int64, quantity int64) (*model.StockLevel, error) {
+	ret := _mock.Called(ctx, sneakerID, variantID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStock")
+	}
+
+	var r0 *model.StockLevel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (*model.StockLevel, error)); ok {
+		return returnFunc(ctx, sneakerID, variantID, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *model.StockLevel); ok {
+		r0 = returnFunc(ctx, sneakerID, variantID, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StockLevel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID, variantID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_SetStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStock'
+type MockProductPostgres_SetStock_Call struct {
+	*mock.Call
+}
+
+// SetStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sneakerID int64
+//   - variantID int64
+//   - quantity int64
+func (_e *MockProductPostgres_Expecter) SetStock(ctx interface{}, sneakerID interface{}, variantID interface{}, quantity interface{}) *MockProductPostgres_SetStock_Call {
+	return &MockProductPostgres_SetStock_Call{Call: _e.mock.On("SetStock", ctx, sneakerID, variantID, quantity)}
+}
+
+func (_c *MockProductPostgres_SetStock_Call) Run(run func(ctx context.Context, sneakerID int64, variantID int64, quantity int64)) *MockProductPostgres_SetStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_SetStock_Call) Return(stockLevel *model.StockLevel, err error) *MockProductPostgres_SetStock_Call {
+	_c.Call.Return(stockLevel, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_SetStock_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64, variantID int64, quantity int64) (*model.StockLevel, error)) *MockProductPostgres_SetStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateImageKey provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) UpdateImageKey(ctx context.Context, id int64, imageKey string) error {
+	ret := _mock.Called(ctx, id, imageKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateImageKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, id, imageKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductPostgres_UpdateImageKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImageKey'
+type MockProductPostgres_UpdateImageKey_Call struct {
+	*mock.Call
+}
+
+// UpdateImageKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - imageKey string
+func (_e *MockProductPostgres_Expecter) UpdateImageKey(ctx interface{}, id interface{}, imageKey interface{}) *MockProductPostgres_UpdateImageKey_Call {
+	return &MockProductPostgres_UpdateImageKey_Call{Call: _e.mock.On("UpdateImageKey", ctx, id, imageKey)}
+}
+
+func (_c *MockProductPostgres_UpdateImageKey_Call) Run(run func(ctx context.Context, id int64, imageKey string)) *MockProductPostgres_UpdateImageKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateImageKey_Call) Return(err error) *MockProductPostgres_UpdateImageKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateImageKey_Call) RunAndReturn(run func(ctx context.Context, id int64, imageKey string) error) *MockProductPostgres_UpdateImageKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSneaker provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error) {
+	ret := _mock.Called(ctx, id, upd, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSneaker")
+	}
+
+	var r0 *model.Sneaker
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, model.SneakerUpdate, int64) (*model.Sneaker, error)); ok {
+		return returnFunc(ctx, id, upd, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, model.SneakerUpdate, int64) *model.Sneaker); ok {
+		r0 = returnFunc(ctx, id, upd, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Sneaker)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, model.SneakerUpdate, int64) error); ok {
+		r1 = returnFunc(ctx, id, upd, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_UpdateSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSneaker'
+type MockProductPostgres_UpdateSneaker_Call struct {
+	*mock.Call
+}
+
+// UpdateSneaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - upd model.SneakerUpdate
+//   - expectedVersion int64
+func (_e *MockProductPostgres_Expecter) UpdateSneaker(ctx interface{}, id interface{}, upd interface{}, expectedVersion interface{}) *MockProductPostgres_UpdateSneaker_Call {
+	return &MockProductPostgres_UpdateSneaker_Call{Call: _e.mock.On("UpdateSneaker", ctx, id, upd, expectedVersion)}
+}
+
+func (_c *MockProductPostgres_UpdateSneaker_Call) Run(run func(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64)) *MockProductPostgres_UpdateSneaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 model.SneakerUpdate
+		if args[2] != nil {
+			arg2 = args[2].(model.SneakerUpdate)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateSneaker_Call) Return(sneaker *model.Sneaker, err error) *MockProductPostgres_UpdateSneaker_Call {
+	_c.Call.Return(sneaker, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error)) *MockProductPostgres_UpdateSneaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVariant provides a mock function for the type MockProductPostgres
+func (_mock *MockProductPostgres) UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, variant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVariant")
+	}
+
+	var r0 *model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) (*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, variant)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) *model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, variant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SneakerVariant) error); ok {
+		r1 = returnFunc(ctx, variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductPostgres_UpdateVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVariant'
+type MockProductPostgres_UpdateVariant_Call struct {
+	*mock.Call
+}
+
+// UpdateVariant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - variant *model.SneakerVariant
+func (_e *MockProductPostgres_Expecter) UpdateVariant(ctx interface{}, variant interface{}) *MockProductPostgres_UpdateVariant_Call {
+	return &MockProductPostgres_UpdateVariant_Call{Call: _e.mock.On("UpdateVariant", ctx, variant)}
+}
+
+func (_c *MockProductPostgres_UpdateVariant_Call) Run(run func(ctx context.Context, variant *model.SneakerVariant)) *MockProductPostgres_UpdateVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SneakerVariant
+		if args[1] != nil {
+			arg1 = args[1].(*model.SneakerVariant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateVariant_Call) Return(sneakerVariant *model.SneakerVariant, err error) *MockProductPostgres_UpdateVariant_Call {
+	_c.Call.Return(sneakerVariant, err)
+	return _c
+}
+
+func (_c *MockProductPostgres_UpdateVariant_Call) RunAndReturn(run func(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)) *MockProductPostgres_UpdateVariant_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strings"
 
+	"product_service/internal/lib/pagetoken"
 	"product_service/internal/model"
 	"product_service/internal/repository"
 	"time"
@@ -16,6 +17,19 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrInvalidPageToken — токен страницы повреждён или выдан для другого запроса.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidSneaker   = errors.New("invalid sneaker")
+)
+
+// Ограничения полей карточки товара (совпадают с размерами колонок).
+const (
+	maxTitleLen       = 255
+	maxDescriptionLen = 4000
+	maxAttributeLen   = 64
+)
+
 // validImageKeyRe соответствует ключам вида "products/<uuid>.<ext>" или "products/<num>.jpg".
 var validImageKeyRe = regexp.MustCompile(`^products/[a-zA-Z0-9_-]+\.[a-zA-Z0-9]+$`)
 
@@ -41,8 +55,29 @@ func productKeyL1(id int64) string {
 	return fmt.Sprintf("product:%d", id)
 }
 
-func productsKeyL2(limit, offset uint64) string {
-	return fmt.Sprintf("products:list:limit:%d:offset:%d", limit, offset)
+func productsKeyL2(limit uint64, afterID int64) string {
+	return fmt.Sprintf("products:list:limit:%d:after:%d", limit, afterID)
+}
+
+// decodeCursor разбирает токен страницы. Курсор должен быть выдан для
+// выборки с тем же отпечатком фильтра (пустым для общего списка).
+func decodeCursor(pageToken, filter string) (*model.SneakerCursor, error) {
+	if pageToken == "" {
+		return nil, nil
+	}
+	var cursor model.SneakerCursor
+	if err := pagetoken.Decode(pageToken, &cursor); err != nil || cursor.Filter != filter || cursor.ID <= 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &cursor, nil
+}
+
+func encodeCursor(cursor *model.SneakerCursor, filter string) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+	cursor.Filter = filter
+	return pagetoken.Encode(cursor)
 }
 
 func (s *Service) GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker, error) {
@@ -70,6 +105,13 @@ func (s *Service) GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker,
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	variants, err := s.repo.GetVariantsBySneakerID(ctx, id)
+	if err != nil {
+		log.Error("failed to get variants from db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dbSneaker.Variants = variants
+
 	//fill the cache
 	if setErr := s.cache.Set(ctx, key, dbSneaker, s.cacheTTL); setErr != nil {
 		log.Error("failed to set cache", slog.String("error", setErr.Error()))
@@ -78,17 +120,42 @@ func (s *Service) GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker,
 	return dbSneaker, nil
 }
 
+// DeleteSneaker снимает товар с продажи (мягкое удаление): он пропадает
+// из каталога и поиска, но остаётся доступен по ID для истории заказов.
 func (s *Service) DeleteSneaker(ctx context.Context, id int64) error {
 	const op = "app.Service.DeleteSneaker"
 	log := s.log.With(slog.String("op", op), slog.Int64("id", id))
 
 	// db
-	if err := s.repo.DeleteSneaker(ctx, id); err != nil {
-		log.Error("failed to delete from db", slog.String("error", err.Error()))
+	if err := s.repo.ArchiveSneaker(ctx, id); err != nil {
+		log.Error("failed to archive in db", slog.String("error", err.Error()))
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	log.Info("deleted from db")
+	log.Info("archived in db")
 
+	s.invalidateSneaker(ctx, log, id)
+
+	return nil
+}
+
+// RestoreSneaker возвращает архивный товар в каталог.
+func (s *Service) RestoreSneaker(ctx context.Context, id int64) error {
+	const op = "app.Service.RestoreSneaker"
+	log := s.log.With(slog.String("op", op), slog.Int64("id", id))
+
+	if err := s.repo.RestoreSneaker(ctx, id); err != nil {
+		log.Error("failed to restore in db", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("restored in db")
+
+	s.invalidateSneaker(ctx, log, id)
+
+	return nil
+}
+
+// invalidateSneaker сбрасывает карточку товара и все закэшированные страницы каталога.
+func (s *Service) invalidateSneaker(ctx context.Context, log *slog.Logger, id int64) {
 	// Invalidate L1 Cache
 	if err := s.cache.Delete(ctx, productKeyL1(id)); err != nil && !errors.Is(err, repository.ErrNotFound) {
 		log.Error("failed to invalidate L1 cache", slog.String("error", err.Error()))
@@ -98,8 +165,6 @@ func (s *Service) DeleteSneaker(ctx context.Context, id int64) error {
 	if err := s.cache.DeleteByPrefix(ctx, "products:list:"); err != nil {
 		log.Error("failed to invalidate L2 cache", slog.String("error", err.Error()))
 	}
-
-	return nil
 }
 
 func (s *Service) GetSneakersByIDs(ctx context.Context, ids []int64) ([]*model.Sneaker, error) {
@@ -115,35 +180,50 @@ func (s *Service) GetSneakersByIDs(ctx context.Context, ids []int64) ([]*model.S
 	return sneakers, nil
 }
 
-func (s *Service) GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*model.Sneaker, error) {
+func (s *Service) GetAllSneakers(ctx context.Context, limit uint64, pageToken string) (*model.SneakerPage, error) {
 	const op = "app.Service.GetAllSneakers"
 	log := s.log.With(slog.String("op", op))
 
+	after, err := decodeCursor(pageToken, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var afterID int64
+	if after != nil {
+		afterID = after.ID
+	}
+
 	// Cache
-	key := productsKeyL2(limit, offset)
-	var cachedSneakers []*model.Sneaker
-	err := s.cache.Get(ctx, key, &cachedSneakers)
+	key := productsKeyL2(limit, afterID)
+	var cachedPage model.SneakerPage
+	err = s.cache.Get(ctx, key, &cachedPage)
 	if err == nil {
 		log.Info("list cache hit")
-		return cachedSneakers, nil
+		return &cachedPage, nil
 	}
 	log.Info("list cache miss")
 
 	//db
-	dbSneakers, err := s.repo.GetAllSneakers(ctx, limit, offset)
+	dbSneakers, next, err := s.repo.GetAllSneakers(ctx, limit, afterID)
 	if err != nil {
 		log.Error("failed to get list from db", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	nextToken, err := encodeCursor(next, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: encode page token: %w", op, err)
+	}
+	page := &model.SneakerPage{Sneakers: dbSneakers, NextPageToken: nextToken}
+
 	// fill L2 Cache
 	if len(dbSneakers) > 0 {
-		if setErr := s.cache.Set(ctx, key, dbSneakers, s.cacheTTL/2); setErr != nil { // Кэш списков живет меньше
+		if setErr := s.cache.Set(ctx, key, page, s.cacheTTL/2); setErr != nil { // Кэш списков живет меньше
 			log.Error("failed to set list cache", slog.String("error", setErr.Error()))
 		}
 	}
 
-	return dbSneakers, nil
+	return page, nil
 }
 
 func (s *Service) AddSneaker(ctx context.Context, sneaker *model.Sneaker) (int64, error) {
@@ -168,6 +248,53 @@ func (s *Service) AddSneaker(ctx context.Context, sneaker *model.Sneaker) (int64
 	return id, nil
 }
 
+func validateSneakerUpdate(upd model.SneakerUpdate) error {
+	if upd.IsEmpty() {
+		return fmt.Errorf("%w: nothing to update", ErrInvalidSneaker)
+	}
+	if upd.Title != nil && (strings.TrimSpace(*upd.Title) == "" || len(*upd.Title) > maxTitleLen) {
+		return fmt.Errorf("%w: title must be 1..%d characters", ErrInvalidSneaker, maxTitleLen)
+	}
+	if upd.Price != nil && *upd.Price <= 0 {
+		return fmt.Errorf("%w: price must be positive", ErrInvalidSneaker)
+	}
+	if upd.Description != nil && len(*upd.Description) > maxDescriptionLen {
+		return fmt.Errorf("%w: description is longer than %d characters", ErrInvalidSneaker, maxDescriptionLen)
+	}
+	if upd.Brand != nil && len(*upd.Brand) > maxAttributeLen {
+		return fmt.Errorf("%w: brand is longer than %d characters", ErrInvalidSneaker, maxAttributeLen)
+	}
+	if upd.Category != nil && len(*upd.Category) > maxAttributeLen {
+		return fmt.Errorf("%w: category is longer than %d characters", ErrInvalidSneaker, maxAttributeLen)
+	}
+	return nil
+}
+
+// UpdateSneaker меняет только переданные поля карточки. Обновление проходит,
+// если версия в БД всё ещё равна expectedVersion, иначе — repository.ErrVersionConflict.
+func (s *Service) UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error) {
+	const op = "app.Service.UpdateSneaker"
+	log := s.log.With(slog.String("op", op), slog.Int64("id", id))
+
+	if err := validateSneakerUpdate(upd); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if expectedVersion <= 0 {
+		return nil, fmt.Errorf("%s: %w: version is required", op, ErrInvalidSneaker)
+	}
+
+	sneaker, err := s.repo.UpdateSneaker(ctx, id, upd, expectedVersion)
+	if err != nil {
+		log.Error("failed to update sneaker in db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("sneaker updated", slog.Int64("version", sneaker.Version))
+
+	s.invalidateSneaker(ctx, log, id)
+
+	return sneaker, nil
+}
+
 func (s *Service) GenerateUploadURL(ctx context.Context, originalFilename string, contentType string) (uploadURL string, fileKey string, err error) {
 	const op = "app.Service.GenerateUploadURL"
 	log := s.log.With(slog.String("op", op))
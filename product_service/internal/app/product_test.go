@@ -52,14 +52,17 @@ func TestGetSneakerByID_CacheMiss(t *testing.T) {
 	svc := newTestService(repo, cache, fs)
 
 	sneaker := &model.Sneaker{Id: 1, Title: "Nike", Price: 10000}
+	variants := []*model.SneakerVariant{{Id: 7, SneakerId: 1, SKU: "NK-42-BLK", Size: "42", Color: "black"}}
 
 	cache.On("Get", mock.Anything, "product:1", mock.Anything).Return(repository.ErrNotFound)
 	repo.On("GetSneakerByID", mock.Anything, int64(1)).Return(sneaker, nil)
+	repo.On("GetVariantsBySneakerID", mock.Anything, int64(1)).Return(variants, nil)
 	cache.On("Set", mock.Anything, "product:1", sneaker, 10*time.Minute).Return(nil)
 
 	result, err := svc.GetSneakerByID(context.Background(), 1)
 	require.NoError(t, err)
 	assert.Equal(t, "Nike", result.Title)
+	assert.Equal(t, variants, result.Variants)
 	repo.AssertExpectations(t)
 }
 
@@ -116,13 +119,110 @@ func TestDeleteSneaker_Success(t *testing.T) {
 	fs := new(mocks.MockFileStore)
 	svc := newTestService(repo, cache, fs)
 
-	repo.On("DeleteSneaker", mock.Anything, int64(1)).Return(nil)
+	repo.On("ArchiveSneaker", mock.Anything, int64(1)).Return(nil)
 	cache.On("Delete", mock.Anything, "product:1").Return(nil)
 	cache.On("DeleteByPrefix", mock.Anything, "products:list:").Return(nil)
 
 	err := svc.DeleteSneaker(context.Background(), 1)
 	require.NoError(t, err)
 	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestDeleteSneaker_NotFound(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	repo.On("ArchiveSneaker", mock.Anything, int64(1)).Return(repository.ErrNotFound)
+
+	err := svc.DeleteSneaker(context.Background(), 1)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	cache.AssertNotCalled(t, "DeleteByPrefix", mock.Anything, mock.Anything)
+}
+
+// --- RestoreSneaker ---
+
+func TestRestoreSneaker_Success(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	repo.On("RestoreSneaker", mock.Anything, int64(1)).Return(nil)
+	cache.On("Delete", mock.Anything, "product:1").Return(nil)
+	cache.On("DeleteByPrefix", mock.Anything, "products:list:").Return(nil)
+
+	err := svc.RestoreSneaker(context.Background(), 1)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+// --- UpdateSneaker ---
+
+func TestUpdateSneaker_Success(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	price := int64(1500000)
+	upd := model.SneakerUpdate{Price: &price}
+	updated := &model.Sneaker{Id: 1, Title: "Nike", Price: price, Version: 3}
+
+	repo.On("UpdateSneaker", mock.Anything, int64(1), upd, int64(2)).Return(updated, nil)
+	cache.On("Delete", mock.Anything, "product:1").Return(nil)
+	cache.On("DeleteByPrefix", mock.Anything, "products:list:").Return(nil)
+
+	got, err := svc.UpdateSneaker(context.Background(), 1, upd, 2)
+	require.NoError(t, err)
+	assert.Equal(t, updated, got)
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestUpdateSneaker_VersionConflict(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	title := "Nike Air"
+	upd := model.SneakerUpdate{Title: &title}
+	repo.On("UpdateSneaker", mock.Anything, int64(1), upd, int64(2)).Return(nil, repository.ErrVersionConflict)
+
+	_, err := svc.UpdateSneaker(context.Background(), 1, upd, 2)
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	cache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUpdateSneaker_Invalid(t *testing.T) {
+	empty := ""
+	negative := int64(-1)
+
+	tests := []struct {
+		name    string
+		upd     model.SneakerUpdate
+		version int64
+	}{
+		{name: "no fields", upd: model.SneakerUpdate{}, version: 1},
+		{name: "empty title", upd: model.SneakerUpdate{Title: &empty}, version: 1},
+		{name: "negative price", upd: model.SneakerUpdate{Price: &negative}, version: 1},
+		{name: "missing version", upd: model.SneakerUpdate{Description: &empty}, version: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockProductPostgres)
+			svc := newTestService(repo, new(mocks.MockProductCache), new(mocks.MockFileStore))
+
+			_, err := svc.UpdateSneaker(context.Background(), 1, tt.upd, tt.version)
+			assert.ErrorIs(t, err, ErrInvalidSneaker)
+			repo.AssertNotCalled(t, "UpdateSneaker", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
 }
 
 // --- GetAllSneakers ---
@@ -134,13 +234,49 @@ func TestGetAllSneakers_CacheMiss(t *testing.T) {
 	svc := newTestService(repo, cache, fs)
 
 	sneakers := []*model.Sneaker{{Id: 1, Title: "Nike"}}
+	cache.On("Get", mock.Anything, "products:list:limit:20:after:0", mock.Anything).Return(repository.ErrNotFound)
+	repo.On("GetAllSneakers", mock.Anything, uint64(20), int64(0)).Return(sneakers, (*model.SneakerCursor)(nil), nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, 5*time.Minute).Return(nil)
+
+	page, err := svc.GetAllSneakers(context.Background(), 20, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Sneakers, 1)
+	assert.Empty(t, page.NextPageToken)
+}
+
+func TestGetAllSneakers_NextPage(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	first := []*model.Sneaker{{Id: 1}, {Id: 2}}
+	second := []*model.Sneaker{{Id: 3}}
 	cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(repository.ErrNotFound)
-	repo.On("GetAllSneakers", mock.Anything, uint64(20), uint64(0)).Return(sneakers, nil)
-	cache.On("Set", mock.Anything, mock.Anything, sneakers, 5*time.Minute).Return(nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	repo.On("GetAllSneakers", mock.Anything, uint64(2), int64(0)).Return(first, &model.SneakerCursor{ID: 2}, nil)
+	repo.On("GetAllSneakers", mock.Anything, uint64(2), int64(2)).Return(second, (*model.SneakerCursor)(nil), nil)
+
+	page, err := svc.GetAllSneakers(context.Background(), 2, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPageToken)
 
-	result, err := svc.GetAllSneakers(context.Background(), 20, 0)
+	page, err = svc.GetAllSneakers(context.Background(), 2, page.NextPageToken)
 	require.NoError(t, err)
-	assert.Len(t, result, 1)
+	assert.Equal(t, second, page.Sneakers)
+	assert.Empty(t, page.NextPageToken)
+	repo.AssertExpectations(t)
+}
+
+func TestGetAllSneakers_InvalidPageToken(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	_, err := svc.GetAllSneakers(context.Background(), 20, "garbage!")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+	repo.AssertNotCalled(t, "GetAllSneakers", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // --- UpdateProductImage ---
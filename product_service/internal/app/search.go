@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"product_service/internal/model"
+)
+
+var ErrInvalidSearch = errors.New("invalid search request")
+
+// maxSearchQueryLen ограничивает длину поискового запроса.
+const maxSearchQueryLen = 256
+
+// normalizeFilter приводит фильтр к каноническому виду, чтобы одинаковые
+// по смыслу запросы попадали в один и тот же ключ кэша.
+func normalizeFilter(f model.SneakerFilter) (model.SneakerFilter, error) {
+	f.Query = strings.Join(strings.Fields(f.Query), " ")
+	if len(f.Query) > maxSearchQueryLen {
+		return f, fmt.Errorf("%w: query is too long", ErrInvalidSearch)
+	}
+	if f.MinPrice < 0 || f.MaxPrice < 0 {
+		return f, fmt.Errorf("%w: price must not be negative", ErrInvalidSearch)
+	}
+	if f.MaxPrice > 0 && f.MinPrice > f.MaxPrice {
+		return f, fmt.Errorf("%w: min price is greater than max price", ErrInvalidSearch)
+	}
+
+	if f.Sort == "" {
+		f.Sort = model.SortRelevance
+	}
+	if !f.Sort.IsValid() {
+		return f, fmt.Errorf("%w: unknown sort %q", ErrInvalidSearch, f.Sort)
+	}
+
+	f.Brands = normalizeValues(f.Brands)
+	f.Categories = normalizeValues(f.Categories)
+	return f, nil
+}
+
+func normalizeValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// filterFingerprint — отпечаток нормализованного фильтра без учёта страницы.
+func filterFingerprint(f model.SneakerFilter) string {
+	raw := fmt.Sprintf("q=%s|min=%d|max=%d|brands=%s|categories=%s|sort=%s",
+		strings.ToLower(f.Query), f.MinPrice, f.MaxPrice,
+		strings.Join(f.Brands, ","), strings.Join(f.Categories, ","), f.Sort)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:16])
+}
+
+// searchKeyL2 кладёт результаты поиска под общий префикс списков,
+// поэтому любые изменения каталога сбрасывают и их.
+func searchKeyL2(fingerprint string, limit uint64, pageToken string) string {
+	return fmt.Sprintf("products:list:search:%s:limit:%d:after:%s", fingerprint, limit, pageToken)
+}
+
+func (s *Service) SearchSneakers(ctx context.Context, filter model.SneakerFilter, pageToken string) (*model.SneakerPage, error) {
+	const op = "app.Service.SearchSneakers"
+	log := s.log.With(slog.String("op", op))
+
+	filter, err := normalizeFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fingerprint := filterFingerprint(filter)
+	filter.After, err = decodeCursor(pageToken, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Cache
+	key := searchKeyL2(fingerprint, filter.Limit, pageToken)
+	var cachedPage model.SneakerPage
+	if err := s.cache.Get(ctx, key, &cachedPage); err == nil {
+		log.Info("search cache hit")
+		return &cachedPage, nil
+	}
+	log.Info("search cache miss")
+
+	//db
+	dbSneakers, next, err := s.repo.SearchSneakers(ctx, filter)
+	if err != nil {
+		log.Error("failed to search in db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	nextToken, err := encodeCursor(next, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("%s: encode page token: %w", op, err)
+	}
+	page := &model.SneakerPage{Sneakers: dbSneakers, NextPageToken: nextToken}
+
+	// fill L2 Cache
+	if len(dbSneakers) > 0 {
+		if setErr := s.cache.Set(ctx, key, page, s.cacheTTL/2); setErr != nil {
+			log.Error("failed to set search cache", slog.String("error", setErr.Error()))
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"product_service/internal/app/mocks"
+	"product_service/internal/model"
+	"product_service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSneakers_CacheMiss(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	sneakers := []*model.Sneaker{{Id: 7, Title: "Кроссовки Air Jordan 11", Brand: "Nike"}}
+	expected := model.SneakerFilter{
+		Query:  "air jordan",
+		Brands: []string{"Nike"},
+		Sort:   model.SortRelevance,
+		Limit:  20,
+	}
+
+	cache.On("Get", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return len(key) > len("products:list:search:")
+	}), mock.Anything).Return(repository.ErrNotFound)
+	repo.On("SearchSneakers", mock.Anything, expected).Return(sneakers, (*model.SneakerCursor)(nil), nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, 5*time.Minute).Return(nil)
+
+	page, err := svc.SearchSneakers(context.Background(), model.SneakerFilter{
+		Query:  "  air   jordan ",
+		Brands: []string{"Nike", " ", "Nike"},
+		Limit:  20,
+	}, "")
+	require.NoError(t, err)
+	assert.Equal(t, sneakers, page.Sneakers)
+	repo.AssertExpectations(t)
+}
+
+func TestSearchSneakers_InvalidPriceRange(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	_, err := svc.SearchSneakers(context.Background(), model.SneakerFilter{MinPrice: 500000, MaxPrice: 100000}, "")
+	assert.True(t, errors.Is(err, ErrInvalidSearch))
+	repo.AssertNotCalled(t, "SearchSneakers", mock.Anything, mock.Anything)
+}
+
+func TestSearchSneakers_UnknownSort(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	_, err := svc.SearchSneakers(context.Background(), model.SneakerFilter{Sort: "cheapest"}, "")
+	assert.True(t, errors.Is(err, ErrInvalidSearch))
+}
+
+func TestSearchSneakers_RejectsTokenFromAnotherQuery(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(repository.ErrNotFound)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	repo.On("SearchSneakers", mock.Anything, mock.Anything).
+		Return([]*model.Sneaker{{Id: 1, Price: 500000}}, &model.SneakerCursor{ID: 1, Price: 500000}, nil)
+
+	page, err := svc.SearchSneakers(context.Background(), model.SneakerFilter{Query: "nike", Sort: model.SortPriceAsc, Limit: 1}, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPageToken)
+
+	_, err = svc.SearchSneakers(context.Background(), model.SneakerFilter{Query: "puma", Sort: model.SortPriceAsc, Limit: 1}, page.NextPageToken)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestSearchKeyL2_SharesListPrefix(t *testing.T) {
+	a, err := normalizeFilter(model.SneakerFilter{Query: "Air Jordan", Brands: []string{"Puma", "Nike"}})
+	require.NoError(t, err)
+	b, err := normalizeFilter(model.SneakerFilter{Query: "air  jordan", Brands: []string{"Nike", "Puma"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, filterFingerprint(a), filterFingerprint(b))
+	assert.Contains(t, searchKeyL2(filterFingerprint(a), 20, ""), "products:list:")
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"product_service/internal/model"
+	"product_service/internal/repository"
+)
+
+var ErrInvalidVariant = errors.New("invalid variant")
+
+func validateVariant(v *model.SneakerVariant) error {
+	if v.SKU == "" {
+		return fmt.Errorf("%w: sku is required", ErrInvalidVariant)
+	}
+	if v.Size == "" {
+		return fmt.Errorf("%w: size is required", ErrInvalidVariant)
+	}
+	if v.Color == "" {
+		return fmt.Errorf("%w: color is required", ErrInvalidVariant)
+	}
+	if v.Price < 0 {
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidVariant)
+	}
+	return nil
+}
+
+func (s *Service) CreateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	const op = "app.Service.CreateVariant"
+	log := s.log.With(slog.String("op", op), slog.Int64("sneakerID", variant.SneakerId))
+
+	if err := validateVariant(variant); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.repo.CreateVariant(ctx, variant)
+	if err != nil {
+		log.Error("failed to create variant in db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	variant.Id = id
+	log.Info("variant created", slog.Int64("id", id))
+
+	// Варианты кэшируются вместе с карточкой товара
+	s.invalidateProduct(ctx, log, variant.SneakerId)
+
+	return variant, nil
+}
+
+func (s *Service) ListVariants(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error) {
+	const op = "app.Service.ListVariants"
+	log := s.log.With(slog.String("op", op), slog.Int64("sneakerID", sneakerID))
+
+	variants, err := s.repo.GetVariantsBySneakerID(ctx, sneakerID)
+	if err != nil {
+		log.Error("failed to list variants from db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return variants, nil
+}
+
+func (s *Service) UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	const op = "app.Service.UpdateVariant"
+	log := s.log.With(slog.String("op", op), slog.Int64("id", variant.Id))
+
+	if err := validateVariant(variant); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.repo.UpdateVariant(ctx, variant)
+	if err != nil {
+		log.Error("failed to update variant in db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("variant updated")
+
+	s.invalidateProduct(ctx, log, updated.SneakerId)
+
+	return updated, nil
+}
+
+func (s *Service) GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error) {
+	const op = "app.Service.GetVariantsByIDs"
+	log := s.log.With(slog.String("op", op))
+
+	variants, err := s.repo.GetVariantsByIDs(ctx, ids)
+	if err != nil {
+		log.Error("failed to get variants by ids from db", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return variants, nil
+}
+
+// invalidateProduct сбрасывает L1-кэш карточки товара.
+func (s *Service) invalidateProduct(ctx context.Context, log *slog.Logger, sneakerID int64) {
+	if err := s.cache.Delete(ctx, productKeyL1(sneakerID)); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		log.Warn("failed to invalidate L1 cache for product", slog.String("error", err.Error()))
+	}
+}
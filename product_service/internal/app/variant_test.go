@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"product_service/internal/app/mocks"
+	"product_service/internal/model"
+	"product_service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- CreateVariant ---
+
+func TestCreateVariant_Success(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	variant := &model.SneakerVariant{SneakerId: 1, SKU: "NK-42-BLK", Size: "42", Color: "black"}
+
+	repo.On("CreateVariant", mock.Anything, variant).Return(int64(5), nil)
+	cache.On("Delete", mock.Anything, "product:1").Return(nil)
+
+	result, err := svc.CreateVariant(context.Background(), variant)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Id)
+	repo.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestCreateVariant_Validation(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	tests := []struct {
+		name    string
+		variant *model.SneakerVariant
+	}{
+		{"empty sku", &model.SneakerVariant{SneakerId: 1, Size: "42", Color: "black"}},
+		{"empty size", &model.SneakerVariant{SneakerId: 1, SKU: "A", Color: "black"}},
+		{"empty color", &model.SneakerVariant{SneakerId: 1, SKU: "A", Size: "42"}},
+		{"negative price", &model.SneakerVariant{SneakerId: 1, SKU: "A", Size: "42", Color: "black", Price: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateVariant(context.Background(), tt.variant)
+			assert.ErrorIs(t, err, ErrInvalidVariant)
+		})
+	}
+	repo.AssertNotCalled(t, "CreateVariant")
+}
+
+func TestCreateVariant_Duplicate(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	variant := &model.SneakerVariant{SneakerId: 1, SKU: "NK-42-BLK", Size: "42", Color: "black"}
+	repo.On("CreateVariant", mock.Anything, variant).Return(int64(0), repository.ErrAlreadyExists)
+
+	_, err := svc.CreateVariant(context.Background(), variant)
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+	cache.AssertNotCalled(t, "Delete")
+}
+
+// --- UpdateVariant ---
+
+func TestUpdateVariant_InvalidatesProduct(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	in := &model.SneakerVariant{Id: 5, SneakerId: 3, SKU: "NK-43-BLK", Size: "43", Color: "black", Price: 12000}
+	updated := &model.SneakerVariant{Id: 5, SneakerId: 3, SKU: "NK-43-BLK", Size: "43", Color: "black", Price: 12000}
+
+	repo.On("UpdateVariant", mock.Anything, in).Return(updated, nil)
+	cache.On("Delete", mock.Anything, "product:3").Return(nil)
+
+	result, err := svc.UpdateVariant(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.SneakerId)
+	cache.AssertExpectations(t)
+}
+
+func TestUpdateVariant_NotFound(t *testing.T) {
+	repo := new(mocks.MockProductPostgres)
+	cache := new(mocks.MockProductCache)
+	fs := new(mocks.MockFileStore)
+	svc := newTestService(repo, cache, fs)
+
+	// Вариант 99 не существует или принадлежит другому товару.
+	in := &model.SneakerVariant{Id: 99, SneakerId: 3, SKU: "X", Size: "42", Color: "red"}
+	repo.On("UpdateVariant", mock.Anything, in).Return((*model.SneakerVariant)(nil), repository.ErrNotFound)
+
+	_, err := svc.UpdateVariant(context.Background(), in)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestSneakerVariant_EffectivePrice(t *testing.T) {
+	assert.Equal(t, int64(10000), (&model.SneakerVariant{}).EffectivePrice(10000))
+	assert.Equal(t, int64(12000), (&model.SneakerVariant{Price: 12000}).EffectivePrice(10000))
+}
@@ -15,6 +15,8 @@ type Config struct {
 	S3       S3Config      `yaml:"s3"`
 	Redis    RedisConfig   `yaml:"redis"`
 	CacheTTL time.Duration `yaml:"cache_ttl" env:"CACHE_TTL" env-default:"10m"`
+	// ReservationSweepInterval — как часто снимать просроченные резервы остатков.
+	ReservationSweepInterval time.Duration `yaml:"reservation_sweep_interval" env:"RESERVATION_SWEEP_INTERVAL" env-default:"1m"`
 }
 
 type RedisConfig struct {
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 	"product_service/internal/model"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -104,6 +105,131 @@ func (_c *MockApp_AddSneaker_Call) RunAndReturn(run func(ctx context.Context, sn
 	return _c
 }
 
+// CommitStock provides a mock function for the type MockApp
+func (_mock *MockApp) CommitStock(ctx context.Context, orderID int64) error {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockApp_CommitStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitStock'
+type MockApp_CommitStock_Call struct {
+	*mock.Call
+}
+
+// CommitStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockApp_Expecter) CommitStock(ctx interface{}, orderID interface{}) *MockApp_CommitStock_Call {
+	return &MockApp_CommitStock_Call{Call: _e.mock.On("CommitStock", ctx, orderID)}
+}
+
+func (_c *MockApp_CommitStock_Call) Run(run func(ctx context.Context, orderID int64)) *MockApp_CommitStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_CommitStock_Call) Return(err error) *MockApp_CommitStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockApp_CommitStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64) error) *MockApp_CommitStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVariant provides a mock function for the type MockApp
+func (_mock *MockApp) CreateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, variant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVariant")
+	}
+
+	var r0 *model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) (*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, variant)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) *model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, variant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SneakerVariant) error); ok {
+		r1 = returnFunc(ctx, variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_CreateVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVariant'
+type MockApp_CreateVariant_Call struct {
+	*mock.Call
+}
+
+// CreateVariant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - variant *model.SneakerVariant
+func (_e *MockApp_Expecter) CreateVariant(ctx interface{}, variant interface{}) *MockApp_CreateVariant_Call {
+	return &MockApp_CreateVariant_Call{Call: _e.mock.On("CreateVariant", ctx, variant)}
+}
+
+func (_c *MockApp_CreateVariant_Call) Run(run func(ctx context.Context, variant *model.SneakerVariant)) *MockApp_CreateVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SneakerVariant
+		if args[1] != nil {
+			arg1 = args[1].(*model.SneakerVariant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_CreateVariant_Call) Return(sneakerVariant *model.SneakerVariant, err error) *MockApp_CreateVariant_Call {
+	_c.Call.Return(sneakerVariant, err)
+	return _c
+}
+
+func (_c *MockApp_CreateVariant_Call) RunAndReturn(run func(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)) *MockApp_CreateVariant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteSneaker provides a mock function for the type MockApp
 func (_mock *MockApp) DeleteSneaker(ctx context.Context, id int64) error {
 	ret := _mock.Called(ctx, id)
@@ -240,27 +366,27 @@ func (_c *MockApp_GenerateUploadURL_Call) RunAndReturn(run func(ctx context.Cont
 }
 
 // GetAllSneakers provides a mock function for the type MockApp
-func (_mock *MockApp) GetAllSneakers(ctx context.Context, limit uint64, offset uint64) ([]*model.Sneaker, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockApp) GetAllSneakers(ctx context.Context, limit uint64, pageToken string) (*model.SneakerPage, error) {
+	ret := _mock.Called(ctx, limit, pageToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllSneakers")
 	}
 
-	var r0 []*model.Sneaker
+	var r0 *model.SneakerPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]*model.Sneaker, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (*model.SneakerPage, error)); ok {
+		return returnFunc(ctx, limit, pageToken)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) []*model.Sneaker); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) *model.SneakerPage); ok {
+		r0 = returnFunc(ctx, limit, pageToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Sneaker)
+			r0 = ret.Get(0).(*model.SneakerPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, limit, pageToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -275,12 +401,12 @@ type MockApp_GetAllSneakers_Call struct {
 // GetAllSneakers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - limit uint64
-//   - offset uint64
-func (_e *MockApp_Expecter) GetAllSneakers(ctx interface{}, limit interface{}, offset interface{}) *MockApp_GetAllSneakers_Call {
-	return &MockApp_GetAllSneakers_Call{Call: _e.mock.On("GetAllSneakers", ctx, limit, offset)}
+//   - pageToken string
+func (_e *MockApp_Expecter) GetAllSneakers(ctx interface{}, limit interface{}, pageToken interface{}) *MockApp_GetAllSneakers_Call {
+	return &MockApp_GetAllSneakers_Call{Call: _e.mock.On("GetAllSneakers", ctx, limit, pageToken)}
 }
 
-func (_c *MockApp_GetAllSneakers_Call) Run(run func(ctx context.Context, limit uint64, offset uint64)) *MockApp_GetAllSneakers_Call {
+func (_c *MockApp_GetAllSneakers_Call) Run(run func(ctx context.Context, limit uint64, pageToken string)) *MockApp_GetAllSneakers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -290,9 +416,9 @@ func (_c *MockApp_GetAllSneakers_Call) Run(run func(ctx context.Context, limit u
 		if args[1] != nil {
 			arg1 = args[1].(uint64)
 		}
-		var arg2 uint64
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(uint64)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -303,12 +429,12 @@ func (_c *MockApp_GetAllSneakers_Call) Run(run func(ctx context.Context, limit u
 	return _c
 }
 
-func (_c *MockApp_GetAllSneakers_Call) Return(sneakers []*model.Sneaker, err error) *MockApp_GetAllSneakers_Call {
-	_c.Call.Return(sneakers, err)
+func (_c *MockApp_GetAllSneakers_Call) Return(sneakerPage *model.SneakerPage, err error) *MockApp_GetAllSneakers_Call {
+	_c.Call.Return(sneakerPage, err)
 	return _c
 }
 
-func (_c *MockApp_GetAllSneakers_Call) RunAndReturn(run func(ctx context.Context, limit uint64, offset uint64) ([]*model.Sneaker, error)) *MockApp_GetAllSneakers_Call {
+func (_c *MockApp_GetAllSneakers_Call) RunAndReturn(run func(ctx context.Context, limit uint64, pageToken string) (*model.SneakerPage, error)) *MockApp_GetAllSneakers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -449,37 +575,48 @@ func (_c *MockApp_GetSneakersByIDs_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
-// UpdateProductImage provides a mock function for the type MockApp
-func (_mock *MockApp) UpdateProductImage(ctx context.Context, productID int64, imageKey string) error {
-	ret := _mock.Called(ctx, productID, imageKey)
+// GetStock provides a mock function for the type MockApp
+func (_mock *MockApp) GetStock(ctx context.Context, sneakerID int64, variantID int64) (*model.StockLevel, error) {
+	ret := _mock.Called(ctx, sneakerID, variantID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProductImage")
+		panic("no return value specified for GetStock")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
-		r0 = returnFunc(ctx, productID, imageKey)
+	var r0 *model.StockLevel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*model.StockLevel, error)); ok {
+		return returnFunc(ctx, sneakerID, variantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *model.StockLevel); ok {
+		r0 = returnFunc(ctx, sneakerID, variantID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StockLevel)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID, variantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockApp_UpdateProductImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProductImage'
-type MockApp_UpdateProductImage_Call struct {
+// MockApp_GetStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStock'
+type MockApp_GetStock_Call struct {
 	*mock.Call
 }
 
-// UpdateProductImage is a helper method to define mock.On call
+// GetStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - productID int64
-//   - imageKey string
-func (_e *MockApp_Expecter) UpdateProductImage(ctx interface{}, productID interface{}, imageKey interface{}) *MockApp_UpdateProductImage_Call {
-	return &MockApp_UpdateProductImage_Call{Call: _e.mock.On("UpdateProductImage", ctx, productID, imageKey)}
+//   - sneakerID int64
+//   - variantID int64
+func (_e *MockApp_Expecter) GetStock(ctx interface{}, sneakerID interface{}, variantID interface{}) *MockApp_GetStock_Call {
+	return &MockApp_GetStock_Call{Call: _e.mock.On("GetStock", ctx, sneakerID, variantID)}
 }
 
-func (_c *MockApp_UpdateProductImage_Call) Run(run func(ctx context.Context, productID int64, imageKey string)) *MockApp_UpdateProductImage_Call {
+func (_c *MockApp_GetStock_Call) Run(run func(ctx context.Context, sneakerID int64, variantID int64)) *MockApp_GetStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -489,9 +626,9 @@ func (_c *MockApp_UpdateProductImage_Call) Run(run func(ctx context.Context, pro
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 string
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -502,12 +639,696 @@ func (_c *MockApp_UpdateProductImage_Call) Run(run func(ctx context.Context, pro
 	return _c
 }
 
-func (_c *MockApp_UpdateProductImage_Call) Return(err error) *MockApp_UpdateProductImage_Call {
-	_c.Call.Return(err)
+func (_c *MockApp_GetStock_Call) Return(stockLevel *model.StockLevel, err error) *MockApp_GetStock_Call {
+	_c.Call.Return(stockLevel, err)
 	return _c
 }
 
-func (_c *MockApp_UpdateProductImage_Call) RunAndReturn(run func(ctx context.Context, productID int64, imageKey string) error) *MockApp_UpdateProductImage_Call {
+func (_c *MockApp_GetStock_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64, variantID int64) (*model.StockLevel, error)) *MockApp_GetStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVariantsByIDs provides a mock function for the type MockApp
+func (_mock *MockApp) GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVariantsByIDs")
+	}
+
+	var r0 []*model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) ([]*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) []*model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_GetVariantsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVariantsByIDs'
+type MockApp_GetVariantsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetVariantsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *MockApp_Expecter) GetVariantsByIDs(ctx interface{}, ids interface{}) *MockApp_GetVariantsByIDs_Call {
+	return &MockApp_GetVariantsByIDs_Call{Call: _e.mock.On("GetVariantsByIDs", ctx, ids)}
+}
+
+func (_c *MockApp_GetVariantsByIDs_Call) Run(run func(ctx context.Context, ids []int64)) *MockApp_GetVariantsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int64
+		if args[1] != nil {
+			arg1 = args[1].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_GetVariantsByIDs_Call) Return(sneakerVariants []*model.SneakerVariant, err error) *MockApp_GetVariantsByIDs_Call {
+	_c.Call.Return(sneakerVariants, err)
+	return _c
+}
+
+func (_c *MockApp_GetVariantsByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error)) *MockApp_GetVariantsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVariants provides a mock function for the type MockApp
+func (_mock *MockApp) ListVariants(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, sneakerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVariants")
+	}
+
+	var r0 []*model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, sneakerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []*model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, sneakerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_ListVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVariants'
+type MockApp_ListVariants_Call struct {
+	*mock.Call
+}
+
+// ListVariants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sneakerID int64
+func (_e *MockApp_Expecter) ListVariants(ctx interface{}, sneakerID interface{}) *MockApp_ListVariants_Call {
+	return &MockApp_ListVariants_Call{Call: _e.mock.On("ListVariants", ctx, sneakerID)}
+}
+
+func (_c *MockApp_ListVariants_Call) Run(run func(ctx context.Context, sneakerID int64)) *MockApp_ListVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_ListVariants_Call) Return(sneakerVariants []*model.SneakerVariant, err error) *MockApp_ListVariants_Call {
+	_c.Call.Return(sneakerVariants, err)
+	return _c
+}
+
+func (_c *MockApp_ListVariants_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error)) *MockApp_ListVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStock provides a mock function for the type MockApp
+func (_mock *MockApp) ReleaseStock(ctx context.Context, orderID int64) error {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockApp_ReleaseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStock'
+type MockApp_ReleaseStock_Call struct {
+	*mock.Call
+}
+
+// ReleaseStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+func (_e *MockApp_Expecter) ReleaseStock(ctx interface{}, orderID interface{}) *MockApp_ReleaseStock_Call {
+	return &MockApp_ReleaseStock_Call{Call: _e.mock.On("ReleaseStock", ctx, orderID)}
+}
+
+func (_c *MockApp_ReleaseStock_Call) Run(run func(ctx context.Context, orderID int64)) *MockApp_ReleaseStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_ReleaseStock_Call) Return(err error) *MockApp_ReleaseStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockApp_ReleaseStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64) error) *MockApp_ReleaseStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveStock provides a mock function for the type MockApp
+func (_mock *MockApp) ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, ttl time.Duration) error {
+	ret := _mock.Called(ctx, orderID, items, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []model.StockItem, time.Duration) error); ok {
+		r0 = returnFunc(ctx, orderID, items, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockApp_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type MockApp_ReserveStock_Call struct {
+	*mock.Call
+}
+
+// ReserveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int64
+//   - items []model.StockItem
+//   - ttl time.Duration
+func (_e *MockApp_Expecter) ReserveStock(ctx interface{}, orderID interface{}, items interface{}, ttl interface{}) *MockApp_ReserveStock_Call {
+	return &MockApp_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, orderID, items, ttl)}
+}
+
+func (_c *MockApp_ReserveStock_Call) Run(run func(ctx context.Context, orderID int64, items []model.StockItem, ttl time.Duration)) *MockApp_ReserveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []model.StockItem
+		if args[2] != nil {
+			arg2 = args[2].([]model.StockItem)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_ReserveStock_Call) Return(err error) *MockApp_ReserveStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockApp_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, orderID int64, items []model.StockItem, ttl time.Duration) error) *MockApp_ReserveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSneaker provides a mock function for the type MockApp
+func (_mock *MockApp) RestoreSneaker(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSneaker")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockApp_RestoreSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSneaker'
+type MockApp_RestoreSneaker_Call struct {
+	*mock.Call
+}
+
+// RestoreSneaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockApp_Expecter) RestoreSneaker(ctx interface{}, id interface{}) *MockApp_RestoreSneaker_Call {
+	return &MockApp_RestoreSneaker_Call{Call: _e.mock.On("RestoreSneaker", ctx, id)}
+}
+
+func (_c *MockApp_RestoreSneaker_Call) Run(run func(ctx context.Context, id int64)) *MockApp_RestoreSneaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_RestoreSneaker_Call) Return(err error) *MockApp_RestoreSneaker_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockApp_RestoreSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockApp_RestoreSneaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSneakers provides a mock function for the type MockApp
+func (_mock *MockApp) SearchSneakers(ctx context.Context, filter model.SneakerFilter, pageToken string) (*model.SneakerPage, error) {
+	ret := _mock.Called(ctx, filter, pageToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSneakers")
+	}
+
+	var r0 *model.SneakerPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SneakerFilter, string) (*model.SneakerPage, error)); ok {
+		return returnFunc(ctx, filter, pageToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SneakerFilter, string) *model.SneakerPage); ok {
+		r0 = returnFunc(ctx, filter, pageToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SneakerPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.SneakerFilter, string) error); ok {
+		r1 = returnFunc(ctx, filter, pageToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_SearchSneakers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSneakers'
+type MockApp_SearchSneakers_Call struct {
+	*mock.Call
+}
+
+// SearchSneakers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.SneakerFilter
+//   - pageToken string
+func (_e *MockApp_Expecter) SearchSneakers(ctx interface{}, filter interface{}, pageToken interface{}) *MockApp_SearchSneakers_Call {
+	return &MockApp_SearchSneakers_Call{Call: _e.mock.On("SearchSneakers", ctx, filter, pageToken)}
+}
+
+func (_c *MockApp_SearchSneakers_Call) Run(run func(ctx context.Context, filter model.SneakerFilter, pageToken string)) *MockApp_SearchSneakers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.SneakerFilter
+		if args[1] != nil {
+			arg1 = args[1].(model.SneakerFilter)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_SearchSneakers_Call) Return(sneakerPage *model.SneakerPage, err error) *MockApp_SearchSneakers_Call {
+	_c.Call.Return(sneakerPage, err)
+	return _c
+}
+
+func (_c *MockApp_SearchSneakers_Call) RunAndReturn(run func(ctx context.Context, filter model.SneakerFilter, pageToken string) (*model.SneakerPage, error)) *MockApp_SearchSneakers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStock provides a mock function for the type MockApp
+func (_mock *MockApp) SetStock(ctx context.Context, sneakerID int64, variantID int64, quantity int64) (*model.StockLevel, error) {
+	ret := _mock.Called(ctx, sneakerID, variantID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStock")
+	}
+
+	var r0 *model.StockLevel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (*model.StockLevel, error)); ok {
+		return returnFunc(ctx, sneakerID, variantID, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *model.StockLevel); ok {
+		r0 = returnFunc(ctx, sneakerID, variantID, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StockLevel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = returnFunc(ctx, sneakerID, variantID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_SetStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStock'
+type MockApp_SetStock_Call struct {
+	*mock.Call
+}
+
+// SetStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sneakerID int64
+//   - variantID int64
+//   - quantity int64
+func (_e *MockApp_Expecter) SetStock(ctx interface{}, sneakerID interface{}, variantID interface{}, quantity interface{}) *MockApp_SetStock_Call {
+	return &MockApp_SetStock_Call{Call: _e.mock.On("SetStock", ctx, sneakerID, variantID, quantity)}
+}
+
+func (_c *MockApp_SetStock_Call) Run(run func(ctx context.Context, sneakerID int64, variantID int64, quantity int64)) *MockApp_SetStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_SetStock_Call) Return(stockLevel *model.StockLevel, err error) *MockApp_SetStock_Call {
+	_c.Call.Return(stockLevel, err)
+	return _c
+}
+
+func (_c *MockApp_SetStock_Call) RunAndReturn(run func(ctx context.Context, sneakerID int64, variantID int64, quantity int64) (*model.StockLevel, error)) *MockApp_SetStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProductImage provides a mock function for the type MockApp
+func (_mock *MockApp) UpdateProductImage(ctx context.Context, productID int64, imageKey string) error {
+	ret := _mock.Called(ctx, productID, imageKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProductImage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, productID, imageKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockApp_UpdateProductImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProductImage'
+type MockApp_UpdateProductImage_Call struct {
+	*mock.Call
+}
+
+// UpdateProductImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int64
+//   - imageKey string
+func (_e *MockApp_Expecter) UpdateProductImage(ctx interface{}, productID interface{}, imageKey interface{}) *MockApp_UpdateProductImage_Call {
+	return &MockApp_UpdateProductImage_Call{Call: _e.mock.On("UpdateProductImage", ctx, productID, imageKey)}
+}
+
+func (_c *MockApp_UpdateProductImage_Call) Run(run func(ctx context.Context, productID int64, imageKey string)) *MockApp_UpdateProductImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_UpdateProductImage_Call) Return(err error) *MockApp_UpdateProductImage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockApp_UpdateProductImage_Call) RunAndReturn(run func(ctx context.Context, productID int64, imageKey string) error) *MockApp_UpdateProductImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSneaker provides a mock function for the type MockApp
+func (_mock *MockApp) UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error) {
+	ret := _mock.Called(ctx, id, upd, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSneaker")
+	}
+
+	var r0 *model.Sneaker
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, model.SneakerUpdate, int64) (*model.Sneaker, error)); ok {
+		return returnFunc(ctx, id, upd, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, model.SneakerUpdate, int64) *model.Sneaker); ok {
+		r0 = returnFunc(ctx, id, upd, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Sneaker)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, model.SneakerUpdate, int64) error); ok {
+		r1 = returnFunc(ctx, id, upd, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_UpdateSneaker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSneaker'
+type MockApp_UpdateSneaker_Call struct {
+	*mock.Call
+}
+
+// UpdateSneaker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - upd model.SneakerUpdate
+//   - expectedVersion int64
+func (_e *MockApp_Expecter) UpdateSneaker(ctx interface{}, id interface{}, upd interface{}, expectedVersion interface{}) *MockApp_UpdateSneaker_Call {
+	return &MockApp_UpdateSneaker_Call{Call: _e.mock.On("UpdateSneaker", ctx, id, upd, expectedVersion)}
+}
+
+func (_c *MockApp_UpdateSneaker_Call) Run(run func(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64)) *MockApp_UpdateSneaker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 model.SneakerUpdate
+		if args[2] != nil {
+			arg2 = args[2].(model.SneakerUpdate)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_UpdateSneaker_Call) Return(sneaker *model.Sneaker, err error) *MockApp_UpdateSneaker_Call {
+	_c.Call.Return(sneaker, err)
+	return _c
+}
+
+func (_c *MockApp_UpdateSneaker_Call) RunAndReturn(run func(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error)) *MockApp_UpdateSneaker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVariant provides a mock function for the type MockApp
+func (_mock *MockApp) UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	ret := _mock.Called(ctx, variant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVariant")
+	}
+
+	var r0 *model.SneakerVariant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) (*model.SneakerVariant, error)); ok {
+		return returnFunc(ctx, variant)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SneakerVariant) *model.SneakerVariant); ok {
+		r0 = returnFunc(ctx, variant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SneakerVariant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SneakerVariant) error); ok {
+		r1 = returnFunc(ctx, variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockApp_UpdateVariant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVariant'
+type MockApp_UpdateVariant_Call struct {
+	*mock.Call
+}
+
+// UpdateVariant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - variant *model.SneakerVariant
+func (_e *MockApp_Expecter) UpdateVariant(ctx interface{}, variant interface{}) *MockApp_UpdateVariant_Call {
+	return &MockApp_UpdateVariant_Call{Call: _e.mock.On("UpdateVariant", ctx, variant)}
+}
+
+func (_c *MockApp_UpdateVariant_Call) Run(run func(ctx context.Context, variant *model.SneakerVariant)) *MockApp_UpdateVariant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SneakerVariant
+		if args[1] != nil {
+			arg1 = args[1].(*model.SneakerVariant)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockApp_UpdateVariant_Call) Return(sneakerVariant *model.SneakerVariant, err error) *MockApp_UpdateVariant_Call {
+	_c.Call.Return(sneakerVariant, err)
+	return _c
+}
+
+func (_c *MockApp_UpdateVariant_Call) RunAndReturn(run func(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)) *MockApp_UpdateVariant_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	pb "github.com/stpnv0/protos/gen/go/product"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"product_service/internal/app"
 	"product_service/internal/model"
 	"product_service/internal/repository"
 )
@@ -17,11 +19,23 @@ import (
 type App interface {
 	GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker, error)
 	AddSneaker(ctx context.Context, sneaker *model.Sneaker) (int64, error)
-	GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*model.Sneaker, error)
+	GetAllSneakers(ctx context.Context, limit uint64, pageToken string) (*model.SneakerPage, error)
 	GetSneakersByIDs(ctx context.Context, ids []int64) ([]*model.Sneaker, error) // Новый в интерфейсе
+	SearchSneakers(ctx context.Context, filter model.SneakerFilter, pageToken string) (*model.SneakerPage, error)
+	UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error)
 	DeleteSneaker(ctx context.Context, id int64) error
+	RestoreSneaker(ctx context.Context, id int64) error
 	GenerateUploadURL(ctx context.Context, originalFilename string, contentType string) (uploadURL string, fileKey string, err error)
 	UpdateProductImage(ctx context.Context, productID int64, imageKey string) error
+	CreateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)
+	ListVariants(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error)
+	UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error)
+	GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error)
+	SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*model.StockLevel, error)
+	GetStock(ctx context.Context, sneakerID, variantID int64) (*model.StockLevel, error)
+	ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, ttl time.Duration) error
+	ReleaseStock(ctx context.Context, orderID int64) error
+	CommitStock(ctx context.Context, orderID int64) error
 }
 
 type serverAPI struct {
@@ -56,14 +70,26 @@ func (s *serverAPI) AddSneaker(ctx context.Context, req *pb.AddSneakerRequest) (
 	}
 
 	id, err := s.app.AddSneaker(ctx, &model.Sneaker{
-		Title: req.GetTitle(),
-		Price: req.GetPriceKopecks(),
+		Title:       req.GetTitle(),
+		Price:       req.GetPriceKopecks(),
+		Brand:       req.GetBrand(),
+		Category:    req.GetCategory(),
+		Description: req.GetDescription(),
 	})
 	if err != nil {
 		s.log.Error("failed to add sneaker", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
-	return &pb.Sneaker{Id: id, Title: req.GetTitle(), PriceKopecks: req.GetPriceKopecks(), ImageKey: ""}, nil
+	return &pb.Sneaker{
+		Id:           id,
+		Title:        req.GetTitle(),
+		PriceKopecks: req.GetPriceKopecks(),
+		ImageKey:     "",
+		Brand:        req.GetBrand(),
+		Category:     req.GetCategory(),
+		Description:  req.GetDescription(),
+		Version:      1,
+	}, nil
 }
 
 func (s *serverAPI) GenerateUploadURL(ctx context.Context, req *pb.GenerateUploadURLRequest) (*pb.GenerateUploadURLResponse, error) {
@@ -95,18 +121,51 @@ func (s *serverAPI) GetAllSneakers(ctx context.Context, req *pb.GetAllSneakersRe
 	if limit == 0 || limit > 100 {
 		limit = 20
 	}
-	sneakers, err := s.app.GetAllSneakers(ctx, limit, req.GetOffset())
+	page, err := s.app.GetAllSneakers(ctx, limit, req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, app.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 		s.log.Error("failed to get all sneakers", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	protoSneakers := make([]*pb.Sneaker, len(sneakers))
-	for i, sn := range sneakers {
-		protoSneakers[i] = toProtoSneaker(sn)
+	return &pb.GetAllSneakersResponse{
+		Sneakers:      toProtoSneakers(page.Sneakers),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (s *serverAPI) SearchSneakers(ctx context.Context, req *pb.SearchSneakersRequest) (*pb.SearchSneakersResponse, error) {
+	limit := req.GetLimit()
+	if limit == 0 || limit > 100 {
+		limit = 20
+	}
+
+	page, err := s.app.SearchSneakers(ctx, model.SneakerFilter{
+		Query:      req.GetQuery(),
+		MinPrice:   req.GetMinPriceKopecks(),
+		MaxPrice:   req.GetMaxPriceKopecks(),
+		Brands:     req.GetBrands(),
+		Categories: req.GetCategories(),
+		Sort:       fromProtoSort(req.GetSort()),
+		Limit:      limit,
+	}, req.GetPageToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrInvalidSearch):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, app.ErrInvalidPageToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		s.log.Error("failed to search sneakers", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	return &pb.GetAllSneakersResponse{Sneakers: protoSneakers}, nil
+	return &pb.SearchSneakersResponse{
+		Sneakers:      toProtoSneakers(page.Sneakers),
+		NextPageToken: page.NextPageToken,
+	}, nil
 }
 
 func (s *serverAPI) GetSneakersByIDs(ctx context.Context, req *pb.GetSneakersByIDsRequest) (*pb.GetSneakersByIDsResponse, error) {
@@ -124,6 +183,45 @@ func (s *serverAPI) GetSneakersByIDs(ctx context.Context, req *pb.GetSneakersByI
 	return &pb.GetSneakersByIDsResponse{Sneakers: protoSneakers}, nil
 }
 
+func (s *serverAPI) UpdateSneaker(ctx context.Context, req *pb.UpdateSneakerRequest) (*pb.Sneaker, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	var upd model.SneakerUpdate
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "title":
+			upd.Title = &req.Title
+		case "price_kopecks":
+			upd.Price = &req.PriceKopecks
+		case "description":
+			upd.Description = &req.Description
+		case "brand":
+			upd.Brand = &req.Brand
+		case "category":
+			upd.Category = &req.Category
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	sneaker, err := s.app.UpdateSneaker(ctx, req.GetId(), upd, req.GetExpectedVersion())
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrInvalidSneaker):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "sneaker not found")
+		case errors.Is(err, repository.ErrVersionConflict):
+			return nil, status.Error(codes.Aborted, "sneaker was modified concurrently, reload and retry")
+		}
+		s.log.Error("failed to update sneaker", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return toProtoSneaker(sneaker), nil
+}
+
 func (s *serverAPI) DeleteSneaker(ctx context.Context, req *pb.DeleteSneakerRequest) (*emptypb.Empty, error) {
 	err := s.app.DeleteSneaker(ctx, req.GetId())
 	if err != nil {
@@ -136,11 +234,219 @@ func (s *serverAPI) DeleteSneaker(ctx context.Context, req *pb.DeleteSneakerRequ
 	return &emptypb.Empty{}, nil
 }
 
+func (s *serverAPI) RestoreSneaker(ctx context.Context, req *pb.RestoreSneakerRequest) (*emptypb.Empty, error) {
+	err := s.app.RestoreSneaker(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "sneaker not found")
+		}
+		s.log.Error("failed to restore sneaker", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) CreateVariant(ctx context.Context, req *pb.CreateVariantRequest) (*pb.SneakerVariant, error) {
+	if req.GetSneakerId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sneaker_id is required")
+	}
+
+	variant, err := s.app.CreateVariant(ctx, &model.SneakerVariant{
+		SneakerId: req.GetSneakerId(),
+		SKU:       req.GetSku(),
+		Size:      req.GetSize(),
+		Color:     req.GetColor(),
+		Price:     req.GetPriceKopecks(),
+	})
+	if err != nil {
+		return nil, s.variantError(err, "failed to create variant")
+	}
+	return toProtoVariant(variant), nil
+}
+
+func (s *serverAPI) ListVariants(ctx context.Context, req *pb.ListVariantsRequest) (*pb.ListVariantsResponse, error) {
+	variants, err := s.app.ListVariants(ctx, req.GetSneakerId())
+	if err != nil {
+		s.log.Error("failed to list variants", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &pb.ListVariantsResponse{Variants: toProtoVariants(variants)}, nil
+}
+
+func (s *serverAPI) UpdateVariant(ctx context.Context, req *pb.UpdateVariantRequest) (*pb.SneakerVariant, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetSneakerId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sneaker_id is required")
+	}
+
+	variant, err := s.app.UpdateVariant(ctx, &model.SneakerVariant{
+		Id:        req.GetId(),
+		SneakerId: req.GetSneakerId(),
+		SKU:       req.GetSku(),
+		Size:      req.GetSize(),
+		Color:     req.GetColor(),
+		Price:     req.GetPriceKopecks(),
+	})
+	if err != nil {
+		return nil, s.variantError(err, "failed to update variant")
+	}
+	return toProtoVariant(variant), nil
+}
+
+func (s *serverAPI) GetVariantsByIDs(ctx context.Context, req *pb.GetVariantsByIDsRequest) (*pb.GetVariantsByIDsResponse, error) {
+	variants, err := s.app.GetVariantsByIDs(ctx, req.GetIds())
+	if err != nil {
+		s.log.Error("failed to get variants by ids", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &pb.GetVariantsByIDsResponse{Variants: toProtoVariants(variants)}, nil
+}
+
+func (s *serverAPI) variantError(err error, msg string) error {
+	switch {
+	case errors.Is(err, app.ErrInvalidVariant):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "sneaker or variant not found")
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, "variant with this sku, size or color already exists")
+	}
+	s.log.Error(msg, slog.String("error", err.Error()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+func (s *serverAPI) SetStock(ctx context.Context, req *pb.SetStockRequest) (*pb.StockLevel, error) {
+	if req.GetSneakerId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sneaker_id is required")
+	}
+
+	level, err := s.app.SetStock(ctx, req.GetSneakerId(), req.GetVariantId(), req.GetQuantity())
+	if err != nil {
+		return nil, s.stockError(err, "failed to set stock")
+	}
+	return toProtoStock(level), nil
+}
+
+func (s *serverAPI) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.StockLevel, error) {
+	level, err := s.app.GetStock(ctx, req.GetSneakerId(), req.GetVariantId())
+	if err != nil {
+		return nil, s.stockError(err, "failed to get stock")
+	}
+	return toProtoStock(level), nil
+}
+
+func (s *serverAPI) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*emptypb.Empty, error) {
+	items := make([]model.StockItem, len(req.GetItems()))
+	for i, it := range req.GetItems() {
+		items[i] = model.StockItem{
+			SneakerId: it.GetSneakerId(),
+			VariantId: it.GetVariantId(),
+			Quantity:  it.GetQuantity(),
+		}
+	}
+
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	if err := s.app.ReserveStock(ctx, req.GetOrderId(), items, ttl); err != nil {
+		return nil, s.stockError(err, "failed to reserve stock")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) ReleaseStock(ctx context.Context, req *pb.StockOrderRequest) (*emptypb.Empty, error) {
+	if err := s.app.ReleaseStock(ctx, req.GetOrderId()); err != nil {
+		return nil, s.stockError(err, "failed to release stock")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) CommitStock(ctx context.Context, req *pb.StockOrderRequest) (*emptypb.Empty, error) {
+	if err := s.app.CommitStock(ctx, req.GetOrderId()); err != nil {
+		return nil, s.stockError(err, "failed to commit stock")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) stockError(err error, msg string) error {
+	switch {
+	case errors.Is(err, app.ErrInvalidStock):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, "insufficient stock")
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "stock not found")
+	}
+	s.log.Error(msg, slog.String("error", err.Error()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+func toProtoStock(level *model.StockLevel) *pb.StockLevel {
+	return &pb.StockLevel{
+		SneakerId: level.SneakerId,
+		VariantId: level.VariantId,
+		Quantity:  level.Quantity,
+		Reserved:  level.Reserved,
+		Available: level.Available(),
+	}
+}
+
 func toProtoSneaker(sneaker *model.Sneaker) *pb.Sneaker {
 	return &pb.Sneaker{
 		Id:           sneaker.Id,
 		Title:        sneaker.Title,
 		PriceKopecks: sneaker.Price,
 		ImageKey:     sneaker.ImageKey,
+		Variants:     toProtoVariants(sneaker.Variants),
+		Brand:        sneaker.Brand,
+		Category:     sneaker.Category,
+		Description:  sneaker.Description,
+		Version:      sneaker.Version,
+		Archived:     sneaker.ArchivedAt != nil,
+	}
+}
+
+func fromProtoSort(sort pb.SearchSort) model.SearchSort {
+	switch sort {
+	case pb.SearchSort_SEARCH_SORT_PRICE_ASC:
+		return model.SortPriceAsc
+	case pb.SearchSort_SEARCH_SORT_PRICE_DESC:
+		return model.SortPriceDesc
+	case pb.SearchSort_SEARCH_SORT_NEWEST:
+		return model.SortNewest
+	case pb.SearchSort_SEARCH_SORT_POPULARITY:
+		return model.SortPopularity
+	default:
+		return model.SortRelevance
+	}
+}
+
+func toProtoSneakers(sneakers []*model.Sneaker) []*pb.Sneaker {
+	out := make([]*pb.Sneaker, len(sneakers))
+	for i, sn := range sneakers {
+		out[i] = toProtoSneaker(sn)
+	}
+	return out
+}
+
+func toProtoVariant(v *model.SneakerVariant) *pb.SneakerVariant {
+	return &pb.SneakerVariant{
+		Id:           v.Id,
+		SneakerId:    v.SneakerId,
+		Sku:          v.SKU,
+		Size:         v.Size,
+		Color:        v.Color,
+		PriceKopecks: v.Price,
+	}
+}
+
+func toProtoVariants(variants []*model.SneakerVariant) []*pb.SneakerVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	out := make([]*pb.SneakerVariant, len(variants))
+	for i, v := range variants {
+		out[i] = toProtoVariant(v)
 	}
+	return out
 }
@@ -0,0 +1,35 @@
+package pagetoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCursor struct {
+	ID    int64 `json:"id"`
+	Price int64 `json:"p,omitempty"`
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	token, err := Encode(testCursor{ID: 42, Price: 799900})
+	require.NoError(t, err)
+	assert.NotContains(t, token, "=")
+
+	var got testCursor
+	require.NoError(t, Decode(token, &got))
+	assert.Equal(t, testCursor{ID: 42, Price: 799900}, got)
+}
+
+func TestDecode_EmptyTokenIsFirstPage(t *testing.T) {
+	got := testCursor{ID: 7}
+	require.NoError(t, Decode("", &got))
+	assert.Equal(t, int64(7), got.ID)
+}
+
+func TestDecode_Garbage(t *testing.T) {
+	var got testCursor
+	assert.ErrorIs(t, Decode("not a token!", &got), ErrInvalidToken)
+	assert.ErrorIs(t, Decode("bm90LWpzb24", &got), ErrInvalidToken)
+}
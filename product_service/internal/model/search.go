@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// SearchSort — порядок сортировки результатов поиска.
+type SearchSort string
+
+const (
+	SortRelevance  SearchSort = "relevance"
+	SortPriceAsc   SearchSort = "price_asc"
+	SortPriceDesc  SearchSort = "price_desc"
+	SortNewest     SearchSort = "newest"
+	SortPopularity SearchSort = "popularity"
+)
+
+// IsValid сообщает, поддерживается ли порядок сортировки.
+func (s SearchSort) IsValid() bool {
+	switch s {
+	case SortRelevance, SortPriceAsc, SortPriceDesc, SortNewest, SortPopularity:
+		return true
+	}
+	return false
+}
+
+// SneakerFilter — параметры поиска по каталогу.
+// Нулевые MinPrice/MaxPrice и пустые списки означают отсутствие фильтра.
+type SneakerFilter struct {
+	Query      string
+	MinPrice   int64
+	MaxPrice   int64
+	Brands     []string
+	Categories []string
+	Sort       SearchSort
+	Limit      uint64
+	After      *SneakerCursor // nil — первая страница
+}
+
+// SneakerCursor — позиция в выдаче каталога для keyset-пагинации.
+// Заполняются только поля, участвующие в выбранной сортировке.
+type SneakerCursor struct {
+	ID        int64     `json:"id"`
+	Price     int64     `json:"p,omitempty"`
+	CreatedAt time.Time `json:"t,omitzero"`
+	Sales     int64     `json:"n,omitempty"`
+	Rank      float32   `json:"r,omitempty"`
+	// Filter — отпечаток фильтра, для которого выдан курсор:
+	// токен от другого запроса отклоняется.
+	Filter string `json:"f,omitempty"`
+}
+
+// SneakerPage — страница выдачи каталога.
+type SneakerPage struct {
+	Sneakers      []*Sneaker
+	NextPageToken string
+}
@@ -1,8 +1,35 @@
 package model
 
+import "time"
+
 type Sneaker struct {
-	Id       int64
-	Title    string
-	Price    int64
-	ImageKey string
+	Id          int64
+	Title       string
+	Price       int64
+	ImageKey    string
+	Brand       string
+	Category    string
+	Description string
+	// Version растёт при каждом изменении карточки и используется
+	// для оптимистичной блокировки в UpdateSneaker.
+	Version int64
+	// ArchivedAt — момент снятия с продажи. Архивный товар пропадает из каталога,
+	// но по-прежнему доступен по ID для истории заказов.
+	ArchivedAt *time.Time
+	Variants   []*SneakerVariant `db:"-"`
+}
+
+// SneakerUpdate — частичное обновление карточки товара.
+// nil-поле означает «не менять».
+type SneakerUpdate struct {
+	Title       *string
+	Price       *int64
+	Description *string
+	Brand       *string
+	Category    *string
+}
+
+// IsEmpty сообщает, что обновлять нечего.
+func (u SneakerUpdate) IsEmpty() bool {
+	return u.Title == nil && u.Price == nil && u.Description == nil && u.Brand == nil && u.Category == nil
 }
@@ -0,0 +1,29 @@
+package model
+
+// Статусы резерва товара под заказ.
+const (
+	ReservationReserved  = "RESERVED"
+	ReservationCommitted = "COMMITTED"
+	ReservationReleased  = "RELEASED"
+)
+
+// StockLevel — остаток товара (или его варианта) на складе.
+// VariantId = 0 означает товар без вариантов.
+type StockLevel struct {
+	SneakerId int64
+	VariantId int64
+	Quantity  int64
+	Reserved  int64
+}
+
+// Available возвращает количество, доступное для резервирования.
+func (s *StockLevel) Available() int64 {
+	return s.Quantity - s.Reserved
+}
+
+// StockItem — позиция, под которую резервируется остаток.
+type StockItem struct {
+	SneakerId int64
+	VariantId int64
+	Quantity  int64
+}
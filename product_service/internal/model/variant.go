@@ -0,0 +1,20 @@
+package model
+
+// SneakerVariant — конкретный SKU модели: размер + расцветка.
+// Price = 0 означает, что используется цена самой модели.
+type SneakerVariant struct {
+	Id        int64
+	SneakerId int64
+	SKU       string
+	Size      string
+	Color     string
+	Price     int64
+}
+
+// EffectivePrice возвращает цену варианта с учётом цены модели.
+func (v *SneakerVariant) EffectivePrice(sneakerPrice int64) int64 {
+	if v.Price > 0 {
+		return v.Price
+	}
+	return sneakerPrice
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"product_service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// releaseReservationsQuery снимает резервы, подходящие под условие, и возвращает
+// зарезервированное количество в свободный остаток одним запросом.
+const releaseReservationsQuery = `
+	WITH released AS (
+		UPDATE stock_reservations SET status = 'RELEASED', updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM stock_reservations
+			WHERE status = 'RESERVED' AND %s
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING sneaker_id, variant_id, quantity
+	), restocked AS (
+		UPDATE stock s SET reserved = s.reserved - r.quantity, updated_at = NOW()
+		FROM (
+			SELECT sneaker_id, variant_id, SUM(quantity) AS quantity
+			FROM released GROUP BY sneaker_id, variant_id
+		) r
+		WHERE s.sneaker_id = r.sneaker_id AND s.variant_id = r.variant_id
+		RETURNING 1
+	)
+	SELECT COUNT(*) FROM released`
+
+func (r *PostgresRepo) SetStock(ctx context.Context, sneakerID, variantID, quantity int64) (*model.StockLevel, error) {
+	query := `INSERT INTO stock (sneaker_id, variant_id, quantity) VALUES ($1, $2, $3)
+		ON CONFLICT (sneaker_id, variant_id) DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = NOW()
+		RETURNING sneaker_id, variant_id, quantity, reserved`
+
+	var s model.StockLevel
+	err := r.db.QueryRow(ctx, query, sneakerID, variantID, quantity).
+		Scan(&s.SneakerId, &s.VariantId, &s.Quantity, &s.Reserved)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23514": // check_violation: остаток меньше уже зарезервированного
+				return nil, ErrInsufficientStock
+			case "23503": // foreign_key_violation
+				return nil, ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("failed to set stock: %w", err)
+	}
+
+	return &s, nil
+}
+
+func (r *PostgresRepo) GetStock(ctx context.Context, sneakerID, variantID int64) (*model.StockLevel, error) {
+	query := "SELECT sneaker_id, variant_id, quantity, reserved FROM stock WHERE sneaker_id = $1 AND variant_id = $2"
+
+	var s model.StockLevel
+	err := r.db.QueryRow(ctx, query, sneakerID, variantID).
+		Scan(&s.SneakerId, &s.VariantId, &s.Quantity, &s.Reserved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	return &s, nil
+}
+
+// ReserveStock атомарно резервирует все позиции заказа: либо все, либо ни одной.
+// Повторный вызов для уже зарезервированного заказа ничего не делает.
+func (r *PostgresRepo) ReserveStock(ctx context.Context, orderID int64, items []model.StockItem, expiresAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, "SELECT status FROM stock_reservations WHERE order_id = $1 FOR UPDATE", orderID)
+	if err != nil {
+		return fmt.Errorf("failed to query reservations: %w", err)
+	}
+	statuses, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to collect reservation rows: %w", err)
+	}
+	for _, st := range statuses {
+		if st != model.ReservationReleased {
+			return nil
+		}
+	}
+	if len(statuses) > 0 {
+		// Заказ повторно уходит в оплату — старые снятые резервы больше не нужны
+		if _, err := tx.Exec(ctx, "DELETE FROM stock_reservations WHERE order_id = $1", orderID); err != nil {
+			return fmt.Errorf("failed to delete released reservations: %w", err)
+		}
+	}
+
+	for _, item := range mergeStockItems(items) {
+		// Архивный товар больше не продаётся, даже если остаток на складе есть.
+		ct, err := tx.Exec(ctx,
+			`UPDATE stock SET reserved = reserved + $3, updated_at = NOW()
+			 WHERE sneaker_id = $1 AND variant_id = $2 AND quantity - reserved >= $3
+			   AND EXISTS (SELECT 1 FROM sneakers WHERE id = $1 AND archived_at IS NULL)`,
+			item.SneakerId, item.VariantId, item.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if ct.RowsAffected() == 0 {
+			return fmt.Errorf("%w: sneaker %d variant %d", ErrInsufficientStock, item.SneakerId, item.VariantId)
+		}
+
+		_, err = tx.Exec(ctx,
+			`INSERT INTO stock_reservations (order_id, sneaker_id, variant_id, quantity, expires_at)
+			 VALUES ($1, $2, $3, $4, $5)`,
+			orderID, item.SneakerId, item.VariantId, item.Quantity, expiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert reservation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return nil
+}
+
+// ReleaseStock снимает активные резервы заказа. Уже списанные резервы не трогает.
+func (r *PostgresRepo) ReleaseStock(ctx context.Context, orderID int64) (int64, error) {
+	var released int64
+	err := r.db.QueryRow(ctx, fmt.Sprintf(releaseReservationsQuery, "order_id = $1"), orderID).Scan(&released)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stock: %w", err)
+	}
+	return released, nil
+}
+
+// ReleaseExpiredReservations снимает резервы, срок которых истёк до before.
+func (r *PostgresRepo) ReleaseExpiredReservations(ctx context.Context, before time.Time) (int64, error) {
+	var released int64
+	err := r.db.QueryRow(ctx, fmt.Sprintf(releaseReservationsQuery, "expires_at < $1"), before).Scan(&released)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired reservations: %w", err)
+	}
+	return released, nil
+}
+
+// CommitStock списывает зарезервированный остаток оплаченного заказа.
+// Если резерв успел истечь, товар списывается из свободного остатка, а при его
+// нехватке возвращается ErrInsufficientStock.
+func (r *PostgresRepo) CommitStock(ctx context.Context, orderID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx,
+		`SELECT sneaker_id, variant_id, quantity, status FROM stock_reservations
+		 WHERE order_id = $1 ORDER BY sneaker_id, variant_id FOR UPDATE`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to query reservations: %w", err)
+	}
+
+	type reservation struct {
+		item   model.StockItem
+		status string
+	}
+	reservations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (reservation, error) {
+		var res reservation
+		err := row.Scan(&res.item.SneakerId, &res.item.VariantId, &res.item.Quantity, &res.status)
+		return res, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect reservation rows: %w", err)
+	}
+	if len(reservations) == 0 {
+		return ErrNotFound
+	}
+
+	for _, res := range reservations {
+		var query string
+		switch res.status {
+		case model.ReservationCommitted:
+			return nil
+		case model.ReservationReserved:
+			query = `UPDATE stock SET quantity = quantity - $3, reserved = reserved - $3, updated_at = NOW()
+				WHERE sneaker_id = $1 AND variant_id = $2`
+		default:
+			query = `UPDATE stock SET quantity = quantity - $3, updated_at = NOW()
+				WHERE sneaker_id = $1 AND variant_id = $2 AND quantity - reserved >= $3`
+		}
+
+		ct, err := tx.Exec(ctx, query, res.item.SneakerId, res.item.VariantId, res.item.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to commit stock: %w", err)
+		}
+		if ct.RowsAffected() == 0 {
+			return fmt.Errorf("%w: sneaker %d variant %d", ErrInsufficientStock, res.item.SneakerId, res.item.VariantId)
+		}
+
+		// Счётчик продаж используется для сортировки каталога по популярности.
+		_, err = tx.Exec(ctx, "UPDATE sneakers SET sales_count = sales_count + $2 WHERE id = $1",
+			res.item.SneakerId, res.item.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to update sales count: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE stock_reservations SET status = 'COMMITTED', updated_at = NOW() WHERE order_id = $1", orderID)
+	if err != nil {
+		return fmt.Errorf("failed to mark reservations committed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return nil
+}
+
+// mergeStockItems схлопывает повторяющиеся позиции и сортирует их,
+// чтобы параллельные резервы блокировали строки stock в одном порядке.
+func mergeStockItems(items []model.StockItem) []model.StockItem {
+	type key struct{ sneakerID, variantID int64 }
+	merged := make(map[key]int64, len(items))
+	for _, it := range items {
+		merged[key{it.SneakerId, it.VariantId}] += it.Quantity
+	}
+
+	out := make([]model.StockItem, 0, len(merged))
+	for k, q := range merged {
+		out = append(out, model.StockItem{SneakerId: k.sneakerID, VariantId: k.variantID, Quantity: q})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SneakerId != out[j].SneakerId {
+			return out[i].SneakerId < out[j].SneakerId
+		}
+		return out[i].VariantId < out[j].VariantId
+	})
+	return out
+}
@@ -5,12 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"product_service/internal/model"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrNotFound = errors.New("entity not found")
+// sneakerColumns — колонки, из которых собирается model.Sneaker.
+const sneakerColumns = "id, title, price, image_key, brand, category, description, version, archived_at"
+
+var (
+	ErrNotFound      = errors.New("entity not found")
+	ErrAlreadyExists = errors.New("entity already exists")
+	// ErrInsufficientStock — на складе не хватает свободного остатка.
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrVersionConflict — запись изменилась после того, как её прочитал клиент.
+	ErrVersionConflict = errors.New("version conflict")
+)
 
 type PostgresRepo struct {
 	db *pgxpool.Pool
@@ -22,28 +34,39 @@ func New(db *pgxpool.Pool) *PostgresRepo {
 	}
 }
 
-func (r *PostgresRepo) GetAllSneakers(ctx context.Context, limit, offset uint64) ([]*model.Sneaker, error) {
-	query := "SELECT id, title, price, image_key FROM sneakers ORDER BY id LIMIT $1 OFFSET $2"
+// GetAllSneakers возвращает страницу каталога в порядке id, начиная после afterID,
+// и курсор следующей страницы (nil, если страница последняя).
+func (r *PostgresRepo) GetAllSneakers(ctx context.Context, limit uint64, afterID int64) ([]*model.Sneaker, *model.SneakerCursor, error) {
+	query := "SELECT " + sneakerColumns + " FROM sneakers WHERE id > $1 AND archived_at IS NULL ORDER BY id LIMIT $2"
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.db.Query(ctx, query, afterID, limit+1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query sneakers: %w", err)
+		return nil, nil, fmt.Errorf("failed to query sneakers: %w", err)
 	}
 	defer rows.Close()
 
 	sneakers, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[model.Sneaker])
 	if err != nil {
-		return nil, fmt.Errorf("failed to collect sneaker rows: %w", err)
+		return nil, nil, fmt.Errorf("failed to collect sneaker rows: %w", err)
 	}
 
-	return sneakers, nil
+	var next *model.SneakerCursor
+	if uint64(len(sneakers)) > limit {
+		sneakers = sneakers[:limit]
+		next = &model.SneakerCursor{ID: sneakers[len(sneakers)-1].Id}
+	}
+
+	return sneakers, next, nil
 }
 
 func (r *PostgresRepo) AddSneaker(ctx context.Context, sneaker *model.Sneaker) (int64, error) {
-	query := "INSERT INTO sneakers (title, price, image_key) VALUES ($1, $2, $3) RETURNING id"
+	query := `INSERT INTO sneakers (title, price, image_key, brand, category, description)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 
 	var id int64
-	err := r.db.QueryRow(ctx, query, sneaker.Title, sneaker.Price, sneaker.ImageKey).Scan(&id)
+	err := r.db.QueryRow(ctx, query,
+		sneaker.Title, sneaker.Price, sneaker.ImageKey, sneaker.Brand, sneaker.Category, sneaker.Description,
+	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("failed to add sneaker: %w", err)
 	}
@@ -52,12 +75,14 @@ func (r *PostgresRepo) AddSneaker(ctx context.Context, sneaker *model.Sneaker) (
 }
 
 func (r *PostgresRepo) GetSneakerByID(ctx context.Context, id int64) (*model.Sneaker, error) {
-	query := "SELECT id, title, price, image_key from sneakers WHERE id = $1"
+	query := "SELECT " + sneakerColumns + " from sneakers WHERE id = $1"
 
-	row := r.db.QueryRow(ctx, query, id)
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sneaker: %w", err)
+	}
 
-	var s model.Sneaker
-	err := row.Scan(&s.Id, &s.Title, &s.Price, &s.ImageKey)
+	s, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.Sneaker])
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -65,14 +90,14 @@ func (r *PostgresRepo) GetSneakerByID(ctx context.Context, id int64) (*model.Sne
 		return nil, fmt.Errorf("failed to scan sneaker row: %w", err)
 	}
 
-	return &s, nil
+	return s, nil
 }
 
 func (r *PostgresRepo) GetSneakersByIDs(ctx context.Context, ids []int64) ([]*model.Sneaker, error) {
 	if len(ids) == 0 {
 		return []*model.Sneaker{}, nil
 	}
-	query := "SELECT id, title, price, image_key from sneakers WHERE id = ANY($1)"
+	query := "SELECT " + sneakerColumns + " from sneakers WHERE id = ANY($1)"
 
 	rows, err := r.db.Query(ctx, query, ids)
 	if err != nil {
@@ -87,23 +112,54 @@ func (r *PostgresRepo) GetSneakersByIDs(ctx context.Context, ids []int64) ([]*mo
 	return sneakers, nil
 }
 
-func (r *PostgresRepo) DeleteSneaker(ctx context.Context, id int64) error {
-	query := "DELETE FROM sneakers WHERE id = $1"
+// ArchiveSneaker снимает товар с продажи. Повторная архивация ничего не меняет.
+func (r *PostgresRepo) ArchiveSneaker(ctx context.Context, id int64) error {
+	query := `UPDATE sneakers SET archived_at = NOW(), version = version + 1
+		WHERE id = $1 AND archived_at IS NULL`
 
 	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete sneaker: %w", err)
+		return fmt.Errorf("failed to archive sneaker: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return ErrNotFound
+		return r.ensureSneakerExists(ctx, id)
+	}
+
+	return nil
+}
+
+// RestoreSneaker возвращает архивный товар в каталог.
+func (r *PostgresRepo) RestoreSneaker(ctx context.Context, id int64) error {
+	query := `UPDATE sneakers SET archived_at = NULL, version = version + 1
+		WHERE id = $1 AND archived_at IS NOT NULL`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore sneaker: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return r.ensureSneakerExists(ctx, id)
+	}
+
+	return nil
+}
+
+// ensureSneakerExists возвращает ErrNotFound, если товара с таким id нет.
+func (r *PostgresRepo) ensureSneakerExists(ctx context.Context, id int64) error {
+	var exists bool
+	if err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM sneakers WHERE id = $1)", id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check sneaker existence: %w", err)
+	}
+	if !exists {
+		return ErrNotFound
+	}
 	return nil
 }
 
 func (r *PostgresRepo) UpdateImageKey(ctx context.Context, id int64, imageKey string) error {
-	query := "UPDATE sneakers SET image_key = $1 WHERE id = $2"
+	query := "UPDATE sneakers SET image_key = $1, version = version + 1 WHERE id = $2"
 	result, err := r.db.Exec(ctx, query, imageKey, id)
 	if err != nil {
 		return fmt.Errorf("failed to update sneaker image key: %w", err)
@@ -113,3 +169,137 @@ func (r *PostgresRepo) UpdateImageKey(ctx context.Context, id int64, imageKey st
 	}
 	return nil
 }
+
+// UpdateSneaker применяет частичное обновление, если версия записи всё ещё
+// равна expectedVersion, и возвращает обновлённую карточку.
+func (r *PostgresRepo) UpdateSneaker(ctx context.Context, id int64, upd model.SneakerUpdate, expectedVersion int64) (*model.Sneaker, error) {
+	var (
+		sets []string
+		args []any
+	)
+	set := func(column string, v any) {
+		args = append(args, v)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	if upd.Title != nil {
+		set("title", *upd.Title)
+	}
+	if upd.Price != nil {
+		set("price", *upd.Price)
+	}
+	if upd.Description != nil {
+		set("description", *upd.Description)
+	}
+	if upd.Brand != nil {
+		set("brand", *upd.Brand)
+	}
+	if upd.Category != nil {
+		set("category", *upd.Category)
+	}
+	sets = append(sets, "version = version + 1")
+
+	args = append(args, id, expectedVersion)
+	query := fmt.Sprintf("UPDATE sneakers SET %s WHERE id = $%d AND version = $%d RETURNING %s",
+		strings.Join(sets, ", "), len(args)-1, len(args), sneakerColumns)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sneaker: %w", err)
+	}
+
+	sneaker, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[model.Sneaker])
+	if err == nil {
+		return sneaker, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to scan sneaker row: %w", err)
+	}
+
+	// Ни одна строка не обновилась: товара нет или его уже кто-то изменил.
+	if err := r.ensureSneakerExists(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrVersionConflict
+}
+
+func (r *PostgresRepo) CreateVariant(ctx context.Context, variant *model.SneakerVariant) (int64, error) {
+	query := "INSERT INTO sneaker_variants (sneaker_id, sku, size, color, price) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, variant.SneakerId, variant.SKU, variant.Size, variant.Color, variant.Price).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create variant: %w", mapVariantErr(err))
+	}
+
+	return id, nil
+}
+
+func (r *PostgresRepo) GetVariantsBySneakerID(ctx context.Context, sneakerID int64) ([]*model.SneakerVariant, error) {
+	query := "SELECT id, sneaker_id, sku, size, color, price FROM sneaker_variants WHERE sneaker_id = $1 ORDER BY id"
+
+	rows, err := r.db.Query(ctx, query, sneakerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query variants: %w", err)
+	}
+
+	variants, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[model.SneakerVariant])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect variant rows: %w", err)
+	}
+
+	return variants, nil
+}
+
+func (r *PostgresRepo) GetVariantsByIDs(ctx context.Context, ids []int64) ([]*model.SneakerVariant, error) {
+	if len(ids) == 0 {
+		return []*model.SneakerVariant{}, nil
+	}
+	query := "SELECT id, sneaker_id, sku, size, color, price FROM sneaker_variants WHERE id = ANY($1)"
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query variants by ids: %w", err)
+	}
+
+	variants, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[model.SneakerVariant])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect variant rows: %w", err)
+	}
+
+	return variants, nil
+}
+
+// UpdateVariant обновляет вариант товара variant.SneakerId. Вариант другого товара
+// не меняется и возвращается ErrNotFound.
+func (r *PostgresRepo) UpdateVariant(ctx context.Context, variant *model.SneakerVariant) (*model.SneakerVariant, error) {
+	query := `UPDATE sneaker_variants SET sku = $1, size = $2, color = $3, price = $4
+		WHERE id = $5 AND sneaker_id = $6
+		RETURNING id, sneaker_id, sku, size, color, price`
+
+	var v model.SneakerVariant
+	err := r.db.QueryRow(ctx, query, variant.SKU, variant.Size, variant.Color, variant.Price, variant.Id, variant.SneakerId).
+		Scan(&v.Id, &v.SneakerId, &v.SKU, &v.Size, &v.Color, &v.Price)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update variant: %w", mapVariantErr(err))
+	}
+
+	return &v, nil
+}
+
+// mapVariantErr переводит ошибки ограничений sneaker_variants в доменные.
+func mapVariantErr(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case "23505": // unique_violation
+		return ErrAlreadyExists
+	case "23503": // foreign_key_violation
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"product_service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// searchTSQuery объединяет разбор запроса русской и английской конфигурациями:
+// в каталоге встречаются как кириллические, так и латинские названия.
+const searchTSQuery = "(websearch_to_tsquery('russian', %[1]s) || websearch_to_tsquery('english', %[1]s))"
+
+// searchRow — строка выдачи вместе со значениями ключей сортировки для курсора.
+type searchRow struct {
+	sneaker   model.Sneaker
+	createdAt time.Time
+	sales     int64
+	rank      float32
+}
+
+// SearchSneakers ищет товары по тексту и фильтрам каталога.
+// Возвращает не больше filter.Limit товаров и курсор следующей страницы
+// (nil, если страница последняя).
+func (r *PostgresRepo) SearchSneakers(ctx context.Context, filter model.SneakerFilter) ([]*model.Sneaker, *model.SneakerCursor, error) {
+	var (
+		conds = []string{"archived_at IS NULL"}
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	rankExpr := "0::real"
+	if filter.Query != "" {
+		tsQuery := fmt.Sprintf(searchTSQuery, arg(filter.Query))
+		conds = append(conds, "search_vector @@ "+tsQuery)
+		rankExpr = "ts_rank(search_vector, " + tsQuery + ")"
+	}
+	if filter.MinPrice > 0 {
+		conds = append(conds, "price >= "+arg(filter.MinPrice))
+	}
+	if filter.MaxPrice > 0 {
+		conds = append(conds, "price <= "+arg(filter.MaxPrice))
+	}
+	if len(filter.Brands) > 0 {
+		conds = append(conds, "brand = ANY("+arg(filter.Brands)+")")
+	}
+	if len(filter.Categories) > 0 {
+		conds = append(conds, "category = ANY("+arg(filter.Categories)+")")
+	}
+
+	// id в конце каждой сортировки делает порядок детерминированным,
+	// а условие по курсору продолжает выдачу строго после последней строки.
+	var orderBy string
+	after := filter.After
+	switch filter.Sort {
+	case model.SortPriceAsc:
+		orderBy = "price ASC, id ASC"
+		if after != nil {
+			conds = append(conds, fmt.Sprintf("(price, id) > (%s, %s)", arg(after.Price), arg(after.ID)))
+		}
+	case model.SortPriceDesc:
+		orderBy = "price DESC, id DESC"
+		if after != nil {
+			conds = append(conds, fmt.Sprintf("(price, id) < (%s, %s)", arg(after.Price), arg(after.ID)))
+		}
+	case model.SortNewest:
+		orderBy = "created_at DESC, id DESC"
+		if after != nil {
+			conds = append(conds, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(after.CreatedAt), arg(after.ID)))
+		}
+	case model.SortPopularity:
+		orderBy = "sales_count DESC, id ASC"
+		if after != nil {
+			n, id := arg(after.Sales), arg(after.ID)
+			conds = append(conds, fmt.Sprintf("(sales_count < %[1]s OR (sales_count = %[1]s AND id > %[2]s))", n, id))
+		}
+	default:
+		orderBy = "rank DESC, id ASC"
+		if after != nil {
+			rank, id := arg(after.Rank), arg(after.ID)
+			conds = append(conds, fmt.Sprintf("(%[1]s < %[2]s::real OR (%[1]s = %[2]s::real AND id > %[3]s))", rankExpr, rank, id))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT " + sneakerColumns + ", created_at, sales_count, ")
+	b.WriteString(rankExpr + " AS rank FROM sneakers")
+	b.WriteString(" WHERE ")
+	b.WriteString(strings.Join(conds, " AND "))
+	b.WriteString(" ORDER BY " + orderBy)
+	// Лишняя строка показывает, есть ли следующая страница.
+	b.WriteString(" LIMIT " + arg(filter.Limit+1))
+
+	rows, err := r.db.Query(ctx, b.String(), args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search sneakers: %w", err)
+	}
+	defer rows.Close()
+
+	found, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (searchRow, error) {
+		var sr searchRow
+		s := &sr.sneaker
+		err := row.Scan(&s.Id, &s.Title, &s.Price, &s.ImageKey, &s.Brand, &s.Category, &s.Description, &s.Version, &s.ArchivedAt,
+			&sr.createdAt, &sr.sales, &sr.rank)
+		return sr, err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to collect sneaker rows: %w", err)
+	}
+
+	var next *model.SneakerCursor
+	if uint64(len(found)) > filter.Limit {
+		found = found[:filter.Limit]
+		last := found[len(found)-1]
+		next = &model.SneakerCursor{ID: last.sneaker.Id}
+		switch filter.Sort {
+		case model.SortPriceAsc, model.SortPriceDesc:
+			next.Price = last.sneaker.Price
+		case model.SortNewest:
+			next.CreatedAt = last.createdAt
+		case model.SortPopularity:
+			next.Sales = last.sales
+		default:
+			next.Rank = last.rank
+		}
+	}
+
+	sneakers := make([]*model.Sneaker, len(found))
+	for i := range found {
+		sneakers[i] = &found[i].sneaker
+	}
+
+	return sneakers, next, nil
+}
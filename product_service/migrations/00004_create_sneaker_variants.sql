@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS sneaker_variants (
+    id BIGSERIAL PRIMARY KEY,
+    sneaker_id BIGINT NOT NULL REFERENCES sneakers(id) ON DELETE CASCADE,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    size VARCHAR(16) NOT NULL,
+    color VARCHAR(64) NOT NULL,
+    price BIGINT NOT NULL DEFAULT 0,
+    UNIQUE (sneaker_id, size, color)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sneaker_variants_sneaker_id ON sneaker_variants (sneaker_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS sneaker_variants;
@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS stock (
+    sneaker_id BIGINT NOT NULL REFERENCES sneakers(id) ON DELETE CASCADE,
+    variant_id BIGINT NOT NULL DEFAULT 0, -- 0 — товар без вариантов
+    quantity BIGINT NOT NULL DEFAULT 0,
+    reserved BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sneaker_id, variant_id),
+    CHECK (quantity >= 0),
+    CHECK (reserved >= 0 AND reserved <= quantity)
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    sneaker_id BIGINT NOT NULL,
+    variant_id BIGINT NOT NULL DEFAULT 0,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'RESERVED', -- RESERVED, COMMITTED, RELEASED
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (order_id, sneaker_id, variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order_id ON stock_reservations (order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expires_at ON stock_reservations (expires_at) WHERE status = 'RESERVED';
+
+-- Строки остатков для уже существующих товаров и их вариантов. Реальных остатков миграция
+-- не знает, поэтому все они нулевые: до SetStock товары не продаются.
+INSERT INTO stock (sneaker_id, variant_id, quantity)
+SELECT id, 0, 0 FROM sneakers
+UNION ALL
+SELECT sneaker_id, id, 0 FROM sneaker_variants
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_reservations;
+DROP TABLE IF EXISTS stock;
@@ -0,0 +1,40 @@
+-- +goose Up
+ALTER TABLE sneakers
+    ADD COLUMN IF NOT EXISTS brand VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS sales_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+-- Названия смешанные (русские и латинские), поэтому индексируем обеими конфигурациями.
+ALTER TABLE sneakers
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(brand, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(category, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_sneakers_search_vector ON sneakers USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_sneakers_price ON sneakers (price);
+CREATE INDEX IF NOT EXISTS idx_sneakers_brand ON sneakers (brand);
+CREATE INDEX IF NOT EXISTS idx_sneakers_category ON sneakers (category);
+
+UPDATE sneakers SET brand = 'Nike' WHERE title ILIKE '%Nike%' OR title ILIKE '%Air Jordan%';
+UPDATE sneakers SET brand = 'Puma' WHERE title ILIKE '%Puma%';
+UPDATE sneakers SET brand = 'Demix' WHERE title ILIKE '%Demix%';
+UPDATE sneakers SET category = 'basketball'
+WHERE title ILIKE ANY (ARRAY['%Jordan%', '%Kyrie%', '%Lebron%', '%CURRY%']);
+UPDATE sneakers SET category = 'running' WHERE title ILIKE '%Future Ride%';
+-- Категорию остальных по названию не определить: она остаётся пустой до правки администратором.
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sneakers_category;
+DROP INDEX IF EXISTS idx_sneakers_brand;
+DROP INDEX IF EXISTS idx_sneakers_price;
+DROP INDEX IF EXISTS idx_sneakers_search_vector;
+ALTER TABLE sneakers
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS sales_count,
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS brand;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE sneakers
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE sneakers
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS description;
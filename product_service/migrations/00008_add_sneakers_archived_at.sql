@@ -0,0 +1,11 @@
+-- +goose Up
+-- Товары не удаляются физически: на них ссылаются позиции старых заказов.
+ALTER TABLE sneakers
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_sneakers_active ON sneakers (id) WHERE archived_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sneakers_active;
+ALTER TABLE sneakers
+    DROP COLUMN IF EXISTS archived_at;
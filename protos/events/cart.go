@@ -0,0 +1,6 @@
+package events
+
+// SourceCartService — источник событий корзины. Данные CartItemAdded, CartItemUpdated,
+// CartItemRemoved и CartCleared — events.CartEvent, CartAbandoned — events.CartAbandoned;
+// разбираются через Decode и UnmarshalData.
+const SourceCartService = "cart_service"
@@ -0,0 +1,129 @@
+// Package events — кодирование и разбор событий Kafka по контрактам proto/events.
+//
+// Событие передаётся конвертом Envelope (CloudEvents 1.0, структурный режим) с данными
+// в google.protobuf.Any. Версия схемы — заголовок schema_version: совместимые изменения
+// (новые поля, новые типы событий) её не меняют, несовместимые — поднимают, и потребитель,
+// не знающий версию, получает ErrUnsupportedSchema вместо неверно разобранных данных.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+)
+
+const (
+	// SpecVersion — версия CloudEvents в поле specversion.
+	SpecVersion = "1.0"
+	// SchemaVersion — текущая версия схемы событий.
+	SchemaVersion = "1"
+	// ContentType — тип содержимого сообщения в структурном режиме CloudEvents.
+	ContentType = "application/cloudevents+protobuf"
+)
+
+// Заголовки сообщения Kafka. event_id и event_type дублируют атрибуты конверта,
+// чтобы фильтровать и отбрасывать повторы, не разбирая тело.
+const (
+	HeaderEventID       = "event_id"
+	HeaderEventType     = "event_type"
+	HeaderSchemaVersion = "schema_version"
+	HeaderContentType   = "content-type"
+)
+
+var (
+	// ErrUnsupportedSchema — версия схемы сообщения неизвестна этой версии библиотеки.
+	ErrUnsupportedSchema = errors.New("unsupported event schema version")
+	// ErrInvalidEnvelope — тело не разбирается как конверт или в нём нет обязательных атрибутов.
+	ErrInvalidEnvelope = errors.New("invalid event envelope")
+	// ErrUnexpectedData — данные конверта другого типа, чем ожидает потребитель.
+	ErrUnexpectedData = errors.New("unexpected event data type")
+)
+
+// Meta — атрибуты события для Marshal.
+type Meta struct {
+	ID      string
+	Source  string
+	Type    string
+	Subject string
+	// Time — когда событие произошло; нулевое — момент кодирования.
+	Time time.Time
+	// TraceParent и TraceState — контекст трассировки W3C; пустые, если трассировки нет.
+	TraceParent string
+	TraceState  string
+}
+
+// Marshal кодирует событие в конверт версии SchemaVersion.
+func Marshal(meta Meta, data proto.Message) ([]byte, error) {
+	const op = "events.Marshal"
+
+	if meta.ID == "" || meta.Source == "" || meta.Type == "" {
+		return nil, fmt.Errorf("%s: id, source and type are required: %w", op, ErrInvalidEnvelope)
+	}
+	if meta.Time.IsZero() {
+		meta.Time = time.Now()
+	}
+
+	payload, err := anypb.New(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: pack data: %w", op, err)
+	}
+
+	value, err := proto.Marshal(&eventsv1.Envelope{
+		Id:          meta.ID,
+		Source:      meta.Source,
+		Type:        meta.Type,
+		Specversion: SpecVersion,
+		Time:        timestamppb.New(meta.Time),
+		Subject:     meta.Subject,
+		Traceparent: meta.TraceParent,
+		Tracestate:  meta.TraceState,
+		Data:        payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: marshal envelope: %w", op, err)
+	}
+	return value, nil
+}
+
+// Decode разбирает конверт из тела сообщения с заголовком schema_version = schemaVersion.
+// Неизвестные поля, добавленные в схему позже, пропускаются.
+func Decode(schemaVersion string, value []byte) (*eventsv1.Envelope, error) {
+	const op = "events.Decode"
+
+	if schemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("%s: version %q: %w", op, schemaVersion, ErrUnsupportedSchema)
+	}
+
+	var env eventsv1.Envelope
+	if err := proto.Unmarshal(value, &env); err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", op, ErrInvalidEnvelope, err)
+	}
+	if env.GetSpecversion() != SpecVersion {
+		return nil, fmt.Errorf("%s: specversion %q: %w", op, env.GetSpecversion(), ErrInvalidEnvelope)
+	}
+	if env.GetId() == "" || env.GetSource() == "" || env.GetType() == "" || env.GetData() == nil {
+		return nil, fmt.Errorf("%s: id, source, type and data are required: %w", op, ErrInvalidEnvelope)
+	}
+	return &env, nil
+}
+
+// UnmarshalData разбирает данные конверта в msg. Если в конверте сообщение другого
+// типа, возвращает ErrUnexpectedData.
+func UnmarshalData(env *eventsv1.Envelope, msg proto.Message) error {
+	const op = "events.UnmarshalData"
+
+	if !env.GetData().MessageIs(msg) {
+		return fmt.Errorf("%s: got %q, want %q: %w", op, env.GetData().GetTypeUrl(),
+			msg.ProtoReflect().Descriptor().FullName(), ErrUnexpectedData)
+	}
+	if err := env.GetData().UnmarshalTo(msg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/stpnv0/protos/events"
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+)
+
+var testMeta = events.Meta{
+	ID:          "42",
+	Source:      events.SourceOrderService,
+	Type:        "OrderCreated",
+	Subject:     "order-7",
+	Time:        time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+}
+
+func testOrderEvent() *eventsv1.OrderEvent {
+	return &eventsv1.OrderEvent{
+		OrderId:        7,
+		UserId:         3,
+		Status:         "PENDING",
+		TotalAmount:    25000,
+		CustomerEmail:  "user@example.com",
+		PromoCode:      "SALE",
+		DiscountAmount: 1000,
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	value, err := events.Marshal(testMeta, testOrderEvent())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	env, event, err := events.DecodeOrderEvent(events.SchemaVersion, value)
+	if err != nil {
+		t.Fatalf("DecodeOrderEvent: %v", err)
+	}
+	if env.GetId() != "42" || env.GetSource() != "order_service" || env.GetType() != "OrderCreated" ||
+		env.GetSubject() != "order-7" || env.GetSpecversion() != events.SpecVersion ||
+		env.GetTraceparent() != testMeta.TraceParent || !env.GetTime().AsTime().Equal(testMeta.Time) {
+		t.Errorf("unexpected envelope: %v", env)
+	}
+	if !proto.Equal(event, testOrderEvent()) {
+		t.Errorf("got %v, want %v", event, testOrderEvent())
+	}
+}
+
+func TestRoundTrip_CartAbandoned(t *testing.T) {
+	data := &eventsv1.CartAbandoned{
+		UserId: 3,
+		Items:  []*eventsv1.CartLine{{ItemId: "5", SneakerId: 10, VariantId: 42, Quantity: 2}},
+	}
+	value, err := events.Marshal(events.Meta{ID: "cart-3", Source: events.SourceCartService, Type: "CartAbandoned"}, data)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	env, err := events.Decode(events.SchemaVersion, value)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	var got eventsv1.CartAbandoned
+	if err := events.UnmarshalData(env, &got); err != nil {
+		t.Fatalf("UnmarshalData: %v", err)
+	}
+	if !proto.Equal(&got, data) {
+		t.Errorf("got %v, want %v", &got, data)
+	}
+
+	// Данные корзины не разбираются как событие заказа.
+	if _, _, err := events.DecodeOrderEvent(events.SchemaVersion, value); !errors.Is(err, events.ErrUnexpectedData) {
+		t.Errorf("got %v, want ErrUnexpectedData", err)
+	}
+}
+
+func TestMarshal_RequiresAttributes(t *testing.T) {
+	meta := testMeta
+	meta.ID = ""
+
+	if _, err := events.Marshal(meta, testOrderEvent()); !errors.Is(err, events.ErrInvalidEnvelope) {
+		t.Fatalf("got %v, want ErrInvalidEnvelope", err)
+	}
+}
+
+// Сообщения, уже лежащие в топике, должны читаться и после изменений схемы.
+// testdata/order_created.v1.hex записан первой версией схемы и не перегенерируется.
+func TestDecode_GoldenV1(t *testing.T) {
+	raw, err := os.ReadFile("testdata/order_created.v1.hex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := hex.DecodeString(string(bytes.TrimSpace(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, event, err := events.DecodeOrderEvent("1", value)
+	if err != nil {
+		t.Fatalf("DecodeOrderEvent: %v", err)
+	}
+	if env.GetId() != "42" || env.GetType() != "OrderCreated" || env.GetSubject() != "order-7" ||
+		!env.GetTime().AsTime().Equal(testMeta.Time) {
+		t.Errorf("unexpected envelope: %v", env)
+	}
+	if !proto.Equal(event, testOrderEvent()) {
+		t.Errorf("got %v, want %v", event, testOrderEvent())
+	}
+}
+
+// Номера полей — часть контракта: переименовать поле можно, перенумеровать или
+// поменять тип — только с новой версией схемы.
+func TestSchemaFieldNumbers(t *testing.T) {
+	tests := []struct {
+		msg    proto.Message
+		fields map[protoreflect.Name]protoreflect.FieldNumber
+	}{
+		{
+			msg: &eventsv1.Envelope{},
+			fields: map[protoreflect.Name]protoreflect.FieldNumber{
+				"id": 1, "source": 2, "type": 3, "specversion": 4, "time": 5, "subject": 6,
+				"traceparent": 7, "tracestate": 8, "data": 9,
+			},
+		},
+		{
+			msg: &eventsv1.OrderEvent{},
+			fields: map[protoreflect.Name]protoreflect.FieldNumber{
+				"order_id": 1, "user_id": 2, "status": 3, "total_amount": 4, "customer_email": 5,
+				"reason": 6, "refunded_amount": 7, "carrier": 8, "tracking_number": 9,
+				"promo_code": 10, "discount_amount": 11,
+			},
+		},
+		{
+			msg: &eventsv1.CartEvent{},
+			fields: map[protoreflect.Name]protoreflect.FieldNumber{
+				"user_id": 1, "item_id": 2, "sneaker_id": 3, "variant_id": 4, "quantity": 5,
+			},
+		},
+		{
+			msg: &eventsv1.CartAbandoned{},
+			fields: map[protoreflect.Name]protoreflect.FieldNumber{
+				"user_id": 1, "items": 2, "updated_at": 3,
+			},
+		},
+		{
+			msg: &eventsv1.CartLine{},
+			fields: map[protoreflect.Name]protoreflect.FieldNumber{
+				"item_id": 1, "sneaker_id": 2, "variant_id": 3, "quantity": 4, "added_at": 5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		desc := tt.msg.ProtoReflect().Descriptor()
+		t.Run(string(desc.FullName()), func(t *testing.T) {
+			for name, number := range tt.fields {
+				field := desc.Fields().ByName(name)
+				if field == nil {
+					t.Errorf("field %s removed", name)
+					continue
+				}
+				if field.Number() != number {
+					t.Errorf("field %s: number %d, want %d", name, field.Number(), number)
+				}
+			}
+		})
+	}
+}
+
+// Потребитель со старой схемой читает события, в которые добавили поля.
+func TestDecode_UnknownFieldsIgnored(t *testing.T) {
+	data, err := proto.Marshal(testOrderEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = protowire.AppendTag(data, 100, protowire.BytesType)
+	data = protowire.AppendString(data, "added later")
+
+	env := &eventsv1.Envelope{
+		Id: "1", Source: "order_service", Type: "OrderCreated", Specversion: events.SpecVersion,
+		Data: &anypb.Any{TypeUrl: "type.googleapis.com/events.OrderEvent", Value: data},
+	}
+	value, err := proto.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value = protowire.AppendTag(value, 100, protowire.VarintType)
+	value = protowire.AppendVarint(value, 1)
+
+	_, event, err := events.DecodeOrderEvent(events.SchemaVersion, value)
+	if err != nil {
+		t.Fatalf("DecodeOrderEvent: %v", err)
+	}
+	if event.GetOrderId() != 7 || event.GetCustomerEmail() != "user@example.com" {
+		t.Errorf("unexpected event: %v", event)
+	}
+}
+
+func TestDecodeOrderEvent_LegacyJSON(t *testing.T) {
+	value := []byte(`{"event_type":"OrderShipped","order_id":7,"user_id":3,"status":"SHIPPED",` +
+		`"total_amount":25000,"carrier":"cdek","tracking_number":"TR-1","timestamp":"2025-03-01T12:00:00Z"}`)
+
+	env, event, err := events.DecodeOrderEvent("", value)
+	if err != nil {
+		t.Fatalf("DecodeOrderEvent: %v", err)
+	}
+	if env.GetId() != "" || env.GetType() != "OrderShipped" || env.GetSource() != "order_service" ||
+		env.GetSubject() != "order-7" || !env.GetTime().AsTime().Equal(testMeta.Time) {
+		t.Errorf("unexpected envelope: %v", env)
+	}
+	want := &eventsv1.OrderEvent{
+		OrderId: 7, UserId: 3, Status: "SHIPPED", TotalAmount: 25000, Carrier: "cdek", TrackingNumber: "TR-1",
+	}
+	if !proto.Equal(event, want) {
+		t.Errorf("got %v, want %v", event, want)
+	}
+}
+
+// Прежний OrderCreated нёс ссылку на оплату в payment_url.
+func TestDecodeOrderEvent_LegacyPaymentURL(t *testing.T) {
+	value := []byte(`{"event_type":"OrderCreated","order_id":7,"user_id":3,"status":"PENDING_PAYMENT",` +
+		`"total_amount":25000,"payment_url":"https://pay.example/7","timestamp":"2025-03-01T12:00:00Z"}`)
+
+	_, event, err := events.DecodeOrderEvent("", value)
+	if err != nil {
+		t.Fatalf("DecodeOrderEvent: %v", err)
+	}
+	if event.GetPaymentUrl() != "https://pay.example/7" {
+		t.Errorf("payment_url = %q", event.GetPaymentUrl())
+	}
+}
+
+func TestDecodeOrderEvent_Errors(t *testing.T) {
+	valid, err := events.Marshal(testMeta, testOrderEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongData, err := events.Marshal(testMeta, &eventsv1.Envelope{Id: "nested"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noSpec, err := proto.Marshal(&eventsv1.Envelope{Id: "1", Source: "order_service", Type: "OrderCreated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		schemaVersion string
+		value         []byte
+		wantErr       error
+	}{
+		{name: "future schema version", schemaVersion: "2", value: valid, wantErr: events.ErrUnsupportedSchema},
+		{name: "garbage", schemaVersion: "1", value: []byte("not protobuf"), wantErr: events.ErrInvalidEnvelope},
+		{name: "missing specversion", schemaVersion: "1", value: noSpec, wantErr: events.ErrInvalidEnvelope},
+		{name: "wrong data type", schemaVersion: "1", value: wrongData, wantErr: events.ErrUnexpectedData},
+		{name: "legacy without type", value: []byte(`{"order_id":7}`), wantErr: events.ErrInvalidEnvelope},
+		{name: "legacy garbage", value: []byte("{"), wantErr: events.ErrInvalidEnvelope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := events.DecodeOrderEvent(tt.schemaVersion, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/stpnv0/protos/gen/go/events"
+)
+
+// SourceOrderService — источник событий заказа.
+const SourceOrderService = "order_service"
+
+// legacyOrderEvent — JSON-событие заказа, которое order_service публиковал до конвертов
+// (сообщения без заголовка schema_version).
+type legacyOrderEvent struct {
+	EventType      string `json:"event_type"`
+	OrderID        int64  `json:"order_id"`
+	UserID         int64  `json:"user_id"`
+	Status         string `json:"status"`
+	TotalAmount    int64  `json:"total_amount"`
+	CustomerEmail  string `json:"customer_email"`
+	RefundedAmount int64  `json:"refunded_amount"`
+	Reason         string `json:"reason"`
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"tracking_number"`
+	PromoCode      string `json:"promo_code"`
+	DiscountAmount int64  `json:"discount_amount"`
+	PaymentURL     string `json:"payment_url"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// DecodeOrderEvent разбирает событие заказа. Сообщения без заголовка schema_version
+// (schemaVersion = "") разбираются как JSON прежнего формата: у такого конверта нет id —
+// идентификатором остаётся заголовок event_id.
+func DecodeOrderEvent(schemaVersion string, value []byte) (*eventsv1.Envelope, *eventsv1.OrderEvent, error) {
+	const op = "events.DecodeOrderEvent"
+
+	if schemaVersion == "" {
+		env, event, err := decodeLegacyOrderEvent(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return env, event, nil
+	}
+
+	env, err := Decode(schemaVersion, value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var event eventsv1.OrderEvent
+	if err := UnmarshalData(env, &event); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return env, &event, nil
+}
+
+func decodeLegacyOrderEvent(value []byte) (*eventsv1.Envelope, *eventsv1.OrderEvent, error) {
+	var legacy legacyOrderEvent
+	if err := json.Unmarshal(value, &legacy); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if legacy.EventType == "" {
+		return nil, nil, fmt.Errorf("event_type is required: %w", ErrInvalidEnvelope)
+	}
+
+	env := &eventsv1.Envelope{
+		Source:      SourceOrderService,
+		Type:        legacy.EventType,
+		Specversion: SpecVersion,
+		Subject:     fmt.Sprintf("order-%d", legacy.OrderID),
+	}
+	if ts, err := time.Parse(time.RFC3339, legacy.Timestamp); err == nil {
+		env.Time = timestamppb.New(ts)
+	}
+
+	return env, &eventsv1.OrderEvent{
+		OrderId:        legacy.OrderID,
+		UserId:         legacy.UserID,
+		Status:         legacy.Status,
+		TotalAmount:    legacy.TotalAmount,
+		CustomerEmail:  legacy.CustomerEmail,
+		Reason:         legacy.Reason,
+		RefundedAmount: legacy.RefundedAmount,
+		Carrier:        legacy.Carrier,
+		TrackingNumber: legacy.TrackingNumber,
+		PromoCode:      legacy.PromoCode,
+		DiscountAmount: legacy.DiscountAmount,
+		PaymentUrl:     legacy.PaymentURL,
+	}, nil
+}
@@ -29,6 +29,7 @@ type CartItem struct {
 	SneakerId     int64                  `protobuf:"varint,2,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	AddedAt       int64                  `protobuf:"varint,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	VariantId     int64                  `protobuf:"varint,5,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,6 +92,13 @@ func (x *CartItem) GetAddedAt() int64 {
 	return 0
 }
 
+func (x *CartItem) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type Cart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -156,6 +164,7 @@ type AddToCartRequest struct {
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	SneakerId     int64                  `protobuf:"varint,2,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	VariantId     int64                  `protobuf:"varint,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -211,10 +220,20 @@ func (x *AddToCartRequest) GetQuantity() int32 {
 	return 0
 }
 
+func (x *AddToCartRequest) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
 type AddToCartResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Строка корзины после добавления: повторно добавленный товар
+	// увеличивает количество существующей строки.
+	Item          *CartItem `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -263,6 +282,13 @@ func (x *AddToCartResponse) GetMessage() string {
 	return ""
 }
 
+func (x *AddToCartResponse) GetItem() *CartItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
 type GetCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -412,9 +438,11 @@ func (x *UpdateQuantityRequest) GetQuantity() int32 {
 }
 
 type UpdateQuantityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Строка корзины после изменения.
+	Item          *CartItem `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -463,6 +491,13 @@ func (x *UpdateQuantityResponse) GetMessage() string {
 	return ""
 }
 
+func (x *UpdateQuantityResponse) GetItem() *CartItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
 type RemoveFromCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -667,26 +702,31 @@ var File_cart_cart_proto protoreflect.FileDescriptor
 
 const file_cart_cart_proto_rawDesc = "" +
 	"\n" +
-	"\x0fcart/cart.proto\x12\x04cart\"p\n" +
+	"\x0fcart/cart.proto\x12\x04cart\"\x8f\x01\n" +
 	"\bCartItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
 	"sneaker_id\x18\x02 \x01(\x03R\tsneakerId\x12\x1a\n" +
 	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x19\n" +
-	"\badded_at\x18\x04 \x01(\x03R\aaddedAt\"d\n" +
+	"\badded_at\x18\x04 \x01(\x03R\aaddedAt\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x05 \x01(\x03R\tvariantId\"d\n" +
 	"\x04Cart\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12$\n" +
 	"\x05items\x18\x02 \x03(\v2\x0e.cart.CartItemR\x05items\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"f\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"\x85\x01\n" +
 	"\x10AddToCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
 	"\n" +
 	"sneaker_id\x18\x02 \x01(\x03R\tsneakerId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"G\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\x03R\tvariantId\"k\n" +
 	"\x11AddToCartResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\")\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04item\x18\x03 \x01(\v2\x0e.cart.CartItemR\x04item\")\n" +
 	"\x0eGetCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\"1\n" +
 	"\x0fGetCartResponse\x12\x1e\n" +
@@ -695,10 +735,11 @@ const file_cart_cart_proto_rawDesc = "" +
 	"\x15UpdateQuantityRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
 	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"L\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"p\n" +
 	"\x16UpdateQuantityResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"I\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04item\x18\x03 \x01(\v2\x0e.cart.CartItemR\x04item\"I\n" +
 	"\x15RemoveFromCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
 	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"L\n" +
@@ -746,22 +787,24 @@ var file_cart_cart_proto_goTypes = []any{
 }
 var file_cart_cart_proto_depIdxs = []int32{
 	0,  // 0: cart.Cart.items:type_name -> cart.CartItem
-	1,  // 1: cart.GetCartResponse.cart:type_name -> cart.Cart
-	2,  // 2: cart.CartService.AddToCart:input_type -> cart.AddToCartRequest
-	4,  // 3: cart.CartService.GetCart:input_type -> cart.GetCartRequest
-	6,  // 4: cart.CartService.UpdateCartItemQuantity:input_type -> cart.UpdateQuantityRequest
-	8,  // 5: cart.CartService.RemoveFromCart:input_type -> cart.RemoveFromCartRequest
-	10, // 6: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
-	3,  // 7: cart.CartService.AddToCart:output_type -> cart.AddToCartResponse
-	5,  // 8: cart.CartService.GetCart:output_type -> cart.GetCartResponse
-	7,  // 9: cart.CartService.UpdateCartItemQuantity:output_type -> cart.UpdateQuantityResponse
-	9,  // 10: cart.CartService.RemoveFromCart:output_type -> cart.RemoveFromCartResponse
-	11, // 11: cart.CartService.ClearCart:output_type -> cart.ClearCartResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	0,  // 1: cart.AddToCartResponse.item:type_name -> cart.CartItem
+	1,  // 2: cart.GetCartResponse.cart:type_name -> cart.Cart
+	0,  // 3: cart.UpdateQuantityResponse.item:type_name -> cart.CartItem
+	2,  // 4: cart.CartService.AddToCart:input_type -> cart.AddToCartRequest
+	4,  // 5: cart.CartService.GetCart:input_type -> cart.GetCartRequest
+	6,  // 6: cart.CartService.UpdateCartItemQuantity:input_type -> cart.UpdateQuantityRequest
+	8,  // 7: cart.CartService.RemoveFromCart:input_type -> cart.RemoveFromCartRequest
+	10, // 8: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
+	3,  // 9: cart.CartService.AddToCart:output_type -> cart.AddToCartResponse
+	5,  // 10: cart.CartService.GetCart:output_type -> cart.GetCartResponse
+	7,  // 11: cart.CartService.UpdateCartItemQuantity:output_type -> cart.UpdateQuantityResponse
+	9,  // 12: cart.CartService.RemoveFromCart:output_type -> cart.RemoveFromCartResponse
+	11, // 13: cart.CartService.ClearCart:output_type -> cart.ClearCartResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_cart_cart_proto_init() }
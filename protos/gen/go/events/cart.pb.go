@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: events/cart.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CartEvent — данные событий изменения корзины cart_service: CartItemAdded,
+// CartItemUpdated, CartItemRemoved и CartCleared (у него заполнен только user_id).
+type CartEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// id строки корзины.
+	ItemId    string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SneakerId int64  `protobuf:"varint,3,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	// 0 — товар без вариантов.
+	VariantId int64 `protobuf:"varint,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// Количество после изменения; у CartItemRemoved — сколько было удалено.
+	Quantity      int32 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartEvent) Reset() {
+	*x = CartEvent{}
+	mi := &file_events_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartEvent) ProtoMessage() {}
+
+func (x *CartEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartEvent.ProtoReflect.Descriptor instead.
+func (*CartEvent) Descriptor() ([]byte, []int) {
+	return file_events_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CartEvent) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CartEvent) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CartEvent) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *CartEvent) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *CartEvent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// CartAbandoned — данные события CartAbandoned: корзина с товарами,
+// которую не меняли дольше порога. Повторно для той же корзины событие приходит,
+// только если её снова изменили и снова забыли.
+type CartAbandoned struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*CartLine            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Когда корзину меняли в последний раз.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartAbandoned) Reset() {
+	*x = CartAbandoned{}
+	mi := &file_events_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartAbandoned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartAbandoned) ProtoMessage() {}
+
+func (x *CartAbandoned) ProtoReflect() protoreflect.Message {
+	mi := &file_events_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartAbandoned.ProtoReflect.Descriptor instead.
+func (*CartAbandoned) Descriptor() ([]byte, []int) {
+	return file_events_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CartAbandoned) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CartAbandoned) GetItems() []*CartLine {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CartAbandoned) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CartLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SneakerId     int64                  `protobuf:"varint,2,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartLine) Reset() {
+	*x = CartLine{}
+	mi := &file_events_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLine) ProtoMessage() {}
+
+func (x *CartLine) ProtoReflect() protoreflect.Message {
+	mi := &file_events_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLine.ProtoReflect.Descriptor instead.
+func (*CartLine) Descriptor() ([]byte, []int) {
+	return file_events_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartLine) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CartLine) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *CartLine) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *CartLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartLine) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+var File_events_cart_proto protoreflect.FileDescriptor
+
+const file_events_cart_proto_rawDesc = "" +
+	"\n" +
+	"\x11events/cart.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x01\n" +
+	"\tCartEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x03 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\x03R\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\"\x8b\x01\n" +
+	"\rCartAbandoned\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12&\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.events.CartLineR\x05items\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb4\x01\n" +
+	"\bCartLine\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x02 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x03R\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x125\n" +
+	"\badded_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAtB(Z&github.com/stpnv0/protos/gen/go/eventsb\x06proto3"
+
+var (
+	file_events_cart_proto_rawDescOnce sync.Once
+	file_events_cart_proto_rawDescData []byte
+)
+
+func file_events_cart_proto_rawDescGZIP() []byte {
+	file_events_cart_proto_rawDescOnce.Do(func() {
+		file_events_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_cart_proto_rawDesc), len(file_events_cart_proto_rawDesc)))
+	})
+	return file_events_cart_proto_rawDescData
+}
+
+var file_events_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_cart_proto_goTypes = []any{
+	(*CartEvent)(nil),             // 0: events.CartEvent
+	(*CartAbandoned)(nil),         // 1: events.CartAbandoned
+	(*CartLine)(nil),              // 2: events.CartLine
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_events_cart_proto_depIdxs = []int32{
+	2, // 0: events.CartAbandoned.items:type_name -> events.CartLine
+	3, // 1: events.CartAbandoned.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 2: events.CartLine.added_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_events_cart_proto_init() }
+func file_events_cart_proto_init() {
+	if File_events_cart_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_cart_proto_rawDesc), len(file_events_cart_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_cart_proto_goTypes,
+		DependencyIndexes: file_events_cart_proto_depIdxs,
+		MessageInfos:      file_events_cart_proto_msgTypes,
+	}.Build()
+	File_events_cart_proto = out.File
+	file_events_cart_proto_goTypes = nil
+	file_events_cart_proto_depIdxs = nil
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,12 +23,74 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SearchSort — порядок сортировки результатов поиска.
+type SearchSort int32
+
+const (
+	SearchSort_SEARCH_SORT_RELEVANCE  SearchSort = 0 // по релевантности, без запроса — по id
+	SearchSort_SEARCH_SORT_PRICE_ASC  SearchSort = 1
+	SearchSort_SEARCH_SORT_PRICE_DESC SearchSort = 2
+	SearchSort_SEARCH_SORT_NEWEST     SearchSort = 3
+	SearchSort_SEARCH_SORT_POPULARITY SearchSort = 4
+)
+
+// Enum value maps for SearchSort.
+var (
+	SearchSort_name = map[int32]string{
+		0: "SEARCH_SORT_RELEVANCE",
+		1: "SEARCH_SORT_PRICE_ASC",
+		2: "SEARCH_SORT_PRICE_DESC",
+		3: "SEARCH_SORT_NEWEST",
+		4: "SEARCH_SORT_POPULARITY",
+	}
+	SearchSort_value = map[string]int32{
+		"SEARCH_SORT_RELEVANCE":  0,
+		"SEARCH_SORT_PRICE_ASC":  1,
+		"SEARCH_SORT_PRICE_DESC": 2,
+		"SEARCH_SORT_NEWEST":     3,
+		"SEARCH_SORT_POPULARITY": 4,
+	}
+)
+
+func (x SearchSort) Enum() *SearchSort {
+	p := new(SearchSort)
+	*p = x
+	return p
+}
+
+func (x SearchSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_product_product_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchSort) Type() protoreflect.EnumType {
+	return &file_product_product_proto_enumTypes[0]
+}
+
+func (x SearchSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchSort.Descriptor instead.
+func (SearchSort) EnumDescriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{0}
+}
+
 type Sneaker struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	PriceKopecks  int64                  `protobuf:"varint,3,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"`
 	ImageKey      string                 `protobuf:"bytes,4,opt,name=image_key,json=imageKey,proto3" json:"image_key,omitempty"`
+	Variants      []*SneakerVariant      `protobuf:"bytes,5,rep,name=variants,proto3" json:"variants,omitempty"`
+	Brand         string                 `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Version       int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`    // растёт при каждом изменении карточки
+	Archived      bool                   `protobuf:"varint,10,opt,name=archived,proto3" json:"archived,omitempty"` // снят с продажи: не виден в каталоге, но доступен по ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,17 +153,147 @@ func (x *Sneaker) GetImageKey() string {
 	return ""
 }
 
+func (x *Sneaker) GetVariants() []*SneakerVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Sneaker) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Sneaker) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Sneaker) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Sneaker) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Sneaker) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+// SneakerVariant — конкретный SKU модели (размер + расцветка).
+type SneakerVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SneakerId     int64                  `protobuf:"varint,2,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	Size          string                 `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	Color         string                 `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	PriceKopecks  int64                  `protobuf:"varint,6,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"` // 0 — используется цена модели
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SneakerVariant) Reset() {
+	*x = SneakerVariant{}
+	mi := &file_product_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SneakerVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SneakerVariant) ProtoMessage() {}
+
+func (x *SneakerVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SneakerVariant.ProtoReflect.Descriptor instead.
+func (*SneakerVariant) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SneakerVariant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SneakerVariant) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *SneakerVariant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SneakerVariant) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *SneakerVariant) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *SneakerVariant) GetPriceKopecks() int64 {
+	if x != nil {
+		return x.PriceKopecks
+	}
+	return 0
+}
+
 type AddSneakerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	PriceKopecks  int64                  `protobuf:"varint,2,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"`
+	Brand         string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddSneakerRequest) Reset() {
 	*x = AddSneakerRequest{}
-	mi := &file_product_product_proto_msgTypes[1]
+	mi := &file_product_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -112,7 +305,7 @@ func (x *AddSneakerRequest) String() string {
 func (*AddSneakerRequest) ProtoMessage() {}
 
 func (x *AddSneakerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[1]
+	mi := &file_product_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -125,7 +318,7 @@ func (x *AddSneakerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSneakerRequest.ProtoReflect.Descriptor instead.
 func (*AddSneakerRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{1}
+	return file_product_product_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *AddSneakerRequest) GetTitle() string {
@@ -142,6 +335,129 @@ func (x *AddSneakerRequest) GetPriceKopecks() int64 {
 	return 0
 }
 
+func (x *AddSneakerRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *AddSneakerRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AddSneakerRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// UpdateSneakerRequest меняет только поля, перечисленные в update_mask:
+// title, price_kopecks, description, brand, category.
+type UpdateSneakerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // версия, которую видел клиент
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	PriceKopecks    int64                  `protobuf:"varint,5,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"`
+	Description     string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Brand           string                 `protobuf:"bytes,7,opt,name=brand,proto3" json:"brand,omitempty"`
+	Category        string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateSneakerRequest) Reset() {
+	*x = UpdateSneakerRequest{}
+	mi := &file_product_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSneakerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSneakerRequest) ProtoMessage() {}
+
+func (x *UpdateSneakerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSneakerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSneakerRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateSneakerRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSneakerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateSneakerRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateSneakerRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateSneakerRequest) GetPriceKopecks() int64 {
+	if x != nil {
+		return x.PriceKopecks
+	}
+	return 0
+}
+
+func (x *UpdateSneakerRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateSneakerRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *UpdateSneakerRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 type GenerateUploadURLRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	OriginalFilename string                 `protobuf:"bytes,1,opt,name=original_filename,json=originalFilename,proto3" json:"original_filename,omitempty"`
@@ -152,7 +468,7 @@ type GenerateUploadURLRequest struct {
 
 func (x *GenerateUploadURLRequest) Reset() {
 	*x = GenerateUploadURLRequest{}
-	mi := &file_product_product_proto_msgTypes[2]
+	mi := &file_product_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +480,7 @@ func (x *GenerateUploadURLRequest) String() string {
 func (*GenerateUploadURLRequest) ProtoMessage() {}
 
 func (x *GenerateUploadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[2]
+	mi := &file_product_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +493,7 @@ func (x *GenerateUploadURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateUploadURLRequest.ProtoReflect.Descriptor instead.
 func (*GenerateUploadURLRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{2}
+	return file_product_product_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GenerateUploadURLRequest) GetOriginalFilename() string {
@@ -203,7 +519,7 @@ type GetSneakerByIDRequest struct {
 
 func (x *GetSneakerByIDRequest) Reset() {
 	*x = GetSneakerByIDRequest{}
-	mi := &file_product_product_proto_msgTypes[3]
+	mi := &file_product_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -215,7 +531,7 @@ func (x *GetSneakerByIDRequest) String() string {
 func (*GetSneakerByIDRequest) ProtoMessage() {}
 
 func (x *GetSneakerByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[3]
+	mi := &file_product_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +544,7 @@ func (x *GetSneakerByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSneakerByIDRequest.ProtoReflect.Descriptor instead.
 func (*GetSneakerByIDRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{3}
+	return file_product_product_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetSneakerByIDRequest) GetId() int64 {
@@ -248,7 +564,7 @@ type UpdateProductImageRequest struct {
 
 func (x *UpdateProductImageRequest) Reset() {
 	*x = UpdateProductImageRequest{}
-	mi := &file_product_product_proto_msgTypes[4]
+	mi := &file_product_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -260,7 +576,7 @@ func (x *UpdateProductImageRequest) String() string {
 func (*UpdateProductImageRequest) ProtoMessage() {}
 
 func (x *UpdateProductImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[4]
+	mi := &file_product_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +589,7 @@ func (x *UpdateProductImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductImageRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductImageRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{4}
+	return file_product_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateProductImageRequest) GetProductId() int64 {
@@ -290,6 +606,7 @@ func (x *UpdateProductImageRequest) GetImageKey() string {
 	return ""
 }
 
+// DeleteSneakerRequest архивирует товар: строка остаётся для истории заказов.
 type DeleteSneakerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -299,7 +616,7 @@ type DeleteSneakerRequest struct {
 
 func (x *DeleteSneakerRequest) Reset() {
 	*x = DeleteSneakerRequest{}
-	mi := &file_product_product_proto_msgTypes[5]
+	mi := &file_product_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -311,7 +628,7 @@ func (x *DeleteSneakerRequest) String() string {
 func (*DeleteSneakerRequest) ProtoMessage() {}
 
 func (x *DeleteSneakerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[5]
+	mi := &file_product_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +641,7 @@ func (x *DeleteSneakerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSneakerRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSneakerRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{5}
+	return file_product_product_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteSneakerRequest) GetId() int64 {
@@ -334,28 +651,28 @@ func (x *DeleteSneakerRequest) GetId() int64 {
 	return 0
 }
 
-type GetSneakersByIDsRequest struct {
+type RestoreSneakerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSneakersByIDsRequest) Reset() {
-	*x = GetSneakersByIDsRequest{}
-	mi := &file_product_product_proto_msgTypes[6]
+func (x *RestoreSneakerRequest) Reset() {
+	*x = RestoreSneakerRequest{}
+	mi := &file_product_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSneakersByIDsRequest) String() string {
+func (x *RestoreSneakerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSneakersByIDsRequest) ProtoMessage() {}
+func (*RestoreSneakerRequest) ProtoMessage() {}
 
-func (x *GetSneakersByIDsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[6]
+func (x *RestoreSneakerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,41 +683,85 @@ func (x *GetSneakersByIDsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSneakersByIDsRequest.ProtoReflect.Descriptor instead.
-func (*GetSneakersByIDsRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RestoreSneakerRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSneakerRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetSneakersByIDsRequest) GetIds() []int64 {
+func (x *RestoreSneakerRequest) GetId() int64 {
 	if x != nil {
-		return x.Ids
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type GetAllSneakersRequest struct {
+type GetSneakersByIDsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         uint64                 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        uint64                 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllSneakersRequest) Reset() {
-	*x = GetAllSneakersRequest{}
-	mi := &file_product_product_proto_msgTypes[7]
+func (x *GetSneakersByIDsRequest) Reset() {
+	*x = GetSneakersByIDsRequest{}
+	mi := &file_product_product_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllSneakersRequest) String() string {
+func (x *GetSneakersByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSneakersByIDsRequest) ProtoMessage() {}
+
+func (x *GetSneakersByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSneakersByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetSneakersByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSneakersByIDsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetAllSneakersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         uint64                 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // пусто — первая страница
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllSneakersRequest) Reset() {
+	*x = GetAllSneakersRequest{}
+	mi := &file_product_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllSneakersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*GetAllSneakersRequest) ProtoMessage() {}
 
 func (x *GetAllSneakersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[7]
+	mi := &file_product_product_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +774,7 @@ func (x *GetAllSneakersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllSneakersRequest.ProtoReflect.Descriptor instead.
 func (*GetAllSneakersRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{7}
+	return file_product_product_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetAllSneakersRequest) GetLimit() uint64 {
@@ -423,13 +784,165 @@ func (x *GetAllSneakersRequest) GetLimit() uint64 {
 	return 0
 }
 
-func (x *GetAllSneakersRequest) GetOffset() uint64 {
+func (x *GetAllSneakersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchSneakersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Query           string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	MinPriceKopecks int64                  `protobuf:"varint,2,opt,name=min_price_kopecks,json=minPriceKopecks,proto3" json:"min_price_kopecks,omitempty"` // 0 — без ограничения
+	MaxPriceKopecks int64                  `protobuf:"varint,3,opt,name=max_price_kopecks,json=maxPriceKopecks,proto3" json:"max_price_kopecks,omitempty"` // 0 — без ограничения
+	Brands          []string               `protobuf:"bytes,4,rep,name=brands,proto3" json:"brands,omitempty"`
+	Categories      []string               `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"`
+	Sort            SearchSort             `protobuf:"varint,6,opt,name=sort,proto3,enum=product.SearchSort" json:"sort,omitempty"`
+	Limit           uint64                 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken       string                 `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // пусто — первая страница
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchSneakersRequest) Reset() {
+	*x = SearchSneakersRequest{}
+	mi := &file_product_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSneakersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSneakersRequest) ProtoMessage() {}
+
+func (x *SearchSneakersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSneakersRequest.ProtoReflect.Descriptor instead.
+func (*SearchSneakersRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchSneakersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSneakersRequest) GetMinPriceKopecks() int64 {
+	if x != nil {
+		return x.MinPriceKopecks
+	}
+	return 0
+}
+
+func (x *SearchSneakersRequest) GetMaxPriceKopecks() int64 {
+	if x != nil {
+		return x.MaxPriceKopecks
+	}
+	return 0
+}
+
+func (x *SearchSneakersRequest) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *SearchSneakersRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *SearchSneakersRequest) GetSort() SearchSort {
+	if x != nil {
+		return x.Sort
+	}
+	return SearchSort_SEARCH_SORT_RELEVANCE
+}
+
+func (x *SearchSneakersRequest) GetLimit() uint64 {
 	if x != nil {
-		return x.Offset
+		return x.Limit
 	}
 	return 0
 }
 
+func (x *SearchSneakersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchSneakersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sneakers      []*Sneaker             `protobuf:"bytes,1,rep,name=sneakers,proto3" json:"sneakers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — страниц больше нет
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSneakersResponse) Reset() {
+	*x = SearchSneakersResponse{}
+	mi := &file_product_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSneakersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSneakersResponse) ProtoMessage() {}
+
+func (x *SearchSneakersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSneakersResponse.ProtoReflect.Descriptor instead.
+func (*SearchSneakersResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchSneakersResponse) GetSneakers() []*Sneaker {
+	if x != nil {
+		return x.Sneakers
+	}
+	return nil
+}
+
+func (x *SearchSneakersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type GenerateUploadURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
@@ -440,7 +953,7 @@ type GenerateUploadURLResponse struct {
 
 func (x *GenerateUploadURLResponse) Reset() {
 	*x = GenerateUploadURLResponse{}
-	mi := &file_product_product_proto_msgTypes[8]
+	mi := &file_product_product_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -452,7 +965,7 @@ func (x *GenerateUploadURLResponse) String() string {
 func (*GenerateUploadURLResponse) ProtoMessage() {}
 
 func (x *GenerateUploadURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[8]
+	mi := &file_product_product_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +978,7 @@ func (x *GenerateUploadURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateUploadURLResponse.ProtoReflect.Descriptor instead.
 func (*GenerateUploadURLResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{8}
+	return file_product_product_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GenerateUploadURLResponse) GetUploadUrl() string {
@@ -491,7 +1004,7 @@ type GetSneakersByIDsResponse struct {
 
 func (x *GetSneakersByIDsResponse) Reset() {
 	*x = GetSneakersByIDsResponse{}
-	mi := &file_product_product_proto_msgTypes[9]
+	mi := &file_product_product_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -503,7 +1016,7 @@ func (x *GetSneakersByIDsResponse) String() string {
 func (*GetSneakersByIDsResponse) ProtoMessage() {}
 
 func (x *GetSneakersByIDsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[9]
+	mi := &file_product_product_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +1029,7 @@ func (x *GetSneakersByIDsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSneakersByIDsResponse.ProtoReflect.Descriptor instead.
 func (*GetSneakersByIDsResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{9}
+	return file_product_product_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetSneakersByIDsResponse) GetSneakers() []*Sneaker {
@@ -529,13 +1042,14 @@ func (x *GetSneakersByIDsResponse) GetSneakers() []*Sneaker {
 type GetAllSneakersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sneakers      []*Sneaker             `protobuf:"bytes,1,rep,name=sneakers,proto3" json:"sneakers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто — страниц больше нет
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAllSneakersResponse) Reset() {
 	*x = GetAllSneakersResponse{}
-	mi := &file_product_product_proto_msgTypes[10]
+	mi := &file_product_product_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +1061,7 @@ func (x *GetAllSneakersResponse) String() string {
 func (*GetAllSneakersResponse) ProtoMessage() {}
 
 func (x *GetAllSneakersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[10]
+	mi := &file_product_product_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +1074,7 @@ func (x *GetAllSneakersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllSneakersResponse.ProtoReflect.Descriptor instead.
 func (*GetAllSneakersResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{10}
+	return file_product_product_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetAllSneakersResponse) GetSneakers() []*Sneaker {
@@ -570,52 +1084,871 @@ func (x *GetAllSneakersResponse) GetSneakers() []*Sneaker {
 	return nil
 }
 
-var File_product_product_proto protoreflect.FileDescriptor
+func (x *GetAllSneakersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
 
-const file_product_product_proto_rawDesc = "" +
-	"\n" +
-	"\x15product/product.proto\x12\aproduct\x1a\x1bgoogle/protobuf/empty.proto\"q\n" +
-	"\aSneaker\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12#\n" +
-	"\rprice_kopecks\x18\x03 \x01(\x03R\fpriceKopecks\x12\x1b\n" +
-	"\timage_key\x18\x04 \x01(\tR\bimageKey\"N\n" +
-	"\x11AddSneakerRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12#\n" +
-	"\rprice_kopecks\x18\x02 \x01(\x03R\fpriceKopecks\"j\n" +
-	"\x18GenerateUploadURLRequest\x12+\n" +
-	"\x11original_filename\x18\x01 \x01(\tR\x10originalFilename\x12!\n" +
-	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"'\n" +
-	"\x15GetSneakerByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"W\n" +
-	"\x19UpdateProductImageRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1b\n" +
-	"\timage_key\x18\x02 \x01(\tR\bimageKey\"&\n" +
-	"\x14DeleteSneakerRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"+\n" +
-	"\x17GetSneakersByIDsRequest\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\x03R\x03ids\"E\n" +
-	"\x15GetAllSneakersRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x04R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x04R\x06offset\"U\n" +
-	"\x19GenerateUploadURLResponse\x12\x1d\n" +
-	"\n" +
-	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x19\n" +
-	"\bfile_key\x18\x02 \x01(\tR\afileKey\"H\n" +
-	"\x18GetSneakersByIDsResponse\x12,\n" +
-	"\bsneakers\x18\x01 \x03(\v2\x10.product.SneakerR\bsneakers\"F\n" +
-	"\x16GetAllSneakersResponse\x12,\n" +
-	"\bsneakers\x18\x01 \x03(\v2\x10.product.SneakerR\bsneakers2\xab\x04\n" +
+type CreateVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Size          string                 `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	Color         string                 `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	PriceKopecks  int64                  `protobuf:"varint,5,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVariantRequest) Reset() {
+	*x = CreateVariantRequest{}
+	mi := &file_product_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVariantRequest) ProtoMessage() {}
+
+func (x *CreateVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVariantRequest.ProtoReflect.Descriptor instead.
+func (*CreateVariantRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateVariantRequest) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *CreateVariantRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateVariantRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *CreateVariantRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateVariantRequest) GetPriceKopecks() int64 {
+	if x != nil {
+		return x.PriceKopecks
+	}
+	return 0
+}
+
+type ListVariantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVariantsRequest) Reset() {
+	*x = ListVariantsRequest{}
+	mi := &file_product_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVariantsRequest) ProtoMessage() {}
+
+func (x *ListVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVariantsRequest.ProtoReflect.Descriptor instead.
+func (*ListVariantsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListVariantsRequest) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+type ListVariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*SneakerVariant      `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVariantsResponse) Reset() {
+	*x = ListVariantsResponse{}
+	mi := &file_product_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVariantsResponse) ProtoMessage() {}
+
+func (x *ListVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVariantsResponse.ProtoReflect.Descriptor instead.
+func (*ListVariantsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListVariantsResponse) GetVariants() []*SneakerVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type UpdateVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Size          string                 `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	Color         string                 `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	PriceKopecks  int64                  `protobuf:"varint,5,opt,name=price_kopecks,json=priceKopecks,proto3" json:"price_kopecks,omitempty"`
+	SneakerId     int64                  `protobuf:"varint,6,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"` // товар, которому должен принадлежать вариант
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVariantRequest) Reset() {
+	*x = UpdateVariantRequest{}
+	mi := &file_product_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVariantRequest) ProtoMessage() {}
+
+func (x *UpdateVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVariantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVariantRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateVariantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateVariantRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateVariantRequest) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *UpdateVariantRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *UpdateVariantRequest) GetPriceKopecks() int64 {
+	if x != nil {
+		return x.PriceKopecks
+	}
+	return 0
+}
+
+func (x *UpdateVariantRequest) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+type GetVariantsByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVariantsByIDsRequest) Reset() {
+	*x = GetVariantsByIDsRequest{}
+	mi := &file_product_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVariantsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVariantsByIDsRequest) ProtoMessage() {}
+
+func (x *GetVariantsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVariantsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetVariantsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetVariantsByIDsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetVariantsByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*SneakerVariant      `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVariantsByIDsResponse) Reset() {
+	*x = GetVariantsByIDsResponse{}
+	mi := &file_product_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVariantsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVariantsByIDsResponse) ProtoMessage() {}
+
+func (x *GetVariantsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVariantsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetVariantsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetVariantsByIDsResponse) GetVariants() []*SneakerVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+// StockLevel — остатки по товару или его варианту (variant_id = 0 — товар без вариантов).
+type StockLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`   // физически на складе
+	Reserved      int64                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`   // зарезервировано под неоплаченные заказы
+	Available     int64                  `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"` // quantity - reserved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLevel) Reset() {
+	*x = StockLevel{}
+	mi := &file_product_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLevel) ProtoMessage() {}
+
+func (x *StockLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLevel.ProtoReflect.Descriptor instead.
+func (*StockLevel) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StockLevel) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *StockLevel) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *StockLevel) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockLevel) GetReserved() int64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *StockLevel) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type SetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockRequest) Reset() {
+	*x = SetStockRequest{}
+	mi := &file_product_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockRequest) ProtoMessage() {}
+
+func (x *SetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockRequest.ProtoReflect.Descriptor instead.
+func (*SetStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetStockRequest) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *SetStockRequest) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *SetStockRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_product_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetStockRequest) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *GetStockRequest) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type StockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SneakerId     int64                  `protobuf:"varint,1,opt,name=sneaker_id,json=sneakerId,proto3" json:"sneaker_id,omitempty"`
+	VariantId     int64                  `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_product_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StockItem) GetSneakerId() int64 {
+	if x != nil {
+		return x.SneakerId
+	}
+	return 0
+}
+
+func (x *StockItem) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *StockItem) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 0 — срок по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_product_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReserveStockRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type StockOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockOrderRequest) Reset() {
+	*x = StockOrderRequest{}
+	mi := &file_product_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockOrderRequest) ProtoMessage() {}
+
+func (x *StockOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockOrderRequest.ProtoReflect.Descriptor instead.
+func (*StockOrderRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StockOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+var File_product_product_proto protoreflect.FileDescriptor
+
+const file_product_product_proto_rawDesc = "" +
+	"\n" +
+	"\x15product/product.proto\x12\aproduct\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xb0\x02\n" +
+	"\aSneaker\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12#\n" +
+	"\rprice_kopecks\x18\x03 \x01(\x03R\fpriceKopecks\x12\x1b\n" +
+	"\timage_key\x18\x04 \x01(\tR\bimageKey\x123\n" +
+	"\bvariants\x18\x05 \x03(\v2\x17.product.SneakerVariantR\bvariants\x12\x14\n" +
+	"\x05brand\x18\x06 \x01(\tR\x05brand\x12\x1a\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\x12\x1a\n" +
+	"\barchived\x18\n" +
+	" \x01(\bR\barchived\"\xa0\x01\n" +
+	"\x0eSneakerVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x02 \x01(\x03R\tsneakerId\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\x14\n" +
+	"\x05color\x18\x05 \x01(\tR\x05color\x12#\n" +
+	"\rprice_kopecks\x18\x06 \x01(\x03R\fpriceKopecks\"\xa2\x01\n" +
+	"\x11AddSneakerRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12#\n" +
+	"\rprice_kopecks\x18\x02 \x01(\x03R\fpriceKopecks\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\x9d\x02\n" +
+	"\x14UpdateSneakerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12#\n" +
+	"\rprice_kopecks\x18\x05 \x01(\x03R\fpriceKopecks\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05brand\x18\a \x01(\tR\x05brand\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\"j\n" +
+	"\x18GenerateUploadURLRequest\x12+\n" +
+	"\x11original_filename\x18\x01 \x01(\tR\x10originalFilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"'\n" +
+	"\x15GetSneakerByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"W\n" +
+	"\x19UpdateProductImageRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1b\n" +
+	"\timage_key\x18\x02 \x01(\tR\bimageKey\"&\n" +
+	"\x14DeleteSneakerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"'\n" +
+	"\x15RestoreSneakerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"+\n" +
+	"\x17GetSneakersByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"Z\n" +
+	"\x15GetAllSneakersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x04R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageTokenJ\x04\b\x02\x10\x03R\x06offset\"\xa9\x02\n" +
+	"\x15SearchSneakersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12*\n" +
+	"\x11min_price_kopecks\x18\x02 \x01(\x03R\x0fminPriceKopecks\x12*\n" +
+	"\x11max_price_kopecks\x18\x03 \x01(\x03R\x0fmaxPriceKopecks\x12\x16\n" +
+	"\x06brands\x18\x04 \x03(\tR\x06brands\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\tR\n" +
+	"categories\x12'\n" +
+	"\x04sort\x18\x06 \x01(\x0e2\x13.product.SearchSortR\x04sort\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x04R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageTokenJ\x04\b\b\x10\tR\x06offset\"n\n" +
+	"\x16SearchSneakersResponse\x12,\n" +
+	"\bsneakers\x18\x01 \x03(\v2\x10.product.SneakerR\bsneakers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"U\n" +
+	"\x19GenerateUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x19\n" +
+	"\bfile_key\x18\x02 \x01(\tR\afileKey\"H\n" +
+	"\x18GetSneakersByIDsResponse\x12,\n" +
+	"\bsneakers\x18\x01 \x03(\v2\x10.product.SneakerR\bsneakers\"n\n" +
+	"\x16GetAllSneakersResponse\x12,\n" +
+	"\bsneakers\x18\x01 \x03(\v2\x10.product.SneakerR\bsneakers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x96\x01\n" +
+	"\x14CreateVariantRequest\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x14\n" +
+	"\x05color\x18\x04 \x01(\tR\x05color\x12#\n" +
+	"\rprice_kopecks\x18\x05 \x01(\x03R\fpriceKopecks\"4\n" +
+	"\x13ListVariantsRequest\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\"K\n" +
+	"\x14ListVariantsResponse\x123\n" +
+	"\bvariants\x18\x01 \x03(\v2\x17.product.SneakerVariantR\bvariants\"\xa6\x01\n" +
+	"\x14UpdateVariantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x14\n" +
+	"\x05color\x18\x04 \x01(\tR\x05color\x12#\n" +
+	"\rprice_kopecks\x18\x05 \x01(\x03R\fpriceKopecks\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x06 \x01(\x03R\tsneakerId\"+\n" +
+	"\x17GetVariantsByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"O\n" +
+	"\x18GetVariantsByIDsResponse\x123\n" +
+	"\bvariants\x18\x01 \x03(\v2\x17.product.SneakerVariantR\bvariants\"\xa0\x01\n" +
+	"\n" +
+	"StockLevel\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x03R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\x03R\tavailable\"k\n" +
+	"\x0fSetStockRequest\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\"O\n" +
+	"\x0fGetStockRequest\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\"e\n" +
+	"\tStockItem\x12\x1d\n" +
+	"\n" +
+	"sneaker_id\x18\x01 \x01(\x03R\tsneakerId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\"{\n" +
+	"\x13ReserveStockRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12(\n" +
+	"\x05items\x18\x02 \x03(\v2\x12.product.StockItemR\x05items\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\".\n" +
+	"\x11StockOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId*\x92\x01\n" +
+	"\n" +
+	"SearchSort\x12\x19\n" +
+	"\x15SEARCH_SORT_RELEVANCE\x10\x00\x12\x19\n" +
+	"\x15SEARCH_SORT_PRICE_ASC\x10\x01\x12\x1a\n" +
+	"\x16SEARCH_SORT_PRICE_DESC\x10\x02\x12\x16\n" +
+	"\x12SEARCH_SORT_NEWEST\x10\x03\x12\x1a\n" +
+	"\x16SEARCH_SORT_POPULARITY\x10\x042\x85\v\n" +
 	"\aProduct\x12:\n" +
 	"\n" +
 	"AddSneaker\x12\x1a.product.AddSneakerRequest\x1a\x10.product.Sneaker\x12B\n" +
 	"\x0eGetSneakerByID\x12\x1e.product.GetSneakerByIDRequest\x1a\x10.product.Sneaker\x12W\n" +
 	"\x10GetSneakersByIDs\x12 .product.GetSneakersByIDsRequest\x1a!.product.GetSneakersByIDsResponse\x12Q\n" +
-	"\x0eGetAllSneakers\x12\x1e.product.GetAllSneakersRequest\x1a\x1f.product.GetAllSneakersResponse\x12F\n" +
-	"\rDeleteSneaker\x12\x1d.product.DeleteSneakerRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
+	"\x0eGetAllSneakers\x12\x1e.product.GetAllSneakersRequest\x1a\x1f.product.GetAllSneakersResponse\x12Q\n" +
+	"\x0eSearchSneakers\x12\x1e.product.SearchSneakersRequest\x1a\x1f.product.SearchSneakersResponse\x12@\n" +
+	"\rUpdateSneaker\x12\x1d.product.UpdateSneakerRequest\x1a\x10.product.Sneaker\x12F\n" +
+	"\rDeleteSneaker\x12\x1d.product.DeleteSneakerRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x0eRestoreSneaker\x12\x1e.product.RestoreSneakerRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
 	"\x11GenerateUploadURL\x12!.product.GenerateUploadURLRequest\x1a\".product.GenerateUploadURLResponse\x12P\n" +
-	"\x12UpdateProductImage\x12\".product.UpdateProductImageRequest\x1a\x16.google.protobuf.EmptyB1Z/github.com/stpnv0/protos/gen/go/product;productb\x06proto3"
+	"\x12UpdateProductImage\x12\".product.UpdateProductImageRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\rCreateVariant\x12\x1d.product.CreateVariantRequest\x1a\x17.product.SneakerVariant\x12K\n" +
+	"\fListVariants\x12\x1c.product.ListVariantsRequest\x1a\x1d.product.ListVariantsResponse\x12G\n" +
+	"\rUpdateVariant\x12\x1d.product.UpdateVariantRequest\x1a\x17.product.SneakerVariant\x12W\n" +
+	"\x10GetVariantsByIDs\x12 .product.GetVariantsByIDsRequest\x1a!.product.GetVariantsByIDsResponse\x129\n" +
+	"\bSetStock\x12\x18.product.SetStockRequest\x1a\x13.product.StockLevel\x129\n" +
+	"\bGetStock\x12\x18.product.GetStockRequest\x1a\x13.product.StockLevel\x12D\n" +
+	"\fReserveStock\x12\x1c.product.ReserveStockRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\fReleaseStock\x12\x1a.product.StockOrderRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\vCommitStock\x12\x1a.product.StockOrderRequest\x1a\x16.google.protobuf.EmptyB1Z/github.com/stpnv0/protos/gen/go/product;productb\x06proto3"
 
 var (
 	file_product_product_proto_rawDescOnce sync.Once
@@ -629,43 +1962,94 @@ func file_product_product_proto_rawDescGZIP() []byte {
 	return file_product_product_proto_rawDescData
 }
 
-var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_product_product_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_product_product_proto_goTypes = []any{
-	(*Sneaker)(nil),                   // 0: product.Sneaker
-	(*AddSneakerRequest)(nil),         // 1: product.AddSneakerRequest
-	(*GenerateUploadURLRequest)(nil),  // 2: product.GenerateUploadURLRequest
-	(*GetSneakerByIDRequest)(nil),     // 3: product.GetSneakerByIDRequest
-	(*UpdateProductImageRequest)(nil), // 4: product.UpdateProductImageRequest
-	(*DeleteSneakerRequest)(nil),      // 5: product.DeleteSneakerRequest
-	(*GetSneakersByIDsRequest)(nil),   // 6: product.GetSneakersByIDsRequest
-	(*GetAllSneakersRequest)(nil),     // 7: product.GetAllSneakersRequest
-	(*GenerateUploadURLResponse)(nil), // 8: product.GenerateUploadURLResponse
-	(*GetSneakersByIDsResponse)(nil),  // 9: product.GetSneakersByIDsResponse
-	(*GetAllSneakersResponse)(nil),    // 10: product.GetAllSneakersResponse
-	(*emptypb.Empty)(nil),             // 11: google.protobuf.Empty
+	(SearchSort)(0),                   // 0: product.SearchSort
+	(*Sneaker)(nil),                   // 1: product.Sneaker
+	(*SneakerVariant)(nil),            // 2: product.SneakerVariant
+	(*AddSneakerRequest)(nil),         // 3: product.AddSneakerRequest
+	(*UpdateSneakerRequest)(nil),      // 4: product.UpdateSneakerRequest
+	(*GenerateUploadURLRequest)(nil),  // 5: product.GenerateUploadURLRequest
+	(*GetSneakerByIDRequest)(nil),     // 6: product.GetSneakerByIDRequest
+	(*UpdateProductImageRequest)(nil), // 7: product.UpdateProductImageRequest
+	(*DeleteSneakerRequest)(nil),      // 8: product.DeleteSneakerRequest
+	(*RestoreSneakerRequest)(nil),     // 9: product.RestoreSneakerRequest
+	(*GetSneakersByIDsRequest)(nil),   // 10: product.GetSneakersByIDsRequest
+	(*GetAllSneakersRequest)(nil),     // 11: product.GetAllSneakersRequest
+	(*SearchSneakersRequest)(nil),     // 12: product.SearchSneakersRequest
+	(*SearchSneakersResponse)(nil),    // 13: product.SearchSneakersResponse
+	(*GenerateUploadURLResponse)(nil), // 14: product.GenerateUploadURLResponse
+	(*GetSneakersByIDsResponse)(nil),  // 15: product.GetSneakersByIDsResponse
+	(*GetAllSneakersResponse)(nil),    // 16: product.GetAllSneakersResponse
+	(*CreateVariantRequest)(nil),      // 17: product.CreateVariantRequest
+	(*ListVariantsRequest)(nil),       // 18: product.ListVariantsRequest
+	(*ListVariantsResponse)(nil),      // 19: product.ListVariantsResponse
+	(*UpdateVariantRequest)(nil),      // 20: product.UpdateVariantRequest
+	(*GetVariantsByIDsRequest)(nil),   // 21: product.GetVariantsByIDsRequest
+	(*GetVariantsByIDsResponse)(nil),  // 22: product.GetVariantsByIDsResponse
+	(*StockLevel)(nil),                // 23: product.StockLevel
+	(*SetStockRequest)(nil),           // 24: product.SetStockRequest
+	(*GetStockRequest)(nil),           // 25: product.GetStockRequest
+	(*StockItem)(nil),                 // 26: product.StockItem
+	(*ReserveStockRequest)(nil),       // 27: product.ReserveStockRequest
+	(*StockOrderRequest)(nil),         // 28: product.StockOrderRequest
+	(*fieldmaskpb.FieldMask)(nil),     // 29: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),             // 30: google.protobuf.Empty
 }
 var file_product_product_proto_depIdxs = []int32{
-	0,  // 0: product.GetSneakersByIDsResponse.sneakers:type_name -> product.Sneaker
-	0,  // 1: product.GetAllSneakersResponse.sneakers:type_name -> product.Sneaker
-	1,  // 2: product.Product.AddSneaker:input_type -> product.AddSneakerRequest
-	3,  // 3: product.Product.GetSneakerByID:input_type -> product.GetSneakerByIDRequest
-	6,  // 4: product.Product.GetSneakersByIDs:input_type -> product.GetSneakersByIDsRequest
-	7,  // 5: product.Product.GetAllSneakers:input_type -> product.GetAllSneakersRequest
-	5,  // 6: product.Product.DeleteSneaker:input_type -> product.DeleteSneakerRequest
-	2,  // 7: product.Product.GenerateUploadURL:input_type -> product.GenerateUploadURLRequest
-	4,  // 8: product.Product.UpdateProductImage:input_type -> product.UpdateProductImageRequest
-	0,  // 9: product.Product.AddSneaker:output_type -> product.Sneaker
-	0,  // 10: product.Product.GetSneakerByID:output_type -> product.Sneaker
-	9,  // 11: product.Product.GetSneakersByIDs:output_type -> product.GetSneakersByIDsResponse
-	10, // 12: product.Product.GetAllSneakers:output_type -> product.GetAllSneakersResponse
-	11, // 13: product.Product.DeleteSneaker:output_type -> google.protobuf.Empty
-	8,  // 14: product.Product.GenerateUploadURL:output_type -> product.GenerateUploadURLResponse
-	11, // 15: product.Product.UpdateProductImage:output_type -> google.protobuf.Empty
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	2,  // 0: product.Sneaker.variants:type_name -> product.SneakerVariant
+	29, // 1: product.UpdateSneakerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 2: product.SearchSneakersRequest.sort:type_name -> product.SearchSort
+	1,  // 3: product.SearchSneakersResponse.sneakers:type_name -> product.Sneaker
+	1,  // 4: product.GetSneakersByIDsResponse.sneakers:type_name -> product.Sneaker
+	1,  // 5: product.GetAllSneakersResponse.sneakers:type_name -> product.Sneaker
+	2,  // 6: product.ListVariantsResponse.variants:type_name -> product.SneakerVariant
+	2,  // 7: product.GetVariantsByIDsResponse.variants:type_name -> product.SneakerVariant
+	26, // 8: product.ReserveStockRequest.items:type_name -> product.StockItem
+	3,  // 9: product.Product.AddSneaker:input_type -> product.AddSneakerRequest
+	6,  // 10: product.Product.GetSneakerByID:input_type -> product.GetSneakerByIDRequest
+	10, // 11: product.Product.GetSneakersByIDs:input_type -> product.GetSneakersByIDsRequest
+	11, // 12: product.Product.GetAllSneakers:input_type -> product.GetAllSneakersRequest
+	12, // 13: product.Product.SearchSneakers:input_type -> product.SearchSneakersRequest
+	4,  // 14: product.Product.UpdateSneaker:input_type -> product.UpdateSneakerRequest
+	8,  // 15: product.Product.DeleteSneaker:input_type -> product.DeleteSneakerRequest
+	9,  // 16: product.Product.RestoreSneaker:input_type -> product.RestoreSneakerRequest
+	5,  // 17: product.Product.GenerateUploadURL:input_type -> product.GenerateUploadURLRequest
+	7,  // 18: product.Product.UpdateProductImage:input_type -> product.UpdateProductImageRequest
+	17, // 19: product.Product.CreateVariant:input_type -> product.CreateVariantRequest
+	18, // 20: product.Product.ListVariants:input_type -> product.ListVariantsRequest
+	20, // 21: product.Product.UpdateVariant:input_type -> product.UpdateVariantRequest
+	21, // 22: product.Product.GetVariantsByIDs:input_type -> product.GetVariantsByIDsRequest
+	24, // 23: product.Product.SetStock:input_type -> product.SetStockRequest
+	25, // 24: product.Product.GetStock:input_type -> product.GetStockRequest
+	27, // 25: product.Product.ReserveStock:input_type -> product.ReserveStockRequest
+	28, // 26: product.Product.ReleaseStock:input_type -> product.StockOrderRequest
+	28, // 27: product.Product.CommitStock:input_type -> product.StockOrderRequest
+	1,  // 28: product.Product.AddSneaker:output_type -> product.Sneaker
+	1,  // 29: product.Product.GetSneakerByID:output_type -> product.Sneaker
+	15, // 30: product.Product.GetSneakersByIDs:output_type -> product.GetSneakersByIDsResponse
+	16, // 31: product.Product.GetAllSneakers:output_type -> product.GetAllSneakersResponse
+	13, // 32: product.Product.SearchSneakers:output_type -> product.SearchSneakersResponse
+	1,  // 33: product.Product.UpdateSneaker:output_type -> product.Sneaker
+	30, // 34: product.Product.DeleteSneaker:output_type -> google.protobuf.Empty
+	30, // 35: product.Product.RestoreSneaker:output_type -> google.protobuf.Empty
+	14, // 36: product.Product.GenerateUploadURL:output_type -> product.GenerateUploadURLResponse
+	30, // 37: product.Product.UpdateProductImage:output_type -> google.protobuf.Empty
+	2,  // 38: product.Product.CreateVariant:output_type -> product.SneakerVariant
+	19, // 39: product.Product.ListVariants:output_type -> product.ListVariantsResponse
+	2,  // 40: product.Product.UpdateVariant:output_type -> product.SneakerVariant
+	22, // 41: product.Product.GetVariantsByIDs:output_type -> product.GetVariantsByIDsResponse
+	23, // 42: product.Product.SetStock:output_type -> product.StockLevel
+	23, // 43: product.Product.GetStock:output_type -> product.StockLevel
+	30, // 44: product.Product.ReserveStock:output_type -> google.protobuf.Empty
+	30, // 45: product.Product.ReleaseStock:output_type -> google.protobuf.Empty
+	30, // 46: product.Product.CommitStock:output_type -> google.protobuf.Empty
+	28, // [28:47] is the sub-list for method output_type
+	9,  // [9:28] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_product_product_proto_init() }
@@ -678,13 +2062,14 @@ func file_product_product_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_product_proto_rawDesc), len(file_product_product_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_product_product_proto_goTypes,
 		DependencyIndexes: file_product_product_proto_depIdxs,
+		EnumInfos:         file_product_product_proto_enumTypes,
 		MessageInfos:      file_product_product_proto_msgTypes,
 	}.Build()
 	File_product_product_proto = out.File
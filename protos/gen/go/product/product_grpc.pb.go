@@ -24,9 +24,21 @@ const (
 	Product_GetSneakerByID_FullMethodName     = "/product.Product/GetSneakerByID"
 	Product_GetSneakersByIDs_FullMethodName   = "/product.Product/GetSneakersByIDs"
 	Product_GetAllSneakers_FullMethodName     = "/product.Product/GetAllSneakers"
+	Product_SearchSneakers_FullMethodName     = "/product.Product/SearchSneakers"
+	Product_UpdateSneaker_FullMethodName      = "/product.Product/UpdateSneaker"
 	Product_DeleteSneaker_FullMethodName      = "/product.Product/DeleteSneaker"
+	Product_RestoreSneaker_FullMethodName     = "/product.Product/RestoreSneaker"
 	Product_GenerateUploadURL_FullMethodName  = "/product.Product/GenerateUploadURL"
 	Product_UpdateProductImage_FullMethodName = "/product.Product/UpdateProductImage"
+	Product_CreateVariant_FullMethodName      = "/product.Product/CreateVariant"
+	Product_ListVariants_FullMethodName       = "/product.Product/ListVariants"
+	Product_UpdateVariant_FullMethodName      = "/product.Product/UpdateVariant"
+	Product_GetVariantsByIDs_FullMethodName   = "/product.Product/GetVariantsByIDs"
+	Product_SetStock_FullMethodName           = "/product.Product/SetStock"
+	Product_GetStock_FullMethodName           = "/product.Product/GetStock"
+	Product_ReserveStock_FullMethodName       = "/product.Product/ReserveStock"
+	Product_ReleaseStock_FullMethodName       = "/product.Product/ReleaseStock"
+	Product_CommitStock_FullMethodName        = "/product.Product/CommitStock"
 )
 
 // ProductClient is the client API for Product service.
@@ -37,9 +49,21 @@ type ProductClient interface {
 	GetSneakerByID(ctx context.Context, in *GetSneakerByIDRequest, opts ...grpc.CallOption) (*Sneaker, error)
 	GetSneakersByIDs(ctx context.Context, in *GetSneakersByIDsRequest, opts ...grpc.CallOption) (*GetSneakersByIDsResponse, error)
 	GetAllSneakers(ctx context.Context, in *GetAllSneakersRequest, opts ...grpc.CallOption) (*GetAllSneakersResponse, error)
+	SearchSneakers(ctx context.Context, in *SearchSneakersRequest, opts ...grpc.CallOption) (*SearchSneakersResponse, error)
+	UpdateSneaker(ctx context.Context, in *UpdateSneakerRequest, opts ...grpc.CallOption) (*Sneaker, error)
 	DeleteSneaker(ctx context.Context, in *DeleteSneakerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreSneaker(ctx context.Context, in *RestoreSneakerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GenerateUploadURL(ctx context.Context, in *GenerateUploadURLRequest, opts ...grpc.CallOption) (*GenerateUploadURLResponse, error)
 	UpdateProductImage(ctx context.Context, in *UpdateProductImageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*SneakerVariant, error)
+	ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error)
+	UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*SneakerVariant, error)
+	GetVariantsByIDs(ctx context.Context, in *GetVariantsByIDsRequest, opts ...grpc.CallOption) (*GetVariantsByIDsResponse, error)
+	SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*StockLevel, error)
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*StockLevel, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReleaseStock(ctx context.Context, in *StockOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CommitStock(ctx context.Context, in *StockOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type productClient struct {
@@ -90,6 +114,26 @@ func (c *productClient) GetAllSneakers(ctx context.Context, in *GetAllSneakersRe
 	return out, nil
 }
 
+func (c *productClient) SearchSneakers(ctx context.Context, in *SearchSneakersRequest, opts ...grpc.CallOption) (*SearchSneakersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSneakersResponse)
+	err := c.cc.Invoke(ctx, Product_SearchSneakers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) UpdateSneaker(ctx context.Context, in *UpdateSneakerRequest, opts ...grpc.CallOption) (*Sneaker, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sneaker)
+	err := c.cc.Invoke(ctx, Product_UpdateSneaker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productClient) DeleteSneaker(ctx context.Context, in *DeleteSneakerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -100,6 +144,16 @@ func (c *productClient) DeleteSneaker(ctx context.Context, in *DeleteSneakerRequ
 	return out, nil
 }
 
+func (c *productClient) RestoreSneaker(ctx context.Context, in *RestoreSneakerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Product_RestoreSneaker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productClient) GenerateUploadURL(ctx context.Context, in *GenerateUploadURLRequest, opts ...grpc.CallOption) (*GenerateUploadURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenerateUploadURLResponse)
@@ -120,6 +174,96 @@ func (c *productClient) UpdateProductImage(ctx context.Context, in *UpdateProduc
 	return out, nil
 }
 
+func (c *productClient) CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*SneakerVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SneakerVariant)
+	err := c.cc.Invoke(ctx, Product_CreateVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVariantsResponse)
+	err := c.cc.Invoke(ctx, Product_ListVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*SneakerVariant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SneakerVariant)
+	err := c.cc.Invoke(ctx, Product_UpdateVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) GetVariantsByIDs(ctx context.Context, in *GetVariantsByIDsRequest, opts ...grpc.CallOption) (*GetVariantsByIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVariantsByIDsResponse)
+	err := c.cc.Invoke(ctx, Product_GetVariantsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*StockLevel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockLevel)
+	err := c.cc.Invoke(ctx, Product_SetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*StockLevel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockLevel)
+	err := c.cc.Invoke(ctx, Product_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Product_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) ReleaseStock(ctx context.Context, in *StockOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Product_ReleaseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productClient) CommitStock(ctx context.Context, in *StockOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Product_CommitStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServer is the server API for Product service.
 // All implementations must embed UnimplementedProductServer
 // for forward compatibility.
@@ -128,9 +272,21 @@ type ProductServer interface {
 	GetSneakerByID(context.Context, *GetSneakerByIDRequest) (*Sneaker, error)
 	GetSneakersByIDs(context.Context, *GetSneakersByIDsRequest) (*GetSneakersByIDsResponse, error)
 	GetAllSneakers(context.Context, *GetAllSneakersRequest) (*GetAllSneakersResponse, error)
+	SearchSneakers(context.Context, *SearchSneakersRequest) (*SearchSneakersResponse, error)
+	UpdateSneaker(context.Context, *UpdateSneakerRequest) (*Sneaker, error)
 	DeleteSneaker(context.Context, *DeleteSneakerRequest) (*emptypb.Empty, error)
+	RestoreSneaker(context.Context, *RestoreSneakerRequest) (*emptypb.Empty, error)
 	GenerateUploadURL(context.Context, *GenerateUploadURLRequest) (*GenerateUploadURLResponse, error)
 	UpdateProductImage(context.Context, *UpdateProductImageRequest) (*emptypb.Empty, error)
+	CreateVariant(context.Context, *CreateVariantRequest) (*SneakerVariant, error)
+	ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error)
+	UpdateVariant(context.Context, *UpdateVariantRequest) (*SneakerVariant, error)
+	GetVariantsByIDs(context.Context, *GetVariantsByIDsRequest) (*GetVariantsByIDsResponse, error)
+	SetStock(context.Context, *SetStockRequest) (*StockLevel, error)
+	GetStock(context.Context, *GetStockRequest) (*StockLevel, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*emptypb.Empty, error)
+	ReleaseStock(context.Context, *StockOrderRequest) (*emptypb.Empty, error)
+	CommitStock(context.Context, *StockOrderRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedProductServer()
 }
 
@@ -153,15 +309,51 @@ func (UnimplementedProductServer) GetSneakersByIDs(context.Context, *GetSneakers
 func (UnimplementedProductServer) GetAllSneakers(context.Context, *GetAllSneakersRequest) (*GetAllSneakersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAllSneakers not implemented")
 }
+func (UnimplementedProductServer) SearchSneakers(context.Context, *SearchSneakersRequest) (*SearchSneakersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchSneakers not implemented")
+}
+func (UnimplementedProductServer) UpdateSneaker(context.Context, *UpdateSneakerRequest) (*Sneaker, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSneaker not implemented")
+}
 func (UnimplementedProductServer) DeleteSneaker(context.Context, *DeleteSneakerRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteSneaker not implemented")
 }
+func (UnimplementedProductServer) RestoreSneaker(context.Context, *RestoreSneakerRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreSneaker not implemented")
+}
 func (UnimplementedProductServer) GenerateUploadURL(context.Context, *GenerateUploadURLRequest) (*GenerateUploadURLResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GenerateUploadURL not implemented")
 }
 func (UnimplementedProductServer) UpdateProductImage(context.Context, *UpdateProductImageRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateProductImage not implemented")
 }
+func (UnimplementedProductServer) CreateVariant(context.Context, *CreateVariantRequest) (*SneakerVariant, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (UnimplementedProductServer) ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVariants not implemented")
+}
+func (UnimplementedProductServer) UpdateVariant(context.Context, *UpdateVariantRequest) (*SneakerVariant, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateVariant not implemented")
+}
+func (UnimplementedProductServer) GetVariantsByIDs(context.Context, *GetVariantsByIDsRequest) (*GetVariantsByIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVariantsByIDs not implemented")
+}
+func (UnimplementedProductServer) SetStock(context.Context, *SetStockRequest) (*StockLevel, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedProductServer) GetStock(context.Context, *GetStockRequest) (*StockLevel, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedProductServer) ReserveStock(context.Context, *ReserveStockRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedProductServer) ReleaseStock(context.Context, *StockOrderRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedProductServer) CommitStock(context.Context, *StockOrderRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitStock not implemented")
+}
 func (UnimplementedProductServer) mustEmbedUnimplementedProductServer() {}
 func (UnimplementedProductServer) testEmbeddedByValue()                 {}
 
@@ -255,6 +447,42 @@ func _Product_GetAllSneakers_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Product_SearchSneakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSneakersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).SearchSneakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_SearchSneakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).SearchSneakers(ctx, req.(*SearchSneakersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_UpdateSneaker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSneakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).UpdateSneaker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_UpdateSneaker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).UpdateSneaker(ctx, req.(*UpdateSneakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Product_DeleteSneaker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteSneakerRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +501,24 @@ func _Product_DeleteSneaker_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Product_RestoreSneaker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSneakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).RestoreSneaker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_RestoreSneaker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).RestoreSneaker(ctx, req.(*RestoreSneakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Product_GenerateUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateUploadURLRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +555,168 @@ func _Product_UpdateProductImage_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Product_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_CreateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).CreateVariant(ctx, req.(*CreateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_ListVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).ListVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_ListVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).ListVariants(ctx, req.(*ListVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_UpdateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).UpdateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_UpdateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).UpdateVariant(ctx, req.(*UpdateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_GetVariantsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVariantsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).GetVariantsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_GetVariantsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).GetVariantsByIDs(ctx, req.(*GetVariantsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_SetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).SetStock(ctx, req.(*SetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).ReleaseStock(ctx, req.(*StockOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Product_CommitStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServer).CommitStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Product_CommitStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServer).CommitStock(ctx, req.(*StockOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Product_ServiceDesc is the grpc.ServiceDesc for Product service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -332,10 +740,22 @@ var Product_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllSneakers",
 			Handler:    _Product_GetAllSneakers_Handler,
 		},
+		{
+			MethodName: "SearchSneakers",
+			Handler:    _Product_SearchSneakers_Handler,
+		},
+		{
+			MethodName: "UpdateSneaker",
+			Handler:    _Product_UpdateSneaker_Handler,
+		},
 		{
 			MethodName: "DeleteSneaker",
 			Handler:    _Product_DeleteSneaker_Handler,
 		},
+		{
+			MethodName: "RestoreSneaker",
+			Handler:    _Product_RestoreSneaker_Handler,
+		},
 		{
 			MethodName: "GenerateUploadURL",
 			Handler:    _Product_GenerateUploadURL_Handler,
@@ -344,6 +764,42 @@ var Product_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProductImage",
 			Handler:    _Product_UpdateProductImage_Handler,
 		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _Product_CreateVariant_Handler,
+		},
+		{
+			MethodName: "ListVariants",
+			Handler:    _Product_ListVariants_Handler,
+		},
+		{
+			MethodName: "UpdateVariant",
+			Handler:    _Product_UpdateVariant_Handler,
+		},
+		{
+			MethodName: "GetVariantsByIDs",
+			Handler:    _Product_GetVariantsByIDs_Handler,
+		},
+		{
+			MethodName: "SetStock",
+			Handler:    _Product_SetStock_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _Product_GetStock_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _Product_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _Product_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "CommitStock",
+			Handler:    _Product_CommitStock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "product/product.proto",
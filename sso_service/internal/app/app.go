@@ -7,6 +7,7 @@ import (
 	"os"
 	grpcapp "sso/internal/app/grpc"
 	"sso/internal/services/auth"
+	"sso/internal/services/profile"
 	"sso/internal/storage/postgres"
 	"time"
 )
@@ -49,7 +50,9 @@ func New(
 
 	authService := auth.New(log, storage, storage, storage, tokenTTL)
 
-	grpcApp := grpcapp.New(log, authService, grpcPort)
+	profileService := profile.New(log, storage)
+
+	grpcApp := grpcapp.New(log, authService, profileService, grpcPort)
 
 	return &App{
 		GRPCServer: grpcApp,
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net"
 	authgrpc "sso/internal/grpc/auth"
+	profilegrpc "sso/internal/grpc/profile"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -20,7 +21,7 @@ type App struct {
 	port       int
 }
 
-func New(log *slog.Logger, authService authgrpc.Auth, port int) *App {
+func New(log *slog.Logger, authService authgrpc.Auth, profileService profilegrpc.Profile, port int) *App {
 	recoveryOpts := []grpc_recovery.Option{
 		grpc_recovery.WithRecoveryHandler(func(p interface{}) error {
 			log.Error("panic recovered", slog.Any("panic", p))
@@ -38,6 +39,7 @@ func New(log *slog.Logger, authService authgrpc.Auth, port int) *App {
 	)
 
 	authgrpc.Register(gRPCServer, authService)
+	profilegrpc.Register(gRPCServer, profileService)
 
 	return &App{
 		log:        log,
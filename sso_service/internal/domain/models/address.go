@@ -0,0 +1,13 @@
+package models
+
+// Address — адрес доставки из адресной книги пользователя.
+type Address struct {
+	ID            int64
+	UserID        int64
+	RecipientName string
+	Phone         string
+	City          string
+	AddressLine   string // улица, дом, квартира
+	PostalCode    string
+	Comment       string // для курьера
+}
@@ -0,0 +1,171 @@
+package profilegrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/services/profile"
+
+	ssov1 "github.com/stpnv0/protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Profile interface {
+	CreateAddress(ctx context.Context, addr models.Address) (models.Address, error)
+	Addresses(ctx context.Context, userID int64) ([]models.Address, error)
+	Address(ctx context.Context, userID, addressID int64) (models.Address, error)
+	UpdateAddress(ctx context.Context, addr models.Address) (models.Address, error)
+	DeleteAddress(ctx context.Context, userID, addressID int64) error
+}
+
+type serverAPI struct {
+	ssov1.UnimplementedProfileServer
+	profile Profile
+}
+
+func Register(gRPCServer *grpc.Server, profile Profile) {
+	ssov1.RegisterProfileServer(gRPCServer, &serverAPI{profile: profile})
+}
+
+func (s *serverAPI) CreateAddress(
+	ctx context.Context,
+	in *ssov1.CreateAddressRequest,
+) (*ssov1.CreateAddressResponse, error) {
+	if in.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if in.GetAddress() == nil {
+		return nil, status.Error(codes.InvalidArgument, "address is required")
+	}
+
+	addr := addressFromProto(in.GetUserId(), in.GetAddress())
+	addr.ID = 0
+
+	created, err := s.profile.CreateAddress(ctx, addr)
+	if err != nil {
+		return nil, toStatus(err, "failed to create address")
+	}
+
+	return &ssov1.CreateAddressResponse{Address: addressToProto(created)}, nil
+}
+
+func (s *serverAPI) ListAddresses(
+	ctx context.Context,
+	in *ssov1.ListAddressesRequest,
+) (*ssov1.ListAddressesResponse, error) {
+	if in.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	addrs, err := s.profile.Addresses(ctx, in.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list addresses")
+	}
+
+	resp := &ssov1.ListAddressesResponse{Addresses: make([]*ssov1.Address, 0, len(addrs))}
+	for _, addr := range addrs {
+		resp.Addresses = append(resp.Addresses, addressToProto(addr))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) GetAddress(
+	ctx context.Context,
+	in *ssov1.GetAddressRequest,
+) (*ssov1.GetAddressResponse, error) {
+	if in.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if in.GetAddressId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "address_id is required")
+	}
+
+	addr, err := s.profile.Address(ctx, in.GetUserId(), in.GetAddressId())
+	if err != nil {
+		return nil, toStatus(err, "failed to get address")
+	}
+
+	return &ssov1.GetAddressResponse{Address: addressToProto(addr)}, nil
+}
+
+func (s *serverAPI) UpdateAddress(
+	ctx context.Context,
+	in *ssov1.UpdateAddressRequest,
+) (*ssov1.UpdateAddressResponse, error) {
+	if in.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if in.GetAddress().GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "address.id is required")
+	}
+
+	updated, err := s.profile.UpdateAddress(ctx, addressFromProto(in.GetUserId(), in.GetAddress()))
+	if err != nil {
+		return nil, toStatus(err, "failed to update address")
+	}
+
+	return &ssov1.UpdateAddressResponse{Address: addressToProto(updated)}, nil
+}
+
+func (s *serverAPI) DeleteAddress(
+	ctx context.Context,
+	in *ssov1.DeleteAddressRequest,
+) (*ssov1.DeleteAddressResponse, error) {
+	if in.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if in.GetAddressId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "address_id is required")
+	}
+
+	if err := s.profile.DeleteAddress(ctx, in.GetUserId(), in.GetAddressId()); err != nil {
+		return nil, toStatus(err, "failed to delete address")
+	}
+
+	return &ssov1.DeleteAddressResponse{}, nil
+}
+
+func toStatus(err error, internalMsg string) error {
+	switch {
+	case errors.Is(err, profile.ErrInvalidAddress):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, profile.ErrAddressNotFound):
+		return status.Error(codes.NotFound, "address not found")
+	case errors.Is(err, profile.ErrTooManyAddresses):
+		return status.Error(codes.FailedPrecondition, "address book is full")
+	default:
+		return status.Error(codes.Internal, internalMsg)
+	}
+}
+
+func addressFromProto(userID int64, in *ssov1.Address) models.Address {
+	return models.Address{
+		ID:            in.GetId(),
+		UserID:        userID,
+		RecipientName: in.GetRecipientName(),
+		Phone:         in.GetPhone(),
+		City:          in.GetCity(),
+		AddressLine:   in.GetAddressLine(),
+		PostalCode:    in.GetPostalCode(),
+		Comment:       in.GetComment(),
+	}
+}
+
+func addressToProto(addr models.Address) *ssov1.Address {
+	return &ssov1.Address{
+		Id:            addr.ID,
+		RecipientName: addr.RecipientName,
+		Phone:         addr.Phone,
+		City:          addr.City,
+		AddressLine:   addr.AddressLine,
+		PostalCode:    addr.PostalCode,
+		Comment:       addr.Comment,
+	}
+}
@@ -0,0 +1,197 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/storage"
+	"strings"
+	"unicode"
+)
+
+// MaxAddresses — сколько адресов можно хранить в адресной книге одного пользователя.
+const MaxAddresses = 10
+
+var (
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrAddressNotFound  = errors.New("address not found")
+	ErrTooManyAddresses = errors.New("too many addresses")
+)
+
+type Profile struct {
+	log       *slog.Logger
+	addresses AddressStorage
+}
+
+type AddressStorage interface {
+	SaveAddress(ctx context.Context, addr models.Address) (int64, error)
+	Address(ctx context.Context, userID, addressID int64) (models.Address, error)
+	Addresses(ctx context.Context, userID int64) ([]models.Address, error)
+	CountAddresses(ctx context.Context, userID int64) (int, error)
+	UpdateAddress(ctx context.Context, addr models.Address) error
+	DeleteAddress(ctx context.Context, userID, addressID int64) error
+}
+
+// New returns a new instance of the Profile service
+func New(log *slog.Logger, addresses AddressStorage) *Profile {
+	return &Profile{
+		log:       log,
+		addresses: addresses,
+	}
+}
+
+// CreateAddress добавляет адрес в адресную книгу пользователя и возвращает его с присвоенным ID.
+func (p *Profile) CreateAddress(ctx context.Context, addr models.Address) (models.Address, error) {
+	const op = "Profile.CreateAddress"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", addr.UserID),
+	)
+
+	addr = normalize(addr)
+	if err := validate(addr); err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := p.addresses.CountAddresses(ctx, addr.UserID)
+	if err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if count >= MaxAddresses {
+		return models.Address{}, fmt.Errorf("%s: %w", op, ErrTooManyAddresses)
+	}
+
+	id, err := p.addresses.SaveAddress(ctx, addr)
+	if err != nil {
+		log.Error("failed to save address", slog.String("error", err.Error()))
+
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+	addr.ID = id
+
+	log.Info("address created", slog.Int64("address_id", id))
+
+	return addr, nil
+}
+
+// Addresses возвращает адресную книгу пользователя.
+func (p *Profile) Addresses(ctx context.Context, userID int64) ([]models.Address, error) {
+	const op = "Profile.Addresses"
+
+	addrs, err := p.addresses.Addresses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return addrs, nil
+}
+
+// Address возвращает адрес пользователя. Чужой адрес неотличим от несуществующего.
+func (p *Profile) Address(ctx context.Context, userID, addressID int64) (models.Address, error) {
+	const op = "Profile.Address"
+
+	addr, err := p.addresses.Address(ctx, userID, addressID)
+	if err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, mapStorageErr(err))
+	}
+
+	return addr, nil
+}
+
+// UpdateAddress полностью заменяет поля адреса пользователя.
+func (p *Profile) UpdateAddress(ctx context.Context, addr models.Address) (models.Address, error) {
+	const op = "Profile.UpdateAddress"
+
+	addr = normalize(addr)
+	if err := validate(addr); err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := p.addresses.UpdateAddress(ctx, addr); err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, mapStorageErr(err))
+	}
+
+	p.log.Info("address updated",
+		slog.String("op", op),
+		slog.Int64("user_id", addr.UserID),
+		slog.Int64("address_id", addr.ID),
+	)
+
+	return addr, nil
+}
+
+// DeleteAddress удаляет адрес из адресной книги. Заказы, оформленные на этот адрес,
+// не затрагиваются — order_service хранит копию адреса в самом заказе.
+func (p *Profile) DeleteAddress(ctx context.Context, userID, addressID int64) error {
+	const op = "Profile.DeleteAddress"
+
+	if err := p.addresses.DeleteAddress(ctx, userID, addressID); err != nil {
+		return fmt.Errorf("%s: %w", op, mapStorageErr(err))
+	}
+
+	p.log.Info("address deleted",
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("address_id", addressID),
+	)
+
+	return nil
+}
+
+func mapStorageErr(err error) error {
+	if errors.Is(err, storage.ErrAddressNotFound) {
+		return ErrAddressNotFound
+	}
+	return err
+}
+
+func normalize(addr models.Address) models.Address {
+	addr.RecipientName = strings.TrimSpace(addr.RecipientName)
+	addr.Phone = strings.TrimSpace(addr.Phone)
+	addr.City = strings.TrimSpace(addr.City)
+	addr.AddressLine = strings.TrimSpace(addr.AddressLine)
+	addr.PostalCode = strings.TrimSpace(addr.PostalCode)
+	addr.Comment = strings.TrimSpace(addr.Comment)
+	return addr
+}
+
+func validate(addr models.Address) error {
+	switch {
+	case addr.RecipientName == "":
+		return fmt.Errorf("%w: recipient_name is required", ErrInvalidAddress)
+	case addr.City == "":
+		return fmt.Errorf("%w: city is required", ErrInvalidAddress)
+	case addr.AddressLine == "":
+		return fmt.Errorf("%w: address_line is required", ErrInvalidAddress)
+	case !validPhone(addr.Phone):
+		return fmt.Errorf("%w: phone must contain 10 to 15 digits", ErrInvalidAddress)
+	case len(addr.Comment) > 500:
+		return fmt.Errorf("%w: comment is too long", ErrInvalidAddress)
+	}
+
+	for _, r := range addr.PostalCode {
+		if !unicode.IsDigit(r) {
+			return fmt.Errorf("%w: postal_code must contain only digits", ErrInvalidAddress)
+		}
+	}
+
+	return nil
+}
+
+// validPhone допускает привычные разделители (+7 (999) 123-45-67), считая только цифры.
+func validPhone(phone string) bool {
+	digits := 0
+	for _, r := range phone {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case r == '+' || r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= 10 && digits <= 15
+}
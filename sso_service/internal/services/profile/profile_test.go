@@ -0,0 +1,122 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"sso/internal/domain/models"
+	"sso/internal/services/profile/mocks"
+	"sso/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func validAddress() models.Address {
+	return models.Address{
+		UserID:        1,
+		RecipientName: " Иван Петров ",
+		Phone:         "+7 (999) 123-45-67",
+		City:          "Москва",
+		AddressLine:   "ул. Ленина, д. 1, кв. 2",
+		PostalCode:    "101000",
+	}
+}
+
+// --- CreateAddress ---
+
+func TestCreateAddress_Success(t *testing.T) {
+	st := new(mocks.MockAddressStorage)
+	svc := New(testLogger, st)
+
+	st.On("CountAddresses", mock.Anything, int64(1)).Return(0, nil)
+	st.On("SaveAddress", mock.Anything, mock.MatchedBy(func(a models.Address) bool {
+		return a.RecipientName == "Иван Петров" && a.UserID == 1
+	})).Return(int64(5), nil)
+
+	addr, err := svc.CreateAddress(context.Background(), validAddress())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), addr.ID)
+	assert.Equal(t, "Иван Петров", addr.RecipientName)
+	st.AssertExpectations(t)
+}
+
+func TestCreateAddress_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *models.Address)
+	}{
+		{"no recipient", func(a *models.Address) { a.RecipientName = "  " }},
+		{"no city", func(a *models.Address) { a.City = "" }},
+		{"no address line", func(a *models.Address) { a.AddressLine = "" }},
+		{"short phone", func(a *models.Address) { a.Phone = "12345" }},
+		{"letters in phone", func(a *models.Address) { a.Phone = "+7 999 CALL-ME-NOW" }},
+		{"letters in postal code", func(a *models.Address) { a.PostalCode = "10A000" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := new(mocks.MockAddressStorage)
+			svc := New(testLogger, st)
+
+			addr := validAddress()
+			tt.modify(&addr)
+
+			_, err := svc.CreateAddress(context.Background(), addr)
+			assert.ErrorIs(t, err, ErrInvalidAddress)
+			st.AssertNotCalled(t, "SaveAddress", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateAddress_TooMany(t *testing.T) {
+	st := new(mocks.MockAddressStorage)
+	svc := New(testLogger, st)
+
+	st.On("CountAddresses", mock.Anything, int64(1)).Return(MaxAddresses, nil)
+
+	_, err := svc.CreateAddress(context.Background(), validAddress())
+	assert.ErrorIs(t, err, ErrTooManyAddresses)
+	st.AssertNotCalled(t, "SaveAddress", mock.Anything, mock.Anything)
+}
+
+// --- Address / UpdateAddress / DeleteAddress ---
+
+func TestAddress_NotFound(t *testing.T) {
+	st := new(mocks.MockAddressStorage)
+	svc := New(testLogger, st)
+
+	st.On("Address", mock.Anything, int64(1), int64(9)).
+		Return(models.Address{}, fmt.Errorf("storage.postgres.Address: %w", storage.ErrAddressNotFound))
+
+	_, err := svc.Address(context.Background(), 1, 9)
+	assert.ErrorIs(t, err, ErrAddressNotFound)
+}
+
+func TestUpdateAddress_NotFound(t *testing.T) {
+	st := new(mocks.MockAddressStorage)
+	svc := New(testLogger, st)
+
+	addr := validAddress()
+	addr.ID = 9
+	st.On("UpdateAddress", mock.Anything, mock.Anything).Return(storage.ErrAddressNotFound)
+
+	_, err := svc.UpdateAddress(context.Background(), addr)
+	assert.ErrorIs(t, err, ErrAddressNotFound)
+}
+
+func TestDeleteAddress_Success(t *testing.T) {
+	st := new(mocks.MockAddressStorage)
+	svc := New(testLogger, st)
+
+	st.On("DeleteAddress", mock.Anything, int64(1), int64(3)).Return(nil)
+
+	require.NoError(t, svc.DeleteAddress(context.Background(), 1, 3))
+	st.AssertExpectations(t)
+}
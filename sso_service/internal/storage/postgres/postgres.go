@@ -103,6 +103,110 @@ func (s *Storage) UpdateAppSecret(ctx context.Context, appID int, secret string)
 	return nil
 }
 
+func (s *Storage) SaveAddress(ctx context.Context, addr models.Address) (int64, error) {
+	const op = "storage.postgres.SaveAddress"
+
+	var id int64
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO addresses(user_id, recipient_name, phone, city, address_line, postal_code, comment)
+		VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		addr.UserID, addr.RecipientName, addr.Phone, addr.City, addr.AddressLine, addr.PostalCode, addr.Comment,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) Address(ctx context.Context, userID, addressID int64) (models.Address, error) {
+	const op = "storage.postgres.Address"
+
+	var addr models.Address
+	err := s.db.QueryRow(ctx, `
+		SELECT id, user_id, recipient_name, phone, city, address_line, postal_code, comment
+		FROM addresses WHERE id = $1 AND user_id = $2`, addressID, userID,
+	).Scan(&addr.ID, &addr.UserID, &addr.RecipientName, &addr.Phone, &addr.City, &addr.AddressLine, &addr.PostalCode, &addr.Comment)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Address{}, fmt.Errorf("%s: %w", op, storage.ErrAddressNotFound)
+		}
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return addr, nil
+}
+
+func (s *Storage) Addresses(ctx context.Context, userID int64) ([]models.Address, error) {
+	const op = "storage.postgres.Addresses"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT id, user_id, recipient_name, phone, city, address_line, postal_code, comment
+		FROM addresses WHERE user_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var addrs []models.Address
+	for rows.Next() {
+		var addr models.Address
+		if err := rows.Scan(&addr.ID, &addr.UserID, &addr.RecipientName, &addr.Phone, &addr.City, &addr.AddressLine, &addr.PostalCode, &addr.Comment); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return addrs, nil
+}
+
+func (s *Storage) CountAddresses(ctx context.Context, userID int64) (int, error) {
+	const op = "storage.postgres.CountAddresses"
+
+	var count int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM addresses WHERE user_id = $1", userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) UpdateAddress(ctx context.Context, addr models.Address) error {
+	const op = "storage.postgres.UpdateAddress"
+
+	tag, err := s.db.Exec(ctx, `
+		UPDATE addresses
+		SET recipient_name = $1, phone = $2, city = $3, address_line = $4, postal_code = $5, comment = $6, updated_at = NOW()
+		WHERE id = $7 AND user_id = $8`,
+		addr.RecipientName, addr.Phone, addr.City, addr.AddressLine, addr.PostalCode, addr.Comment, addr.ID, addr.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAddressNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteAddress(ctx context.Context, userID, addressID int64) error {
+	const op = "storage.postgres.DeleteAddress"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM addresses WHERE id = $1 AND user_id = $2", addressID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAddressNotFound)
+	}
+
+	return nil
+}
+
 func (s *Storage) Close() {
 	s.db.Close()
 }